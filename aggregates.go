@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"slices"
+	"time"
+)
+
+// trimmedMean returns the mean of rtts after dropping percent of the samples
+// from each end, percent must be below 50
+func trimmedMean(rtts []time.Duration, percent float64) time.Duration {
+	if len(rtts) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(rtts)
+	slices.Sort(sorted)
+
+	trim := int(float64(len(sorted)) * percent / 100)
+	kept := sorted[trim : len(sorted)-trim]
+
+	var sum time.Duration
+	for _, rtt := range kept {
+		sum += rtt
+	}
+	return sum / time.Duration(len(kept))
+}
+
+// jitter returns the mean absolute difference of consecutive rtts, in the
+// order the replies were received
+func jitter(rtts []time.Duration) time.Duration {
+	if len(rtts) < 2 {
+		return 0
+	}
+	var sum time.Duration
+	for i := 1; i < len(rtts); i++ {
+		d := rtts[i] - rtts[i-1]
+		if d < 0 {
+			d = -d
+		}
+		sum += d
+	}
+	return sum / time.Duration(len(rtts)-1)
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrimmedMean(t *testing.T) {
+	ms := func(values ...int) []time.Duration {
+		rtts := make([]time.Duration, 0, len(values))
+		for _, v := range values {
+			rtts = append(rtts, time.Duration(v)*time.Millisecond)
+		}
+		return rtts
+	}
+
+	tests := []struct {
+		name     string
+		rtts     []time.Duration
+		percent  float64
+		expected time.Duration
+	}{
+		{name: "no samples", rtts: nil, percent: 10, expected: 0},
+		{name: "no trimming", rtts: ms(10, 20, 90), percent: 0, expected: 40 * time.Millisecond},
+		{name: "too few samples to trim", rtts: ms(10, 20, 90), percent: 10, expected: 40 * time.Millisecond},
+		{name: "outliers dropped", rtts: ms(90, 10, 11, 12, 13, 14, 15, 16, 17, 1), percent: 10, expected: 13500 * time.Microsecond},
+		{name: "single sample left", rtts: ms(1, 5, 100), percent: 49, expected: 5 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, trimmedMean(tt.rtts, tt.percent))
+		})
+	}
+}
+
+func TestJitter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), jitter(nil))
+	assert.Equal(t, time.Duration(0), jitter([]time.Duration{time.Second}))
+	// Steady latency has no jitter however high it is
+	assert.Equal(t, time.Duration(0), jitter([]time.Duration{time.Second, time.Second, time.Second}))
+	// |20-10| + |15-20| + |40-15| over 3 differences
+	assert.Equal(t, 40*time.Millisecond/3, jitter([]time.Duration{
+		10 * time.Millisecond, 20 * time.Millisecond, 15 * time.Millisecond, 40 * time.Millisecond,
+	}))
+}
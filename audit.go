@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// auditRecord describes a probe sent to a destination
+type auditRecord struct {
+	Time        time.Time `json:"time"`
+	Scheduled   time.Time `json:"scheduled"`
+	Source      string    `json:"source"`
+	Endpoint    string    `json:"endpoint"`
+	IP          string    `json:"ip,omitempty"`
+	PacketsSent int       `json:"packets_sent"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// auditLog records every probe to a file of JSON lines, or to the collector
+// log if no path is configured
+type auditLog struct {
+	source string
+	logger *zap.Logger
+
+	mu  sync.Mutex
+	w   io.WriteCloser
+	enc *json.Encoder
+}
+
+func newAuditLog(cfg AuditConfig, logger *zap.Logger) (*auditLog, error) {
+	a := &auditLog{source: cfg.Source, logger: logger.Named("audit")}
+	if a.source == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, err
+		}
+		a.source = hostname
+	}
+	if cfg.Path != "" {
+		f, err := os.OpenFile(cfg.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+		if err != nil {
+			return nil, err
+		}
+		a.w = f
+		a.enc = json.NewEncoder(f)
+	}
+	return a, nil
+}
+
+// record appends r, stamped with the source, to the audit stream
+func (a *auditLog) record(r auditRecord) {
+	r.Source = a.source
+	if a.enc == nil {
+		a.logger.Info("Probe sent",
+			zap.Time("time", r.Time),
+			zap.Time("scheduled", r.Scheduled),
+			zap.String("source", r.Source),
+			zap.String("endpoint", r.Endpoint),
+			zap.String("ip", r.IP),
+			zap.Int("packets_sent", r.PacketsSent),
+			zap.String("error", r.Error))
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.w == nil {
+		// Closed at shutdown
+		return
+	}
+	if err := a.enc.Encode(r); err != nil {
+		a.logger.Error("Failed to write audit record", zap.Error(err))
+	}
+}
+
+// close closes the audit file, it may be called more than once
+func (a *auditLog) close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.w == nil {
+		return nil
+	}
+	err := a.w.Close()
+	a.w = nil
+	return err
+}
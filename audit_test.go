@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+func TestScraperAuditFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{
+		{Endpoint: "192.0.2.1", Count: 4},
+		{Endpoint: "192.0.2.2", Count: 4},
+		// Injected faults do not send probes
+		{Endpoint: "192.0.2.3", Count: 4, FaultInjection: &FaultInjectionConfig{Error: "timeout"}},
+	}
+	cfg.Audit = AuditConfig{Enabled: true, Path: path, Source: "probe-1"}
+
+	started := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	clock := pingchecktest.NewClock(started)
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 4, PacketsRecv: 4})
+	fakeProber.SetRunError("192.0.2.2", errors.New("i/o timeout"))
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type),
+		newFactoryOptions(WithProber(fakeProber), WithClock(clock)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	// Probes are scheduled initial_delay after start, and sent late
+	scheduled := started.Add(cfg.InitialDelay)
+	now := scheduled.Add(3 * time.Millisecond)
+	clock.Set(now)
+	for i := range cfg.Targets {
+		_, _ = scraper.scrapeTarget(context.Background(), i)
+	}
+	require.NoError(t, scraper.shutdown(context.Background()))
+	// Closing again at the shutdown of another signal is fine
+	require.NoError(t, scraper.shutdown(context.Background()))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	records := make(map[string]auditRecord)
+	lines := bufio.NewScanner(f)
+	for lines.Scan() {
+		var r auditRecord
+		require.NoError(t, json.Unmarshal(lines.Bytes(), &r))
+		records[r.Endpoint] = r
+	}
+	require.Len(t, records, 2)
+	assert.Equal(t, auditRecord{Time: now, Scheduled: scheduled, Source: "probe-1", Endpoint: "192.0.2.1", IP: "192.0.2.1", PacketsSent: 4}, records["192.0.2.1"])
+	assert.Equal(t, auditRecord{Time: now, Scheduled: scheduled, Source: "probe-1", Endpoint: "192.0.2.2", Error: "i/o timeout"}, records["192.0.2.2"])
+}
+
+func TestAuditLogToCollectorLog(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	audit, err := newAuditLog(AuditConfig{Enabled: true}, zap.New(core))
+	require.NoError(t, err)
+
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+	audit.record(auditRecord{Time: time.Unix(1_700_000_000, 0), Endpoint: "192.0.2.1", IP: "192.0.2.1", PacketsSent: 4})
+	require.NoError(t, audit.close())
+
+	entries := logs.FilterMessage("Probe sent").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "audit", entries[0].LoggerName)
+	assert.Equal(t, hostname, entries[0].ContextMap()["source"])
+	assert.Equal(t, "192.0.2.1", entries[0].ContextMap()["endpoint"])
+	assert.Equal(t, int64(4), entries[0].ContextMap()["packets_sent"])
+}
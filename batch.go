@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+)
+
+// newBatchingConsumer passes metrics on to next in batches of at most
+// maxDataPoints data points, so large fleets stay within exporter limits
+func newBatchingConsumer(next consumer.Metrics, maxDataPoints int) (consumer.Metrics, error) {
+	return consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		var err error
+		for _, batch := range splitMetrics(md, maxDataPoints) {
+			err = multierr.Append(err, next.ConsumeMetrics(ctx, batch))
+		}
+		return err
+	}, consumer.WithCapabilities(next.Capabilities()))
+}
+
+// splitMetrics splits md into batches of at most maxDataPoints data points.
+// Metrics are split between data points, resources and scopes are repeated
+// in every batch they have data points in.
+func splitMetrics(md pmetric.Metrics, maxDataPoints int) []pmetric.Metrics {
+	if maxDataPoints <= 0 || md.DataPointCount() <= maxDataPoints {
+		return []pmetric.Metrics{md}
+	}
+
+	var batches []pmetric.Metrics
+	var sm pmetric.ScopeMetrics
+	size := maxDataPoints
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		srcRM := md.ResourceMetrics().At(i)
+		for j := 0; j < srcRM.ScopeMetrics().Len(); j++ {
+			srcSM := srcRM.ScopeMetrics().At(j)
+			// Data points of another scope go to an entry of their own
+			sameScope := false
+			for k := 0; k < srcSM.Metrics().Len(); k++ {
+				m := srcSM.Metrics().At(k)
+				total := dataPointCount(m)
+				for start := 0; start < total; {
+					if size == maxDataPoints {
+						batches = append(batches, pmetric.NewMetrics())
+						size = 0
+						sameScope = false
+					}
+					if !sameScope {
+						rm := batches[len(batches)-1].ResourceMetrics().AppendEmpty()
+						srcRM.Resource().CopyTo(rm.Resource())
+						rm.SetSchemaUrl(srcRM.SchemaUrl())
+						sm = rm.ScopeMetrics().AppendEmpty()
+						srcSM.Scope().CopyTo(sm.Scope())
+						sm.SetSchemaUrl(srcSM.SchemaUrl())
+						sameScope = true
+					}
+					end := min(total, start+maxDataPoints-size)
+					copyDataPoints(m, sm.Metrics().AppendEmpty(), start, end)
+					size += end - start
+					start = end
+				}
+			}
+		}
+	}
+	return batches
+}
+
+// dataPointCount returns the number of data points of m
+func dataPointCount(m pmetric.Metric) int {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		return m.Gauge().DataPoints().Len()
+	case pmetric.MetricTypeSum:
+		return m.Sum().DataPoints().Len()
+	case pmetric.MetricTypeHistogram:
+		return m.Histogram().DataPoints().Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		return m.ExponentialHistogram().DataPoints().Len()
+	case pmetric.MetricTypeSummary:
+		return m.Summary().DataPoints().Len()
+	}
+	return 0
+}
+
+// copyDataPoints copies src with only its data points in [start, end) to dest
+func copyDataPoints(src, dest pmetric.Metric, start, end int) {
+	dest.SetName(src.Name())
+	dest.SetDescription(src.Description())
+	dest.SetUnit(src.Unit())
+	src.Metadata().CopyTo(dest.Metadata())
+
+	switch src.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := dest.SetEmptyGauge().DataPoints()
+		for i := start; i < end; i++ {
+			src.Gauge().DataPoints().At(i).CopyTo(dps.AppendEmpty())
+		}
+	case pmetric.MetricTypeSum:
+		sum := dest.SetEmptySum()
+		sum.SetAggregationTemporality(src.Sum().AggregationTemporality())
+		sum.SetIsMonotonic(src.Sum().IsMonotonic())
+		for i := start; i < end; i++ {
+			src.Sum().DataPoints().At(i).CopyTo(sum.DataPoints().AppendEmpty())
+		}
+	case pmetric.MetricTypeHistogram:
+		histogram := dest.SetEmptyHistogram()
+		histogram.SetAggregationTemporality(src.Histogram().AggregationTemporality())
+		for i := start; i < end; i++ {
+			src.Histogram().DataPoints().At(i).CopyTo(histogram.DataPoints().AppendEmpty())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		histogram := dest.SetEmptyExponentialHistogram()
+		histogram.SetAggregationTemporality(src.ExponentialHistogram().AggregationTemporality())
+		for i := start; i < end; i++ {
+			src.ExponentialHistogram().DataPoints().At(i).CopyTo(histogram.DataPoints().AppendEmpty())
+		}
+	case pmetric.MetricTypeSummary:
+		dps := dest.SetEmptySummary().DataPoints()
+		for i := start; i < end; i++ {
+			src.Summary().DataPoints().At(i).CopyTo(dps.AppendEmpty())
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// newTestMetrics returns metrics of one resource per endpoint, each with a
+// gauge of gaugePoints and a sum of one data point
+func newTestMetrics(endpoints []string, gaugePoints int) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, endpoint := range endpoints {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("net.peer.name", endpoint)
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName("github.com/lukeod/pingcheckreceiver")
+
+		gauge := sm.Metrics().AppendEmpty()
+		gauge.SetName("ping.duration")
+		gauge.SetUnit("ms")
+		dps := gauge.SetEmptyGauge().DataPoints()
+		for i := 0; i < gaugePoints; i++ {
+			dps.AppendEmpty().SetDoubleValue(float64(i))
+		}
+
+		sum := sm.Metrics().AppendEmpty()
+		sum.SetName("ping.packets.sent")
+		sum.SetEmptySum().SetIsMonotonic(true)
+		sum.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		sum.Sum().DataPoints().AppendEmpty().SetIntValue(4)
+	}
+	return md
+}
+
+func TestSplitMetrics(t *testing.T) {
+	md := newTestMetrics([]string{"192.0.2.1", "192.0.2.2"}, 3)
+	require.Equal(t, 8, md.DataPointCount())
+
+	// Below the limit metrics are passed on as they are
+	assert.Len(t, splitMetrics(md, 0), 1)
+	assert.Len(t, splitMetrics(md, 8), 1)
+
+	batches := splitMetrics(md, 3)
+	require.Len(t, batches, 3)
+	assert.Equal(t, 3, batches[0].DataPointCount())
+	assert.Equal(t, 3, batches[1].DataPointCount())
+	assert.Equal(t, 2, batches[2].DataPointCount())
+
+	// The gauge of the second endpoint is split across batches
+	second := batches[1].ResourceMetrics()
+	require.Equal(t, 2, second.Len())
+	name, _ := second.At(1).Resource().Attributes().Get("net.peer.name")
+	assert.Equal(t, "192.0.2.2", name.Str())
+	gauge := second.At(1).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "ping.duration", gauge.Name())
+	assert.Equal(t, "ms", gauge.Unit())
+	assert.Equal(t, 2, gauge.Gauge().DataPoints().Len())
+	assert.Equal(t, "github.com/lukeod/pingcheckreceiver", second.At(1).ScopeMetrics().At(0).Scope().Name())
+
+	last := batches[2].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, last.Len())
+	assert.Equal(t, float64(2), last.At(0).Gauge().DataPoints().At(0).DoubleValue())
+	assert.True(t, last.At(1).Sum().IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, last.At(1).Sum().AggregationTemporality())
+}
+
+func TestBatchingConsumer(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	batching, err := newBatchingConsumer(sink, 2)
+	require.NoError(t, err)
+
+	require.NoError(t, batching.ConsumeMetrics(context.Background(), newTestMetrics([]string{"192.0.2.1", "192.0.2.2"}, 1)))
+	require.Len(t, sink.AllMetrics(), 2)
+	for _, md := range sink.AllMetrics() {
+		assert.Equal(t, 2, md.DataPointCount())
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// processBudget is shared by the receivers of every factory in the process,
+// e.g. instances run by different teams in pipelines of their own. Sockets
+// and the ICMP rate limit of the host are process-wide, so are their budget.
+var processBudget = newSocketBudget()
+
+// socketBudget bounds the runs of the receivers registered with it together:
+// how many are in flight, each holding a socket, and how many packets they
+// send per second. The strictest limits any receiver asks for apply to all.
+type socketBudget struct {
+	mu      sync.Mutex
+	members map[*pingScraper]budgetMember
+	running int
+
+	// Packets that may be sent right away, refilled at the packet rate up to
+	// one second's worth. Runs larger than that take it into debt.
+	tokens float64
+	filled time.Time
+
+	// changed is closed and replaced whenever a run ends or the limits change
+	changed chan struct{}
+
+	now func() time.Time
+}
+
+// budgetMember is what a receiver registered with the budget
+type budgetMember struct {
+	limits    SharedBudgetConfig
+	endpoints int
+}
+
+func newSocketBudget() *socketBudget {
+	return &socketBudget{
+		members: make(map[*pingScraper]budgetMember),
+		changed: make(chan struct{}),
+		now:     time.Now,
+	}
+}
+
+// register adds the receiver s probing endpoints to the budget, and returns
+// how many sockets the receivers may have open at once, in aggregate
+func (b *socketBudget) register(s *pingScraper, endpoints int) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.members[s] = budgetMember{limits: s.cfg.SharedBudget, endpoints: endpoints}
+	b.notifyLocked()
+
+	sockets := 0
+	for _, m := range b.members {
+		sockets += m.endpoints
+	}
+	if maxSockets, _ := b.limitsLocked(); maxSockets > 0 {
+		sockets = min(sockets, maxSockets)
+	}
+	return sockets
+}
+
+// unregister removes s from the budget, its limits no longer apply
+func (b *socketBudget) unregister(s *pingScraper) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.members[s]; ok {
+		delete(b.members, s)
+		b.notifyLocked()
+	}
+}
+
+// limitsLocked returns the strictest limits of the members, 0 if unlimited
+func (b *socketBudget) limitsLocked() (maxSockets int, packetRate float64) {
+	for _, m := range b.members {
+		if n := m.limits.MaxSockets; n > 0 && (maxSockets == 0 || n < maxSockets) {
+			maxSockets = n
+		}
+		if r := m.limits.MaxPacketsPerSecond; r > 0 && (packetRate == 0 || r < packetRate) {
+			packetRate = r
+		}
+	}
+	return maxSockets, packetRate
+}
+
+// acquire waits until a run sending packets fits the budget, and returns the
+// function releasing its socket once it ended
+func (b *socketBudget) acquire(ctx context.Context, packets int) (func(), error) {
+	for {
+		b.mu.Lock()
+		wait, ok := b.tryAcquireLocked(packets)
+		changed := b.changed
+		b.mu.Unlock()
+		if ok {
+			var once sync.Once
+			return func() { once.Do(b.release) }, nil
+		}
+
+		var timer *time.Timer
+		var expired <-chan time.Time
+		if wait > 0 {
+			timer = time.NewTimer(wait)
+			expired = timer.C
+		}
+		var err error
+		select {
+		case <-ctx.Done():
+			err = fmt.Errorf("waiting for the shared budget: %w", ctx.Err())
+		case <-changed:
+		case <-expired:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// tryAcquireLocked takes a socket and packets from the budget if both are
+// available. Otherwise it returns how long until enough packets are, 0 if
+// it is waiting for a socket.
+func (b *socketBudget) tryAcquireLocked(packets int) (time.Duration, bool) {
+	maxSockets, packetRate := b.limitsLocked()
+	if maxSockets > 0 && b.running >= maxSockets {
+		return 0, false
+	}
+	if packetRate > 0 {
+		now := b.now()
+		if b.filled.IsZero() {
+			b.tokens = packetRate
+		} else {
+			b.tokens = min(b.tokens+now.Sub(b.filled).Seconds()*packetRate, packetRate)
+		}
+		b.filled = now
+
+		need := min(float64(packets), packetRate)
+		if b.tokens < need {
+			return time.Duration((need - b.tokens) / packetRate * float64(time.Second)), false
+		}
+		b.tokens -= float64(packets)
+	}
+	b.running++
+	return 0, true
+}
+
+// release returns the socket of a run that ended
+func (b *socketBudget) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.running--
+	b.notifyLocked()
+}
+
+// notifyLocked wakes the runs waiting for the budget
+func (b *socketBudget) notifyLocked() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+// packets returns how many packets a run of target sends
+func (t Target) packets() int {
+	e := t.effective()
+	if e.Count == 0 {
+		return max(int(e.RunTimeout/e.Interval), 1)
+	}
+	packets := e.Count
+	if e.DiscardFirst {
+		packets++
+	}
+	if e.Prewarm {
+		packets++
+	}
+	return packets
+}
+
+// run runs pinger, which probes target, within the shared budget
+func (s *pingScraper) run(ctx context.Context, target Target, pinger prober.Pinger) (*prober.Statistics, error) {
+	stats, _, err := s.runBurst(ctx, target, pinger)
+	return stats, err
+}
+
+// runBurst is run, also returning when the packets started to be sent once
+// the budget allowed, zero if they were not
+func (s *pingScraper) runBurst(ctx context.Context, target Target, pinger prober.Pinger) (*prober.Statistics, time.Time, error) {
+	release, err := s.budget.acquire(ctx, target.packets())
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer release()
+	started := s.clock.Now()
+	stats, err := pinger.Run(ctx)
+	return stats, started, err
+}
@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+)
+
+// budgetScraper returns a scraper of cfg drawing on budget
+func budgetScraper(cfg *Config, budget *socketBudget) *pingScraper {
+	fo := newFactoryOptions(WithProber(pingchecktest.NewProber()))
+	fo.budget = budget
+	return newScraper(cfg, receivertest.NewNopSettings(metadata.Type), fo)
+}
+
+func TestSocketBudgetSockets(t *testing.T) {
+	budget := newSocketBudget()
+	unlimited := createDefaultConfig().(*Config)
+	limited := createDefaultConfig().(*Config)
+	limited.SharedBudget.MaxSockets = 1
+	a, b := budgetScraper(unlimited, budget), budgetScraper(limited, budget)
+	budget.register(a, 10)
+
+	// Without limits runs are not held back
+	releaseA, err := budget.acquire(context.Background(), 4)
+	require.NoError(t, err)
+	releaseB, err := budget.acquire(context.Background(), 4)
+	require.NoError(t, err)
+	releaseA()
+	releaseB()
+
+	// The strictest limit of any receiver applies to all
+	assert.Equal(t, 1, budget.register(b, 10))
+	release, err := budget.acquire(context.Background(), 4)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = budget.acquire(ctx, 4)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// A run waiting for a socket starts once one is released
+	acquired := make(chan func())
+	go func() {
+		release, err := budget.acquire(context.Background(), 4)
+		assert.NoError(t, err)
+		acquired <- release
+	}()
+	release()
+	release = <-acquired
+	release()
+	release() // Releasing again is a no-op
+
+	// The limit is lifted once the receiver asking for it leaves
+	release, err = budget.acquire(context.Background(), 4)
+	require.NoError(t, err)
+	budget.unregister(b)
+	releaseA, err = budget.acquire(context.Background(), 4)
+	require.NoError(t, err)
+	release()
+	releaseA()
+	assert.Zero(t, budget.running)
+}
+
+func TestSocketBudgetPacketRate(t *testing.T) {
+	budget := newSocketBudget()
+	now := time.Unix(1_700_000_000, 0)
+	budget.now = func() time.Time { return now }
+	cfg := createDefaultConfig().(*Config)
+	cfg.SharedBudget.MaxPacketsPerSecond = 10
+	budget.register(budgetScraper(cfg, budget), 1)
+
+	// One second's worth of packets may be sent right away
+	_, ok := budget.tryAcquireLocked(8)
+	assert.True(t, ok)
+	wait, ok := budget.tryAcquireLocked(4)
+	assert.False(t, ok)
+	assert.Equal(t, 200*time.Millisecond, wait)
+
+	now = now.Add(200 * time.Millisecond)
+	_, ok = budget.tryAcquireLocked(4)
+	assert.True(t, ok)
+
+	// Runs larger than that wait for a full bucket and take it into debt
+	now = now.Add(time.Second)
+	_, ok = budget.tryAcquireLocked(30)
+	assert.True(t, ok)
+	wait, ok = budget.tryAcquireLocked(1)
+	assert.False(t, ok)
+	assert.Equal(t, 2100*time.Millisecond, wait)
+}
+
+func TestTargetPackets(t *testing.T) {
+	assert.Equal(t, 5, Target{Count: 5}.packets())
+	assert.Equal(t, 7, Target{Count: 5, Prewarm: true, DiscardFirst: true}.packets())
+	assert.Equal(t, 20, Target{Duration: 10 * time.Second, Interval: 500 * time.Millisecond}.packets())
+}
+
+func TestScraperSharedBudgetLimits(t *testing.T) {
+	budget := newSocketBudget()
+	newCfg := func(endpoints ...string) *Config {
+		cfg := createDefaultConfig().(*Config)
+		for _, endpoint := range endpoints {
+			cfg.Targets = append(cfg.Targets, Target{Endpoint: endpoint, Count: 4})
+		}
+		return cfg
+	}
+	limits := func() hostLimits { return hostLimits{openFiles: fdHeadroom + 3} }
+
+	first := budgetScraper(newCfg("192.0.2.1", "192.0.2.2"), budget)
+	first.readLimits = limits
+	require.NoError(t, first.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, first.shutdown(context.Background())) }()
+
+	// File descriptors are shared with the other receivers of the process
+	second := budgetScraper(newCfg("192.0.2.3", "192.0.2.4"), budget)
+	second.readLimits = limits
+	err := second.start(context.Background(), componenttest.NewNopHost())
+	assert.EqualError(t, err, "probing 4 endpoints concurrently needs about 68 file descriptors, but the limit is 67: raise it (ulimit -n) or configure fewer targets")
+	require.NoError(t, second.shutdown(context.Background()))
+
+	// unless the budget keeps them below the limit
+	cfg := newCfg("192.0.2.3", "192.0.2.4")
+	cfg.SharedBudget.MaxSockets = 3
+	third := budgetScraper(cfg, budget)
+	third.readLimits = limits
+	require.NoError(t, third.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, third.shutdown(context.Background())) }()
+
+	for i := range cfg.Targets {
+		_, err = third.scrapeTarget(context.Background(), i)
+		require.NoError(t, err)
+	}
+	assert.Zero(t, budget.running)
+}
@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"time"
+
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// ConfigOption sets an option of a Config built with NewConfig
+type ConfigOption func(*Config)
+
+// TargetOption sets an option of a Target built with NewTarget
+type TargetOption func(*Target)
+
+// NewConfig returns the default config with opts applied in order, or an
+// error if the result is not valid. Programs assembling collector configs in
+// Go should prefer it over decoding hand-built maps.
+func NewConfig(opts ...ConfigOption) (*Config, error) {
+	cfg := createDefaultConfig().(*Config)
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// WithTarget adds a target probing endpoint, see AddTarget
+func WithTarget(endpoint string, opts ...TargetOption) ConfigOption {
+	return func(cfg *Config) {
+		cfg.AddTarget(endpoint, opts...)
+	}
+}
+
+// WithCollectionInterval sets how often targets are probed
+func WithCollectionInterval(interval time.Duration) ConfigOption {
+	return func(cfg *Config) {
+		cfg.CollectionInterval = interval
+	}
+}
+
+// WithPrivileged sets whether raw ICMP sockets are used
+func WithPrivileged(privileged bool) ConfigOption {
+	return func(cfg *Config) {
+		cfg.Privileged = privileged
+	}
+}
+
+// WithProfile sets the profile and the options it presets. Options applied
+// after it take precedence over the preset. Unknown profiles fail validation.
+func WithProfile(profile string) ConfigOption {
+	return func(cfg *Config) {
+		cfg.Profile = profile
+		if preset, ok := profiles[profile]; ok {
+			preset.apply(cfg)
+		}
+	}
+}
+
+// AddTarget adds a target probing endpoint with the same defaults as a target
+// decoded from YAML and opts applied in order
+func (cfg *Config) AddTarget(endpoint string, opts ...TargetOption) {
+	cfg.Targets = append(cfg.Targets, NewTarget(endpoint, opts...))
+}
+
+// NewTarget returns a target probing endpoint with the same defaults as a
+// target decoded from YAML and opts applied in order
+func NewTarget(endpoint string, opts ...TargetOption) Target {
+	t := Target{Endpoint: endpoint, Count: defaultCount, RunTimeout: defaultRunTimeout, Interval: defaultInterval}
+	for _, opt := range opts {
+		opt(&t)
+	}
+	return t
+}
+
+// WithCount sets the number of packets sent per probe, 0 keeps the default.
+// WithDuration pings continuously instead.
+func WithCount(count int) TargetOption {
+	return func(t *Target) {
+		t.Count = count
+	}
+}
+
+// WithDuration pings continuously at the interval for duration, instead of
+// sending a number of packets within the run timeout
+func WithDuration(duration time.Duration) TargetOption {
+	return func(t *Target) {
+		t.Count = 0
+		t.RunTimeout = 0
+		t.Duration = duration
+	}
+}
+
+// WithInterval sets the interval between packets
+func WithInterval(interval time.Duration) TargetOption {
+	return func(t *Target) {
+		t.Interval = interval
+	}
+}
+
+// WithRunTimeout bounds a whole probe
+func WithRunTimeout(timeout time.Duration) TargetOption {
+	return func(t *Target) {
+		t.RunTimeout = timeout
+	}
+}
+
+// WithPacketTimeout sets how long to wait for each reply
+func WithPacketTimeout(timeout time.Duration) TargetOption {
+	return func(t *Target) {
+		t.PacketTimeout = timeout
+	}
+}
+
+// WithTCPPort probes the target by connecting to port instead of pinging
+func WithTCPPort(port int) TargetOption {
+	return func(t *Target) {
+		t.Protocol = prober.ProtocolTCP
+		t.Port = port
+	}
+}
+
+// WithNetwork resolves the endpoint in network, ip4 or ip6 to force an
+// address family
+func WithNetwork(network string) TargetOption {
+	return func(t *Target) {
+		t.Network = network
+	}
+}
+
+// WithDualStack probes both the IPv4 and the IPv6 address of the endpoint
+func WithDualStack() TargetOption {
+	return func(t *Target) {
+		t.DualStack = true
+	}
+}
+
+// WithResolveAll probes every address the hostname of the endpoint resolves to
+func WithResolveAll() TargetOption {
+	return func(t *Target) {
+		t.ResolveAll = true
+	}
+}
+
+// WithGroup sets the group of the target
+func WithGroup(group string) TargetOption {
+	return func(t *Target) {
+		t.Group = group
+	}
+}
+
+// WithLabels sets the labels added as attributes to every data point of the target
+func WithLabels(labels map[string]string) TargetOption {
+	return func(t *Target) {
+		t.Labels = labels
+	}
+}
+
+// WithPriority sets the priority of the target in the first scrape after start
+func WithPriority(priority int) TargetOption {
+	return func(t *Target) {
+		t.Priority = priority
+	}
+}
+
+// WithWeight sets the weight of the target under max_concurrent_probes
+func WithWeight(weight int) TargetOption {
+	return func(t *Target) {
+		t.Weight = weight
+	}
+}
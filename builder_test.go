@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestNewConfigMatchesDecoded(t *testing.T) {
+	built, err := NewConfig(
+		WithProfile(profileLarge),
+		WithCollectionInterval(30*time.Second),
+		WithTarget("192.0.2.1"),
+		WithTarget("router.example.com",
+			WithGroup("branch"),
+			WithLabels(map[string]string{"tier": "edge"}),
+			WithWeight(10),
+			WithPriority(5),
+			WithCount(10),
+			WithInterval(200*time.Millisecond),
+			WithRunTimeout(3*time.Second),
+			WithPacketTimeout(time.Second)),
+		WithTarget("192.0.2.2", WithDuration(10*time.Second)),
+		WithTarget("api.example.com", WithTCPPort(443), WithNetwork("ip6")),
+		WithTarget("anycast.example.com", WithResolveAll()),
+	)
+	require.NoError(t, err)
+
+	decoded := createDefaultConfig().(*Config)
+	require.NoError(t, decoded.Unmarshal(confmap.NewFromStringMap(map[string]any{
+		"profile":             "large",
+		"collection_interval": "30s",
+		"targets": []any{
+			map[string]any{"endpoint": "192.0.2.1"},
+			map[string]any{
+				"endpoint":       "router.example.com",
+				"group":          "branch",
+				"labels":         map[string]any{"tier": "edge"},
+				"weight":         10,
+				"priority":       5,
+				"count":          10,
+				"interval":       "200ms",
+				"run_timeout":    "3s",
+				"packet_timeout": "1s",
+			},
+			map[string]any{"endpoint": "192.0.2.2", "duration": "10s"},
+			map[string]any{"endpoint": "api.example.com", "protocol": "tcp", "port": 443, "network": "ip6"},
+			map[string]any{"endpoint": "anycast.example.com", "resolve_all": true},
+		},
+	})))
+	assert.Equal(t, decoded, built)
+}
+
+func TestNewConfigOptionOrder(t *testing.T) {
+	cfg, err := NewConfig(WithProfile(profileLarge), WithTarget("192.0.2.1"), func(cfg *Config) {
+		cfg.MaxConcurrentProbes = 100
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 100, cfg.MaxConcurrentProbes)
+	assert.Equal(t, 5_000, cfg.MaxDatapointsPerBatch)
+}
+
+func TestNewConfigInvalid(t *testing.T) {
+	_, err := NewConfig()
+	assert.EqualError(t, err, "at least one target must be specified")
+
+	_, err = NewConfig(WithTarget("192.0.2.1", WithCount(-1)))
+	assert.EqualError(t, err, "targets[0]: count cannot be negative")
+}
+
+func TestConfigAddTarget(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.AddTarget("192.0.2.1")
+	cfg.AddTarget("192.0.2.2", WithCount(0))
+	assert.Equal(t, []Target{
+		{Endpoint: "192.0.2.1", Count: defaultCount, RunTimeout: defaultRunTimeout, Interval: defaultInterval},
+		{Endpoint: "192.0.2.2", RunTimeout: defaultRunTimeout, Interval: defaultInterval},
+	}, cfg.Targets)
+	require.NoError(t, cfg.Validate())
+}
@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/multierr"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+)
+
+// CalendarConfig defines the business hours availability is measured in,
+// e.g. those of a customer SLA
+type CalendarConfig struct {
+	// Timezone the hours are written in, e.g. Europe/Berlin (default: Local)
+	Timezone string `mapstructure:"timezone"`
+
+	// Hours are the windows of business hours
+	Hours []TimeWindow `mapstructure:"hours"`
+
+	// Groups whose targets use the calendar unless they name one
+	Groups []string `mapstructure:"groups"`
+}
+
+// downtime is how long a target was down, and the share of that within the
+// business hours of its calendar
+type downtime struct {
+	total   time.Duration
+	inHours time.Duration
+}
+
+// downtimeBetween returns the downtime from start to end, split by c if not nil
+func downtimeBetween(c *CalendarConfig, start, end time.Time) downtime {
+	d := downtime{total: end.Sub(start)}
+	if c != nil {
+		d.inHours = c.inHours(start, end)
+	}
+	return d
+}
+
+// inHours returns the share of the time from start to end within the hours
+// of c. Windows are whole minutes, so it is taken minute by minute.
+func (c *CalendarConfig) inHours(start, end time.Time) time.Duration {
+	// Validated with the config
+	loc, _ := time.LoadLocation(c.Timezone)
+	var total time.Duration
+	for t := start; t.Before(end); {
+		next := t.Truncate(time.Minute).Add(time.Minute)
+		if next.After(end) {
+			next = end
+		}
+		if c.contains(t.In(loc)) {
+			total += next.Sub(t)
+		}
+		t = next
+	}
+	return total
+}
+
+// contains reports whether t, in the time zone of c, is in business hours
+func (c *CalendarConfig) contains(t time.Time) bool {
+	return slices.ContainsFunc(c.Hours, func(w TimeWindow) bool {
+		return w.contains(t)
+	})
+}
+
+// targetCalendar returns the calendar target names, or else that of its
+// group, nil if none
+func (cfg *Config) targetCalendar(target Target) *CalendarConfig {
+	if target.Calendar != "" {
+		if c, ok := cfg.Calendars[target.Calendar]; ok {
+			return &c
+		}
+		return nil
+	}
+	if target.Group == "" {
+		return nil
+	}
+	for _, name := range slices.Sorted(maps.Keys(cfg.Calendars)) {
+		if c := cfg.Calendars[name]; slices.Contains(c.Groups, target.Group) {
+			return &c
+		}
+	}
+	return nil
+}
+
+// endpointCalendar returns the calendar of the first target of endpoint,
+// whose probes the others share
+func (cfg *Config) endpointCalendar(endpoint string) *CalendarConfig {
+	for _, target := range cfg.Targets {
+		if target.Endpoint == endpoint {
+			return cfg.targetCalendar(target)
+		}
+	}
+	return nil
+}
+
+func validateCalendars(calendars map[string]CalendarConfig) error {
+	var err error
+	groups := make(map[string]string)
+	for _, name := range slices.Sorted(maps.Keys(calendars)) {
+		c := calendars[name]
+		var cErr error
+		if _, tErr := time.LoadLocation(c.Timezone); tErr != nil {
+			cErr = multierr.Append(cErr, within("timezone", asInvalid("", CodeInvalidValue, tErr)))
+		}
+		if len(c.Hours) == 0 {
+			cErr = multierr.Append(cErr, invalid("hours", CodeRequired, "hours cannot be empty"))
+		}
+		for i, w := range c.Hours {
+			cErr = multierr.Append(cErr, within(fmt.Sprintf("hours[%d]", i), w.validate()))
+		}
+		for i, group := range c.Groups {
+			if other, ok := groups[group]; ok {
+				cErr = multierr.Append(cErr, within(fmt.Sprintf("groups[%d]", i),
+					invalid("", CodeConflict, "group %q already uses calendar %q", group, other)))
+				continue
+			}
+			groups[group] = name
+		}
+		err = multierr.Append(err, within(name, cErr))
+	}
+	return within("calendars", err)
+}
+
+// recordBusinessHours records the downtime of target within and outside the
+// business hours of its calendar, if it has one
+func (s *pingScraper) recordBusinessHours(mb *metadata.MetricsBuilder, metrics metadata.MetricsConfig, target Target, o *probeOutcome) {
+	if !metrics.PingBusinessHoursDowntime.Enabled || s.cfg.targetCalendar(target) == nil {
+		return
+	}
+	d := s.reportedDowntime(o)
+	now := pcommon.NewTimestampFromTime(s.clock.Now())
+	mb.RecordPingBusinessHoursDowntimeDataPoint(now, d.inHours.Seconds(), target.Endpoint, true)
+	mb.RecordPingBusinessHoursDowntimeDataPoint(now, (d.total - d.inHours).Seconds(), target.Endpoint, false)
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+func TestCalendarInHours(t *testing.T) {
+	c := &CalendarConfig{
+		Timezone: "UTC",
+		Hours:    []TimeWindow{{Days: []string{"mon"}, Start: "09:00", End: "17:00"}},
+	}
+	monday := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	// Only the share from 09:00 counts
+	d := downtimeBetween(c, monday.Add(8*time.Hour+30*time.Minute+15*time.Second), monday.Add(9*time.Hour+20*time.Minute))
+	assert.Equal(t, 49*time.Minute+45*time.Second, d.total)
+	assert.Equal(t, 20*time.Minute, d.inHours)
+
+	// Tuesday is not a business day
+	d = downtimeBetween(c, monday.Add(33*time.Hour), monday.Add(34*time.Hour))
+	assert.Equal(t, time.Duration(0), d.inHours)
+
+	// Without a calendar nothing is in hours
+	d = downtimeBetween(nil, monday.Add(10*time.Hour), monday.Add(11*time.Hour))
+	assert.Equal(t, time.Hour, d.total)
+	assert.Equal(t, time.Duration(0), d.inHours)
+}
+
+func TestTargetCalendar(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Calendars = map[string]CalendarConfig{
+		"office": {Timezone: "UTC", Groups: []string{"branch"}},
+		"store":  {Timezone: "Europe/Berlin"},
+	}
+
+	assert.Equal(t, "UTC", cfg.targetCalendar(Target{Group: "branch"}).Timezone)
+	assert.Equal(t, "Europe/Berlin", cfg.targetCalendar(Target{Group: "branch", Calendar: "store"}).Timezone)
+	assert.Nil(t, cfg.targetCalendar(Target{Group: "core"}))
+	assert.Nil(t, cfg.targetCalendar(Target{}))
+}
+
+func TestScraperBusinessHoursDowntime(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics.PingBusinessHoursDowntime.Enabled = true
+	cfg.Calendars = map[string]CalendarConfig{"office": {
+		Timezone: "UTC",
+		Hours:    []TimeWindow{{Start: "09:00", End: "17:00"}},
+	}}
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4, Calendar: "office"}, {Endpoint: "192.0.2.2", Count: 4}}
+
+	// Down from 16:58 to 17:02, half of it in business hours
+	clock := pingchecktest.NewClock(time.Date(2026, 1, 5, 16, 58, 0, 0, time.UTC))
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 4})
+	fakeProber.SetResult("192.0.2.2", prober.Statistics{PacketsSent: 4})
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type),
+		newFactoryOptions(WithProber(fakeProber), WithClock(clock)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	_, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	clock.Advance(4 * time.Minute)
+	md, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+
+	got := map[bool]float64{}
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		if m.Name() != "ping.business_hours.downtime" {
+			return
+		}
+		for i := 0; i < m.Sum().DataPoints().Len(); i++ {
+			dp := m.Sum().DataPoints().At(i)
+			inHours, _ := dp.Attributes().Get("business_hours")
+			got[inHours.Bool()] = dp.DoubleValue()
+		}
+	})
+	assert.Equal(t, map[bool]float64{true: 120, false: 120}, got)
+
+	// Targets without a calendar only report ping.downtime
+	md, err = scraper.scrapeTarget(context.Background(), 1)
+	require.NoError(t, err)
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		assert.NotEqual(t, "ping.business_hours.downtime", m.Name())
+	})
+}
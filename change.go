@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"math"
+)
+
+// skipUnchanged reports whether the metrics of outcome o are dropped because
+// they barely changed since the target's results were last emitted. Failures
+// are always emitted, so are results older than max_staleness.
+func (s *pingScraper) skipUnchanged(b *targetBuilder, o *probeOutcome) bool {
+	cfg := s.cfg.ReportOnChange
+	if !cfg.Enabled {
+		return false
+	}
+
+	now := s.clock.Now()
+	last := b.reported
+	if o.err == nil && !o.suppressed && last != nil && now.Sub(b.reportedAt) < cfg.MaxStaleness &&
+		(last.stats.AvgRtt-o.stats.AvgRtt).Abs() < cfg.MinRTTChange &&
+		math.Abs(last.stats.PacketLoss-o.stats.PacketLoss)/100 < cfg.MinLossChange {
+		return true
+	}
+
+	// The first success after a failure is always emitted
+	if o.err == nil && !o.suppressed {
+		b.reported = o
+	} else {
+		b.reported = nil
+	}
+	b.reportedAt = now
+	return false
+}
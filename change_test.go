@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+func TestScraperReportOnChange(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4}}
+	cfg.ReportOnChange.Enabled = true
+
+	fakeProber := pingchecktest.NewProber()
+	clock := pingchecktest.NewClock(time.Unix(1_700_000_000, 0))
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber), WithClock(clock)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	emitted := func(stats prober.Statistics) bool {
+		fakeProber.SetResult("192.0.2.1", stats)
+		clock.Advance(time.Minute)
+		md, _ := scraper.scrapeTarget(context.Background(), 0)
+		return md.DataPointCount() > 0
+	}
+	healthy := prober.Statistics{PacketsSent: 4, PacketsRecv: 4, AvgRtt: 20 * time.Millisecond}
+
+	assert.True(t, emitted(healthy))
+	// Below min_rtt_change of the last emitted result
+	assert.False(t, emitted(prober.Statistics{PacketsSent: 4, PacketsRecv: 4, AvgRtt: 23 * time.Millisecond}))
+	assert.False(t, emitted(prober.Statistics{PacketsSent: 4, PacketsRecv: 4, AvgRtt: 16 * time.Millisecond}))
+	assert.True(t, emitted(prober.Statistics{PacketsSent: 4, PacketsRecv: 4, AvgRtt: 26 * time.Millisecond}))
+	// Loss of a quarter of the packets is a change
+	assert.True(t, emitted(prober.Statistics{PacketsSent: 4, PacketsRecv: 3, PacketLoss: 25, AvgRtt: 26 * time.Millisecond}))
+	assert.True(t, emitted(healthy))
+
+	// Unchanged results are emitted again after max_staleness
+	for range 4 {
+		assert.False(t, emitted(healthy))
+	}
+	assert.True(t, emitted(healthy))
+}
+
+func TestScraperReportOnChangeEmitsFailures(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4}}
+	cfg.ReportOnChange.Enabled = true
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 4, PacketsRecv: 4, AvgRtt: 20 * time.Millisecond})
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	b := scraper.builders[0]
+	assert.False(t, scraper.skipUnchanged(b, &probeOutcome{stats: &prober.Statistics{AvgRtt: 20 * time.Millisecond}}))
+	failure := &probeOutcome{err: errors.New("i/o timeout")}
+	assert.False(t, scraper.skipUnchanged(b, failure))
+	assert.False(t, scraper.skipUnchanged(b, failure))
+	// The first success after a failure is emitted
+	assert.False(t, scraper.skipUnchanged(b, &probeOutcome{stats: &prober.Statistics{AvgRtt: 20 * time.Millisecond}}))
+	assert.True(t, scraper.skipUnchanged(b, &probeOutcome{stats: &prober.Statistics{AvgRtt: 20 * time.Millisecond}}))
+}
@@ -4,14 +4,22 @@
 package pingcheckreceiver
 
 import (
-	"errors"
+	"cmp"
 	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"runtime"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/scraper/scraperhelper"
 	"go.uber.org/multierr"
 
 	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/prober"
 )
 
 // Config defines the configuration for the Ping receiver
@@ -22,8 +30,356 @@ type Config struct {
 	// Targets to ping
 	Targets []Target `mapstructure:"targets"`
 
+	// Profile presets options for a deployment size: small, medium or large.
+	// Options configured explicitly take precedence (default: none)
+	Profile string `mapstructure:"profile"`
+
 	// Privileged mode for raw ICMP sockets
 	Privileged bool `mapstructure:"privileged"`
+
+	// StrictReplies only counts replies from the probed address that echo the
+	// whole payload, other replies count as lost (default: false)
+	StrictReplies bool `mapstructure:"strict_replies"`
+
+	// Share of samples dropped from each end for ping.duration.trimmed_mean (default: 10)
+	TrimmedMeanPercent float64 `mapstructure:"trimmed_mean_percent"`
+
+	// AllowEmptyTargets starts the receiver even if no target is configured or
+	// none can be resolved yet, e.g. when targets come from discovery (default: false)
+	AllowEmptyTargets bool `mapstructure:"allow_empty_targets"`
+
+	// MaxConcurrentProbes limits how many targets are probed at once, by
+	// descending weight, 0 probes all at once (default: 0)
+	MaxConcurrentProbes int `mapstructure:"max_concurrent_probes"`
+
+	// SharedBudget bounds the probes of every receiver in the collector
+	// together, the strictest budget of any receiver applies to all
+	SharedBudget SharedBudgetConfig `mapstructure:"shared_budget"`
+
+	// Shedding drops the detailed metrics of low priority targets while the
+	// collector is short of memory, keeping whether they are up
+	Shedding SheddingConfig `mapstructure:"shedding"`
+
+	// AllowedCIDRs are the only ranges targets may be probed in, checked
+	// before probing as hostnames may resolve elsewhere (default: any)
+	AllowedCIDRs []string `mapstructure:"allowed_cidrs"`
+
+	// DeniedCIDRs are ranges targets are never probed in, even if allowed
+	DeniedCIDRs []string `mapstructure:"denied_cidrs"`
+
+	// RecreateThreshold is the number of consecutive DNS or socket errors after
+	// which a target's pinger is recreated with fresh resolution, 0 disables (default: 3)
+	RecreateThreshold int `mapstructure:"recreate_threshold"`
+
+	// ResolveMode is when the hostnames of targets are resolved: startup, once
+	// when their pinger is created, every_scrape, before every probe, or ttl,
+	// before the first probe after resolution_ttl passed (default: startup)
+	ResolveMode string `mapstructure:"resolve_mode"`
+
+	// ResolutionTTL is how long an address is probed in the ttl resolve_mode
+	// before its hostname is resolved again (default: 5m)
+	ResolutionTTL time.Duration `mapstructure:"resolution_ttl"`
+
+	// MaxDatapointsPerBatch splits the metrics of a scrape into batches of at
+	// most this many data points, 0 disables (default: 0)
+	MaxDatapointsPerBatch int `mapstructure:"max_datapoints_per_batch"`
+
+	// Overflow caps the data points of a collection cycle
+	Overflow OverflowConfig `mapstructure:"overflow"`
+
+	// HealthyEmitEvery emits the metrics of targets answering every packet
+	// only every this many scrapes, failures and loss are always emitted.
+	// 0 or 1 emits every scrape (default: 0)
+	HealthyEmitEvery int `mapstructure:"healthy_emit_every"`
+
+	// MetricPrefix is prepended to the name of every metric, e.g. corp.netmon
+	// for corp.netmon.ping.duration (default: none)
+	MetricPrefix string `mapstructure:"metric_prefix"`
+
+	// MetricNames selects the names of metrics renamed by a release during
+	// their deprecation window: both, new or old (default: both)
+	MetricNames string `mapstructure:"metric_names"`
+
+	// CounterTemporality of the sum metrics: cumulative or delta (default: cumulative)
+	CounterTemporality string `mapstructure:"counter_temporality"`
+
+	// DurationHistogram reports the round-trip times of individual packets as
+	// a histogram, for percentiles the aggregate metrics cannot give
+	DurationHistogram DurationHistogramConfig `mapstructure:"duration_histogram"`
+
+	// Heatmap defines the buckets of ping.duration.heatmap
+	Heatmap HeatmapConfig `mapstructure:"heatmap"`
+
+	// ResourceAttributes are text/template strings evaluated per target, e.g.
+	// service.name: "probe-{{ .Group }}"
+	ResourceAttributes map[string]string `mapstructure:"resource_attributes"`
+
+	// ReportOnChange drops the metrics of targets whose results barely changed
+	ReportOnChange ReportOnChangeConfig `mapstructure:"report_on_change"`
+
+	// Audit records every probe sent, for compliance reviews
+	Audit AuditConfig `mapstructure:"audit"`
+
+	// Diagnostics collected for targets that stay down
+	Diagnostics DiagnosticsConfig `mapstructure:"diagnostics"`
+
+	// InterfaceCheck relates failures to the local egress interface being down
+	InterfaceCheck InterfaceCheckConfig `mapstructure:"interface_check"`
+
+	// Holidays suppresses or tags probes during the events of an iCalendar,
+	// e.g. regional holidays and change freezes
+	Holidays HolidaysConfig `mapstructure:"holidays"`
+
+	// ConnectivityCheck detects captive portals
+	ConnectivityCheck ConnectivityCheckConfig `mapstructure:"connectivity_check"`
+
+	// LocalCheck probes the local network before failures are attributed to targets
+	LocalCheck LocalCheckConfig `mapstructure:"local_check"`
+
+	// FirstResponder elevates probing while many targets are down
+	FirstResponder FirstResponderConfig `mapstructure:"first_responder"`
+
+	// PassiveCheck asks a flow source whether failing targets were seen sending traffic
+	PassiveCheck PassiveCheckConfig `mapstructure:"passive_check"`
+
+	// Traces defines the spans of probes reported in a traces pipeline
+	Traces TracesConfig `mapstructure:"traces"`
+
+	// Calendars define business hours by name, for targets naming them or
+	// in their groups, see ping.business_hours.downtime
+	Calendars map[string]CalendarConfig `mapstructure:"calendars"`
+
+	// HealthRegistry is an extension implementing HealthRegistrar the
+	// reachability of the targets is published to (default: none)
+	HealthRegistry *component.ID `mapstructure:"health_registry"`
+
+	// SelfTest probes loopback once at start and reports the outcome as the
+	// receiver's component status (default: false)
+	SelfTest bool `mapstructure:"self_test"`
+
+	// Deduplicate probes targets that other receivers with deduplicate enabled
+	// also probe only once, e.g. a host found by several discovery sources.
+	// Its data points carry the labels of every such target (default: false)
+	Deduplicate bool `mapstructure:"deduplicate"`
+
+	// SourceWatch recreates the pingers of targets whose local source address
+	// changed, e.g. after a DHCP renewal or an uplink failover (default: false)
+	SourceWatch bool `mapstructure:"source_watch"`
+}
+
+// OverflowConfig defines what happens to data points beyond the limit of a collection cycle
+type OverflowConfig struct {
+	// MaxDatapoints emitted per collection cycle, 0 disables (default: 0)
+	MaxDatapoints int `mapstructure:"max_datapoints"`
+
+	// Policy for the data points beyond the limit: drop_oldest or
+	// aggregate_overflow (default: drop_oldest)
+	Policy string `mapstructure:"policy"`
+}
+
+// SharedBudgetConfig bounds the probes of every receiver in the process
+// together, e.g. instances of several teams in one collector
+type SharedBudgetConfig struct {
+	// MaxSockets is how many probes may run at once, each holding a socket,
+	// 0 does not limit them (default: 0)
+	MaxSockets int `mapstructure:"max_sockets"`
+
+	// MaxPacketsPerSecond is how many packets probes may send per second,
+	// 0 does not limit them (default: 0)
+	MaxPacketsPerSecond float64 `mapstructure:"max_packets_per_second"`
+}
+
+// SheddingConfig defines when the detailed metrics of targets are shed
+type SheddingConfig struct {
+	// Enabled turns on shedding (default: false)
+	Enabled bool `mapstructure:"enabled"`
+
+	// LimitMiB is the memory the collector may use, 0 uses the memory limit
+	// of its cgroup (default: 0)
+	LimitMiB uint64 `mapstructure:"limit_mib"`
+
+	// SoftLimitPercentage is the share of the limit above which the targets
+	// of lowest priority are shed (default: 80)
+	SoftLimitPercentage uint32 `mapstructure:"soft_limit_percentage"`
+
+	// HardLimitPercentage is the share of the limit above which every target
+	// is shed (default: 95)
+	HardLimitPercentage uint32 `mapstructure:"hard_limit_percentage"`
+}
+
+// DurationHistogramConfig defines the ping.duration.histogram metric
+type DurationHistogramConfig struct {
+	// Enabled turns on the metric (default: false)
+	Enabled bool `mapstructure:"enabled"`
+
+	// Type of the histogram: explicit or exponential (default: explicit)
+	Type string `mapstructure:"type"`
+
+	// Buckets are the upper bounds of the buckets of explicit histograms in
+	// milliseconds, in increasing order (default: 1, 2, 5, 10, 20, 50, 100,
+	// 200, 500, 1000, 2000)
+	Buckets []float64 `mapstructure:"buckets"`
+
+	// MaxScale of exponential histograms, lowered as needed to fit the
+	// round-trip times of a probe into 160 buckets, -10 to 20 (default: 20)
+	MaxScale int32 `mapstructure:"max_scale"`
+}
+
+// HeatmapConfig defines the buckets the round-trip times of a probe are
+// counted into for ping.duration.heatmap
+type HeatmapConfig struct {
+	// Buckets are the upper bounds of the buckets in milliseconds, in
+	// increasing order (default: 1, 2, 5, 10, 25, 50, 100, 250, 500, 1000)
+	Buckets []float64 `mapstructure:"buckets"`
+}
+
+// ReportOnChangeConfig defines when a target's results changed enough to be emitted
+type ReportOnChangeConfig struct {
+	// Enabled turns on dropping unchanged results (default: false)
+	Enabled bool `mapstructure:"enabled"`
+
+	// MinRTTChange of the average round-trip time that is reported (default: 5ms)
+	MinRTTChange time.Duration `mapstructure:"min_rtt_change"`
+
+	// MinLossChange of the packet loss ratio that is reported (default: 0.05)
+	MinLossChange float64 `mapstructure:"min_loss_change"`
+
+	// MaxStaleness after which results are emitted even if unchanged (default: 5m)
+	MaxStaleness time.Duration `mapstructure:"max_staleness"`
+}
+
+// AuditConfig defines the audit stream of probes
+type AuditConfig struct {
+	// Enabled turns on recording every probe sent (default: false)
+	Enabled bool `mapstructure:"enabled"`
+
+	// Path of a file records are appended to as JSON lines, the collector
+	// log is used when empty
+	Path string `mapstructure:"path"`
+
+	// Source identifying this collector in records (default: the hostname)
+	Source string `mapstructure:"source"`
+}
+
+// LocalCheckConfig defines the local stack health check run on failure
+type LocalCheckConfig struct {
+	// Enabled turns on probing loopback and the default gateway on failure (default: false)
+	Enabled bool `mapstructure:"enabled"`
+
+	// Gateway to probe, detected from the routing table on Linux when empty
+	Gateway string `mapstructure:"gateway"`
+
+	// Timeout for each probe (default: 1s)
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// FirstResponderConfig defines when and how probing is elevated during incidents
+type FirstResponderConfig struct {
+	// Enabled turns on elevated probing (default: false)
+	Enabled bool `mapstructure:"enabled"`
+
+	// DownFraction of targets down that starts an incident (default: 0.5)
+	DownFraction float64 `mapstructure:"down_fraction"`
+
+	// Interval between packets during an incident (default: 200ms)
+	Interval time.Duration `mapstructure:"interval"`
+
+	// Duration of an incident's elevated probing (default: 10m)
+	Duration time.Duration `mapstructure:"duration"`
+}
+
+// TracesConfig defines the spans of probes
+type TracesConfig struct {
+	// PacketSpans adds a child span for every packet of a probe (default: false)
+	PacketSpans bool `mapstructure:"packet_spans"`
+}
+
+// PassiveCheckConfig defines where passively observed traffic is looked up
+type PassiveCheckConfig struct {
+	// Extension implementing FlowSource, e.g. one fed by NetFlow or conntrack
+	// (default: none, unless a FlowSource is given as a factory option)
+	Extension *component.ID `mapstructure:"extension"`
+
+	// Window before a failed probe in which traffic counts as seen (default: 5m)
+	Window time.Duration `mapstructure:"window"`
+}
+
+// ConnectivityCheckConfig defines the captive portal check
+type ConnectivityCheckConfig struct {
+	// Enabled turns on the connectivity.state metric (default: false)
+	Enabled bool `mapstructure:"enabled"`
+
+	// URL expected to answer 204 No Content (default: http://connectivitycheck.gstatic.com/generate_204)
+	URL string `mapstructure:"url"`
+
+	// Timeout for the check (default: 5s)
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+const (
+	temporalityCumulative = "cumulative"
+	temporalityDelta      = "delta"
+)
+
+// Modes of resolve_mode
+const (
+	resolveStartup     = "startup"
+	resolveEveryScrape = "every_scrape"
+	resolveTTL         = "ttl"
+)
+
+const (
+	overflowDropOldest = "drop_oldest"
+	overflowAggregate  = "aggregate_overflow"
+)
+
+const (
+	interfaceActionTag      = "tag"
+	interfaceActionSuppress = "suppress"
+)
+
+// InterfaceCheckConfig defines how failures are handled while the local egress interface is down
+type InterfaceCheckConfig struct {
+	// Enabled turns on watching the host's network interfaces (default: false)
+	Enabled bool `mapstructure:"enabled"`
+
+	// Action for failures while the egress interface is down: tag or suppress (default: tag)
+	Action string `mapstructure:"action"`
+}
+
+// HolidaysConfig defines the iCalendar whose events suppress or tag the
+// probes of matching targets. Events with categories match the targets of
+// those groups, events without match every target.
+type HolidaysConfig struct {
+	// Source is the path or http(s) URL of the iCalendar file (default: none)
+	Source string `mapstructure:"source"`
+
+	// RefreshInterval is how often the calendar is loaded again (default: 1h)
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+
+	// Timezone of all-day and floating events, e.g. Europe/Berlin (default: Local)
+	Timezone string `mapstructure:"timezone"`
+
+	// Action for probes during events: suppress or tag (default: suppress)
+	Action string `mapstructure:"action"`
+}
+
+// DiagnosticsConfig defines the diagnostic bundle run on sustained failure
+type DiagnosticsConfig struct {
+	// Enabled turns on diagnostics for failing targets (default: false)
+	Enabled bool `mapstructure:"enabled"`
+
+	// Number of consecutive failed scrapes before diagnostics run (default: 3)
+	FailureThreshold int `mapstructure:"failure_threshold"`
+
+	// DNS resolvers (host:port) to query for hostname targets
+	Resolvers []string `mapstructure:"resolvers"`
+
+	// TCP ports to probe on the target
+	TCPPorts []int `mapstructure:"tcp_ports"`
+
+	// Timeout for the whole diagnostic run (default: 10s)
+	Timeout time.Duration `mapstructure:"timeout"`
 }
 
 // Target defines a ping target configuration
@@ -31,38 +387,931 @@ type Target struct {
 	// Endpoint to ping (hostname or IP)
 	Endpoint string `mapstructure:"endpoint"`
 
-	// Number of packets to send (default: 4)
+	// Protocol to probe with, icmp or tcp (default: icmp). tcp measures how
+	// long connections to port take to establish, for networks blocking ICMP.
+	Protocol string `mapstructure:"protocol"`
+
+	// Port connected to by tcp probes
+	Port int `mapstructure:"port"`
+
+	// Network the endpoint is resolved in, ip4 or ip6 to force an address
+	// family (default: ip, either)
+	Network string `mapstructure:"network"`
+
+	// DualStack probes both the IPv4 and the IPv6 address of the endpoint,
+	// each reported as metric streams of its own (default: false)
+	DualStack bool `mapstructure:"dual_stack"`
+
+	// ResolveAll probes every address the hostname of the endpoint resolves
+	// to before every scrape, each reported as metric streams of its own,
+	// e.g. for anycast or multi-homed services (default: false)
+	ResolveAll bool `mapstructure:"resolve_all"`
+
+	// Group of the target, available to resource attribute templates
+	Group string `mapstructure:"group"`
+
+	// Labels added as attributes to every data point of the target, e.g.
+	// datacenter: us-east (default: none)
+	Labels map[string]string `mapstructure:"labels"`
+
+	// Weight of the target, targets of higher weight are probed first when
+	// max_concurrent_probes is set (default: 0)
+	Weight int `mapstructure:"weight"`
+
+	// Priority of the target in the first scrape after start, targets of
+	// higher priority are probed before the others start, and shed last under
+	// memory pressure (default: 0)
+	Priority int `mapstructure:"priority"`
+
+	// Number of packets to send (default: 4). An explicit count of 0 in YAML
+	// pings continuously until the run timeout, see Unmarshal; targets built in
+	// Go set Duration for that, as an unset count is the default.
 	Count int `mapstructure:"count"`
 
-	// Timeout for ping operation (default: 5s)
+	// Deprecated: Timeout is an alias of RunTimeout
 	Timeout time.Duration `mapstructure:"timeout"`
 
+	// RunTimeout bounds a whole run, including sending all packets (default: 5s)
+	RunTimeout time.Duration `mapstructure:"run_timeout"`
+
+	// PacketTimeout is how long to wait for each reply, later replies count as
+	// lost (default: until the run ends)
+	PacketTimeout time.Duration `mapstructure:"packet_timeout"`
+
 	// Interval between packets (default: 1s)
 	Interval time.Duration `mapstructure:"interval"`
+
+	// Duration to ping for at interval, instead of sending count packets
+	Duration time.Duration `mapstructure:"duration"`
+
+	// Prewarm sends a probe excluded from statistics before every burst, so
+	// ARP/ND resolution does not inflate the first RTT of LAN targets
+	Prewarm bool `mapstructure:"prewarm"`
+
+	// DiscardFirst excludes the first probe of every burst from statistics,
+	// an extra probe is sent so count probes remain measured
+	DiscardFirst bool `mapstructure:"discard_first"`
+
+	// Underlay address of a tunnel endpoint, probed after the endpoint on
+	// every scrape for ping.tunnel metrics (default: none)
+	Underlay string `mapstructure:"underlay"`
+
+	// PacketSize is the payload of the packets of the regular probe in bytes,
+	// reported as packet.size (default: 24)
+	PacketSize int `mapstructure:"packet_size"`
+
+	// PacketSizes probed in addition to the regular probe on every scrape, as
+	// payload bytes, for ping.size_sweep metrics (default: none)
+	PacketSizes []int `mapstructure:"packet_sizes"`
+
+	// DSCPValues probed in addition to the regular probe on every scrape, for
+	// ping.dscp metrics per traffic class, e.g. 46 for EF (default: none)
+	DSCPValues []int `mapstructure:"dscp_values"`
+
+	// PathMTUDiscovery searches the largest packet reaching the target with
+	// the Don't Fragment flag on every scrape, for ping.path_mtu (default: false)
+	PathMTUDiscovery bool `mapstructure:"path_mtu_discovery"`
+
+	// PathMTUMax is the largest path MTU searched in bytes (default: 1500)
+	PathMTUMax int `mapstructure:"path_mtu_max"`
+
+	// TTL is the TTL or hop limit of the packets sent to the target, e.g. to
+	// keep probes within a site (default: 64)
+	TTL int `mapstructure:"ttl"`
+
+	// Metrics enabled or disabled for this target only, e.g. to report full
+	// round-trip time statistics for critical targets (default: as configured
+	// for the receiver)
+	Metrics map[string]metadata.MetricConfig `mapstructure:"metrics"`
+
+	// Synthetic faults applied to results, for testing alerting pipelines only
+	FaultInjection *FaultInjectionConfig `mapstructure:"fault_injection"`
+
+	// WakeOnFail sends a Wake-on-LAN packet to targets that stopped answering,
+	// e.g. lab equipment expected to sleep (default: none)
+	WakeOnFail *WakeOnFailConfig `mapstructure:"wake_on_fail"`
+
+	// SNMP checks whether the management plane of targets whose ping failed
+	// still answers, for devices that deprioritize ICMP (default: none)
+	SNMP *SNMPCheckConfig `mapstructure:"snmp"`
+
+	// SLA thresholds results are classified against, for ping.sla.status (default: none)
+	SLA *SLAConfig `mapstructure:"sla"`
+
+	// Entity identifies the device the target is on its resource, for
+	// backends linking telemetry to device inventory (default: none)
+	Entity *EntityConfig `mapstructure:"entity"`
+
+	// Calendar names the entry of calendars whose business hours the
+	// downtime of the target is split by (default: that of its group)
+	Calendar string `mapstructure:"calendar"`
+
+	// Traceroute traces the path to the target periodically, for per-hop
+	// metrics (default: none)
+	Traceroute *TracerouteConfig `mapstructure:"traceroute"`
+}
+
+// Probing defaults of a target
+const (
+	defaultCount      = 4
+	defaultRunTimeout = 5 * time.Second
+	defaultInterval   = time.Second
+)
+
+// networkAny resolves endpoints to an address of either family
+const networkAny = "ip"
+
+// maxPacketSize is the largest ICMP payload an IPv4 packet can carry
+const maxPacketSize = 65507
+
+// maxTTL is the largest TTL or hop limit of a packet
+const maxTTL = 255
+
+// Bounds of path_mtu_max, 68 bytes is the smallest MTU IPv4 allows
+const (
+	minPathMTU     = 68
+	maxPathMTU     = 65535
+	defaultPathMTU = 1500
+)
+
+// Unmarshal applies the probing defaults, so that the decoded target holds
+// the values it is probed with. They are applied before decoding, so that an
+// explicit count of 0 is told apart from an unset one: it selects continuous
+// pinging for the run timeout, which is decoded as a duration of the run
+// timeout. Targets with a duration are not counted and run for that duration.
+// The deprecated timeout is moved to run_timeout, both being set fails
+// validation.
+func (t *Target) Unmarshal(conf *confmap.Conf) error {
+	if !conf.IsSet("duration") {
+		t.Count = defaultCount
+		if !conf.IsSet("timeout") {
+			t.RunTimeout = defaultRunTimeout
+		}
+	}
+	t.Interval = defaultInterval
+	if err := conf.Unmarshal(t); err != nil {
+		return err
+	}
+	if conf.IsSet("timeout") && !conf.IsSet("run_timeout") {
+		t.RunTimeout, t.Timeout = t.Timeout, 0
+	}
+	if conf.IsSet("count") && t.Count == 0 && t.Duration == 0 {
+		t.Duration, t.RunTimeout = cmp.Or(t.RunTimeout, defaultRunTimeout), 0
+	}
+	return nil
+}
+
+// Marshal emits the count a target assembled field by field is probed with,
+// as a count of 0 read back selects continuous pinging
+func (t Target) Marshal(conf *confmap.Conf) error {
+	if t.Count == 0 && t.Duration == 0 {
+		t.Count = defaultCount
+	}
+	// The plain type keeps the encoder from calling Marshal again
+	type plain Target
+	return conf.Marshal(plain(t))
 }
 
-// Validate implements component.Config
+// probeProtocol returns the protocol the target is probed with
+func (t Target) probeProtocol() string {
+	return cmp.Or(t.Protocol, prober.ProtocolICMP)
+}
+
+// probeNetwork returns the network the target's endpoint is resolved in
+func (t Target) probeNetwork() string {
+	return cmp.Or(t.Network, networkAny)
+}
+
+// effective returns the target with deprecated and unset options resolved
+// to the values used for probing. Decoded targets and those of NewTarget hold
+// their defaults already, targets assembled field by field may not.
+func (t Target) effective() Target {
+	if t.RunTimeout == 0 {
+		t.RunTimeout = t.Timeout
+	}
+	// A duration is a continuous run bounded by that duration, only an
+	// explicit one pings continuously
+	if t.Duration > 0 {
+		t.Count = 0
+		t.RunTimeout = t.Duration
+	} else if t.Count == 0 {
+		t.Count = defaultCount
+	}
+	if t.RunTimeout == 0 {
+		t.RunTimeout = defaultRunTimeout
+	}
+	if t.Interval == 0 {
+		t.Interval = defaultInterval
+	}
+	return t
+}
+
+// validateTimeouts checks that the run timeout leaves room for every packet
+// and its reply
+func (t Target) validateTimeouts() error {
+	e := t.effective()
+	if e.PacketTimeout > e.RunTimeout {
+		return invalid("packet_timeout", CodeConflict, "packet_timeout %s exceeds run_timeout %s", e.PacketTimeout, e.RunTimeout)
+	}
+	if e.Count == 0 {
+		return nil
+	}
+	packets := e.Count
+	if e.DiscardFirst {
+		packets++
+	}
+	// The last packet is sent this long after the first
+	lastSend := time.Duration(packets-1) * e.Interval
+	if e.PacketTimeout == 0 && lastSend >= e.RunTimeout {
+		return invalid("run_timeout", CodeConflict, "run_timeout %s ends before all %d packets are sent %s apart", e.RunTimeout, packets, e.Interval)
+	}
+	if e.PacketTimeout > 0 && lastSend+e.PacketTimeout > e.RunTimeout {
+		return invalid("run_timeout", CodeConflict, "run_timeout %s is shorter than sending %d packets %s apart plus packet_timeout %s",
+			e.RunTimeout, packets, e.Interval, e.PacketTimeout)
+	}
+	return nil
+}
+
+// FaultInjectionConfig defines synthetic degradation applied to a target's results
+type FaultInjectionConfig struct {
+	// Ratio of packets to report as lost (0.0 to 1.0)
+	Loss float64 `mapstructure:"loss"`
+
+	// Latency added to every round-trip time
+	Latency time.Duration `mapstructure:"latency"`
+
+	// Error type reported instead of running the probe
+	Error string `mapstructure:"error"`
+}
+
+// WakeOnFailConfig defines when and where a target's Wake-on-LAN packet is sent
+type WakeOnFailConfig struct {
+	// MAC address of the target's network interface
+	MAC string `mapstructure:"mac"`
+
+	// Failures is the number of consecutive failed scrapes after which a
+	// packet is sent before every probe (default: 3)
+	Failures int `mapstructure:"failures"`
+
+	// Broadcast address and port the packet is sent to (default: 255.255.255.255:9)
+	Broadcast string `mapstructure:"broadcast"`
+}
+
+// SNMPCheckConfig defines the SNMPv2c sysUpTime request sent to failing targets
+type SNMPCheckConfig struct {
+	// Community of the request (default: public)
+	Community string `mapstructure:"community"`
+
+	// Port of the SNMP agent (default: 161)
+	Port int `mapstructure:"port"`
+
+	// Timeout of the request (default: 2s)
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// TracerouteConfig defines the periodic traceroute of a target
+type TracerouteConfig struct {
+	// Interval between traceroutes (default: 5m)
+	Interval time.Duration `mapstructure:"interval"`
+
+	// MaxHops is the largest TTL sent (default: 30)
+	MaxHops int `mapstructure:"max_hops"`
+
+	// ProbesPerHop is the number of packets sent per hop (default: 3)
+	ProbesPerHop int `mapstructure:"probes_per_hop"`
+
+	// HopTimeout is how long to wait for the reply to each packet (default: 1s)
+	HopTimeout time.Duration `mapstructure:"hop_timeout"`
+
+	// Log reports the path of every traceroute as a log record (default: false)
+	Log bool `mapstructure:"log"`
+
+	// ASN annotates the hops with their origin AS: cymru, looked up over DNS,
+	// or database, read from asn_database (default: none)
+	ASN string `mapstructure:"asn"`
+
+	// ASNDatabase is a file of "prefix asn" lines for asn: database
+	ASNDatabase string `mapstructure:"asn_database"`
+}
+
+// SLAConfig defines the latency and loss a target is expected to stay within,
+// optionally by time of day
+type SLAConfig struct {
+	// MaxLatency of the average round-trip time, 0 does not check latency
+	MaxLatency time.Duration `mapstructure:"max_latency"`
+
+	// MaxLoss ratio of packets lost (0.0 to 1.0), unset does not check loss
+	MaxLoss *float64 `mapstructure:"max_loss"`
+
+	// Timezone schedules are written in, e.g. Europe/Berlin (default: Local)
+	Timezone string `mapstructure:"timezone"`
+
+	// Schedules with thresholds of their own, the first one matching the
+	// time of a scrape applies
+	Schedules []SLASchedule `mapstructure:"schedules"`
+}
+
+// SLASchedule defines thresholds that apply within a time window. Thresholds
+// left unset are those of the SLA.
+type SLASchedule struct {
+	// Name of the schedule, reported as sla.window
+	Name string `mapstructure:"name"`
+
+	TimeWindow `mapstructure:",squash"`
+
+	MaxLatency time.Duration `mapstructure:"max_latency"`
+	MaxLoss    *float64      `mapstructure:"max_loss"`
+}
+
+// TimeWindow is a daily time range on some days of the week
+type TimeWindow struct {
+	// Days of the week, mon to sun (default: every day)
+	Days []string `mapstructure:"days"`
+
+	// Start and End as HH:MM, an end before the start ends on the next day
+	Start string `mapstructure:"start"`
+	End   string `mapstructure:"end"`
+}
+
+// Validate implements component.Config. Every problem found is returned as a
+// ValidationError, see ValidationErrors.
 func (cfg *Config) Validate() error {
 	var err error
 
-	if len(cfg.Targets) == 0 {
-		err = multierr.Append(err, errors.New("at least one target must be specified"))
+	if len(cfg.Targets) == 0 && !cfg.AllowEmptyTargets {
+		err = multierr.Append(err, invalid("targets", CodeRequired, "at least one target must be specified"))
 	}
 
+	if _, ok := profiles[cfg.Profile]; cfg.Profile != "" && !ok {
+		err = multierr.Append(err, invalid("profile", CodeInvalidValue, "profile must be %s, %s or %s, got %q",
+			profileSmall, profileMedium, profileLarge, cfg.Profile))
+	}
+
+	if cfg.TrimmedMeanPercent < 0 || cfg.TrimmedMeanPercent >= 50 {
+		err = multierr.Append(err, invalid("trimmed_mean_percent", CodeOutOfRange, "trimmed_mean_percent must be at least 0 and below 50"))
+	}
+
+	if cfg.RecreateThreshold < 0 {
+		err = multierr.Append(err, invalid("recreate_threshold", CodeOutOfRange, "recreate_threshold cannot be negative"))
+	}
+
+	switch cfg.ResolveMode {
+	case "", resolveStartup, resolveEveryScrape:
+	case resolveTTL:
+		if cfg.ResolutionTTL <= 0 {
+			err = multierr.Append(err, invalid("resolution_ttl", CodeOutOfRange, "resolution_ttl must be positive with resolve_mode %s", resolveTTL))
+		}
+	default:
+		err = multierr.Append(err, invalid("resolve_mode", CodeInvalidValue, "resolve_mode must be %s, %s or %s, got %q",
+			resolveStartup, resolveEveryScrape, resolveTTL, cfg.ResolveMode))
+	}
+
+	if cfg.MaxConcurrentProbes < 0 {
+		err = multierr.Append(err, invalid("max_concurrent_probes", CodeOutOfRange, "max_concurrent_probes cannot be negative"))
+	}
+	err = multierr.Append(err, within("shared_budget", cfg.SharedBudget.validate()))
+	err = multierr.Append(err, within("shedding", cfg.Shedding.validate()))
+
+	if cfg.MaxDatapointsPerBatch < 0 {
+		err = multierr.Append(err, invalid("max_datapoints_per_batch", CodeOutOfRange, "max_datapoints_per_batch cannot be negative"))
+	}
+
+	if cfg.HealthyEmitEvery < 0 {
+		err = multierr.Append(err, invalid("healthy_emit_every", CodeOutOfRange, "healthy_emit_every cannot be negative"))
+	}
+
+	if cfg.MetricPrefix != "" && !validMetricPrefix.MatchString(cfg.MetricPrefix) {
+		err = multierr.Append(err, invalid("metric_prefix", CodeInvalidFormat,
+			"metric_prefix %q must be dot separated names of letters, digits and underscores", cfg.MetricPrefix))
+	}
+
+	switch cfg.MetricNames {
+	case "", metricNamesBoth, metricNamesNew, metricNamesOld:
+	default:
+		err = multierr.Append(err, invalid("metric_names", CodeInvalidValue, "metric_names must be %s, %s or %s, got %q",
+			metricNamesBoth, metricNamesNew, metricNamesOld, cfg.MetricNames))
+	}
+
+	switch cfg.CounterTemporality {
+	case "", temporalityCumulative, temporalityDelta:
+	default:
+		err = multierr.Append(err, invalid("counter_temporality", CodeInvalidValue, "counter_temporality must be %s or %s, got %q",
+			temporalityCumulative, temporalityDelta, cfg.CounterTemporality))
+	}
+
+	templates, tmplErr := parseResourceAttributes(cfg.ResourceAttributes)
+	err = multierr.Append(err, tmplErr)
+
+	guard, guardErr := parseDestinationGuard(cfg.AllowedCIDRs, cfg.DeniedCIDRs)
+	err = multierr.Append(err, guardErr)
+
+	firstOfEndpoint := make(map[string]int)
 	for i, target := range cfg.Targets {
-		if target.Endpoint == "" {
-			err = multierr.Append(err, fmt.Errorf("targets[%d]: endpoint cannot be empty", i))
+		tErr := cfg.validateTarget(target, guard, templates)
+		// Targets of the same endpoint share its probes
+		if j, ok := firstOfEndpoint[target.Endpoint]; !ok {
+			firstOfEndpoint[target.Endpoint] = i
+		} else if other := cfg.Targets[j]; other.probeProtocol() != target.probeProtocol() || other.Port != target.Port ||
+			other.probeNetwork() != target.probeNetwork() || other.DualStack != target.DualStack || other.ResolveAll != target.ResolveAll {
+			tErr = multierr.Append(tErr, invalid("endpoint", CodeConflict,
+				"protocol, port, network, dual_stack and resolve_all must match targets[%d] of the same endpoint", j))
+		}
+		err = multierr.Append(err, within(fmt.Sprintf("targets[%d]", i), tErr))
+	}
+
+	err = multierr.Append(err, validateCalendars(cfg.Calendars))
+	err = multierr.Append(err, within("overflow", cfg.Overflow.validate()))
+	err = multierr.Append(err, within("duration_histogram", cfg.DurationHistogram.validate()))
+	err = multierr.Append(err, within("heatmap", cfg.Heatmap.validate(cfg.heatmapEnabled())))
+	err = multierr.Append(err, within("report_on_change", cfg.ReportOnChange.validate()))
+	err = multierr.Append(err, within("diagnostics", cfg.Diagnostics.validate()))
+	err = multierr.Append(err, within("interface_check", cfg.InterfaceCheck.validate()))
+	err = multierr.Append(err, within("holidays", cfg.Holidays.validate()))
+	err = multierr.Append(err, within("connectivity_check", cfg.ConnectivityCheck.validate()))
+	err = multierr.Append(err, within("local_check", cfg.LocalCheck.validate()))
+	err = multierr.Append(err, within("first_responder", cfg.FirstResponder.validate()))
+	if cfg.PassiveCheck.Window < 0 {
+		err = multierr.Append(err, within("passive_check", invalid("window", CodeOutOfRange, "window cannot be negative")))
+	}
+
+	return err
+}
+
+// validateTarget returns the problems of target, with paths relative to it
+func (cfg *Config) validateTarget(target Target, guard destinationGuard, templates []resourceTemplate) error {
+	var err error
+	if target.Endpoint == "" {
+		err = multierr.Append(err, invalid("endpoint", CodeRequired, "endpoint cannot be empty"))
+	}
+	// Hostnames are checked once resolved
+	if addr, parseErr := netip.ParseAddr(target.Endpoint); parseErr == nil {
+		err = multierr.Append(err, asInvalid("endpoint", CodeForbidden, guard.check(addr)))
+	}
+	if target.Count < 0 {
+		err = multierr.Append(err, invalid("count", CodeOutOfRange, "count cannot be negative"))
+	}
+	if target.Timeout < 0 {
+		err = multierr.Append(err, invalid("timeout", CodeOutOfRange, "timeout cannot be negative"))
+	}
+	if target.RunTimeout < 0 {
+		err = multierr.Append(err, invalid("run_timeout", CodeOutOfRange, "run_timeout cannot be negative"))
+	}
+	if target.PacketTimeout < 0 {
+		err = multierr.Append(err, invalid("packet_timeout", CodeOutOfRange, "packet_timeout cannot be negative"))
+	}
+	if target.Interval < 0 {
+		err = multierr.Append(err, invalid("interval", CodeOutOfRange, "interval cannot be negative"))
+	}
+	if target.Duration < 0 {
+		err = multierr.Append(err, invalid("duration", CodeOutOfRange, "duration cannot be negative"))
+	}
+	if target.Timeout > 0 && target.RunTimeout > 0 {
+		err = multierr.Append(err, invalid("timeout", CodeConflict, "timeout and run_timeout cannot both be set"))
+	}
+	if target.Duration > 0 && target.Count > 0 {
+		err = multierr.Append(err, invalid("duration", CodeConflict, "count and duration cannot both be set"))
+	}
+	if target.Duration > 0 && (target.Timeout > 0 || target.RunTimeout > 0) {
+		err = multierr.Append(err, invalid("duration", CodeConflict, "run_timeout and duration cannot both be set"))
+	}
+	if target.Count >= 0 && target.Timeout >= 0 && target.RunTimeout >= 0 && target.PacketTimeout >= 0 && target.Interval >= 0 {
+		err = multierr.Append(err, target.validateTimeouts())
+	}
+	// Templates may refer to fields that do not exist
+	if _, resErr := targetResource(templates, target); resErr != nil {
+		err = multierr.Append(err, asInvalid("resource_attributes", CodeInvalidValue, resErr))
+	}
+	if _, mErr := cfg.targetMetrics(target); mErr != nil {
+		err = multierr.Append(err, asInvalid("metrics", CodeInvalidValue, mErr))
+	}
+	if target.Underlay != "" && target.Underlay == target.Endpoint {
+		err = multierr.Append(err, invalid("underlay", CodeConflict, "underlay must differ from endpoint"))
+	}
+	if target.PacketSize != 0 && (target.PacketSize < prober.MinSize || target.PacketSize > maxPacketSize) {
+		err = multierr.Append(err, invalid("packet_size", CodeOutOfRange, "packet_size must be between %d and %d",
+			prober.MinSize, maxPacketSize))
+	}
+	for j, size := range target.PacketSizes {
+		if size < prober.MinSize || size > maxPacketSize {
+			err = multierr.Append(err, within(fmt.Sprintf("packet_sizes[%d]", j),
+				invalid("", CodeOutOfRange, "%d must be between %d and %d", size, prober.MinSize, maxPacketSize)))
+		}
+	}
+	for j, dscp := range target.DSCPValues {
+		if dscp < 0 || dscp > maxDSCP {
+			err = multierr.Append(err, within(fmt.Sprintf("dscp_values[%d]", j),
+				invalid("", CodeOutOfRange, "%d must be between 0 and %d", dscp, maxDSCP)))
+		}
+	}
+	if target.TTL < 0 || target.TTL > maxTTL {
+		err = multierr.Append(err, invalid("ttl", CodeOutOfRange, "ttl must be between 1 and %d", maxTTL))
+	}
+	if target.PathMTUMax != 0 && (target.PathMTUMax < minPathMTU || target.PathMTUMax > maxPathMTU) {
+		err = multierr.Append(err, invalid("path_mtu_max", CodeOutOfRange, "path_mtu_max must be between %d and %d",
+			minPathMTU, maxPathMTU))
+	}
+	switch target.Protocol {
+	case "", prober.ProtocolICMP:
+		if target.Port != 0 {
+			err = multierr.Append(err, invalid("port", CodeConflict, "port requires protocol %s", prober.ProtocolTCP))
+		}
+	case prober.ProtocolTCP:
+		if target.Port < 1 || target.Port > 65535 {
+			err = multierr.Append(err, invalid("port", CodeOutOfRange, "port must be between 1 and 65535 for protocol %s", prober.ProtocolTCP))
+		}
+		if target.PacketSize != 0 || len(target.PacketSizes) > 0 || len(target.DSCPValues) > 0 || target.PathMTUDiscovery || target.TTL != 0 {
+			err = multierr.Append(err, invalid("protocol", CodeConflict,
+				"packet_size, packet_sizes, dscp_values, path_mtu_discovery and ttl require protocol %s", prober.ProtocolICMP))
+		}
+	default:
+		err = multierr.Append(err, invalid("protocol", CodeInvalidValue, "protocol must be %s or %s, got %q",
+			prober.ProtocolICMP, prober.ProtocolTCP, target.Protocol))
+	}
+	switch target.Network {
+	case "", networkAny, prober.NetworkIPv4, prober.NetworkIPv6:
+	default:
+		err = multierr.Append(err, invalid("network", CodeInvalidValue, "network must be %s, %s or %s, got %q",
+			networkAny, prober.NetworkIPv4, prober.NetworkIPv6, target.Network))
+	}
+	if target.DualStack {
+		if target.probeNetwork() != networkAny {
+			err = multierr.Append(err, invalid("dual_stack", CodeConflict, "dual_stack and network cannot both be set"))
+		}
+		if net.ParseIP(target.Endpoint) != nil {
+			err = multierr.Append(err, invalid("dual_stack", CodeConflict, "dual_stack requires a hostname, got %s", target.Endpoint))
+		}
+	}
+	if target.ResolveAll {
+		if target.DualStack {
+			err = multierr.Append(err, invalid("resolve_all", CodeConflict, "resolve_all and dual_stack cannot both be set"))
+		}
+		if net.ParseIP(target.Endpoint) != nil {
+			err = multierr.Append(err, invalid("resolve_all", CodeConflict, "resolve_all requires a hostname, got %s", target.Endpoint))
+		}
+	}
+	if target.FaultInjection != nil {
+		err = multierr.Append(err, within("fault_injection", target.FaultInjection.validate()))
+	}
+	if target.WakeOnFail != nil {
+		err = multierr.Append(err, within("wake_on_fail", target.WakeOnFail.validate()))
+	}
+	if target.SNMP != nil {
+		err = multierr.Append(err, within("snmp", target.SNMP.validate()))
+	}
+	err = multierr.Append(err, validateLabels(target.Labels))
+	if target.SLA != nil {
+		err = multierr.Append(err, within("sla", target.SLA.validate()))
+	}
+	if target.Entity != nil {
+		err = multierr.Append(err, within("entity", target.Entity.validate(cfg.ResourceAttributes)))
+	}
+	if _, ok := cfg.Calendars[target.Calendar]; target.Calendar != "" && !ok {
+		err = multierr.Append(err, invalid("calendar", CodeInvalidValue, "unknown calendar %q", target.Calendar))
+	}
+	if target.Traceroute != nil {
+		err = multierr.Append(err, within("traceroute", target.Traceroute.validate()))
+		// Datagram ICMP sockets do not receive the replies of routers
+		if !cfg.Privileged && runtime.GOOS != "windows" {
+			err = multierr.Append(err, invalid("traceroute", CodeConflict, "traceroute requires privileged: true"))
+		}
+	}
+	return err
+}
+
+func (f *FaultInjectionConfig) validate() error {
+	var err error
+	if f.Loss < 0 || f.Loss > 1 {
+		err = multierr.Append(err, invalid("loss", CodeOutOfRange, "loss must be between 0 and 1"))
+	}
+	if f.Latency < 0 {
+		err = multierr.Append(err, invalid("latency", CodeOutOfRange, "latency cannot be negative"))
+	}
+	if _, ok := metadata.MapAttributeErrorType[f.Error]; f.Error != "" && !ok {
+		err = multierr.Append(err, invalid("error", CodeInvalidValue, "unknown error type %q", f.Error))
+	}
+	return err
+}
+
+func (w *WakeOnFailConfig) validate() error {
+	var err error
+	if mac, mErr := net.ParseMAC(w.MAC); mErr != nil || len(mac) != 6 {
+		err = multierr.Append(err, invalid("mac", CodeInvalidFormat, "mac must be a 6 byte MAC address, got %q", w.MAC))
+	}
+	if w.Failures < 0 {
+		err = multierr.Append(err, invalid("failures", CodeOutOfRange, "failures cannot be negative"))
+	}
+	if _, _, bErr := net.SplitHostPort(w.Broadcast); w.Broadcast != "" && bErr != nil {
+		err = multierr.Append(err, within("broadcast", asInvalid("", CodeInvalidFormat, bErr)))
+	}
+	return err
+}
+
+func (c *SNMPCheckConfig) validate() error {
+	var err error
+	if c.Port < 0 || c.Port > 65535 {
+		err = multierr.Append(err, invalid("port", CodeOutOfRange, "port must be between 0 and 65535, got %d", c.Port))
+	}
+	if c.Timeout < 0 {
+		err = multierr.Append(err, invalid("timeout", CodeOutOfRange, "timeout cannot be negative"))
+	}
+	return err
+}
+
+func (c *TracerouteConfig) validate() error {
+	var err error
+	if c.Interval < 0 {
+		err = multierr.Append(err, invalid("interval", CodeOutOfRange, "interval cannot be negative"))
+	}
+	if c.MaxHops < 0 || c.MaxHops > maxTTL {
+		err = multierr.Append(err, invalid("max_hops", CodeOutOfRange, "max_hops must be between 1 and %d", maxTTL))
+	}
+	if c.ProbesPerHop < 0 || c.ProbesPerHop > maxTracerouteProbesPerHop {
+		err = multierr.Append(err, invalid("probes_per_hop", CodeOutOfRange, "probes_per_hop must be between 1 and %d", maxTracerouteProbesPerHop))
+	}
+	if c.HopTimeout < 0 {
+		err = multierr.Append(err, invalid("hop_timeout", CodeOutOfRange, "hop_timeout cannot be negative"))
+	}
+	switch c.ASN {
+	case "", asnCymru:
+	case asnDatabase:
+		if c.ASNDatabase == "" {
+			err = multierr.Append(err, invalid("asn_database", CodeRequired, "asn_database is required with asn %s", asnDatabase))
+		}
+	default:
+		err = multierr.Append(err, invalid("asn", CodeInvalidValue, "asn must be %s or %s, got %q", asnCymru, asnDatabase, c.ASN))
+	}
+	return err
+}
+
+func (c *SLAConfig) validate() error {
+	var err error
+	err = multierr.Append(err, validateThresholds(c.MaxLatency, c.MaxLoss))
+	if _, tErr := time.LoadLocation(c.Timezone); tErr != nil {
+		err = multierr.Append(err, within("timezone", asInvalid("", CodeInvalidValue, tErr)))
+	}
+	names := make(map[string]bool)
+	for i, schedule := range c.Schedules {
+		var sErr error
+		if schedule.Name == "" || schedule.Name == slaDefaultWindow {
+			sErr = multierr.Append(sErr, invalid("name", CodeRequired, "name cannot be empty or %q", slaDefaultWindow))
+		} else if names[schedule.Name] {
+			sErr = multierr.Append(sErr, invalid("name", CodeDuplicate, "duplicate name %q", schedule.Name))
+		}
+		names[schedule.Name] = true
+		sErr = multierr.Append(sErr, schedule.TimeWindow.validate())
+		sErr = multierr.Append(sErr, validateThresholds(schedule.MaxLatency, schedule.MaxLoss))
+		err = multierr.Append(err, within(fmt.Sprintf("schedules[%d]", i), sErr))
+	}
+	return err
+}
+
+func validateThresholds(maxLatency time.Duration, maxLoss *float64) error {
+	var err error
+	if maxLatency < 0 {
+		err = multierr.Append(err, invalid("max_latency", CodeOutOfRange, "max_latency cannot be negative"))
+	}
+	if maxLoss != nil && (*maxLoss < 0 || *maxLoss > 1) {
+		err = multierr.Append(err, invalid("max_loss", CodeOutOfRange, "max_loss must be between 0 and 1"))
+	}
+	return err
+}
+
+func (w *TimeWindow) validate() error {
+	var err error
+	for i, day := range w.Days {
+		if _, ok := weekdays[day]; !ok {
+			err = multierr.Append(err, invalid(fmt.Sprintf("days[%d]", i), CodeInvalidValue, "unknown day %q, expected mon to sun", day))
 		}
-		if target.Count < 0 {
-			err = multierr.Append(err, fmt.Errorf("targets[%d]: count cannot be negative", i))
+	}
+	if _, sErr := parseTimeOfDay(w.Start); sErr != nil {
+		err = multierr.Append(err, within("start", asInvalid("", CodeInvalidFormat, sErr)))
+	}
+	if _, eErr := parseTimeOfDay(w.End); eErr != nil {
+		err = multierr.Append(err, within("end", asInvalid("", CodeInvalidFormat, eErr)))
+	}
+	return err
+}
+
+func (cfg *OverflowConfig) validate() error {
+	var err error
+	if cfg.MaxDatapoints < 0 {
+		err = multierr.Append(err, invalid("max_datapoints", CodeOutOfRange, "max_datapoints cannot be negative"))
+	}
+	switch cfg.Policy {
+	case "", overflowDropOldest, overflowAggregate:
+	default:
+		err = multierr.Append(err, invalid("policy", CodeInvalidValue, "policy must be %s or %s, got %q",
+			overflowDropOldest, overflowAggregate, cfg.Policy))
+	}
+	return err
+}
+
+func (cfg *SharedBudgetConfig) validate() error {
+	var err error
+	if cfg.MaxSockets < 0 {
+		err = multierr.Append(err, invalid("max_sockets", CodeOutOfRange, "max_sockets cannot be negative"))
+	}
+	if cfg.MaxPacketsPerSecond < 0 {
+		err = multierr.Append(err, invalid("max_packets_per_second", CodeOutOfRange, "max_packets_per_second cannot be negative"))
+	}
+	return err
+}
+
+func (cfg *SheddingConfig) validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	var err error
+	if cfg.SoftLimitPercentage == 0 || cfg.SoftLimitPercentage > 100 {
+		err = multierr.Append(err, invalid("soft_limit_percentage", CodeOutOfRange, "soft_limit_percentage must be between 1 and 100"))
+	}
+	if cfg.HardLimitPercentage == 0 || cfg.HardLimitPercentage > 100 {
+		err = multierr.Append(err, invalid("hard_limit_percentage", CodeOutOfRange, "hard_limit_percentage must be between 1 and 100"))
+	}
+	if err == nil && cfg.SoftLimitPercentage >= cfg.HardLimitPercentage {
+		err = invalid("soft_limit_percentage", CodeConflict, "soft_limit_percentage must be below hard_limit_percentage")
+	}
+	return err
+}
+
+func (cfg *DurationHistogramConfig) validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	switch cfg.Type {
+	case "", histogramExplicit:
+	case histogramExponential:
+		if cfg.MaxScale < minExponentialScale || cfg.MaxScale > maxExponentialScale {
+			return invalid("max_scale", CodeOutOfRange, "max_scale must be between %d and %d, got %d",
+				minExponentialScale, maxExponentialScale, cfg.MaxScale)
 		}
-		if target.Timeout < 0 {
-			err = multierr.Append(err, fmt.Errorf("targets[%d]: timeout cannot be negative", i))
+		return nil
+	default:
+		return invalid("type", CodeInvalidValue, "type must be %s or %s, got %q", histogramExplicit, histogramExponential, cfg.Type)
+	}
+	return validateBuckets(cfg.Buckets)
+}
+
+func (cfg *HeatmapConfig) validate(enabled bool) error {
+	if !enabled {
+		return nil
+	}
+	return validateBuckets(cfg.Buckets)
+}
+
+// heatmapEnabled reports whether ping.duration.heatmap is enabled for the
+// receiver or any of its targets
+func (cfg *Config) heatmapEnabled() bool {
+	if cfg.Metrics.PingDurationHeatmap.Enabled {
+		return true
+	}
+	for _, target := range cfg.Targets {
+		if target.Metrics["ping.duration.heatmap"].Enabled {
+			return true
 		}
-		if target.Interval < 0 {
-			err = multierr.Append(err, fmt.Errorf("targets[%d]: interval cannot be negative", i))
+	}
+	return false
+}
+
+// validateBuckets checks that the upper bounds of buckets increase strictly
+func validateBuckets(buckets []float64) error {
+	if len(buckets) == 0 {
+		return invalid("buckets", CodeRequired, "buckets cannot be empty")
+	}
+	var err error
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i] <= buckets[i-1] {
+			err = multierr.Append(err, within(fmt.Sprintf("buckets[%d]", i),
+				invalid("", CodeOutOfRange, "%g must be greater than the bucket before it", buckets[i])))
 		}
 	}
+	return err
+}
+
+func (cfg *ReportOnChangeConfig) validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
 
+	var err error
+	if cfg.MinRTTChange < 0 {
+		err = multierr.Append(err, invalid("min_rtt_change", CodeOutOfRange, "min_rtt_change cannot be negative"))
+	}
+	if cfg.MinLossChange < 0 || cfg.MinLossChange > 1 {
+		err = multierr.Append(err, invalid("min_loss_change", CodeOutOfRange, "min_loss_change must be between 0 and 1"))
+	}
+	if cfg.MaxStaleness <= 0 {
+		err = multierr.Append(err, invalid("max_staleness", CodeOutOfRange, "max_staleness must be positive"))
+	}
+	return err
+}
+
+func (cfg *DiagnosticsConfig) validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	var err error
+	if cfg.FailureThreshold < 1 {
+		err = multierr.Append(err, invalid("failure_threshold", CodeOutOfRange, "failure_threshold must be at least 1"))
+	}
+	if cfg.Timeout <= 0 {
+		err = multierr.Append(err, invalid("timeout", CodeOutOfRange, "timeout must be positive"))
+	}
+	for i, resolver := range cfg.Resolvers {
+		if _, _, splitErr := net.SplitHostPort(resolver); splitErr != nil {
+			err = multierr.Append(err, within(fmt.Sprintf("resolvers[%d]", i), asInvalid("", CodeInvalidFormat, splitErr)))
+		}
+	}
+	for i, port := range cfg.TCPPorts {
+		if port < 1 || port > 65535 {
+			err = multierr.Append(err, within(fmt.Sprintf("tcp_ports[%d]", i), invalid("", CodeOutOfRange, "port %d out of range", port)))
+		}
+	}
+	return err
+}
+
+func (cfg *InterfaceCheckConfig) validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	switch cfg.Action {
+	case interfaceActionTag, interfaceActionSuppress:
+		return nil
+	default:
+		return invalid("action", CodeInvalidValue, "unknown action %q", cfg.Action)
+	}
+}
+
+func (cfg *HolidaysConfig) validate() error {
+	if cfg.Source == "" {
+		return nil
+	}
+	var err error
+	if scheme, _, ok := strings.Cut(cfg.Source, "://"); ok && scheme != "http" && scheme != "https" {
+		err = multierr.Append(err, invalid("source", CodeInvalidValue, "source must be a path or an http or https URL, got %q", cfg.Source))
+	}
+	if cfg.RefreshInterval <= 0 {
+		err = multierr.Append(err, invalid("refresh_interval", CodeOutOfRange, "refresh_interval must be positive"))
+	}
+	if _, tErr := time.LoadLocation(cfg.Timezone); tErr != nil {
+		err = multierr.Append(err, within("timezone", asInvalid("", CodeInvalidValue, tErr)))
+	}
+	switch cfg.Action {
+	case interfaceActionTag, interfaceActionSuppress:
+	default:
+		err = multierr.Append(err, invalid("action", CodeInvalidValue, "unknown action %q", cfg.Action))
+	}
+	return err
+}
+
+func (cfg *ConnectivityCheckConfig) validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	var err error
+	if u, parseErr := url.Parse(cfg.URL); parseErr != nil {
+		err = multierr.Append(err, within("url", asInvalid("", CodeInvalidFormat, parseErr)))
+	} else if u.Scheme != "http" {
+		// A portal can only intercept plain HTTP
+		err = multierr.Append(err, invalid("url", CodeInvalidValue, "url must use http, got %q", u.Scheme))
+	}
+	if cfg.Timeout <= 0 {
+		err = multierr.Append(err, invalid("timeout", CodeOutOfRange, "timeout must be positive"))
+	}
+	return err
+}
+
+func (cfg *FirstResponderConfig) validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	var err error
+	if cfg.DownFraction <= 0 || cfg.DownFraction > 1 {
+		err = multierr.Append(err, invalid("down_fraction", CodeOutOfRange, "down_fraction must be greater than 0 and at most 1"))
+	}
+	if cfg.Interval <= 0 {
+		err = multierr.Append(err, invalid("interval", CodeOutOfRange, "interval must be positive"))
+	}
+	if cfg.Duration <= 0 {
+		err = multierr.Append(err, invalid("duration", CodeOutOfRange, "duration must be positive"))
+	}
+	return err
+}
+
+func (cfg *LocalCheckConfig) validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	var err error
+	if cfg.Gateway != "" && net.ParseIP(cfg.Gateway) == nil {
+		err = multierr.Append(err, invalid("gateway", CodeInvalidFormat, "gateway %q is not an IP address", cfg.Gateway))
+	}
+	if cfg.Timeout <= 0 {
+		err = multierr.Append(err, invalid("timeout", CodeOutOfRange, "timeout must be positive"))
+	}
 	return err
 }
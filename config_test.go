@@ -5,17 +5,23 @@ package pingcheckreceiver
 
 import (
 	"errors"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/scraper/scraperhelper"
 	"go.uber.org/multierr"
 
 	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/prober"
 )
 
 func TestConfigValidate(t *testing.T) {
+	invalidLoss := 1.5
 	tests := []struct {
 		name        string
 		config      Config
@@ -28,10 +34,10 @@ func TestConfigValidate(t *testing.T) {
 				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
 				Targets: []Target{
 					{
-						Endpoint: "google.com",
-						Count:    4,
-						Timeout:  5 * time.Second,
-						Interval: time.Second,
+						Endpoint:   "google.com",
+						Count:      4,
+						RunTimeout: 5 * time.Second,
+						Interval:   time.Second,
 					},
 				},
 			},
@@ -46,6 +52,438 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expectedErr: errors.New("at least one target must be specified"),
 		},
+		{
+			name: "invalid trimmed mean percent",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "google.com"}},
+				TrimmedMeanPercent:   50,
+			},
+			expectedErr: errors.New("trimmed_mean_percent must be at least 0 and below 50"),
+		},
+		{
+			name: "negative recreate threshold",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "google.com"}},
+				RecreateThreshold:    -1,
+			},
+			expectedErr: errors.New("recreate_threshold cannot be negative"),
+		},
+		{
+			name: "invalid calendars",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "google.com", Calendar: "support"}},
+				Calendars: map[string]CalendarConfig{
+					"office": {Hours: []TimeWindow{{Start: "08:00", End: "18:00"}}, Groups: []string{"branch"}},
+					"store":  {Timezone: "Mars/Olympus", Groups: []string{"branch"}},
+				},
+			},
+			expectedErr: errors.New(`targets[0]: unknown calendar "support"; ` +
+				`calendars: store: timezone: unknown time zone Mars/Olympus; ` +
+				`calendars: store: hours cannot be empty; ` +
+				`calendars: store: groups[0]: group "branch" already uses calendar "office"`),
+		},
+		{
+			name: "invalid resolve mode",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "google.com"}},
+				ResolveMode:          "always",
+			},
+			expectedErr: errors.New(`resolve_mode must be startup, every_scrape or ttl, got "always"`),
+		},
+		{
+			name: "resolution ttl missing",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "google.com"}},
+				ResolveMode:          resolveTTL,
+			},
+			expectedErr: errors.New("resolution_ttl must be positive with resolve_mode ttl"),
+		},
+		{
+			name: "negative max datapoints per batch",
+			config: Config{
+				ControllerConfig:      scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig:  metadata.DefaultMetricsBuilderConfig(),
+				Targets:               []Target{{Endpoint: "google.com"}},
+				MaxDatapointsPerBatch: -1,
+			},
+			expectedErr: errors.New("max_datapoints_per_batch cannot be negative"),
+		},
+		{
+			name: "resource attribute template of unknown field",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "google.com"}},
+				ResourceAttributes:   map[string]string{"service.name": "probe-{{ .Site }}"},
+			},
+			expectedErr: errors.New(`targets[0]: resource_attributes: service.name: template: service.name:1:9: executing "service.name" at <.Site>: can't evaluate field Site in type pingcheckreceiver.Target`),
+		},
+		{
+			name: "unknown counter temporality",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "google.com"}},
+				CounterTemporality:   "gauge",
+			},
+			expectedErr: errors.New(`counter_temporality must be cumulative or delta, got "gauge"`),
+		},
+		{
+			name: "negative healthy_emit_every",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "google.com"}},
+				HealthyEmitEvery:     -1,
+			},
+			expectedErr: errors.New("healthy_emit_every cannot be negative"),
+		},
+		{
+			name: "target in denied range",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "google.com"}, {Endpoint: "10.1.2.3"}},
+				AllowedCIDRs:         []string{"10.0.0.0/8"},
+				DeniedCIDRs:          []string{"10.1.0.0/16"},
+			},
+			expectedErr: errors.New("targets[1]: destination 10.1.2.3 is within denied_cidrs 10.1.0.0/16"),
+		},
+		{
+			name: "invalid packet size",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets: []Target{
+					{Endpoint: "google.com", PacketSize: 70000, PacketSizes: []int{64, 16}},
+					{Endpoint: "example.com", PathMTUDiscovery: true, PathMTUMax: 9216000, TTL: 256},
+				},
+			},
+			expectedErr: multierr.Combine(
+				errors.New("targets[0]: packet_size must be between 24 and 65507"),
+				errors.New("targets[0]: packet_sizes[1]: 16 must be between 24 and 65507"),
+				errors.New("targets[1]: ttl must be between 1 and 255"),
+				errors.New("targets[1]: path_mtu_max must be between 68 and 65535"),
+			),
+		},
+		{
+			name: "invalid dscp value",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "google.com", DSCPValues: []int{46, 64}}},
+			},
+			expectedErr: errors.New("targets[0]: dscp_values[1]: 64 must be between 0 and 63"),
+		},
+		{
+			name: "invalid wake_on_fail",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets: []Target{{Endpoint: "10.0.0.1", WakeOnFail: &WakeOnFailConfig{
+					MAC:      "00:11:22",
+					Failures: -1,
+				}}},
+			},
+			expectedErr: errors.New(`targets[0]: wake_on_fail: mac must be a 6 byte MAC address, got "00:11:22"; ` +
+				"targets[0]: wake_on_fail: failures cannot be negative"),
+		},
+		{
+			name: "invalid snmp",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "10.0.0.1", SNMP: &SNMPCheckConfig{Port: 70000}}},
+			},
+			expectedErr: errors.New("targets[0]: snmp: port must be between 0 and 65535, got 70000"),
+		},
+		{
+			name: "invalid traceroute",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets: []Target{
+					{Endpoint: "10.0.0.1", Traceroute: &TracerouteConfig{MaxHops: 256, ProbesPerHop: 11, ASN: "whois"}},
+					{Endpoint: "10.0.0.2", Traceroute: &TracerouteConfig{Interval: -time.Second, ASN: "database"}},
+				},
+				Privileged: true,
+			},
+			expectedErr: errors.New("targets[0]: traceroute: max_hops must be between 1 and 255; " +
+				"targets[0]: traceroute: probes_per_hop must be between 1 and 10; " +
+				"targets[0]: traceroute: asn must be cymru or database, got \"whois\"; " +
+				"targets[1]: traceroute: interval cannot be negative; " +
+				"targets[1]: traceroute: asn_database is required with asn database"),
+		},
+		{
+			name: "traceroute unprivileged",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "10.0.0.1", Traceroute: &TracerouteConfig{}}},
+			},
+			expectedErr: errors.New("targets[0]: traceroute requires privileged: true"),
+		},
+		{
+			name: "invalid sla",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets: []Target{{Endpoint: "10.0.0.1", SLA: &SLAConfig{
+					MaxLatency: -time.Second,
+					Timezone:   "Mars/Olympus_Mons",
+					Schedules: []SLASchedule{
+						{Name: "backup", TimeWindow: TimeWindow{Start: "01:00", End: "05:00"}},
+						{Name: "backup", TimeWindow: TimeWindow{Start: "01:00", End: "05:00"}, MaxLoss: &invalidLoss},
+					},
+				}}},
+			},
+			expectedErr: errors.New("targets[0]: sla: max_latency cannot be negative; " +
+				"targets[0]: sla: timezone: unknown time zone Mars/Olympus_Mons; " +
+				`targets[0]: sla: schedules[1]: duplicate name "backup"; ` +
+				"targets[0]: sla: schedules[1]: max_loss must be between 0 and 1"),
+		},
+		{
+			name: "invalid entity",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				ResourceAttributes:   map[string]string{"site": "edge-1"},
+				Targets: []Target{
+					{Endpoint: "10.0.0.1", Entity: &EntityConfig{Description: map[string]string{"network.device.name": "sw"}}},
+					{Endpoint: "10.0.0.2", Entity: &EntityConfig{
+						ID:          map[string]string{"site": "edge-2"},
+						Description: map[string]string{"site": "edge-2", "": "sw"},
+					}},
+				},
+			},
+			expectedErr: errors.New("targets[0]: entity: id must set at least one attribute; " +
+				"targets[1]: entity: id: site is set by resource_attributes as well; " +
+				"targets[1]: entity: description: key cannot be empty; " +
+				"targets[1]: entity: description: site is an id attribute as well"),
+		},
+		{
+			name: "invalid first_responder",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "10.0.0.1"}},
+				FirstResponder:       FirstResponderConfig{Enabled: true, DownFraction: 1.5, Interval: time.Second},
+			},
+			expectedErr: errors.New("first_responder: down_fraction must be greater than 0 and at most 1; " +
+				"first_responder: duration must be positive"),
+		},
+		{
+			name: "reserved label",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "10.0.0.1", Labels: map[string]string{"net.peer.name": "router"}}},
+			},
+			expectedErr: errors.New("targets[0]: labels: net.peer.name is an attribute of the receiver"),
+		},
+		{
+			name: "underlay same as endpoint",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "10.0.0.1", Underlay: "10.0.0.1"}},
+			},
+			expectedErr: errors.New("targets[0]: underlay must differ from endpoint"),
+		},
+		{
+			name: "invalid protocol",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets: []Target{
+					{Endpoint: "10.0.0.1", Protocol: "udp"},
+					{Endpoint: "10.0.0.2", Port: 443},
+					{Endpoint: "10.0.0.3", Protocol: "tcp", PacketSize: 1400},
+					{Endpoint: "10.0.0.4", Protocol: "tcp", Port: 443},
+					{Endpoint: "10.0.0.4", Protocol: "tcp", Port: 22},
+					{Endpoint: "10.0.0.5", Protocol: "icmp"},
+					{Endpoint: "10.0.0.5"},
+				},
+			},
+			expectedErr: multierr.Combine(
+				errors.New(`targets[0]: protocol must be icmp or tcp, got "udp"`),
+				errors.New("targets[1]: port requires protocol tcp"),
+				errors.New("targets[2]: port must be between 1 and 65535 for protocol tcp"),
+				errors.New("targets[2]: packet_size, packet_sizes, dscp_values, path_mtu_discovery and ttl require protocol icmp"),
+				errors.New("targets[4]: protocol, port, network, dual_stack and resolve_all must match targets[3] of the same endpoint"),
+			),
+		},
+		{
+			name: "invalid network",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets: []Target{
+					{Endpoint: "example.com", Network: "ipv6"},
+					{Endpoint: "example.net", Network: "ip6"},
+					{Endpoint: "example.net", Network: "ip"},
+					{Endpoint: "example.org", Network: "ip"},
+					{Endpoint: "example.org"},
+				},
+			},
+			expectedErr: multierr.Combine(
+				errors.New(`targets[0]: network must be ip, ip4 or ip6, got "ipv6"`),
+				errors.New("targets[2]: protocol, port, network, dual_stack and resolve_all must match targets[1] of the same endpoint"),
+			),
+		},
+		{
+			name: "invalid dual_stack",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets: []Target{
+					{Endpoint: "example.com", DualStack: true, Network: "ip6"},
+					{Endpoint: "192.0.2.1", DualStack: true},
+					{Endpoint: "example.net", DualStack: true},
+					{Endpoint: "example.net"},
+				},
+			},
+			expectedErr: multierr.Combine(
+				errors.New("targets[0]: dual_stack and network cannot both be set"),
+				errors.New("targets[1]: dual_stack requires a hostname, got 192.0.2.1"),
+				errors.New("targets[3]: protocol, port, network, dual_stack and resolve_all must match targets[2] of the same endpoint"),
+			),
+		},
+		{
+			name: "invalid resolve_all",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets: []Target{
+					{Endpoint: "example.com", ResolveAll: true, DualStack: true},
+					{Endpoint: "192.0.2.1", ResolveAll: true},
+				},
+			},
+			expectedErr: multierr.Combine(
+				errors.New("targets[0]: resolve_all and dual_stack cannot both be set"),
+				errors.New("targets[1]: resolve_all requires a hostname, got 192.0.2.1"),
+			),
+		},
+		{
+			name: "invalid overflow",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "google.com"}},
+				Overflow:             OverflowConfig{MaxDatapoints: -1, Policy: "drop_newest"},
+			},
+			expectedErr: multierr.Combine(
+				errors.New("overflow: max_datapoints cannot be negative"),
+				errors.New(`overflow: policy must be drop_oldest or aggregate_overflow, got "drop_newest"`),
+			),
+		},
+		{
+			name: "negative max_concurrent_probes",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "google.com"}},
+				MaxConcurrentProbes:  -1,
+			},
+			expectedErr: errors.New("max_concurrent_probes cannot be negative"),
+		},
+		{
+			name: "negative shared_budget",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "google.com"}},
+				SharedBudget:         SharedBudgetConfig{MaxSockets: -1, MaxPacketsPerSecond: -5},
+			},
+			expectedErr: multierr.Combine(
+				errors.New("shared_budget: max_sockets cannot be negative"),
+				errors.New("shared_budget: max_packets_per_second cannot be negative"),
+			),
+		},
+		{
+			name: "invalid shedding",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "google.com"}},
+				Shedding:             SheddingConfig{Enabled: true, SoftLimitPercentage: 90, HardLimitPercentage: 80},
+			},
+			expectedErr: errors.New("shedding: soft_limit_percentage must be below hard_limit_percentage"),
+		},
+		{
+			name: "invalid metric prefix",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "google.com"}},
+				MetricPrefix:         "corp.netmon.",
+			},
+			expectedErr: errors.New(`metric_prefix "corp.netmon." must be dot separated names of letters, digits and underscores`),
+		},
+		{
+			name: "invalid duration histogram",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "google.com"}},
+				DurationHistogram:    DurationHistogramConfig{Enabled: true, Buckets: []float64{1, 5, 5, 2}},
+			},
+			expectedErr: errors.New("duration_histogram: buckets[2]: 5 must be greater than the bucket before it; " +
+				"duration_histogram: buckets[3]: 2 must be greater than the bucket before it"),
+		},
+		{
+			name: "invalid heatmap",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets: []Target{{
+					Endpoint: "google.com",
+					Metrics:  map[string]metadata.MetricConfig{"ping.duration.heatmap": {Enabled: true}},
+				}},
+				Heatmap: HeatmapConfig{Buckets: []float64{10, 1}},
+			},
+			expectedErr: errors.New("heatmap: buckets[1]: 1 must be greater than the bucket before it"),
+		},
+		{
+			name: "invalid exponential histogram",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "google.com"}},
+				DurationHistogram:    DurationHistogramConfig{Enabled: true, Type: histogramExponential, MaxScale: 21},
+			},
+			expectedErr: errors.New("duration_histogram: max_scale must be between -10 and 20, got 21"),
+		},
+		{
+			name: "invalid metric names",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "google.com"}},
+				MetricNames:          "legacy",
+			},
+			expectedErr: errors.New(`metric_names must be both, new or old, got "legacy"`),
+		},
+		{
+			name: "no targets allowed",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{},
+				AllowEmptyTargets:    true,
+			},
+			expectedErr: nil,
+		},
 		{
 			name: "empty endpoint",
 			config: Config{
@@ -53,9 +491,9 @@ func TestConfigValidate(t *testing.T) {
 				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
 				Targets: []Target{
 					{
-						Endpoint: "",
-						Count:    4,
-						Timeout:  5 * time.Second,
+						Endpoint:   "",
+						Count:      4,
+						RunTimeout: 5 * time.Second,
 					},
 				},
 			},
@@ -70,9 +508,9 @@ func TestConfigValidate(t *testing.T) {
 				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
 				Targets: []Target{
 					{
-						Endpoint: "google.com",
-						Count:    -1,
-						Timeout:  5 * time.Second,
+						Endpoint:   "google.com",
+						Count:      -1,
+						RunTimeout: 5 * time.Second,
 					},
 				},
 			},
@@ -87,9 +525,9 @@ func TestConfigValidate(t *testing.T) {
 				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
 				Targets: []Target{
 					{
-						Endpoint: "google.com",
-						Count:    0,
-						Timeout:  5 * time.Second,
+						Endpoint:   "google.com",
+						Count:      0,
+						RunTimeout: 5 * time.Second,
 					},
 				},
 			},
@@ -102,32 +540,120 @@ func TestConfigValidate(t *testing.T) {
 				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
 				Targets: []Target{
 					{
-						Endpoint: "google.com",
-						Count:    4,
-						Timeout:  -1 * time.Second,
+						Endpoint:   "google.com",
+						Count:      4,
+						RunTimeout: -1 * time.Second,
 					},
 				},
 			},
 			expectedErr: multierr.Combine(
-				errors.New("targets[0]: timeout cannot be negative"),
+				errors.New("targets[0]: run_timeout cannot be negative"),
 			),
 		},
 		{
 			name: "negative interval",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets: []Target{
+					{
+						Endpoint:   "google.com",
+						Count:      4,
+						RunTimeout: 5 * time.Second,
+						Interval:   -1 * time.Second,
+					},
+				},
+			},
+			expectedErr: multierr.Combine(
+				errors.New("targets[0]: interval cannot be negative"),
+			),
+		},
+		{
+			name: "timeout and run timeout",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets: []Target{
+					{
+						Endpoint:   "google.com",
+						Timeout:    5 * time.Second,
+						RunTimeout: 5 * time.Second,
+					},
+				},
+			},
+			expectedErr: multierr.Combine(
+				errors.New("targets[0]: timeout and run_timeout cannot both be set"),
+			),
+		},
+		{
+			name: "run timeout too short for count",
 			config: Config{
 				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
 				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
 				Targets: []Target{
 					{
 						Endpoint: "google.com",
-						Count:    4,
+						Count:    10,
 						Timeout:  5 * time.Second,
-						Interval: -1 * time.Second,
 					},
 				},
 			},
 			expectedErr: multierr.Combine(
-				errors.New("targets[0]: interval cannot be negative"),
+				errors.New("targets[0]: run_timeout 5s ends before all 10 packets are sent 1s apart"),
+			),
+		},
+		{
+			name: "run timeout too short for packet timeout",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets: []Target{
+					{
+						Endpoint:      "google.com",
+						Count:         4,
+						RunTimeout:    5 * time.Second,
+						PacketTimeout: 3 * time.Second,
+					},
+				},
+			},
+			expectedErr: multierr.Combine(
+				errors.New("targets[0]: run_timeout 5s is shorter than sending 4 packets 1s apart plus packet_timeout 3s"),
+			),
+		},
+		{
+			name: "packet timeout exceeds duration",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets: []Target{
+					{
+						Endpoint:      "google.com",
+						Duration:      time.Second,
+						PacketTimeout: 2 * time.Second,
+					},
+				},
+			},
+			expectedErr: multierr.Combine(
+				errors.New("targets[0]: packet_timeout 2s exceeds run_timeout 1s"),
+			),
+		},
+		{
+			name: "duration with count and timeout",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets: []Target{
+					{
+						Endpoint:   "google.com",
+						Count:      4,
+						RunTimeout: 5 * time.Second,
+						Duration:   30 * time.Second,
+					},
+				},
+			},
+			expectedErr: multierr.Combine(
+				errors.New("targets[0]: count and duration cannot both be set"),
+				errors.New("targets[0]: run_timeout and duration cannot both be set"),
 			),
 		},
 		{
@@ -137,20 +663,177 @@ func TestConfigValidate(t *testing.T) {
 				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
 				Targets: []Target{
 					{
-						Endpoint: "",
-						Count:    -1,
-						Timeout:  -1 * time.Second,
-						Interval: -1 * time.Second,
+						Endpoint:   "",
+						Count:      -1,
+						RunTimeout: -1 * time.Second,
+						Interval:   -1 * time.Second,
 					},
 				},
 			},
 			expectedErr: multierr.Combine(
 				errors.New("targets[0]: endpoint cannot be empty"),
 				errors.New("targets[0]: count cannot be negative"),
-				errors.New("targets[0]: timeout cannot be negative"),
+				errors.New("targets[0]: run_timeout cannot be negative"),
 				errors.New("targets[0]: interval cannot be negative"),
 			),
 		},
+		{
+			name: "invalid fault injection",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets: []Target{
+					{
+						Endpoint: "google.com",
+						FaultInjection: &FaultInjectionConfig{
+							Loss:    1.5,
+							Latency: -time.Second,
+							Error:   "exploded",
+						},
+					},
+				},
+			},
+			expectedErr: multierr.Combine(
+				errors.New("targets[0]: fault_injection: loss must be between 0 and 1; targets[0]: fault_injection: latency cannot be negative; targets[0]: fault_injection: unknown error type \"exploded\""),
+			),
+		},
+		{
+			name: "invalid diagnostics",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets: []Target{
+					{
+						Endpoint: "google.com",
+					},
+				},
+				Diagnostics: DiagnosticsConfig{
+					Enabled:          true,
+					FailureThreshold: 0,
+					Resolvers:        []string{"8.8.8.8"},
+					TCPPorts:         []int{0},
+				},
+			},
+			expectedErr: multierr.Combine(
+				errors.New("diagnostics: failure_threshold must be at least 1"),
+				errors.New("diagnostics: timeout must be positive"),
+				errors.New("diagnostics: resolvers[0]: address 8.8.8.8: missing port in address"),
+				errors.New("diagnostics: tcp_ports[0]: port 0 out of range"),
+			),
+		},
+		{
+			name: "invalid interface check action",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "google.com"}},
+				InterfaceCheck: InterfaceCheckConfig{
+					Enabled: true,
+					Action:  "ignore",
+				},
+			},
+			expectedErr: errors.New(`interface_check: unknown action "ignore"`),
+		},
+		{
+			name: "invalid holidays",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "google.com"}},
+				Holidays: HolidaysConfig{
+					Source:   "ftp://calendars.example/holidays.ics",
+					Timezone: "Mars/Olympus",
+					Action:   "ignore",
+				},
+			},
+			expectedErr: multierr.Combine(
+				errors.New(`holidays: source must be a path or an http or https URL, got "ftp://calendars.example/holidays.ics"`),
+				errors.New("holidays: refresh_interval must be positive"),
+				errors.New("holidays: timezone: unknown time zone Mars/Olympus"),
+				errors.New(`holidays: unknown action "ignore"`),
+			),
+		},
+		{
+			name: "invalid connectivity check",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "google.com"}},
+				ConnectivityCheck: ConnectivityCheckConfig{
+					Enabled: true,
+					URL:     "https://connectivitycheck.gstatic.com/generate_204",
+				},
+			},
+			expectedErr: multierr.Combine(
+				errors.New(`connectivity_check: url must use http, got "https"`),
+				errors.New("connectivity_check: timeout must be positive"),
+			),
+		},
+		{
+			name: "invalid local check",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "google.com"}},
+				LocalCheck: LocalCheckConfig{
+					Enabled: true,
+					Gateway: "router.local",
+				},
+			},
+			expectedErr: multierr.Combine(
+				errors.New(`local_check: gateway "router.local" is not an IP address`),
+				errors.New("local_check: timeout must be positive"),
+			),
+		},
+		{
+			name: "unknown metric override",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets: []Target{
+					{
+						Endpoint: "google.com",
+						Metrics:  map[string]metadata.MetricConfig{"ping.rtt": {Enabled: true}},
+					},
+				},
+			},
+			expectedErr: errors.New(`targets[0]: metrics: unknown metric "ping.rtt"`),
+		},
+		{
+			name: "invalid report_on_change",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "google.com"}},
+				ReportOnChange: ReportOnChangeConfig{
+					Enabled:       true,
+					MinRTTChange:  -time.Millisecond,
+					MinLossChange: 1.5,
+				},
+			},
+			expectedErr: multierr.Combine(
+				errors.New("report_on_change: min_rtt_change cannot be negative"),
+				errors.New("report_on_change: min_loss_change must be between 0 and 1"),
+				errors.New("report_on_change: max_staleness must be positive"),
+			),
+		},
+		{
+			name: "disabled diagnostics are not validated",
+			config: Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets: []Target{
+					{
+						Endpoint: "google.com",
+					},
+				},
+				Diagnostics: DiagnosticsConfig{
+					Enabled:          false,
+					FailureThreshold: 0,
+				},
+			},
+			expectedErr: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -164,3 +847,268 @@ func TestConfigValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestTargetUnmarshalCount(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	conf := confmap.NewFromStringMap(map[string]any{
+		"targets": []any{
+			map[string]any{"endpoint": "192.0.2.1"},
+			map[string]any{"endpoint": "192.0.2.2", "count": 0},
+			map[string]any{"endpoint": "192.0.2.3", "count": 3},
+			map[string]any{"endpoint": "192.0.2.4", "duration": "30s"},
+		},
+	})
+	require.NoError(t, conf.Unmarshal(cfg))
+
+	require.Len(t, cfg.Targets, 4)
+	assert.Equal(t, defaultCount, cfg.Targets[0].Count)
+	assert.Equal(t, 3, cfg.Targets[2].Count)
+	assert.Equal(t, 0, cfg.Targets[3].Count)
+	assert.Equal(t, 30*time.Second, cfg.Targets[3].Duration)
+	assert.NoError(t, cfg.Validate())
+
+	// An explicit count of 0 pings continuously for the run timeout
+	assert.Equal(t, Target{Endpoint: "192.0.2.2", Interval: defaultInterval, Duration: defaultRunTimeout}, cfg.Targets[1])
+	conf = confmap.NewFromStringMap(map[string]any{
+		"targets": []any{map[string]any{"endpoint": "192.0.2.2", "count": 0, "run_timeout": "10s"}},
+	})
+	require.NoError(t, conf.Unmarshal(cfg))
+	assert.Equal(t, 10*time.Second, cfg.Targets[0].Duration)
+	assert.Zero(t, cfg.Targets[0].RunTimeout)
+}
+
+func TestTargetDefaultCount(t *testing.T) {
+	// Targets built in Go without a count send the default count
+	target := Target{Endpoint: "192.0.2.1"}
+	assert.Equal(t, defaultCount, target.effective().Count)
+	assert.Zero(t, Target{Endpoint: "192.0.2.1", Duration: time.Minute}.effective().Count)
+
+	// and keep doing so once marshaled and read back
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{target}
+	conf := confmap.New()
+	require.NoError(t, conf.Marshal(cfg))
+	decoded := createDefaultConfig().(*Config)
+	require.NoError(t, conf.Unmarshal(decoded))
+	require.Len(t, decoded.Targets, 1)
+	assert.Equal(t, defaultCount, decoded.Targets[0].Count)
+	assert.Zero(t, decoded.Targets[0].Duration)
+}
+
+func TestTargetUnmarshalDefaults(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	conf := confmap.NewFromStringMap(map[string]any{
+		"targets": []any{
+			map[string]any{"endpoint": "192.0.2.1"},
+			map[string]any{"endpoint": "192.0.2.2", "run_timeout": "3s", "interval": "500ms"},
+			map[string]any{"endpoint": "192.0.2.3", "timeout": "8s"},
+			map[string]any{"endpoint": "192.0.2.4", "duration": "30s"},
+			map[string]any{"endpoint": "192.0.2.5", "timeout": "8s", "run_timeout": "8s"},
+		},
+	})
+	require.NoError(t, conf.Unmarshal(cfg))
+
+	// The decoded targets hold the values they are probed with
+	require.Len(t, cfg.Targets, 5)
+	for _, target := range cfg.Targets[:4] {
+		e := target.effective()
+		assert.Equal(t, e.Count, target.Count, target.Endpoint)
+		assert.Equal(t, e.Interval, target.Interval, target.Endpoint)
+		assert.Zero(t, target.Timeout, target.Endpoint)
+	}
+	assert.Equal(t, defaultRunTimeout, cfg.Targets[0].RunTimeout)
+	assert.Equal(t, defaultInterval, cfg.Targets[0].Interval)
+	assert.Equal(t, 3*time.Second, cfg.Targets[1].RunTimeout)
+	assert.Equal(t, 500*time.Millisecond, cfg.Targets[1].Interval)
+	// The deprecated alias is moved to run_timeout
+	assert.Equal(t, 8*time.Second, cfg.Targets[2].RunTimeout)
+	// The duration bounds the run
+	assert.Zero(t, cfg.Targets[3].RunTimeout)
+	assert.Equal(t, 30*time.Second, cfg.Targets[3].effective().RunTimeout)
+
+	assert.EqualError(t, cfg.Validate(), "targets[4]: timeout and run_timeout cannot both be set")
+}
+
+func TestTracerouteUnmarshal(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	conf := confmap.NewFromStringMap(map[string]any{
+		"targets": []any{
+			map[string]any{"endpoint": "192.0.2.1", "traceroute": map[string]any{
+				"max_hops":       12,
+				"probes_per_hop": 1,
+				"hop_timeout":    "200ms",
+			}},
+		},
+	})
+	require.NoError(t, conf.Unmarshal(cfg))
+
+	require.Len(t, cfg.Targets, 1)
+	assert.Equal(t, &TracerouteConfig{MaxHops: 12, ProbesPerHop: 1, HopTimeout: 200 * time.Millisecond}, cfg.Targets[0].Traceroute)
+}
+
+func TestConfigMarshalRoundTrip(t *testing.T) {
+	flows := component.MustNewIDWithName("flows", "edge")
+	maxLoss, nightLoss := 0.01, 0.05
+	cfg := createDefaultConfig().(*Config)
+	cfg.CollectionInterval = 30 * time.Second
+	cfg.InitialDelay = 2 * time.Second
+	cfg.Timeout = 20 * time.Second
+	cfg.Metrics.PingPacketLossPercent.Enabled = true
+	cfg.Profile = profileMedium
+	cfg.Privileged = true
+	cfg.StrictReplies = true
+	cfg.TrimmedMeanPercent = 20
+	cfg.AllowEmptyTargets = true
+	cfg.MaxConcurrentProbes = 50
+	cfg.SharedBudget = SharedBudgetConfig{MaxSockets: 200, MaxPacketsPerSecond: 500}
+	cfg.Shedding = SheddingConfig{Enabled: true, LimitMiB: 512, SoftLimitPercentage: 70, HardLimitPercentage: 90}
+	cfg.AllowedCIDRs = []string{"192.0.2.0/24"}
+	cfg.DeniedCIDRs = []string{"192.0.2.128/25"}
+	cfg.RecreateThreshold = 2
+	cfg.ResolveMode = resolveTTL
+	cfg.ResolutionTTL = time.Minute
+	cfg.MaxDatapointsPerBatch = 100
+	cfg.Overflow = OverflowConfig{MaxDatapoints: 1000, Policy: overflowAggregate}
+	cfg.HealthyEmitEvery = 3
+	cfg.MetricPrefix = "net."
+	cfg.MetricNames = metricNamesOld
+	cfg.CounterTemporality = "delta"
+	cfg.Deduplicate = true
+	cfg.SelfTest = true
+	cfg.DurationHistogram = DurationHistogramConfig{Enabled: true, Type: histogramExponential, Buckets: []float64{0.5, 1, 5}, MaxScale: 8}
+	cfg.Heatmap = HeatmapConfig{Buckets: []float64{1, 10, 100}}
+	cfg.Calendars = map[string]CalendarConfig{"office": {
+		Timezone: "Europe/Berlin",
+		Hours:    []TimeWindow{{Days: []string{"mon", "fri"}, Start: "08:00", End: "18:00"}},
+		Groups:   []string{"branch"},
+	}}
+	cfg.ResourceAttributes = map[string]string{"site": "edge-1"}
+	cfg.ReportOnChange = ReportOnChangeConfig{Enabled: true, MinRTTChange: time.Millisecond, MinLossChange: 0.1, MaxStaleness: time.Minute}
+	cfg.Audit = AuditConfig{Enabled: true, Path: "/var/log/ping-audit.log", Source: "opamp"}
+	cfg.Diagnostics = DiagnosticsConfig{Enabled: true, FailureThreshold: 2, Resolvers: []string{"192.0.2.53:53"}, TCPPorts: []int{443}, Timeout: time.Second}
+	cfg.InterfaceCheck = InterfaceCheckConfig{Enabled: true, Action: "skip"}
+	cfg.Holidays = HolidaysConfig{
+		Source:          "https://calendars.example/holidays.ics",
+		RefreshInterval: 6 * time.Hour,
+		Timezone:        "Europe/Berlin",
+		Action:          interfaceActionTag,
+	}
+	cfg.ConnectivityCheck = ConnectivityCheckConfig{Enabled: true, URL: "http://192.0.2.80/", Timeout: time.Second}
+	cfg.LocalCheck = LocalCheckConfig{Enabled: true, Gateway: "192.0.2.254", Timeout: time.Second}
+	cfg.FirstResponder = FirstResponderConfig{Enabled: true, DownFraction: 0.3, Interval: 100 * time.Millisecond, Duration: time.Minute}
+	cfg.PassiveCheck = PassiveCheckConfig{Extension: &flows, Window: time.Minute}
+	registry := component.MustNewID("healthregistry")
+	cfg.HealthRegistry = &registry
+	cfg.Traces = TracesConfig{PacketSpans: true}
+	cfg.SourceWatch = true
+	cfg.Targets = []Target{
+		{
+			Endpoint:         "192.0.2.1",
+			Protocol:         prober.ProtocolTCP,
+			Port:             443,
+			Network:          prober.NetworkIPv4,
+			DualStack:        true,
+			ResolveAll:       true,
+			Group:            "core",
+			Labels:           map[string]string{"rack": "a1"},
+			Weight:           2,
+			Priority:         1,
+			Count:            5,
+			Timeout:          3 * time.Second,
+			RunTimeout:       4 * time.Second,
+			PacketTimeout:    time.Second,
+			Interval:         200 * time.Millisecond,
+			Duration:         10 * time.Second,
+			Prewarm:          true,
+			DiscardFirst:     true,
+			Underlay:         "198.51.100.1",
+			PacketSize:       512,
+			PathMTUDiscovery: true,
+			PathMTUMax:       9000,
+			TTL:              32,
+			PacketSizes:      []int{64, 1400},
+			DSCPValues:       []int{0, 46},
+			Metrics:          map[string]metadata.MetricConfig{"ping.duration.stddev": {Enabled: false}},
+			FaultInjection: &FaultInjectionConfig{
+				Loss:    0.1,
+				Latency: time.Millisecond,
+				Error:   "timeout",
+			},
+			WakeOnFail: &WakeOnFailConfig{MAC: "00:11:22:33:44:55", Failures: 2, Broadcast: "192.0.2.255:9"},
+			SNMP:       &SNMPCheckConfig{Community: "ops", Port: 1161, Timeout: time.Second},
+			SLA: &SLAConfig{
+				MaxLatency: 50 * time.Millisecond,
+				MaxLoss:    &maxLoss,
+				Timezone:   "Europe/Berlin",
+				Schedules: []SLASchedule{{
+					Name:       "night",
+					TimeWindow: TimeWindow{Days: []string{"mon", "tue"}, Start: "22:00", End: "06:00"},
+					MaxLatency: 200 * time.Millisecond,
+					MaxLoss:    &nightLoss,
+				}},
+			},
+			Entity: &EntityConfig{
+				Type:        "network.device",
+				ID:          map[string]string{"network.device.id": "sw-01"},
+				Description: map[string]string{"network.device.name": "core-switch"},
+			},
+			Calendar: "office",
+			Traceroute: &TracerouteConfig{
+				Interval:     10 * time.Minute,
+				MaxHops:      20,
+				ProbesPerHop: 2,
+				HopTimeout:   500 * time.Millisecond,
+				Log:          true,
+				ASN:          "database",
+				ASNDatabase:  "/etc/otelcol/asns.txt",
+			},
+		},
+		// A continuous run, which is what an explicit count of 0 decodes to
+		{Endpoint: "192.0.2.2", Duration: defaultRunTimeout},
+	}
+	// Every option is set, so a block added to the config is round-tripped
+	// here once it is added to the fixture above
+	assertAllFieldsSet(t, reflect.ValueOf(cfg.Targets[0]), "targets[0]")
+	assertAllFieldsSet(t, reflect.ValueOf(*cfg), "config")
+
+	conf := confmap.New()
+	require.NoError(t, conf.Marshal(cfg))
+	decoded := createDefaultConfig().(*Config)
+	require.NoError(t, conf.Unmarshal(decoded))
+
+	reemitted := confmap.New()
+	require.NoError(t, reemitted.Marshal(decoded))
+	assert.Equal(t, conf.ToStringMap(), reemitted.ToStringMap())
+
+	// Whether a metric was set by the user is not marshaled, every decoded
+	// metric counts as set
+	decoded.MetricsBuilderConfig = cfg.MetricsBuilderConfig
+	decoded.Targets[0].Metrics = cfg.Targets[0].Metrics
+	assert.Equal(t, cfg, decoded)
+}
+
+// assertAllFieldsSet fails for every exported field of the receiver's config
+// structs in v that holds its zero value
+func assertAllFieldsSet(t *testing.T, v reflect.Value, path string) {
+	t.Helper()
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct || v.Type().PkgPath() != reflect.TypeFor[Config]().PkgPath() {
+		return
+	}
+	for i := range v.NumField() {
+		field, f := v.Type().Field(i), v.Field(i)
+		if !field.IsExported() || field.Name == "Targets" {
+			continue
+		}
+		if f.IsZero() {
+			t.Errorf("%s.%s is not set", path, field.Name)
+			continue
+		}
+		if f.Kind() == reflect.Slice {
+			f = f.Index(0)
+		}
+		assertAllFieldsSet(t, f, path+"."+field.Name)
+	}
+}
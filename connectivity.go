@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// connectivityScraperType identifies the connectivity check in the collector's
+// own telemetry, target scrapers always start with the receiver type instead
+var connectivityScraperType = component.MustNewType("connectivity")
+
+// connectivityScraper tells a working internet connection apart from one
+// intercepted by a captive portal
+type connectivityScraper struct {
+	cfg    *Config
+	logger *zap.Logger
+	clock  prober.Clock
+	client *http.Client
+	mb     *metadata.MetricsBuilder
+}
+
+func newConnectivityScraper(cfg *Config, settings receiver.Settings, clock prober.Clock) *connectivityScraper {
+	return &connectivityScraper{
+		cfg:    cfg,
+		logger: settings.Logger,
+		clock:  clock,
+		client: &http.Client{
+			// A portal answers with a redirect to its login page, which must not be followed
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		mb: metadata.NewMetricsBuilder(cfg.MetricsBuilderConfig, settings,
+			metadata.WithStartTime(pcommon.NewTimestampFromTime(clock.Now()))),
+	}
+}
+
+// scrape checks connectivity and records 1 for the current state and 0 for the others
+func (c *connectivityScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	state := c.check(ctx)
+	now := pcommon.NewTimestampFromTime(c.clock.Now())
+	for _, s := range []metadata.AttributeState{
+		metadata.AttributeStateFull,
+		metadata.AttributeStatePortal,
+		metadata.AttributeStateNone,
+	} {
+		var val int64
+		if s == state {
+			val = 1
+		}
+		c.mb.RecordConnectivityStateDataPoint(now, val, s)
+	}
+	return c.mb.Emit(), nil
+}
+
+// check requests the configured URL, which answers 204 No Content unless a
+// portal intercepts the request
+func (c *connectivityScraper) check(ctx context.Context) metadata.AttributeState {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.ConnectivityCheck.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.ConnectivityCheck.URL, http.NoBody)
+	if err != nil {
+		c.logger.Debug("Connectivity check failed", zap.Error(err))
+		return metadata.AttributeStateNone
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		c.logger.Debug("Connectivity check failed", zap.Error(err))
+		return metadata.AttributeStateNone
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode == http.StatusNoContent {
+		return metadata.AttributeStateFull
+	}
+	c.logger.Debug("Connectivity check intercepted",
+		zap.String("url", c.cfg.ConnectivityCheck.URL),
+		zap.Int("status", resp.StatusCode),
+		zap.String("location", resp.Header.Get("Location")))
+	return metadata.AttributeStatePortal
+}
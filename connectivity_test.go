@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+)
+
+func TestConnectivityScraper(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  http.HandlerFunc
+		expected string
+	}{
+		{
+			name: "full",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			},
+			expected: "full",
+		},
+		{
+			name: "portal redirect",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "http://portal.example/login", http.StatusFound)
+			},
+			expected: "portal",
+		},
+		{
+			name: "portal page",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte("<html>Accept the terms of use</html>"))
+			},
+			expected: "portal",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			assert.Equal(t, map[string]int64{
+				"full":   boolToInt(tt.expected == "full"),
+				"portal": boolToInt(tt.expected == "portal"),
+				"none":   boolToInt(tt.expected == "none"),
+			}, scrapeConnectivity(t, server.URL))
+		})
+	}
+}
+
+func TestConnectivityScraperNoConnectivity(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	assert.Equal(t, map[string]int64{
+		"full":   0,
+		"portal": 0,
+		"none":   1,
+	}, scrapeConnectivity(t, server.URL))
+}
+
+// scrapeConnectivity runs the check against url and returns the value of each state
+func scrapeConnectivity(t *testing.T, url string) map[string]int64 {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ConnectivityCheck.Enabled = true
+	cfg.ConnectivityCheck.URL = url
+	cfg.ConnectivityCheck.Timeout = time.Second
+
+	clock := pingchecktest.NewClock(time.Unix(1_700_000_000, 0))
+	scraper := newConnectivityScraper(cfg, receivertest.NewNopSettings(metadata.Type), clock)
+	metrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, 1, metrics.MetricCount())
+	m := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "connectivity.state", m.Name())
+
+	states := make(map[string]int64)
+	dps := m.Gauge().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		state, ok := dps.At(i).Attributes().Get("state")
+		require.True(t, ok)
+		states[state.Str()] = dps.At(i).IntValue()
+	}
+	return states
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
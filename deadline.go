@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+)
+
+// maxDeadlineHeadroom caps the part of the scrape budget reserved for
+// emitting results, which is otherwise a tenth of the budget
+const maxDeadlineHeadroom = time.Second
+
+// probeContext returns the context probes of a round run with. Its deadline
+// is a little ahead of the scrape's, so probes still running are cut short
+// and their partial results are emitted before the scrape is given up.
+func probeContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+	headroom := min(time.Until(deadline)/10, maxDeadlineHeadroom)
+	return context.WithDeadline(ctx, deadline.Add(-headroom))
+}
+
+// probeContext returns the context a probe of r runs with. The probe gets the
+// timeout of the scrape that started r from when it starts, rather than that
+// scrape's deadline, so neither cancelling that scrape nor waiting for a slot
+// of max_concurrent_probes cuts it short. Each target's scrape still gives
+// up on its own deadline, so a probe started late may be reported as missed.
+func (r *scrapeRound) probeContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.timeout <= 0 {
+		return probeContext(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	ctx, cancelProbe := probeContext(ctx)
+	return ctx, func() {
+		cancelProbe()
+		cancel()
+	}
+}
+
+// queueContext returns the context targets of r are dispatched with, which is
+// done a little ahead of the deadline of the scrape that started r
+func (r *scrapeRound) queueContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.deadline.IsZero() {
+		return context.WithCancel(ctx)
+	}
+	ctx, cancel := context.WithDeadline(ctx, r.deadline)
+	ctx, cancelQueue := probeContext(ctx)
+	return ctx, func() {
+		cancelQueue()
+		cancel()
+	}
+}
+
+// missedResult is the result of the i-th target when it has none because ctx
+// is done. A missed deadline is recorded as a deadline_exceeded error, so the
+// target shows up as missing instead of silently disappearing from the scrape.
+func (s *pingScraper) missedResult(ctx context.Context, i int, err error) targetResult {
+	target := s.cfg.Targets[i]
+	err = fmt.Errorf("target %s: %w", target.Endpoint, err)
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) || !s.metrics[i].PingErrors.Enabled {
+		return targetResult{metrics: pmetric.NewMetrics(), err: err}
+	}
+
+	// The target's builder may still be held by its probe
+	mbc := s.cfg.MetricsBuilderConfig
+	mbc.Metrics = s.metrics[i]
+	mb := metadata.NewMetricsBuilder(mbc, s.settings, metadata.WithStartTime(s.startTime))
+	mb.RecordPingErrorsDataPoint(
+		pcommon.NewTimestampFromTime(s.clock.Now()),
+		1,
+		target.Endpoint,
+		"",
+		0,
+		metadata.AttributeErrorTypeDeadlineExceeded,
+	)
+	md := mb.Emit(metadata.WithResource(s.resources[i]))
+	putLabels(md, s.labels(i))
+	return targetResult{metrics: md, err: scrapererror.NewPartialScrapeError(err, resultMetricCount(s.metrics[i]))}
+}
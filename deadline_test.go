@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// slowProber answers the endpoints in fast right away, other probes run
+// until their context is done
+type slowProber struct {
+	fast map[string]bool
+}
+
+func (p *slowProber) NewPinger(cfg prober.PingerConfig) (prober.Pinger, error) {
+	return &slowPinger{endpoint: cfg.Endpoint, fast: p.fast[cfg.Endpoint]}, nil
+}
+
+type slowPinger struct {
+	endpoint string
+	fast     bool
+}
+
+func (p *slowPinger) Run(ctx context.Context) (*prober.Statistics, error) {
+	if !p.fast {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return &prober.Statistics{
+		IPAddr:      &net.IPAddr{IP: net.ParseIP(p.endpoint)},
+		PacketsSent: 1,
+		PacketsRecv: 1,
+		AvgRtt:      10 * time.Millisecond,
+	}, nil
+}
+
+func (p *slowPinger) Stop() {}
+
+func TestProbeContext(t *testing.T) {
+	ctx, cancel := probeContext(context.Background())
+	defer cancel()
+	_, ok := ctx.Deadline()
+	assert.False(t, ok)
+
+	deadline := time.Now().Add(time.Minute)
+	parent, cancelParent := context.WithDeadline(context.Background(), deadline)
+	defer cancelParent()
+	ctx, cancel = probeContext(parent)
+	defer cancel()
+	got, ok := ctx.Deadline()
+	require.True(t, ok)
+	assert.Equal(t, deadline.Add(-maxDeadlineHeadroom), got)
+
+	deadline = time.Now().Add(2 * time.Second)
+	parent, cancelParent = context.WithDeadline(context.Background(), deadline)
+	defer cancelParent()
+	ctx, cancel = probeContext(parent)
+	defer cancel()
+	got, ok = ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, deadline.Add(-200*time.Millisecond), got, 10*time.Millisecond)
+}
+
+func TestScraperDeadlineExceeded(t *testing.T) {
+	for _, maxConcurrent := range []int{0, 1} {
+		cfg := createDefaultConfig().(*Config)
+		cfg.Targets = []Target{
+			{Endpoint: "192.0.2.1", Count: 1, Weight: 1},
+			{Endpoint: "192.0.2.2", Count: 1},
+			{Endpoint: "192.0.2.3", Count: 1},
+		}
+		cfg.Metrics.PingErrors.Enabled = true
+		cfg.MaxConcurrentProbes = maxConcurrent
+
+		p := &slowProber{fast: map[string]bool{"192.0.2.1": true}}
+		scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(p)))
+		require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		md, err := scraper.scrapeTarget(ctx, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 0, deadlineErrors(md))
+
+		// Results collected before the deadline are emitted, the others are
+		// marked as missing
+		for i := 1; i < len(cfg.Targets); i++ {
+			md, err = scraper.scrapeTarget(ctx, i)
+			require.Error(t, err)
+			assert.True(t, scrapererror.IsPartialScrapeError(err))
+			assert.Contains(t, err.Error(), "target "+cfg.Targets[i].Endpoint)
+			assert.Equal(t, 1, deadlineErrors(md), "max_concurrent_probes %d, target %d", maxConcurrent, i)
+		}
+		cancel()
+		require.NoError(t, scraper.shutdown(context.Background()))
+	}
+}
+
+func TestScraperRoundOutlivesStartingScrape(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 1}, {Endpoint: "192.0.2.2", Count: 1}}
+	cfg.Metrics.PingErrors.Enabled = true
+
+	p := &slowProber{fast: map[string]bool{"192.0.2.1": true}}
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(p)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	_, err := scraper.scrapeTarget(ctx, 0)
+	require.NoError(t, err)
+	start := time.Now()
+	cancel()
+
+	// The probe of the other target runs until its own deadline
+	md, err := scraper.scrapeTarget(context.Background(), 1)
+	require.Error(t, err)
+	assert.EqualError(t, err, "target 192.0.2.2: ping failed: context deadline exceeded")
+	assert.Equal(t, 1, deadlineErrors(md))
+	assert.Greater(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestScraperRoundProbeDeadlines(t *testing.T) {
+	r := &scrapeRound{}
+	ctx, cancel := r.probeContext(context.Background())
+	defer cancel()
+	_, ok := ctx.Deadline()
+	assert.False(t, ok)
+
+	// Probes get the timeout from when they start, dispatch stops at the deadline
+	r = &scrapeRound{deadline: time.Now().Add(-time.Second), timeout: time.Minute}
+	ctx, cancel = r.probeContext(context.Background())
+	defer cancel()
+	got, ok := ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute-maxDeadlineHeadroom), got, time.Second)
+	queue, cancel := r.queueContext(context.Background())
+	defer cancel()
+	assert.ErrorIs(t, queue.Err(), context.DeadlineExceeded)
+}
+
+// deadlineErrors counts the ping.errors data points of md typed deadline_exceeded
+func deadlineErrors(md pmetric.Metrics) int {
+	count := 0
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				if ms.At(k).Name() != "ping.errors" {
+					continue
+				}
+				dps := ms.At(k).Sum().DataPoints()
+				for l := 0; l < dps.Len(); l++ {
+					errorType, _ := dps.At(l).Attributes().Get("error.type")
+					if errorType.Str() == metadata.AttributeErrorTypeDeadlineExceeded.String() {
+						count++
+					}
+				}
+			}
+		}
+	}
+	return count
+}
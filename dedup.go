@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"maps"
+	"slices"
+	"sync"
+)
+
+// probeKey identifies the probes of a target, targets of equal keys are
+// probed alike
+type probeKey struct {
+	endpoint   string
+	protocol   string
+	port       int
+	network    string
+	dualStack  bool
+	resolveAll bool
+}
+
+// probeKeyOf returns the key of target
+func probeKeyOf(target Target) probeKey {
+	return probeKey{
+		endpoint:   target.Endpoint,
+		protocol:   target.probeProtocol(),
+		port:       target.Port,
+		network:    target.probeNetwork(),
+		dualStack:  target.DualStack,
+		resolveAll: target.ResolveAll,
+	}
+}
+
+// probeClaims de-duplicates identical targets across the receivers of a
+// factory, e.g. a host found by several discovery sources, which each start
+// a receiver for it. Only the receiver that claimed a target first probes it,
+// and its data points carry the labels of every claim.
+type probeClaims struct {
+	mu     sync.Mutex
+	claims map[probeKey][]*probeClaim
+}
+
+// probeClaim is the claim of one receiver on a target
+type probeClaim struct {
+	key    probeKey
+	labels map[string]string
+}
+
+func newProbeClaims() *probeClaims {
+	return &probeClaims{claims: make(map[probeKey][]*probeClaim)}
+}
+
+// claim registers a receiver's interest in probing target
+func (c *probeClaims) claim(target Target) *probeClaim {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pc := &probeClaim{key: probeKeyOf(target), labels: target.Labels}
+	c.claims[pc.key] = append(c.claims[pc.key], pc)
+	return pc
+}
+
+// release withdraws pc, the next claim of its target takes over probing it
+func (c *probeClaims) release(pc *probeClaim) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	claims := slices.DeleteFunc(c.claims[pc.key], func(other *probeClaim) bool { return other == pc })
+	if len(claims) == 0 {
+		delete(c.claims, pc.key)
+		return
+	}
+	c.claims[pc.key] = claims
+}
+
+// owns reports whether pc is the claim that probes its target
+func (c *probeClaims) owns(pc *probeClaim) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	claims := c.claims[pc.key]
+	return len(claims) > 0 && claims[0] == pc
+}
+
+// labels returns the labels of every claim of pc's target. Where claims set
+// a label differently, the earliest claim's value is kept.
+func (c *probeClaims) labels(pc *probeClaim) map[string]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	claims := c.claims[pc.key]
+	if len(claims) <= 1 {
+		return pc.labels
+	}
+	merged := make(map[string]string)
+	for _, other := range slices.Backward(claims) {
+		maps.Copy(merged, other.labels)
+	}
+	return merged
+}
+
+// probes reports whether the i-th target is probed by this receiver rather
+// than by another receiver of an identical target
+func (s *pingScraper) probes(i int) bool {
+	pc := s.claim(i)
+	return pc == nil || s.claims.owns(pc)
+}
+
+// labels returns the labels of the data points of the i-th target
+func (s *pingScraper) labels(i int) map[string]string {
+	pc := s.claim(i)
+	if pc == nil {
+		return s.cfg.Targets[i].Labels
+	}
+	return s.claims.labels(pc)
+}
+
+// claim returns the claim on the i-th target, nil unless deduplicate is
+// enabled or once shut down
+func (s *pingScraper) claim(i int) *probeClaim {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.claimed == nil {
+		return nil
+	}
+	return s.claimed[i]
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+func TestProbeClaims(t *testing.T) {
+	c := newProbeClaims()
+	first := c.claim(Target{Endpoint: "192.0.2.1", Labels: map[string]string{"source": "k8s", "pod": "web-0"}})
+	second := c.claim(Target{Endpoint: "192.0.2.1", Labels: map[string]string{"source": "docker", "container": "web"}})
+	// Probed differently, so not a duplicate
+	other := c.claim(Target{Endpoint: "192.0.2.1", Protocol: prober.ProtocolTCP, Port: 443})
+
+	assert.True(t, c.owns(first))
+	assert.False(t, c.owns(second))
+	assert.True(t, c.owns(other))
+	assert.Equal(t, map[string]string{"source": "k8s", "pod": "web-0", "container": "web"}, c.labels(first))
+	assert.Empty(t, c.labels(other))
+
+	c.release(first)
+	assert.True(t, c.owns(second))
+	assert.Equal(t, map[string]string{"source": "docker", "container": "web"}, c.labels(second))
+	c.release(second)
+	c.release(other)
+	assert.Empty(t, c.claims)
+}
+
+func TestScraperDeduplicate(t *testing.T) {
+	fake := pingchecktest.NewProber()
+	fake.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 3, PacketsRecv: 3})
+	fo := newFactoryOptions(WithProber(fake))
+
+	newDiscovered := func(labels map[string]string) *pingScraper {
+		cfg := createDefaultConfig().(*Config)
+		cfg.Deduplicate = true
+		cfg.Targets = []Target{{Endpoint: "192.0.2.1", Labels: labels}}
+		scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), fo)
+		require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+		return scraper
+	}
+	k8s := newDiscovered(map[string]string{"k8s.pod.name": "web-0"})
+	docker := newDiscovered(map[string]string{"container.name": "web"})
+	defer func() { require.NoError(t, docker.shutdown(context.Background())) }()
+
+	labelsOf := func(md pmetric.Metrics) map[string]any {
+		var labels map[string]any
+		forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+			if m.Name() == "ping.packet_loss" {
+				labels = m.Gauge().DataPoints().At(0).Attributes().AsRaw()
+			}
+		})
+		return labels
+	}
+
+	md, err := k8s.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	labels := labelsOf(md)
+	assert.Equal(t, "web-0", labels["k8s.pod.name"])
+	assert.Equal(t, "web", labels["container.name"])
+
+	md, err = docker.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, md.DataPointCount())
+	assert.Equal(t, 1, fake.Runs("192.0.2.1"))
+
+	// The remaining receiver takes over once the first one stops
+	require.NoError(t, k8s.shutdown(context.Background()))
+	md, err = docker.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	labels = labelsOf(md)
+	assert.Equal(t, "web", labels["container.name"])
+	assert.NotContains(t, labels, "k8s.pod.name")
+	assert.Equal(t, 2, fake.Runs("192.0.2.1"))
+}
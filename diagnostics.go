@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// dnsDiagnostic holds the outcome of resolving a target against a single resolver
+type dnsDiagnostic struct {
+	Resolver string        `json:"resolver"`
+	Addrs    []string      `json:"addrs,omitempty"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// tcpDiagnostic holds the outcome of a TCP connect attempt to a single port
+type tcpDiagnostic struct {
+	Port     int           `json:"port"`
+	Open     bool          `json:"open"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// hopDiagnostic holds the outcome of the packets sent to one hop of a traceroute
+type hopDiagnostic struct {
+	TTL  int             `json:"ttl"`
+	Addr string          `json:"addr,omitempty"`
+	Sent int             `json:"sent"`
+	Rtts []time.Duration `json:"rtts,omitempty"`
+}
+
+// tracerouteDiagnostic holds the path traced to the target
+type tracerouteDiagnostic struct {
+	Hops    []hopDiagnostic `json:"hops,omitempty"`
+	Reached bool            `json:"reached"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// diagnosticBundle is the structured result of a diagnostic run
+type diagnosticBundle struct {
+	DNS        []dnsDiagnostic       `json:"dns,omitempty"`
+	TCP        []tcpDiagnostic       `json:"tcp,omitempty"`
+	Traceroute *tracerouteDiagnostic `json:"traceroute,omitempty"`
+}
+
+// tracer traces the path to the endpoint of a diagnostic run
+type tracer func(ctx context.Context) (*prober.Trace, error)
+
+// runDiagnostics collects a diagnostic bundle for the given endpoint, tracing
+// its path with trace unless nil
+func runDiagnostics(ctx context.Context, endpoint string, cfg DiagnosticsConfig, trace tracer) diagnosticBundle {
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	var bundle diagnosticBundle
+
+	// DNS lookups only make sense for hostnames
+	if net.ParseIP(endpoint) == nil {
+		for _, resolver := range cfg.Resolvers {
+			bundle.DNS = append(bundle.DNS, lookupWithResolver(ctx, endpoint, resolver))
+		}
+	}
+
+	for _, port := range cfg.TCPPorts {
+		bundle.TCP = append(bundle.TCP, probeTCPPort(ctx, endpoint, port))
+	}
+
+	// Traced last, as it takes longest and is cut short by the timeout
+	if trace != nil {
+		bundle.Traceroute = traceDiagnostic(ctx, trace)
+	}
+
+	return bundle
+}
+
+func traceDiagnostic(ctx context.Context, trace tracer) *tracerouteDiagnostic {
+	t, err := trace(ctx)
+	if err != nil {
+		return &tracerouteDiagnostic{Error: err.Error()}
+	}
+	result := &tracerouteDiagnostic{Reached: t.Reached}
+	for _, hop := range t.Hops {
+		h := hopDiagnostic{TTL: hop.TTL, Sent: hop.Sent, Rtts: hop.Rtts}
+		if hop.Addr != nil {
+			h.Addr = hop.Addr.String()
+		}
+		result.Hops = append(result.Hops, h)
+	}
+	return result
+}
+
+func lookupWithResolver(ctx context.Context, host, resolver string) dnsDiagnostic {
+	r := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, resolver)
+		},
+	}
+
+	start := time.Now()
+	addrs, err := r.LookupHost(ctx, host)
+	result := dnsDiagnostic{
+		Resolver: resolver,
+		Addrs:    addrs,
+		Duration: time.Since(start),
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func probeTCPPort(ctx context.Context, host string, port int) tcpDiagnostic {
+	var d net.Dialer
+
+	start := time.Now()
+	conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	result := tcpDiagnostic{
+		Port:     port,
+		Duration: time.Since(start),
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	_ = conn.Close()
+	result.Open = true
+	return result
+}
+
+// logDiagnostics emits the bundle as a single structured log record
+func logDiagnostics(logger *zap.Logger, endpoint string, failures int, bundle diagnosticBundle) {
+	logger.Warn("Target diagnostics",
+		zap.String("endpoint", endpoint),
+		zap.Int("consecutive_failures", failures),
+		zap.Any("dns", bundle.DNS),
+		zap.Any("tcp", bundle.TCP),
+		zap.Any("traceroute", bundle.Traceroute))
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+func TestRunDiagnosticsTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	openPort := listener.Addr().(*net.TCPAddr).Port
+
+	// Grab a free port and close it again so it is very likely refused
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedPort := closed.Addr().(*net.TCPAddr).Port
+	require.NoError(t, closed.Close())
+
+	bundle := runDiagnostics(context.Background(), "127.0.0.1", DiagnosticsConfig{
+		Resolvers: []string{"127.0.0.1:53"},
+		TCPPorts:  []int{openPort, closedPort},
+		Timeout:   time.Second,
+	}, nil)
+
+	// IP literal targets skip DNS lookups
+	assert.Empty(t, bundle.DNS)
+	assert.Nil(t, bundle.Traceroute)
+
+	require.Len(t, bundle.TCP, 2)
+	assert.Equal(t, openPort, bundle.TCP[0].Port)
+	assert.True(t, bundle.TCP[0].Open)
+	assert.Empty(t, bundle.TCP[0].Error)
+	assert.Equal(t, closedPort, bundle.TCP[1].Port)
+	assert.False(t, bundle.TCP[1].Open)
+	assert.NotEmpty(t, bundle.TCP[1].Error)
+}
+
+func TestRunDiagnosticsDNSFailure(t *testing.T) {
+	bundle := runDiagnostics(context.Background(), "target.invalid", DiagnosticsConfig{
+		// Nothing listens on the discard port, so the lookup must fail
+		Resolvers: []string{"127.0.0.1:9"},
+		Timeout:   time.Second,
+	}, nil)
+
+	require.Len(t, bundle.DNS, 1)
+	assert.Equal(t, "127.0.0.1:9", bundle.DNS[0].Resolver)
+	assert.Empty(t, bundle.DNS[0].Addrs)
+	assert.NotEmpty(t, bundle.DNS[0].Error)
+	assert.Empty(t, bundle.TCP)
+}
+
+func TestRunDiagnosticsTraceroute(t *testing.T) {
+	bundle := runDiagnostics(context.Background(), "192.0.2.1", DiagnosticsConfig{Timeout: time.Second},
+		func(context.Context) (*prober.Trace, error) {
+			return &testTrace, nil
+		})
+	require.NotNil(t, bundle.Traceroute)
+	assert.Equal(t, &tracerouteDiagnostic{
+		Hops: []hopDiagnostic{
+			{TTL: 1, Addr: "198.51.100.1", Sent: 3, Rtts: []time.Duration{time.Millisecond, 3 * time.Millisecond}},
+			{TTL: 2, Sent: 3},
+			{TTL: 3, Addr: "192.0.2.1", Sent: 3, Rtts: []time.Duration{10 * time.Millisecond, 10 * time.Millisecond, 10 * time.Millisecond}},
+		},
+		Reached: true,
+	}, bundle.Traceroute)
+
+	bundle = runDiagnostics(context.Background(), "192.0.2.1", DiagnosticsConfig{Timeout: time.Second},
+		func(context.Context) (*prober.Trace, error) {
+			return nil, prober.ErrTraceUnprivileged
+		})
+	require.NotNil(t, bundle.Traceroute)
+	assert.Empty(t, bundle.Traceroute.Hops)
+	assert.Equal(t, prober.ErrTraceUnprivileged.Error(), bundle.Traceroute.Error)
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// lookupEnabled reports whether the hostname of endpoint is resolved before
+// its next probe, as resolve_mode asks or for ping.dns.lookup.duration
+func (s *pingScraper) lookupEnabled(endpoint string) bool {
+	if _, err := netip.ParseAddr(endpoint); err == nil {
+		return false
+	}
+	switch s.cfg.ResolveMode {
+	case resolveEveryScrape:
+		return true
+	case resolveTTL:
+		s.mu.RLock()
+		state, ok := s.states[endpoint]
+		expired := !ok || !state.resolved.IsValid() || s.clock.Now().Sub(state.resolvedAt) >= s.cfg.ResolutionTTL
+		s.mu.RUnlock()
+		if expired {
+			return true
+		}
+	}
+	for i, target := range s.cfg.Targets {
+		if target.Endpoint == endpoint && s.metrics[i].PingDNSLookupDuration.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve looks up the hostname of target before its probe and returns how
+// long the lookup took. The address is kept for the pingers of target, which
+// are recreated if it changed, so they do not resolve it again. Lookups that
+// fail leave the pingers as they are, and report false.
+func (s *pingScraper) resolve(ctx context.Context, target Target) (time.Duration, bool) {
+	network := target.probeNetwork()
+	if target.DualStack {
+		network = prober.NetworkIPv4
+	}
+	started := time.Now()
+	addrs, err := s.lookupIP(ctx, network, target.Endpoint)
+	elapsed := time.Since(started)
+	if err != nil || len(addrs) == 0 {
+		s.logger.Debug("DNS lookup failed",
+			zap.String("endpoint", target.Endpoint),
+			zap.Error(err))
+		return 0, false
+	}
+	addr := addrs[0].Unmap()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := s.stateLocked(target.Endpoint)
+	state.resolved, state.resolvedAt = addr, s.clock.Now()
+	r, ok := s.pingers[target.Endpoint].(prober.Resolved)
+	if !ok {
+		return elapsed, true
+	}
+	if r.IPAddr() == nil {
+		// The pinger would resolve the hostname itself
+		s.dropPingersLocked(target.Endpoint)
+		return elapsed, true
+	}
+	if current, ok := netip.AddrFromSlice(r.IPAddr().IP); ok && current.Unmap() != addr {
+		s.logger.Info("Address of target changed, recreating pinger",
+			zap.String("endpoint", target.Endpoint),
+			zap.Stringer("previous", current.Unmap()),
+			zap.Stringer("current", addr))
+		s.dropPingersLocked(target.Endpoint)
+	}
+	return elapsed, true
+}
+
+// resolvedAddr returns the address last looked up for endpoint, nil if none
+func (s *pingScraper) resolvedAddr(endpoint string) *net.IPAddr {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	state, ok := s.states[endpoint]
+	if !ok || !state.resolved.IsValid() {
+		return nil
+	}
+	return &net.IPAddr{IP: state.resolved.AsSlice()}
+}
+
+// recordDNS records how long resolving the hostname of target took
+func (s *pingScraper) recordDNS(mb *metadata.MetricsBuilder, metrics metadata.MetricsConfig, target Target, o *probeOutcome) {
+	if !o.resolved || !metrics.PingDNSLookupDuration.Enabled {
+		return
+	}
+	mb.RecordPingDNSLookupDurationDataPoint(pcommon.NewTimestampFromTime(s.clock.Now()), milliseconds(o.lookupDuration), target.Endpoint)
+}
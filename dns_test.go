@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+)
+
+// dnsLookups returns the values of the ping.dns.lookup.duration data points of md
+func dnsLookups(md pmetric.Metrics) []float64 {
+	var values []float64
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		if m.Name() == "ping.dns.lookup.duration" {
+			values = append(values, m.Gauge().DataPoints().At(0).DoubleValue())
+		}
+	})
+	return values
+}
+
+func TestScraperDNSLookup(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.AllowEmptyTargets = true
+	cfg.Metrics.PingDNSLookupDuration.Enabled = true
+	cfg.Targets = []Target{{Endpoint: "probe.example", Count: 4}}
+
+	fakeProber := pingchecktest.NewProber()
+	// The pinger is created with the first lookup
+	fakeProber.SetCreateError("probe.example", errors.New("not yet"))
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	addr := netip.MustParseAddr("192.0.2.10")
+	var networks []string
+	scraper.lookupIP = func(_ context.Context, network, host string) ([]netip.Addr, error) {
+		assert.Equal(t, "probe.example", host)
+		networks = append(networks, network)
+		return []netip.Addr{addr}, nil
+	}
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+	fakeProber.SetCreateError("probe.example", nil)
+
+	md, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Len(t, dnsLookups(md), 1)
+	pingerCfg, _ := fakeProber.PingerConfig("probe.example")
+	require.NotNil(t, pingerCfg.IPAddr)
+	assert.Equal(t, "192.0.2.10", pingerCfg.IPAddr.String())
+
+	// The pinger is kept while the address stays the same
+	_, err = scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	first := scraper.pingers["probe.example"]
+
+	// and recreated with the new address once it changes
+	addr = netip.MustParseAddr("192.0.2.11")
+	md, err = scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	assert.NotSame(t, first, scraper.pingers["probe.example"])
+	pingerCfg, _ = fakeProber.PingerConfig("probe.example")
+	assert.Equal(t, "192.0.2.11", pingerCfg.IPAddr.String())
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		if m.Name() == "ping.packets.sent" {
+			ip, _ := m.Sum().DataPoints().At(0).Attributes().Get("net.peer.ip")
+			assert.Equal(t, "192.0.2.11", ip.Str())
+		}
+	})
+	assert.Equal(t, []string{"ip", "ip", "ip"}, networks)
+}
+
+func TestScraperDNSLookupSkipped(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics.PingDNSLookupDuration.Enabled = true
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4}, {Endpoint: "probe.example", Count: 4}}
+
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(pingchecktest.NewProber())))
+	var lookups int
+	scraper.lookupIP = func(context.Context, string, string) ([]netip.Addr, error) {
+		lookups++
+		return nil, errors.New("no such host")
+	}
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	// Addresses are not looked up, and failed lookups are not reported
+	for i := range cfg.Targets {
+		md, err := scraper.scrapeTarget(context.Background(), i)
+		require.NoError(t, err)
+		assert.Empty(t, dnsLookups(md))
+	}
+	assert.Equal(t, 1, lookups)
+}
+
+func TestScraperResolveMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		lookups int
+	}{
+		{name: "startup", mode: resolveStartup, lookups: 0},
+		{name: "every scrape", mode: resolveEveryScrape, lookups: 4},
+		{name: "ttl", mode: resolveTTL, lookups: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.ResolveMode = tt.mode
+			cfg.ResolutionTTL = 90 * time.Second
+			cfg.Targets = []Target{{Endpoint: "probe.example", Count: 4}}
+
+			clock := pingchecktest.NewClock(time.Unix(1_700_000_000, 0))
+			fakeProber := pingchecktest.NewProber()
+			scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type),
+				newFactoryOptions(WithProber(fakeProber), WithClock(clock)))
+			var lookups int
+			scraper.lookupIP = func(context.Context, string, string) ([]netip.Addr, error) {
+				lookups++
+				return []netip.Addr{netip.AddrFrom4([4]byte{192, 0, 2, byte(lookups)})}, nil
+			}
+			require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+			defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+			for range 4 {
+				_, err := scraper.scrapeTarget(context.Background(), 0)
+				require.NoError(t, err)
+				clock.Advance(time.Minute)
+			}
+			assert.Equal(t, tt.lookups, lookups)
+			if tt.lookups > 0 {
+				// The pinger follows the latest address
+				pingerCfg, _ := fakeProber.PingerConfig("probe.example")
+				require.NotNil(t, pingerCfg.IPAddr)
+				assert.Equal(t, byte(tt.lookups), pingerCfg.IPAddr.IP.To4()[3])
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+)
+
+// maxDSCP is the largest value of the 6 bit DSCP field
+const maxDSCP = 63
+
+// recordDSCP records the results of probing target with each of its DSCP
+// values into mb, so a class being dropped while others flow fine shows
+func (s *pingScraper) recordDSCP(mb *metadata.MetricsBuilder, metrics metadata.MetricsConfig, target Target, results []variantResult) {
+	for _, r := range results {
+		// Variants of the size sweep, underlay and address family have no DSCP
+		// value of their own, a DSCP variant may be 0
+		if !r.variant.marked {
+			continue
+		}
+		now := pcommon.NewTimestampFromTime(r.finished)
+		ip := s.ips.String(r.stats.IPAddr)
+		dscp := int64(r.variant.dscp)
+		if r.stats.PacketsRecv > 0 && metrics.PingDscpDuration.Enabled {
+			mb.RecordPingDscpDurationDataPoint(now, milliseconds(r.stats.AvgRtt), target.Endpoint, ip, ipVersion(r.stats.IPAddr), dscp)
+		}
+		if metrics.PingDscpPacketLoss.Enabled {
+			mb.RecordPingDscpPacketLossDataPoint(now, r.stats.PacketLoss/100.0, target.Endpoint, ip, ipVersion(r.stats.IPAddr), dscp)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// dscpProber drops the packets of the DSCP values in dropped, and records
+// the configs of the pingers marking packets
+type dscpProber struct {
+	dropped map[int]bool
+	mu      sync.Mutex
+	marked  []int
+	plain   int
+}
+
+func (p *dscpProber) NewPinger(cfg prober.PingerConfig) (prober.Pinger, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !cfg.MarkDSCP {
+		p.plain++
+		return &dscpPinger{cfg: cfg}, nil
+	}
+	p.marked = append(p.marked, cfg.DSCP)
+	return &dscpPinger{cfg: cfg, dropped: p.dropped[cfg.DSCP]}, nil
+}
+
+type dscpPinger struct {
+	cfg     prober.PingerConfig
+	dropped bool
+}
+
+func (p *dscpPinger) Run(context.Context) (*prober.Statistics, error) {
+	stats := &prober.Statistics{IPAddr: &net.IPAddr{IP: net.ParseIP(p.cfg.Endpoint)}, PacketsSent: 4}
+	if p.dropped {
+		stats.PacketLoss = 100
+		return stats, nil
+	}
+	stats.PacketsRecv = 4
+	stats.AvgRtt = 20500 * time.Microsecond
+	return stats, nil
+}
+
+func (p *dscpPinger) Stop() {}
+
+func TestScraperDSCP(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4, PacketSizes: []int{1400}, DSCPValues: []int{0, 46}}}
+
+	p := &dscpProber{dropped: map[int]bool{46: true}}
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(p)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	md, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+
+	durations := make(map[int64]float64)
+	losses := make(map[int64]float64)
+	sizes := 0
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		var values map[int64]float64
+		switch ms.At(i).Name() {
+		case "ping.dscp.duration":
+			values = durations
+		case "ping.dscp.packet_loss":
+			values = losses
+		case "ping.size_sweep.duration", "ping.size_sweep.packet_loss":
+			sizes += ms.At(i).Gauge().DataPoints().Len()
+			continue
+		default:
+			continue
+		}
+		dps := ms.At(i).Gauge().DataPoints()
+		for j := 0; j < dps.Len(); j++ {
+			dscp, _ := dps.At(j).Attributes().Get("dscp")
+			values[dscp.Int()] = dps.At(j).DoubleValue()
+		}
+	}
+	// Expedited forwarding is dropped while best effort flows fine
+	assert.Equal(t, map[int64]float64{0: 20.5}, durations)
+	assert.Equal(t, map[int64]float64{0: 0, 46: 1}, losses)
+	// Sizes are swept with the default DSCP and reported apart
+	assert.Equal(t, 2, sizes)
+	// Only the DSCP variants mark their packets, the regular probe and the
+	// sweep keep the default marking
+	assert.Equal(t, []int{0, 46}, p.marked)
+	assert.Equal(t, 2, p.plain)
+}
+
+func TestTargetVariants(t *testing.T) {
+	assert.Empty(t, targetVariants(Target{Endpoint: "192.0.2.1"}))
+	assert.Equal(t, []variantKey{{size: 64}, {size: 1400}, {dscp: 0, marked: true}, {dscp: 46, marked: true}},
+		targetVariants(Target{PacketSizes: []int{64, 1400}, DSCPValues: []int{0, 46}}))
+}
@@ -0,0 +1,19 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+)
+
+// recordDualStack records the results of probing target at its address of
+// the other family into mb. They are recorded like the regular probe and set
+// apart by net.peer.ip and net.ip.version.
+func (s *pingScraper) recordDualStack(mb *metadata.MetricsBuilder, metrics metadata.MetricsConfig, target Target, results []variantResult) {
+	for _, r := range results {
+		if r.variant.network != "" {
+			s.recordStats(mb, metrics, target, r.stats, r.finished)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// familyProber resolves every endpoint to the address of the requested family
+type familyProber struct {
+	mu       sync.Mutex
+	results  map[string]prober.Statistics
+	failures map[string]error
+	networks []string
+}
+
+func (p *familyProber) NewPinger(cfg prober.PingerConfig) (prober.Pinger, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.networks = append(p.networks, cfg.Network)
+	return familyPinger{p: p, network: cfg.Network}, nil
+}
+
+type familyPinger struct {
+	p       *familyProber
+	network string
+}
+
+func (f familyPinger) Run(context.Context) (*prober.Statistics, error) {
+	f.p.mu.Lock()
+	defer f.p.mu.Unlock()
+	if err := f.p.failures[f.network]; err != nil {
+		return nil, err
+	}
+	stats := f.p.results[f.network]
+	return &stats, nil
+}
+
+func (familyPinger) Stop() {}
+
+func TestScraperDualStack(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "dual.example.com", Count: 4, DualStack: true}}
+
+	fake := &familyProber{results: map[string]prober.Statistics{
+		prober.NetworkIPv4: {IPAddr: &net.IPAddr{IP: net.ParseIP("192.0.2.1")}, PacketsSent: 4, PacketsRecv: 4, AvgRtt: 10 * time.Millisecond},
+		prober.NetworkIPv6: {IPAddr: &net.IPAddr{IP: net.ParseIP("2001:db8::1")}, PacketsSent: 4, PacketsRecv: 2, PacketLoss: 50, AvgRtt: 30 * time.Millisecond},
+	}}
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fake)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	md, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{prober.NetworkIPv4, prober.NetworkIPv6}, fake.networks)
+
+	// Each family is a metric stream of its own
+	avg := make(map[int64]float64)
+	loss := make(map[int64]float64)
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		var values map[int64]float64
+		switch m.Name() {
+		case "ping.duration.avg":
+			values = avg
+		case "ping.packet_loss":
+			values = loss
+		default:
+			return
+		}
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			version, _ := dps.At(i).Attributes().Get("net.ip.version")
+			values[version.Int()] = dps.At(i).DoubleValue()
+		}
+	})
+	assert.Equal(t, map[int64]float64{4: 10, 6: 30}, avg)
+	assert.Equal(t, map[int64]float64{4: 0, 6: 0.5}, loss)
+}
+
+func TestScraperDualStackFamilyFails(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "dual.example.com", Count: 4, DualStack: true}}
+
+	fake := &familyProber{
+		results: map[string]prober.Statistics{
+			prober.NetworkIPv6: {IPAddr: &net.IPAddr{IP: net.ParseIP("2001:db8::1")}, PacketsSent: 4, PacketsRecv: 4, AvgRtt: 30 * time.Millisecond},
+		},
+		failures: map[string]error{prober.NetworkIPv4: errors.New("network is unreachable")},
+	}
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fake)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	// IPv6 is still probed and reported while IPv4 fails
+	md, err := scraper.scrapeTarget(context.Background(), 0)
+	require.Error(t, err)
+	versions := make(map[int64]bool)
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		if m.Name() == "ping.duration.avg" {
+			version, _ := m.Gauge().DataPoints().At(0).Attributes().Get("net.ip.version")
+			versions[version.Int()] = true
+		}
+	})
+	assert.Equal(t, map[int64]bool{6: true}, versions)
+}
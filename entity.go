@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/xpdata/entity"
+	"go.uber.org/multierr"
+)
+
+// defaultEntityType is the type of entities configured without one
+const defaultEntityType = "network.device"
+
+// EntityConfig identifies the device a target is, so backends adopting the
+// OpenTelemetry entities data model can link its telemetry to their inventory
+type EntityConfig struct {
+	// Type of the entity (default: network.device)
+	Type string `mapstructure:"type"`
+
+	// ID attributes identifying the entity, e.g. network.device.id: sw-01
+	ID map[string]string `mapstructure:"id"`
+
+	// Description attributes of the entity that may change over its
+	// lifetime, e.g. network.device.name: core-switch (default: none)
+	Description map[string]string `mapstructure:"description"`
+}
+
+// validate checks that e is identified, and that its attributes are not set
+// by resource_attributes as well, whose keys are given
+func (e *EntityConfig) validate(resourceKeys map[string]string) error {
+	var err error
+	if len(e.ID) == 0 {
+		err = multierr.Append(err, invalid("id", CodeRequired, "id must set at least one attribute"))
+	}
+	var idErr, descErr error
+	for _, key := range slices.Sorted(maps.Keys(e.ID)) {
+		idErr = multierr.Append(idErr, validateEntityKey(key, resourceKeys))
+	}
+	for _, key := range slices.Sorted(maps.Keys(e.Description)) {
+		if _, ok := e.ID[key]; ok {
+			descErr = multierr.Append(descErr, invalid(key, CodeConflict, "%s is an id attribute as well", key))
+			continue
+		}
+		descErr = multierr.Append(descErr, validateEntityKey(key, resourceKeys))
+	}
+	return multierr.Combine(err, within("id", idErr), within("description", descErr))
+}
+
+// validateEntityKey checks an attribute key of an entity
+func validateEntityKey(key string, resourceKeys map[string]string) error {
+	if key == "" {
+		return invalid("", CodeRequired, "key cannot be empty")
+	}
+	if _, ok := resourceKeys[key]; ok {
+		return invalid(key, CodeConflict, "%s is set by resource_attributes as well", key)
+	}
+	return nil
+}
+
+// attach sets the attributes of e on res, and references them as the
+// entity the resource describes
+func (e *EntityConfig) attach(res pcommon.Resource) {
+	ref := entity.ResourceEntityRefs(res).AppendEmpty()
+	ref.SetType(cmp.Or(e.Type, defaultEntityType))
+	for _, key := range slices.Sorted(maps.Keys(e.ID)) {
+		res.Attributes().PutStr(key, e.ID[key])
+		ref.IdKeys().Append(key)
+	}
+	for _, key := range slices.Sorted(maps.Keys(e.Description)) {
+		res.Attributes().PutStr(key, e.Description[key])
+		ref.DescriptionKeys().Append(key)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/xpdata/entity"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+)
+
+func TestTargetResourceEntity(t *testing.T) {
+	templates, err := parseResourceAttributes(map[string]string{"site": "edge-1"})
+	require.NoError(t, err)
+
+	res, err := targetResource(templates, Target{Endpoint: "192.0.2.1", Entity: &EntityConfig{
+		ID:          map[string]string{"network.device.id": "sw-01"},
+		Description: map[string]string{"network.device.name": "core-switch", "network.device.model": "x100"},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"site":                 "edge-1",
+		"network.device.id":    "sw-01",
+		"network.device.name":  "core-switch",
+		"network.device.model": "x100",
+	}, res.Attributes().AsRaw())
+
+	refs := entity.ResourceEntityRefs(res)
+	require.Equal(t, 1, refs.Len())
+	assert.Equal(t, defaultEntityType, refs.At(0).Type())
+	assert.Equal(t, []string{"network.device.id"}, refs.At(0).IdKeys().AsRaw())
+	assert.Equal(t, []string{"network.device.model", "network.device.name"}, refs.At(0).DescriptionKeys().AsRaw())
+
+	// Targets without an entity reference none
+	res, err = targetResource(templates, Target{Endpoint: "192.0.2.2"})
+	require.NoError(t, err)
+	assert.Equal(t, 0, entity.ResourceEntityRefs(res).Len())
+}
+
+func TestScraperEntity(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4, Entity: &EntityConfig{
+		Type: "host",
+		ID:   map[string]string{"host.id": "a1b2"},
+	}}}
+
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(pingchecktest.NewProber())))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	md, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	res := md.ResourceMetrics().At(0).Resource()
+	host, ok := res.Attributes().Get("host.id")
+	require.True(t, ok)
+	assert.Equal(t, "a1b2", host.Str())
+	refs := entity.ResourceEntityRefs(res)
+	require.Equal(t, 1, refs.Len())
+	assert.Equal(t, "host", refs.At(0).Type())
+}
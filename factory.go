@@ -6,25 +6,96 @@ package pingcheckreceiver
 import (
 	"context"
 	"errors"
+	"fmt"
+	"regexp"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/scraper"
 	"go.opentelemetry.io/collector/scraper/scraperhelper"
 
 	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/prober"
 )
 
 var errConfigNotPing = errors.New("config was not a Ping receiver config")
 
+// invalidTypeChars matches runs of characters not allowed in a component type
+var invalidTypeChars = regexp.MustCompile(`[^0-9a-zA-Z_]+`)
+
+// maxTypeLength is the longest component type the collector accepts
+const maxTypeLength = 63
+
+// FactoryOption customizes the receiver factory, e.g. to inject test doubles
+type FactoryOption func(*factoryOptions)
+
+type factoryOptions struct {
+	prober    prober.Prober
+	clock     prober.Clock
+	flows     FlowSource
+	registrar HealthRegistrar
+	scrapers  *sharedScrapers
+	claims    *probeClaims
+	budget    *socketBudget
+}
+
+// WithProber replaces the ICMP prober used to probe targets
+func WithProber(p prober.Prober) FactoryOption {
+	return func(o *factoryOptions) {
+		o.prober = p
+	}
+}
+
+// WithClock replaces the clock used to timestamp data points
+func WithClock(c prober.Clock) FactoryOption {
+	return func(o *factoryOptions) {
+		o.clock = c
+	}
+}
+
+// WithFlowSource sets the source of passively observed traffic asked about
+// failing targets, in place of a passive_check extension
+func WithFlowSource(f FlowSource) FactoryOption {
+	return func(o *factoryOptions) {
+		o.flows = f
+	}
+}
+
+// WithHealthRegistrar sets the registrar the health of the targets is
+// published to, in place of a health_registry extension
+func WithHealthRegistrar(r HealthRegistrar) FactoryOption {
+	return func(o *factoryOptions) {
+		o.registrar = r
+	}
+}
+
+func newFactoryOptions(opts ...FactoryOption) factoryOptions {
+	fo := factoryOptions{
+		prober:   prober.NewICMPProber(),
+		clock:    prober.SystemClock{},
+		scrapers: newSharedScrapers(),
+		claims:   newProbeClaims(),
+		budget:   processBudget,
+	}
+	for _, opt := range opts {
+		opt(&fo)
+	}
+	return fo
+}
+
 // NewFactory creates a new factory for Ping receiver
-func NewFactory() receiver.Factory {
+func NewFactory(opts ...FactoryOption) receiver.Factory {
+	fo := newFactoryOptions(opts...)
 	return receiver.NewFactory(
 		metadata.Type,
 		createDefaultConfig,
-		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability))
+		receiver.WithMetrics(fo.createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(fo.createLogsReceiver, metadata.LogsStability),
+		receiver.WithTraces(fo.createTracesReceiver, metadata.TracesStability))
 }
 
 func createDefaultConfig() component.Config {
@@ -32,14 +103,78 @@ func createDefaultConfig() component.Config {
 	cfg.CollectionInterval = 60 * time.Second
 
 	return &Config{
-		ControllerConfig:     cfg,
-		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
-		Targets:              []Target{},
-		Privileged:           false,
+		ControllerConfig:      cfg,
+		MetricsBuilderConfig:  metadata.DefaultMetricsBuilderConfig(),
+		Targets:               []Target{},
+		Privileged:            false,
+		TrimmedMeanPercent:    10,
+		AllowEmptyTargets:     false,
+		RecreateThreshold:     3,
+		ResolveMode:           resolveStartup,
+		ResolutionTTL:         5 * time.Minute,
+		MaxDatapointsPerBatch: 0,
+		MetricNames:           metricNamesBoth,
+		CounterTemporality:    temporalityCumulative,
+		Overflow: OverflowConfig{
+			MaxDatapoints: 0,
+			Policy:        overflowDropOldest,
+		},
+		DurationHistogram: DurationHistogramConfig{
+			Enabled:  false,
+			Type:     histogramExplicit,
+			Buckets:  []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, 2000},
+			MaxScale: maxExponentialScale,
+		},
+		Shedding: SheddingConfig{
+			SoftLimitPercentage: 80,
+			HardLimitPercentage: 95,
+		},
+		Heatmap: HeatmapConfig{
+			Buckets: []float64{1, 2, 5, 10, 25, 50, 100, 250, 500, 1000},
+		},
+		ReportOnChange: ReportOnChangeConfig{
+			Enabled:       false,
+			MinRTTChange:  5 * time.Millisecond,
+			MinLossChange: 0.05,
+			MaxStaleness:  5 * time.Minute,
+		},
+		Diagnostics: DiagnosticsConfig{
+			Enabled:          false,
+			FailureThreshold: 3,
+			Resolvers:        []string{"8.8.8.8:53", "1.1.1.1:53"},
+			TCPPorts:         []int{22, 80, 443},
+			Timeout:          10 * time.Second,
+		},
+		InterfaceCheck: InterfaceCheckConfig{
+			Enabled: false,
+			Action:  interfaceActionTag,
+		},
+		Holidays: HolidaysConfig{
+			RefreshInterval: time.Hour,
+			Action:          interfaceActionSuppress,
+		},
+		ConnectivityCheck: ConnectivityCheckConfig{
+			Enabled: false,
+			URL:     "http://connectivitycheck.gstatic.com/generate_204",
+			Timeout: 5 * time.Second,
+		},
+		LocalCheck: LocalCheckConfig{
+			Enabled: false,
+			Timeout: time.Second,
+		},
+		FirstResponder: FirstResponderConfig{
+			Enabled:      false,
+			DownFraction: 0.5,
+			Interval:     200 * time.Millisecond,
+			Duration:     10 * time.Minute,
+		},
+		PassiveCheck: PassiveCheckConfig{
+			Window: 5 * time.Minute,
+		},
 	}
 }
 
-func createMetricsReceiver(
+func (fo factoryOptions) createMetricsReceiver(
 	_ context.Context,
 	settings receiver.Settings,
 	cfg component.Config,
@@ -50,20 +185,148 @@ func createMetricsReceiver(
 		return nil, errConfigNotPing
 	}
 
-	pingScraperInstance := newScraper(pCfg, settings)
-	scraperInstance, err := scraper.NewMetrics(
-		pingScraperInstance.scrape,
-		scraper.WithStart(pingScraperInstance.start),
-		scraper.WithShutdown(pingScraperInstance.shutdown),
-	)
+	types, err := targetScraperTypes(pCfg.Targets)
 	if err != nil {
 		return nil, err
 	}
+	pingScraperInstance, release := fo.scrapers.acquire(pCfg, settings, fo, pipeline.SignalMetrics)
+
+	// One scraper per target attributes scrape errors and timeouts to the target
+	options := make([]scraperhelper.ControllerOption, 0, len(pCfg.Targets))
+	for i, typ := range types {
+		scraperInstance, err := scraper.NewMetrics(
+			func(ctx context.Context) (pmetric.Metrics, error) {
+				return pingScraperInstance.scrapeTarget(ctx, i)
+			},
+			scraper.WithStart(pingScraperInstance.start),
+			scraper.WithShutdown(release),
+		)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, scraperhelper.AddScraper(typ, scraperInstance))
+	}
+
+	if pCfg.ConnectivityCheck.Enabled {
+		connectivity := newConnectivityScraper(pCfg, settings, fo.clock)
+		scraperInstance, err := scraper.NewMetrics(connectivity.scrape)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, scraperhelper.AddScraper(connectivityScraperType, scraperInstance))
+	}
+
+	// Wrapped inside out, deprecated names are added first, then data points
+	// are capped, metrics are renamed and split into batches
+	if pCfg.MaxDatapointsPerBatch > 0 {
+		consumer, err = newBatchingConsumer(consumer, pCfg.MaxDatapointsPerBatch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if pCfg.MetricPrefix != "" {
+		consumer, err = newPrefixingConsumer(consumer, pCfg.MetricPrefix)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if pCfg.Overflow.MaxDatapoints > 0 {
+		consumer, err = newOverflowConsumer(consumer, pCfg.Overflow, settings)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(metricRenames) > 0 && pCfg.MetricNames != metricNamesNew {
+		consumer, err = newAliasingConsumer(consumer, pCfg.MetricNames, metricRenames)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return scraperhelper.NewMetricsController(
 		&pCfg.ControllerConfig,
 		settings,
 		consumer,
-		scraperhelper.AddScraper(metadata.Type, scraperInstance),
+		options...,
 	)
 }
+
+// createLogsReceiver reports failed probes as logs. It shares probes with the
+// metrics receiver of the same config.
+func (fo factoryOptions) createLogsReceiver(
+	_ context.Context,
+	settings receiver.Settings,
+	cfg component.Config,
+	consumer consumer.Logs,
+) (receiver.Logs, error) {
+	pCfg, ok := cfg.(*Config)
+	if !ok {
+		return nil, errConfigNotPing
+	}
+
+	pingScraperInstance, release := fo.scrapers.acquire(pCfg, settings, fo, pipeline.SignalLogs)
+	f := scraper.NewFactory(metadata.Type, nil,
+		scraper.WithLogs(func(context.Context, scraper.Settings, component.Config) (scraper.Logs, error) {
+			return scraper.NewLogs(
+				pingScraperInstance.scrapeLogs,
+				scraper.WithStart(pingScraperInstance.start),
+				scraper.WithShutdown(release),
+			)
+		}, metadata.LogsStability))
+
+	return scraperhelper.NewLogsController(
+		&pCfg.ControllerConfig,
+		settings,
+		consumer,
+		scraperhelper.AddFactoryWithConfig(f, nil),
+	)
+}
+
+// createTracesReceiver reports probes as spans. It shares probes with the
+// metrics and logs receivers of the same config.
+func (fo factoryOptions) createTracesReceiver(
+	_ context.Context,
+	settings receiver.Settings,
+	cfg component.Config,
+	consumer consumer.Traces,
+) (receiver.Traces, error) {
+	pCfg, ok := cfg.(*Config)
+	if !ok {
+		return nil, errConfigNotPing
+	}
+
+	pingScraperInstance, release := fo.scrapers.acquire(pCfg, settings, fo, pipeline.SignalTraces)
+	return &tracesReceiver{
+		cfg:      pCfg,
+		logger:   settings.Logger,
+		scraper:  pingScraperInstance,
+		release:  release,
+		consumer: consumer,
+	}, nil
+}
+
+// targetScraperTypes returns the component type identifying each target's scraper
+// in the collector's own telemetry, e.g. ping_8_8_8_8 for 8.8.8.8
+func targetScraperTypes(targets []Target) ([]component.Type, error) {
+	types := make([]component.Type, 0, len(targets))
+	seen := make(map[string]bool, len(targets))
+	for i, target := range targets {
+		name := metadata.Type.String() + "_" + invalidTypeChars.ReplaceAllString(target.Endpoint, "_")
+		name = name[:min(len(name), maxTypeLength)]
+		if seen[name] {
+			suffix := fmt.Sprintf("_%d", i)
+			name = name[:min(len(name), maxTypeLength-len(suffix))] + suffix
+		}
+		seen[name] = true
+
+		typ, err := component.NewType(name)
+		if err != nil {
+			return nil, fmt.Errorf("targets[%d]: %w", i, err)
+		}
+		types = append(types, typ)
+	}
+	return types, nil
+}
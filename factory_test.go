@@ -5,15 +5,19 @@ package pingcheckreceiver
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 
 	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
 )
 
 func TestNewFactory(t *testing.T) {
@@ -33,6 +37,7 @@ func TestCreateDefaultConfig(t *testing.T) {
 	assert.Equal(t, time.Second, pCfg.InitialDelay)
 	assert.False(t, pCfg.Privileged)
 	assert.Empty(t, pCfg.Targets)
+	assert.Equal(t, 3, pCfg.RecreateThreshold)
 }
 
 func TestCreateMetricsReceiver(t *testing.T) {
@@ -40,9 +45,9 @@ func TestCreateMetricsReceiver(t *testing.T) {
 	cfg := factory.CreateDefaultConfig().(*Config)
 	cfg.Targets = []Target{
 		{
-			Endpoint: "localhost",
-			Count:    1,
-			Timeout:  time.Second,
+			Endpoint:   "localhost",
+			Count:      1,
+			RunTimeout: time.Second,
 		},
 	}
 
@@ -86,3 +91,84 @@ func TestCreateMetricsReceiverWithEmptyTargets(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, receiver)
 }
+
+func TestCreateMetricsReceiverWithFakes(t *testing.T) {
+	fakeProber := pingchecktest.NewProber()
+	clock := pingchecktest.NewClock(time.Unix(1_700_000_000, 0))
+
+	factory := NewFactory(WithProber(fakeProber), WithClock(clock))
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1"}}
+
+	sink := new(consumertest.MetricsSink)
+	receiver, err := factory.CreateMetrics(
+		context.Background(),
+		receivertest.NewNopSettings(metadata.Type),
+		cfg,
+		sink,
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool {
+		return len(sink.AllMetrics()) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, receiver.Shutdown(context.Background()))
+
+	assert.Positive(t, fakeProber.Runs("192.0.2.1"))
+}
+
+func TestTargetScraperTypes(t *testing.T) {
+	types, err := targetScraperTypes([]Target{
+		{Endpoint: "8.8.8.8"},
+		{Endpoint: "2001:db8::1"},
+		{Endpoint: "example.com"},
+		{Endpoint: "example-com"},
+		{Endpoint: strings.Repeat("a", 100) + ".example.com"},
+	})
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(types))
+	for _, typ := range types {
+		names = append(names, typ.String())
+	}
+	assert.Equal(t, []string{
+		"ping_8_8_8_8",
+		"ping_2001_db8_1",
+		"ping_example_com",
+		"ping_example_com_3",
+		"ping_" + strings.Repeat("a", 58),
+	}, names)
+}
+
+func TestCreateMetricsReceiverTargetFailureIsPartial(t *testing.T) {
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetRunError("192.0.2.2", errors.New("network is unreachable"))
+
+	factory := NewFactory(WithProber(fakeProber))
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1"}, {Endpoint: "192.0.2.2"}}
+	cfg.Metrics.PingErrors.Enabled = true
+
+	sink := new(consumertest.MetricsSink)
+	receiver, err := factory.CreateMetrics(
+		context.Background(),
+		receivertest.NewNopSettings(metadata.Type),
+		cfg,
+		sink,
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool {
+		return len(sink.AllMetrics()) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, receiver.Shutdown(context.Background()))
+
+	// The failing target keeps its error metric next to the healthy target's metrics
+	md := sink.AllMetrics()[0]
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+	failed := md.ResourceMetrics().At(1).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, failed.Len())
+	assert.Equal(t, "ping.errors", failed.At(0).Name())
+}
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// injectedError is returned in place of a real probe error when fault injection is configured
+type injectedError struct {
+	errorType metadata.AttributeErrorType
+}
+
+func (e *injectedError) Error() string {
+	return fmt.Sprintf("injected fault: %s", e.errorType)
+}
+
+// injectedErr returns the configured synthetic error, or nil if none is configured
+func (f *FaultInjectionConfig) injectedErr() error {
+	if f == nil || f.Error == "" {
+		return nil
+	}
+	return &injectedError{errorType: metadata.MapAttributeErrorType[f.Error]}
+}
+
+// apply degrades the statistics of a successful run according to the configured faults
+func (f *FaultInjectionConfig) apply(stats *prober.Statistics) {
+	if f == nil {
+		return
+	}
+
+	if f.Loss > 0 && stats.PacketsSent > 0 {
+		// Drop a deterministic share of the replies so alert thresholds can be exercised exactly
+		lost := int(math.Ceil(float64(stats.PacketsSent) * f.Loss))
+		stats.PacketsRecv = max(min(stats.PacketsRecv, stats.PacketsSent-lost), 0)
+		stats.PacketLoss = float64(stats.PacketsSent-stats.PacketsRecv) / float64(stats.PacketsSent) * 100
+		if len(stats.Rtts) > stats.PacketsRecv {
+			stats.Rtts = stats.Rtts[:stats.PacketsRecv]
+		}
+		if stats.PacketsRecv == 0 {
+			stats.MinRtt, stats.MaxRtt, stats.AvgRtt, stats.MedianRtt, stats.StdDevRtt = 0, 0, 0, 0, 0
+			stats.Rtts = nil
+		}
+	}
+
+	if f.Latency > 0 && stats.PacketsRecv > 0 {
+		stats.MinRtt += f.Latency
+		stats.MaxRtt += f.Latency
+		stats.AvgRtt += f.Latency
+		if stats.MedianRtt > 0 {
+			stats.MedianRtt += f.Latency
+		}
+		for i := range stats.Rtts {
+			stats.Rtts[i] += f.Latency
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+func TestFaultInjectionApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		faults   *FaultInjectionConfig
+		stats    prober.Statistics
+		expected prober.Statistics
+	}{
+		{
+			name:     "nil config leaves stats untouched",
+			faults:   nil,
+			stats:    prober.Statistics{PacketsSent: 4, PacketsRecv: 4, MinRtt: time.Millisecond},
+			expected: prober.Statistics{PacketsSent: 4, PacketsRecv: 4, MinRtt: time.Millisecond},
+		},
+		{
+			name:   "partial loss",
+			faults: &FaultInjectionConfig{Loss: 0.5},
+			stats:  prober.Statistics{PacketsSent: 4, PacketsRecv: 4, AvgRtt: time.Millisecond},
+			expected: prober.Statistics{
+				PacketsSent: 4, PacketsRecv: 2, PacketLoss: 50, AvgRtt: time.Millisecond,
+			},
+		},
+		{
+			name:   "partial loss drops samples",
+			faults: &FaultInjectionConfig{Loss: 0.5},
+			stats: prober.Statistics{
+				PacketsSent: 2, PacketsRecv: 2, AvgRtt: time.Millisecond,
+				Rtts: []time.Duration{time.Millisecond, time.Millisecond},
+			},
+			expected: prober.Statistics{
+				PacketsSent: 2, PacketsRecv: 1, PacketLoss: 50, AvgRtt: time.Millisecond,
+				Rtts: []time.Duration{time.Millisecond},
+			},
+		},
+		{
+			name:   "total loss clears rtts",
+			faults: &FaultInjectionConfig{Loss: 1, Latency: time.Second},
+			stats: prober.Statistics{
+				PacketsSent: 3, PacketsRecv: 3, MinRtt: 1, MaxRtt: 3, AvgRtt: 2, StdDevRtt: 1,
+			},
+			expected: prober.Statistics{PacketsSent: 3, PacketsRecv: 0, PacketLoss: 100},
+		},
+		{
+			name:   "latency",
+			faults: &FaultInjectionConfig{Latency: 100 * time.Millisecond},
+			stats: prober.Statistics{
+				PacketsSent: 2, PacketsRecv: 2,
+				MinRtt: time.Millisecond, MaxRtt: 3 * time.Millisecond, AvgRtt: 2 * time.Millisecond, StdDevRtt: time.Millisecond,
+			},
+			expected: prober.Statistics{
+				PacketsSent: 2, PacketsRecv: 2,
+				MinRtt: 101 * time.Millisecond, MaxRtt: 103 * time.Millisecond, AvgRtt: 102 * time.Millisecond, StdDevRtt: time.Millisecond,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := tt.stats
+			tt.faults.apply(&stats)
+			assert.Equal(t, tt.expected, stats)
+		})
+	}
+}
+
+func TestFaultInjectionError(t *testing.T) {
+	var faults *FaultInjectionConfig
+	assert.NoError(t, faults.injectedErr())
+
+	faults = &FaultInjectionConfig{Loss: 0.5}
+	assert.NoError(t, faults.injectedErr())
+
+	faults = &FaultInjectionConfig{Error: "dns_failure"}
+	err := faults.injectedErr()
+	require.Error(t, err)
+	assert.Equal(t, "injected fault: dns_failure", err.Error())
+	assert.Equal(t, metadata.AttributeErrorTypeDNSFailure, categorizeError(err))
+}
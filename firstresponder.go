@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// incidentMode tracks fleet-wide incidents of first_responder. An incident
+// starts when the share of targets down reaches down_fraction and lasts for
+// duration, during which targets are probed at a tighter interval.
+type incidentMode struct {
+	cfg   FirstResponderConfig
+	clock prober.Clock
+
+	mu    sync.Mutex
+	until time.Time
+	// armed is cleared by an incident and set again once the share of targets
+	// down drops below down_fraction, so a lasting outage elevates probing once
+	armed bool
+
+	// Endpoints whose pingers were created for elevated probing
+	elevated map[string]bool
+}
+
+func newIncidentMode(cfg FirstResponderConfig, clock prober.Clock) *incidentMode {
+	return &incidentMode{cfg: cfg, clock: clock, armed: true, elevated: make(map[string]bool)}
+}
+
+// observe records that down of total targets are down and reports whether
+// that starts an incident
+func (m *incidentMode) observe(down, total int) bool {
+	if total == 0 {
+		return false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if float64(down)/float64(total) < m.cfg.DownFraction {
+		m.armed = true
+		return false
+	}
+	if !m.armed {
+		return false
+	}
+	m.armed = false
+	m.until = m.clock.Now().Add(m.cfg.Duration)
+	return true
+}
+
+// active reports whether an incident is ongoing
+func (m *incidentMode) active() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.clock.Now().Before(m.until)
+}
+
+// switched reports whether the pingers of endpoint were created for another
+// mode than the current one, and records that they are about to be recreated
+func (m *incidentMode) switched(endpoint string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	active := m.clock.Now().Before(m.until)
+	if m.elevated[endpoint] == active {
+		return false
+	}
+	m.elevated[endpoint] = active
+	return true
+}
+
+// expire ends an incident whose duration is over and reports whether it did
+func (m *incidentMode) expire() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.until.IsZero() || m.clock.Now().Before(m.until) {
+		return false
+	}
+	m.until = time.Time{}
+	return true
+}
+
+// elevate returns target as probed during an incident, at the first_responder
+// interval with as many more packets as fit in the time its burst took before
+func (m *incidentMode) elevate(target Target) Target {
+	if target.Interval <= m.cfg.Interval {
+		return target
+	}
+	factor := int((target.Interval + m.cfg.Interval - 1) / m.cfg.Interval)
+	target.Count *= factor
+	target.Interval = m.cfg.Interval
+	return target
+}
+
+// observeFleetLocked checks whether the targets down start an incident, s.mu must
+// be held
+func (s *pingScraper) observeFleetLocked() {
+	if s.incident == nil {
+		return
+	}
+	endpoints := make(map[string]bool, len(s.cfg.Targets))
+	for _, target := range s.cfg.Targets {
+		endpoints[target.Endpoint] = true
+	}
+	down := 0
+	for endpoint := range endpoints {
+		if state, ok := s.states[endpoint]; ok && state.consecutiveFailures > 0 {
+			down++
+		}
+	}
+	if !s.incident.observe(down, len(endpoints)) {
+		return
+	}
+	s.logger.Warn("Incident detected, elevating probing",
+		zap.Int("down", down),
+		zap.Int("targets", len(endpoints)),
+		zap.Duration("interval", s.cfg.FirstResponder.Interval),
+		zap.Duration("duration", s.cfg.FirstResponder.Duration))
+}
+
+// checkIncident recreates the pingers of target when an incident started or
+// ended since they were created. Pingers are switched by the target's own
+// probe, stopping them for all at once would cut short probes still running.
+func (s *pingScraper) checkIncident(target Target) {
+	if s.incident == nil {
+		return
+	}
+	if s.incident.expire() {
+		s.logger.Info("Incident period over, probing returns to normal")
+	}
+	if !s.incident.switched(target.Endpoint) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dropPingersLocked(target.Endpoint)
+}
+
+// logIncidentProbe logs the details of a probe that failed or lost packets
+// during an incident
+func (s *pingScraper) logIncidentProbe(target Target, stats *prober.Statistics, err error) {
+	fields := []zap.Field{zap.String("endpoint", target.Endpoint)}
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+	if stats != nil {
+		fields = append(fields,
+			zap.Int("packets_sent", stats.PacketsSent),
+			zap.Int("packets_received", stats.PacketsRecv),
+			zap.Float64("packet_loss", stats.PacketLoss/100),
+			zap.Duration("min_rtt", stats.MinRtt),
+			zap.Duration("avg_rtt", stats.AvgRtt),
+			zap.Duration("max_rtt", stats.MaxRtt),
+			zap.Durations("rtts", stats.Rtts))
+	}
+	s.logger.Info("Probe failed during incident", fields...)
+}
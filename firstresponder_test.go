@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+)
+
+func TestIncidentMode(t *testing.T) {
+	clock := pingchecktest.NewClock(time.Unix(1_700_000_000, 0))
+	m := newIncidentMode(FirstResponderConfig{DownFraction: 0.5, Interval: 200 * time.Millisecond, Duration: time.Minute}, clock)
+
+	assert.False(t, m.observe(1, 4))
+	assert.True(t, m.observe(2, 4))
+	assert.True(t, m.active())
+
+	// A lasting outage starts a single incident
+	assert.False(t, m.observe(3, 4))
+	clock.Advance(time.Minute)
+	assert.False(t, m.active())
+	assert.True(t, m.expire())
+	assert.False(t, m.expire())
+	assert.False(t, m.observe(3, 4))
+
+	// Another one once it recovered
+	assert.False(t, m.observe(0, 4))
+	assert.True(t, m.observe(4, 4))
+	assert.False(t, m.observe(0, 0))
+}
+
+func TestIncidentModeElevate(t *testing.T) {
+	m := newIncidentMode(FirstResponderConfig{Interval: 300 * time.Millisecond}, pingchecktest.NewClock(time.Now()))
+
+	elevated := m.elevate(Target{Count: 4, Interval: time.Second})
+	assert.Equal(t, 16, elevated.Count)
+	assert.Equal(t, 300*time.Millisecond, elevated.Interval)
+
+	// Already probed tighter
+	elevated = m.elevate(Target{Count: 4, Interval: 100 * time.Millisecond})
+	assert.Equal(t, 4, elevated.Count)
+	assert.Equal(t, 100*time.Millisecond, elevated.Interval)
+
+	// Continuous runs stay continuous
+	elevated = m.elevate(Target{Count: 0, Interval: time.Second})
+	assert.Equal(t, 0, elevated.Count)
+}
+
+func TestScraperFirstResponder(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.FirstResponder.Enabled = true
+	cfg.Targets = []Target{
+		{Endpoint: "192.0.2.1", Count: 4},
+		{Endpoint: "192.0.2.2", Count: 4},
+		{Endpoint: "192.0.2.3", Count: 4},
+	}
+
+	clock := pingchecktest.NewClock(time.Unix(1_700_000_000, 0))
+	fakeProber := pingchecktest.NewProber()
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber), WithClock(clock)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	scrape := func() {
+		for i := range cfg.Targets {
+			_, _ = scraper.scrapeTarget(context.Background(), i)
+		}
+		clock.Advance(time.Minute)
+	}
+	interval := func() time.Duration {
+		pingerCfg, ok := fakeProber.PingerConfig("192.0.2.3")
+		require.True(t, ok)
+		return pingerCfg.Interval
+	}
+
+	// One of three down is no incident
+	fakeProber.SetRunError("192.0.2.1", errors.New("i/o timeout"))
+	scrape()
+	scrape()
+	assert.Equal(t, time.Second, interval())
+
+	fakeProber.SetRunError("192.0.2.2", errors.New("i/o timeout"))
+	scrape()
+	scrape()
+	assert.Equal(t, 200*time.Millisecond, interval())
+	pingerCfg, _ := fakeProber.PingerConfig("192.0.2.3")
+	assert.Equal(t, 20, pingerCfg.Count)
+
+	// Bounded even though the outage lasts
+	for range 10 {
+		scrape()
+	}
+	assert.Equal(t, time.Second, interval())
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"fmt"
+	"net/netip"
+
+	"go.uber.org/multierr"
+)
+
+// destinationGuard restricts the addresses that may be probed
+type destinationGuard struct {
+	allowed []netip.Prefix
+	denied  []netip.Prefix
+}
+
+// parseDestinationGuard parses allowed_cidrs and denied_cidrs
+func parseDestinationGuard(allowed, denied []string) (destinationGuard, error) {
+	var g destinationGuard
+	var err error
+	parse := func(option string, cidrs []string) []netip.Prefix {
+		prefixes := make([]netip.Prefix, 0, len(cidrs))
+		for i, cidr := range cidrs {
+			prefix, parseErr := netip.ParsePrefix(cidr)
+			if parseErr != nil {
+				err = multierr.Append(err, within(fmt.Sprintf("%s[%d]", option, i), asInvalid("", CodeInvalidFormat, parseErr)))
+				continue
+			}
+			prefixes = append(prefixes, prefix.Masked())
+		}
+		return prefixes
+	}
+	g.allowed = parse("allowed_cidrs", allowed)
+	g.denied = parse("denied_cidrs", denied)
+	return g, err
+}
+
+// check returns an error if addr may not be probed. Denied ranges take
+// precedence, and without allowed ranges everything else is allowed.
+func (g destinationGuard) check(addr netip.Addr) error {
+	addr = addr.Unmap().WithZone("")
+	for _, prefix := range g.denied {
+		if prefix.Contains(addr) {
+			return fmt.Errorf("destination %s is within denied_cidrs %s", addr, prefix)
+		}
+	}
+	if len(g.allowed) == 0 {
+		return nil
+	}
+	for _, prefix := range g.allowed {
+		if prefix.Contains(addr) {
+			return nil
+		}
+	}
+	return fmt.Errorf("destination %s is not within allowed_cidrs", addr)
+}
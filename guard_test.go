@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+)
+
+func TestDestinationGuard(t *testing.T) {
+	guard, err := parseDestinationGuard([]string{"192.0.2.0/24", "2001:db8::/32"}, []string{"192.0.2.128/25"})
+	require.NoError(t, err)
+
+	assert.NoError(t, guard.check(netip.MustParseAddr("192.0.2.1")))
+	assert.NoError(t, guard.check(netip.MustParseAddr("2001:db8::1")))
+	// IPv4-mapped addresses are checked as IPv4
+	assert.NoError(t, guard.check(netip.MustParseAddr("::ffff:192.0.2.1")))
+	assert.EqualError(t, guard.check(netip.MustParseAddr("192.0.2.200")), "destination 192.0.2.200 is within denied_cidrs 192.0.2.128/25")
+	assert.EqualError(t, guard.check(netip.MustParseAddr("198.51.100.1")), "destination 198.51.100.1 is not within allowed_cidrs")
+
+	// Without allowed ranges only denied ones are refused
+	guard, err = parseDestinationGuard(nil, []string{"10.0.0.0/8"})
+	require.NoError(t, err)
+	assert.NoError(t, guard.check(netip.MustParseAddr("198.51.100.1")))
+	assert.Error(t, guard.check(netip.MustParseAddr("10.1.2.3")))
+
+	_, err = parseDestinationGuard([]string{"192.0.2.0"}, []string{"10.0.0.0/33"})
+	assert.EqualError(t, err, `allowed_cidrs[0]: netip.ParsePrefix("192.0.2.0"): no '/'; denied_cidrs[0]: netip.ParsePrefix("10.0.0.0/33"): prefix length out of range`)
+}
+
+func TestScraperRefusesDeniedDestinations(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{
+		{Endpoint: "192.0.2.1", Count: 4},
+		{Endpoint: "10.1.2.3", Count: 4},
+	}
+	cfg.DeniedCIDRs = []string{"10.0.0.0/8"}
+
+	fakeProber := pingchecktest.NewProber()
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	_, err := scraper.scrapeTarget(context.Background(), 0)
+	assert.NoError(t, err)
+	_, err = scraper.scrapeTarget(context.Background(), 1)
+	assert.ErrorContains(t, err, "refusing to probe 10.1.2.3: destination 10.1.2.3 is within denied_cidrs 10.0.0.0/8")
+	assert.Zero(t, fakeProber.Runs("10.1.2.3"))
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// TargetHealth tells whether targets are reachable according to their latest probes
+type TargetHealth interface {
+	// Reachable reports whether the latest probe of endpoint received a reply,
+	// known is false if endpoint was not probed yet or is not a target
+	Reachable(endpoint string) (reachable, known bool)
+}
+
+// HealthRegistrar is implemented by extensions that collect the health of
+// the targets of receivers, so other components, e.g. custom processors, can
+// look the extension up and combine it into health logic of their own
+type HealthRegistrar interface {
+	// RegisterHealth makes the targets of receiver id available, replacing
+	// those registered for id before
+	RegisterHealth(id component.ID, health TargetHealth)
+
+	// UnregisterHealth removes the targets of receiver id
+	UnregisterHealth(id component.ID)
+}
+
+// HealthRegistry is a HealthRegistrar answering for the targets of every
+// receiver registered with it, for extensions to embed. The zero value is
+// ready to use.
+type HealthRegistry struct {
+	mu      sync.RWMutex
+	sources map[component.ID]TargetHealth
+}
+
+var _ HealthRegistrar = (*HealthRegistry)(nil)
+
+// RegisterHealth implements HealthRegistrar
+func (r *HealthRegistry) RegisterHealth(id component.ID, health TargetHealth) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.sources == nil {
+		r.sources = make(map[component.ID]TargetHealth)
+	}
+	r.sources[id] = health
+}
+
+// UnregisterHealth implements HealthRegistrar
+func (r *HealthRegistry) UnregisterHealth(id component.ID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.sources, id)
+}
+
+// Reachable reports whether any registered receiver found endpoint
+// reachable, known is false if none of them probed it yet
+func (r *HealthRegistry) Reachable(endpoint string) (reachable, known bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, health := range r.sources {
+		ok, probed := health.Reachable(endpoint)
+		if ok {
+			return true, true
+		}
+		known = known || probed
+	}
+	return false, known
+}
+
+// Reachable implements TargetHealth with the state tracked across scrapes
+func (s *pingScraper) Reachable(endpoint string) (reachable, known bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	state, ok := s.states[endpoint]
+	if !ok || !state.observed {
+		return false, false
+	}
+	return !state.down, true
+}
+
+// healthRegistrar returns the registrar the targets are registered with, the
+// health_registry extension if configured, or nil if there is none
+func (s *pingScraper) healthRegistrar(host component.Host) (HealthRegistrar, error) {
+	id := s.cfg.HealthRegistry
+	if id == nil {
+		return s.registrar, nil
+	}
+	ext, ok := host.GetExtensions()[*id]
+	if !ok {
+		return nil, fmt.Errorf("health_registry: extension %s not found", id)
+	}
+	registrar, ok := ext.(HealthRegistrar)
+	if !ok {
+		return nil, fmt.Errorf("health_registry: extension %s is not a health registrar", id)
+	}
+	return registrar, nil
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// registryExtension is an extension embedding a HealthRegistry
+type registryExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	HealthRegistry
+}
+
+// fixedHealth is a TargetHealth with fixed answers
+type fixedHealth map[string]bool
+
+func (h fixedHealth) Reachable(endpoint string) (bool, bool) {
+	reachable, known := h[endpoint]
+	return reachable, known
+}
+
+func TestHealthRegistry(t *testing.T) {
+	var r HealthRegistry
+	_, known := r.Reachable("192.0.2.1")
+	assert.False(t, known)
+
+	a := component.MustNewIDWithName("ping", "a")
+	b := component.MustNewIDWithName("ping", "b")
+	r.RegisterHealth(a, fixedHealth{"192.0.2.1": false, "192.0.2.2": true})
+	r.RegisterHealth(b, fixedHealth{"192.0.2.1": true})
+
+	// A target is reachable if any receiver reached it
+	reachable, known := r.Reachable("192.0.2.1")
+	assert.True(t, reachable)
+	assert.True(t, known)
+
+	r.UnregisterHealth(b)
+	reachable, known = r.Reachable("192.0.2.1")
+	assert.False(t, reachable)
+	assert.True(t, known)
+
+	_, known = r.Reachable("192.0.2.3")
+	assert.False(t, known)
+}
+
+func TestScraperHealthRegistry(t *testing.T) {
+	id := component.MustNewID("healthregistry")
+	cfg := createDefaultConfig().(*Config)
+	cfg.HealthRegistry = &id
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4}, {Endpoint: "192.0.2.2", Count: 4}}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetRunError("192.0.2.2", errors.New("i/o timeout"))
+	settings := receivertest.NewNopSettings(metadata.Type)
+	ext := &registryExtension{}
+	host := extensionHost{extensions: map[component.ID]component.Component{id: ext}}
+	scraper := newScraper(cfg, settings, newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), host))
+
+	// Nothing is known before the first scrape
+	_, known := ext.Reachable("192.0.2.1")
+	assert.False(t, known)
+
+	for i := range cfg.Targets {
+		_, _ = scraper.scrapeTarget(context.Background(), i)
+	}
+	reachable, known := ext.Reachable("192.0.2.1")
+	assert.True(t, reachable)
+	assert.True(t, known)
+	reachable, known = ext.Reachable("192.0.2.2")
+	assert.False(t, reachable)
+	assert.True(t, known)
+
+	fakeProber.SetResult("192.0.2.2", prober.Statistics{PacketsSent: 4, PacketsRecv: 4})
+	for i := range cfg.Targets {
+		_, _ = scraper.scrapeTarget(context.Background(), i)
+	}
+	reachable, _ = ext.Reachable("192.0.2.2")
+	assert.True(t, reachable)
+
+	// Shut down receivers no longer answer
+	require.NoError(t, scraper.shutdown(context.Background()))
+	_, known = ext.Reachable("192.0.2.1")
+	assert.False(t, known)
+}
+
+func TestScraperHealthRegistryExtension(t *testing.T) {
+	id := component.MustNewID("healthregistry")
+	cfg := createDefaultConfig().(*Config)
+	cfg.HealthRegistry = &id
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4}}
+
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(pingchecktest.NewProber())))
+	err := scraper.start(context.Background(), componenttest.NewNopHost())
+	assert.EqualError(t, err, "health_registry: extension healthregistry not found")
+
+	scraper = newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(pingchecktest.NewProber())))
+	host := extensionHost{extensions: map[component.ID]component.Component{id: &fakeFlows{}}}
+	err = scraper.start(context.Background(), host)
+	assert.EqualError(t, err, "health_registry: extension healthregistry is not a health registrar")
+}
+
+func TestWithHealthRegistrar(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4}}
+
+	var registry HealthRegistry
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type),
+		newFactoryOptions(WithProber(pingchecktest.NewProber()), WithHealthRegistrar(&registry)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	_, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	reachable, known := registry.Reachable("192.0.2.1")
+	assert.True(t, reachable)
+	assert.True(t, known)
+}
@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+)
+
+// heatmapOverflow labels the bucket above the last upper bound
+const heatmapOverflow = "+Inf"
+
+// recordHeatmap counts the round-trip times of the probe of target into the
+// heatmap buckets. Every bucket is reported, empty ones too, so the series a
+// heatmap panel reads stay the same from one scrape to the next.
+func (s *pingScraper) recordHeatmap(mb *metadata.MetricsBuilder, metrics metadata.MetricsConfig, target Target, o *probeOutcome) {
+	if !metrics.PingDurationHeatmap.Enabled || o.stats == nil || o.stats.PacketsRecv == 0 {
+		return
+	}
+	counts := heatmapCounts(s.cfg.Heatmap.Buckets, o.stats.Rtts)
+	now := pcommon.NewTimestampFromTime(o.finished)
+	for i, count := range counts {
+		mb.RecordPingDurationHeatmapDataPoint(now, count, target.Endpoint, heatmapBucket(s.cfg.Heatmap.Buckets, i))
+	}
+}
+
+// heatmapCounts counts rtts into the buckets of upper bounds in milliseconds,
+// with one more for those above the last
+func heatmapCounts(bounds []float64, rtts []time.Duration) []int64 {
+	counts := make([]int64, len(bounds)+1)
+	for _, rtt := range rtts {
+		// Buckets include their upper bound
+		counts[sort.SearchFloat64s(bounds, milliseconds(rtt))]++
+	}
+	return counts
+}
+
+// heatmapBucket returns the label of the i-th bucket of bounds
+func heatmapBucket(bounds []float64, i int) string {
+	if i == len(bounds) {
+		return heatmapOverflow
+	}
+	return strconv.FormatFloat(bounds[i], 'f', -1, 64)
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// heatmapCountsOf returns the ping.duration.heatmap counts of md by bucket
+func heatmapCountsOf(md pmetric.Metrics) map[string]int64 {
+	counts := map[string]int64{}
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		if m.Name() != "ping.duration.heatmap" {
+			return
+		}
+		for i := 0; i < m.Gauge().DataPoints().Len(); i++ {
+			dp := m.Gauge().DataPoints().At(i)
+			bucket, _ := dp.Attributes().Get("duration.bucket")
+			counts[bucket.Str()] = dp.IntValue()
+		}
+	})
+	return counts
+}
+
+func TestHeatmapCounts(t *testing.T) {
+	bounds := []float64{1, 2.5, 10}
+	rtts := []time.Duration{500 * time.Microsecond, time.Millisecond, 2 * time.Millisecond, 11 * time.Millisecond}
+	assert.Equal(t, []int64{2, 1, 0, 1}, heatmapCounts(bounds, rtts))
+	assert.Equal(t, "2.5", heatmapBucket(bounds, 1))
+	assert.Equal(t, "+Inf", heatmapBucket(bounds, 3))
+}
+
+func TestScraperHeatmap(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics.PingDurationHeatmap.Enabled = true
+	cfg.Heatmap.Buckets = []float64{5, 50}
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 3}, {Endpoint: "192.0.2.2", Count: 3}}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{
+		PacketsSent: 3,
+		PacketsRecv: 3,
+		Rtts:        []time.Duration{2 * time.Millisecond, 3 * time.Millisecond, 80 * time.Millisecond},
+	})
+	fakeProber.SetResult("192.0.2.2", prober.Statistics{PacketsSent: 3, PacketLoss: 100})
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	pingerCfg, _ := fakeProber.PingerConfig("192.0.2.1")
+	assert.True(t, pingerCfg.RecordRtts)
+
+	// Empty buckets are reported too
+	md, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"5": 2, "50": 0, "+Inf": 1}, heatmapCountsOf(md))
+
+	// Probes without replies have nothing to count
+	md, err = scraper.scrapeTarget(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Empty(t, heatmapCountsOf(md))
+}
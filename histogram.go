@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+)
+
+// durationHistogramName is the name of the metric of duration_histogram.
+// The metrics builder has no histograms, so it is built here.
+const durationHistogramName = "ping.duration.histogram"
+
+// Types of duration_histogram
+const (
+	histogramExplicit    = "explicit"
+	histogramExponential = "exponential"
+)
+
+// Bounds of the scale of exponential histograms, and the number of buckets
+// the scale is lowered to fit the round-trip times of a probe into
+const (
+	minExponentialScale   = -10
+	maxExponentialScale   = 20
+	maxExponentialBuckets = 160
+)
+
+// appendDurationHistogram adds the round-trip times of the probe of the i-th
+// target to md as a ping.duration.histogram data point
+func (s *pingScraper) appendDurationHistogram(md pmetric.Metrics, i int, o *probeOutcome) {
+	if !s.cfg.DurationHistogram.Enabled || o == nil || o.stats == nil || len(o.stats.Rtts) == 0 {
+		return
+	}
+	target := s.cfg.Targets[i]
+
+	m := s.targetScopeMetrics(md, i).Metrics().AppendEmpty()
+	m.SetName(durationHistogramName)
+	m.SetDescription("Distribution of the round-trip times of individual packets")
+	m.SetUnit("ms")
+
+	var attrs pcommon.Map
+	if s.cfg.DurationHistogram.Type == histogramExponential {
+		m.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		dp := m.ExponentialHistogram().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(s.startTime)
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(o.finished))
+		fillExponentialHistogram(dp, s.cfg.DurationHistogram.MaxScale, o.stats.Rtts)
+		attrs = dp.Attributes()
+	} else {
+		m.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		dp := m.Histogram().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(s.startTime)
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(o.finished))
+		fillHistogram(dp, s.cfg.DurationHistogram.Buckets, o.stats.Rtts)
+		attrs = dp.Attributes()
+	}
+	attrs.PutStr("net.peer.name", target.Endpoint)
+	attrs.PutStr("net.peer.ip", s.ips.String(o.stats.IPAddr))
+	attrs.PutInt("net.ip.version", ipVersion(o.stats.IPAddr))
+}
+
+// fillHistogram counts rtts into dp's buckets of upper bounds in milliseconds
+func fillHistogram(dp pmetric.HistogramDataPoint, bounds []float64, rtts []time.Duration) {
+	counts := make([]uint64, len(bounds)+1)
+	var sum float64
+	minMs, maxMs := milliseconds(rtts[0]), milliseconds(rtts[0])
+	for _, rtt := range rtts {
+		ms := milliseconds(rtt)
+		// Buckets include their upper bound
+		counts[sort.SearchFloat64s(bounds, ms)]++
+		sum += ms
+		minMs, maxMs = min(minMs, ms), max(maxMs, ms)
+	}
+	dp.ExplicitBounds().FromRaw(bounds)
+	dp.BucketCounts().FromRaw(counts)
+	dp.SetCount(uint64(len(rtts)))
+	dp.SetSum(sum)
+	dp.SetMin(minMs)
+	dp.SetMax(maxMs)
+}
+
+// fillExponentialHistogram counts rtts into dp's buckets at the highest scale
+// up to maxScale that fits them into maxExponentialBuckets
+func fillExponentialHistogram(dp pmetric.ExponentialHistogramDataPoint, maxScale int32, rtts []time.Duration) {
+	var sum float64
+	var positive []float64
+	minMs, maxMs := milliseconds(rtts[0]), milliseconds(rtts[0])
+	for _, rtt := range rtts {
+		ms := milliseconds(rtt)
+		sum += ms
+		minMs, maxMs = min(minMs, ms), max(maxMs, ms)
+		if ms > 0 {
+			positive = append(positive, ms)
+		}
+	}
+	dp.SetCount(uint64(len(rtts)))
+	dp.SetZeroCount(uint64(len(rtts) - len(positive)))
+	dp.SetSum(sum)
+	dp.SetMin(minMs)
+	dp.SetMax(maxMs)
+	dp.SetScale(maxScale)
+	if len(positive) == 0 {
+		return
+	}
+
+	lowest, highest := slices.Min(positive), slices.Max(positive)
+	scale := maxScale
+	for scale > minExponentialScale &&
+		exponentialIndex(highest, scale)-exponentialIndex(lowest, scale) >= maxExponentialBuckets {
+		scale--
+	}
+	offset := exponentialIndex(lowest, scale)
+	counts := make([]uint64, exponentialIndex(highest, scale)-offset+1)
+	for _, ms := range positive {
+		counts[exponentialIndex(ms, scale)-offset]++
+	}
+	dp.SetScale(scale)
+	dp.Positive().SetOffset(offset)
+	dp.Positive().BucketCounts().FromRaw(counts)
+}
+
+// exponentialIndex returns the index of the bucket of value at scale. Bucket
+// i holds the values greater than base^i and at most base^(i+1), with base
+// 2^(2^-scale).
+func exponentialIndex(value float64, scale int32) int32 {
+	return int32(math.Ceil(math.Ldexp(math.Log2(value), int(scale)))) - 1
+}
+
+// milliseconds returns d in fractional milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// targetScopeMetrics returns the scope metrics of the i-th target in md,
+// adding them if its probe recorded nothing else
+func (s *pingScraper) targetScopeMetrics(md pmetric.Metrics, i int) pmetric.ScopeMetrics {
+	if md.ResourceMetrics().Len() > 0 && md.ResourceMetrics().At(0).ScopeMetrics().Len() > 0 {
+		return md.ResourceMetrics().At(0).ScopeMetrics().At(0)
+	}
+	rm := md.ResourceMetrics().AppendEmpty()
+	s.resources[i].CopyTo(rm.Resource())
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(metadata.ScopeName)
+	sm.Scope().SetVersion(s.settings.BuildInfo.Version)
+	return sm
+}
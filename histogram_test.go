@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+func TestFillHistogram(t *testing.T) {
+	dp := pmetric.NewHistogramDataPoint()
+	fillHistogram(dp, []float64{1, 10, 100}, []time.Duration{
+		500 * time.Microsecond,
+		time.Millisecond, // Upper bounds are inclusive
+		3 * time.Millisecond,
+		10500 * time.Microsecond,
+		time.Second,
+	})
+
+	assert.Equal(t, []float64{1, 10, 100}, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{2, 1, 1, 1}, dp.BucketCounts().AsRaw())
+	assert.Equal(t, uint64(5), dp.Count())
+	assert.InDelta(t, 1015.0, dp.Sum(), 1e-9)
+	assert.InDelta(t, 0.5, dp.Min(), 1e-9)
+	assert.InDelta(t, 1000.0, dp.Max(), 1e-9)
+}
+
+func TestFillExponentialHistogram(t *testing.T) {
+	rtts := []time.Duration{0, time.Millisecond, 2 * time.Millisecond, 3 * time.Millisecond}
+
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	fillExponentialHistogram(dp, 1, rtts)
+	assert.Equal(t, int32(1), dp.Scale())
+	assert.Equal(t, uint64(4), dp.Count())
+	assert.Equal(t, uint64(1), dp.ZeroCount())
+	assert.InDelta(t, 6.0, dp.Sum(), 1e-9)
+	assert.InDelta(t, 0.0, dp.Min(), 1e-9)
+	assert.InDelta(t, 3.0, dp.Max(), 1e-9)
+	// Buckets of base sqrt(2): (0.71, 1], (1, 1.41], (1.41, 2], (2, 2.83], (2.83, 4]
+	assert.Equal(t, int32(-1), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 0, 1, 0, 1}, dp.Positive().BucketCounts().AsRaw())
+
+	// The scale is lowered until the values fit into the buckets
+	dp = pmetric.NewExponentialHistogramDataPoint()
+	fillExponentialHistogram(dp, maxExponentialScale, []time.Duration{time.Microsecond, time.Minute})
+	assert.Less(t, dp.Scale(), int32(maxExponentialScale))
+	assert.LessOrEqual(t, dp.Positive().BucketCounts().Len(), maxExponentialBuckets)
+	assert.Equal(t, uint64(1), dp.Positive().BucketCounts().At(0))
+	assert.Equal(t, uint64(1), dp.Positive().BucketCounts().At(dp.Positive().BucketCounts().Len()-1))
+}
+
+func TestExponentialIndex(t *testing.T) {
+	assert.Equal(t, int32(0), exponentialIndex(2, 0))
+	assert.Equal(t, int32(1), exponentialIndex(3, 0))
+	assert.Equal(t, int32(-1), exponentialIndex(1, 0))
+	assert.Equal(t, int32(3), exponentialIndex(4, 1))
+	assert.Equal(t, int32(0), exponentialIndex(4, -1))
+	assert.Equal(t, int32(0), exponentialIndex(5, -2))
+}
+
+func TestScraperDurationHistogram(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1"}, {Endpoint: "192.0.2.2"}}
+	cfg.DurationHistogram.Enabled = true
+	cfg.DurationHistogram.Buckets = []float64{5, 50}
+
+	fake := pingchecktest.NewProber()
+	fake.SetResult("192.0.2.1", prober.Statistics{
+		PacketsSent: 3,
+		PacketsRecv: 3,
+		IPAddr:      &net.IPAddr{IP: net.ParseIP("192.0.2.1")},
+		AvgRtt:      20 * time.Millisecond,
+		Rtts:        []time.Duration{2 * time.Millisecond, 20 * time.Millisecond, 80 * time.Millisecond},
+	})
+	// Every packet lost leaves no round-trip times to count
+	fake.SetResult("192.0.2.2", prober.Statistics{PacketsSent: 3, PacketLoss: 100})
+
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fake)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	config, ok := fake.PingerConfig("192.0.2.1")
+	require.True(t, ok)
+	assert.True(t, config.RecordRtts)
+
+	md, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	var found bool
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		if m.Name() != durationHistogramName {
+			return
+		}
+		found = true
+		assert.Equal(t, "ms", m.Unit())
+		require.Equal(t, 1, m.Histogram().DataPoints().Len())
+		dp := m.Histogram().DataPoints().At(0)
+		assert.Equal(t, []uint64{1, 1, 1}, dp.BucketCounts().AsRaw())
+		assert.Equal(t, uint64(3), dp.Count())
+		name, _ := dp.Attributes().Get("net.peer.name")
+		assert.Equal(t, "192.0.2.1", name.Str())
+		version, _ := dp.Attributes().Get("net.ip.version")
+		assert.Equal(t, int64(4), version.Int())
+	})
+	assert.True(t, found)
+
+	md, err = scraper.scrapeTarget(context.Background(), 1)
+	require.NoError(t, err)
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		assert.NotEqual(t, durationHistogramName, m.Name())
+	})
+}
+
+func TestScraperExponentialDurationHistogram(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1"}}
+	cfg.DurationHistogram.Enabled = true
+	cfg.DurationHistogram.Type = histogramExponential
+
+	fake := pingchecktest.NewProber()
+	fake.SetResult("192.0.2.1", prober.Statistics{
+		PacketsSent: 2,
+		PacketsRecv: 2,
+		AvgRtt:      15 * time.Millisecond,
+		Rtts:        []time.Duration{10 * time.Millisecond, 20 * time.Millisecond},
+	})
+
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fake)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	md, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	var found bool
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		if m.Name() != durationHistogramName {
+			return
+		}
+		found = true
+		require.Equal(t, pmetric.MetricTypeExponentialHistogram, m.Type())
+		dp := m.ExponentialHistogram().DataPoints().At(0)
+		assert.Equal(t, uint64(2), dp.Count())
+		// 128 buckets per doubling are the most that fit 10ms to 20ms into 160
+		assert.Equal(t, int32(7), dp.Scale())
+		name, _ := dp.Attributes().Get("net.peer.name")
+		assert.Equal(t, "192.0.2.1", name.Str())
+	})
+	assert.True(t, found)
+}
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/lukeod/pingcheckreceiver/internal/ical"
+)
+
+// holidayEventAttribute is the attribute tagging the data points of probes
+// during an event of the holiday calendar
+const holidayEventAttribute = "calendar.event"
+
+// Limits of loading the holiday calendar
+const (
+	holidayLoadTimeout = 30 * time.Second
+	holidayMaxSize     = 10 << 20
+)
+
+// holidayCalendar holds the events of holidays' iCalendar, loaded again
+// every refresh interval
+type holidayCalendar struct {
+	cfg    HolidaysConfig
+	loc    *time.Location
+	client *http.Client
+
+	mu     sync.RWMutex
+	events []ical.Event
+}
+
+func newHolidayCalendar(cfg HolidaysConfig) *holidayCalendar {
+	// Validated with the config
+	loc, _ := time.LoadLocation(cfg.Timezone)
+	return &holidayCalendar{
+		cfg:    cfg,
+		loc:    loc,
+		client: &http.Client{Timeout: holidayLoadTimeout},
+	}
+}
+
+// load reads the calendar from its source and replaces the events. Events
+// of a calendar that fails to load are kept.
+func (h *holidayCalendar) load(ctx context.Context) error {
+	r, err := h.open(ctx)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	events, err := ical.Parse(io.LimitReader(r, holidayMaxSize), h.loc)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = events
+	return nil
+}
+
+// open opens the file or requests the URL of the calendar
+func (h *holidayCalendar) open(ctx context.Context) (io.ReadCloser, error) {
+	if !strings.HasPrefix(h.cfg.Source, "http://") && !strings.HasPrefix(h.cfg.Source, "https://") {
+		return os.Open(h.cfg.Source)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.cfg.Source, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// event returns the event matching target taking place at t, if any
+func (h *holidayCalendar) event(target Target, t time.Time) (ical.Event, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for _, e := range h.events {
+		if !e.Contains(t) {
+			continue
+		}
+		if len(e.Categories) == 0 || slices.Contains(e.Categories, target.Group) {
+			return e, true
+		}
+	}
+	return ical.Event{}, false
+}
+
+// startHolidays loads the holiday calendar, and again every refresh interval
+// in the background. A calendar failing to load leaves probes as they are.
+func (s *pingScraper) startHolidays() {
+	s.holidays = newHolidayCalendar(s.cfg.Holidays)
+	s.loadHolidays()
+
+	s.bgWG.Add(1)
+	go func() {
+		defer s.bgWG.Done()
+		ticker := time.NewTicker(s.cfg.Holidays.RefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.bgCtx.Done():
+				return
+			case <-ticker.C:
+				s.loadHolidays()
+			}
+		}
+	}()
+}
+
+func (s *pingScraper) loadHolidays() {
+	if err := s.holidays.load(s.bgCtx); err != nil {
+		s.logger.Warn("Failed to load holiday calendar",
+			zap.String("source", s.cfg.Holidays.Source),
+			zap.Error(err))
+	}
+}
+
+// holidayEvent returns the event of the holiday calendar target is in now
+func (s *pingScraper) holidayEvent(target Target) (ical.Event, bool) {
+	if s.holidays == nil {
+		return ical.Event{}, false
+	}
+	return s.holidays.event(target, s.clock.Now())
+}
+
+// holidayLabels returns the attribute tagging the data points of target
+// during a holiday, nil outside of them or if they suppress probes
+func (s *pingScraper) holidayLabels(target Target) map[string]string {
+	if s.cfg.Holidays.Action != interfaceActionTag {
+		return nil
+	}
+	e, ok := s.holidayEvent(target)
+	if !ok {
+		return nil
+	}
+	return map[string]string{holidayEventAttribute: e.Summary}
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+const testHolidays = `BEGIN:VCALENDAR
+BEGIN:VEVENT
+SUMMARY:Public holiday
+DTSTART;VALUE=DATE:20261225
+END:VEVENT
+BEGIN:VEVENT
+SUMMARY:Branch change freeze
+CATEGORIES:branch
+DTSTART:20261201T000000Z
+DTEND:20261205T000000Z
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestHolidayCalendarEvent(t *testing.T) {
+	h := newHolidayCalendar(HolidaysConfig{Source: filepath.Join(t.TempDir(), "holidays.ics"), Timezone: "UTC"})
+	require.NoError(t, os.WriteFile(h.cfg.Source, []byte(testHolidays), 0o600))
+	require.NoError(t, h.load(context.Background()))
+
+	e, ok := h.event(Target{Endpoint: "192.0.2.1"}, time.Date(2026, 12, 25, 12, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	assert.Equal(t, "Public holiday", e.Summary)
+
+	// Events with categories only match the targets of those groups
+	freeze := time.Date(2026, 12, 2, 12, 0, 0, 0, time.UTC)
+	_, ok = h.event(Target{Endpoint: "192.0.2.1"}, freeze)
+	assert.False(t, ok)
+	_, ok = h.event(Target{Endpoint: "192.0.2.1", Group: "branch"}, freeze)
+	assert.True(t, ok)
+
+	// Events are kept if the calendar fails to load again
+	require.NoError(t, os.Remove(h.cfg.Source))
+	assert.Error(t, h.load(context.Background()))
+	_, ok = h.event(Target{Endpoint: "192.0.2.1", Group: "branch"}, freeze)
+	assert.True(t, ok)
+}
+
+func TestHolidayCalendarURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/holidays.ics" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, testHolidays)
+	}))
+	defer server.Close()
+
+	h := newHolidayCalendar(HolidaysConfig{Source: server.URL + "/holidays.ics", Timezone: "UTC"})
+	require.NoError(t, h.load(context.Background()))
+	assert.Len(t, h.events, 2)
+
+	h = newHolidayCalendar(HolidaysConfig{Source: server.URL + "/missing.ics", Timezone: "UTC"})
+	assert.EqualError(t, h.load(context.Background()), "unexpected status 404 Not Found")
+}
+
+func TestScraperHolidays(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holidays.ics")
+	require.NoError(t, os.WriteFile(path, []byte(testHolidays), 0o600))
+
+	for _, action := range []string{interfaceActionSuppress, interfaceActionTag} {
+		t.Run(action, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Holidays = HolidaysConfig{Source: path, RefreshInterval: time.Hour, Timezone: "UTC", Action: action}
+			cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4}}
+
+			clock := pingchecktest.NewClock(time.Date(2026, 12, 25, 12, 0, 0, 0, time.UTC))
+			fakeProber := pingchecktest.NewProber()
+			fakeProber.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 4, PacketsRecv: 4})
+			scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type),
+				newFactoryOptions(WithProber(fakeProber), WithClock(clock)))
+			require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+			defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+			md, err := scraper.scrapeTarget(context.Background(), 0)
+			require.NoError(t, err)
+			if action == interfaceActionSuppress {
+				assert.Equal(t, 0, md.DataPointCount())
+				assert.Equal(t, 0, fakeProber.Runs("192.0.2.1"))
+				return
+			}
+			require.Positive(t, md.DataPointCount())
+			forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+				if m.Name() == "ping.packets.sent" {
+					event, ok := m.Sum().DataPoints().At(0).Attributes().Get("calendar.event")
+					require.True(t, ok)
+					assert.Equal(t, "Public holiday", event.Str())
+				}
+			})
+		})
+	}
+}
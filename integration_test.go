@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+// TestIntegration runs the real ICMP prober against responders with known
+// behavior: the kernel echoes on loopback, and TEST-NET-2 (RFC 5737) is never answered.
+func TestIntegration(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.CollectionInterval = time.Minute
+	cfg.InitialDelay = 0
+	// Raw sockets are available when running as root, e.g. in CI containers
+	cfg.Privileged = os.Geteuid() == 0
+	cfg.Metrics.PingErrors.Enabled = true
+	cfg.Targets = []Target{
+		{
+			Endpoint:   "127.0.0.1",
+			Count:      3,
+			Interval:   50 * time.Millisecond,
+			RunTimeout: 2 * time.Second,
+		},
+		{
+			Endpoint:   "198.51.100.1",
+			Count:      2,
+			Interval:   50 * time.Millisecond,
+			RunTimeout: 500 * time.Millisecond,
+		},
+	}
+
+	sink := new(consumertest.MetricsSink)
+	rcvr, err := factory.CreateMetrics(context.Background(), receivertest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool {
+		return len(sink.AllMetrics()) > 0
+	}, 10*time.Second, 50*time.Millisecond)
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+
+	actual := sink.AllMetrics()[0]
+	expectedFile := filepath.Join("testdata", "integration", "expected.json")
+	if *updateGolden {
+		require.NoError(t, pingchecktest.WriteMetrics(expectedFile, actual))
+	}
+
+	expected, err := pingchecktest.ReadMetrics(expectedFile)
+	require.NoError(t, err)
+	require.NoError(t, pingchecktest.CompareMetrics(expected, actual,
+		pingchecktest.IgnoreTimestamps(),
+		pingchecktest.IgnoreMetricValues(
+			"ping.duration.min",
+			"ping.duration.max",
+			"ping.duration.avg",
+			"ping.duration.median",
+			"ping.duration.stddev",
+		),
+	))
+}
+
+// TestIntegrationResponder runs the real TCP prober against a responder that
+// is taken down between scrapes
+func TestIntegrationResponder(t *testing.T) {
+	responder, err := pingchecktest.NewResponder()
+	require.NoError(t, err)
+	defer func() { require.NoError(t, responder.Close()) }()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{
+		Endpoint:   responder.Endpoint(),
+		Protocol:   "tcp",
+		Port:       responder.Port(),
+		Count:      4,
+		Interval:   10 * time.Millisecond,
+		RunTimeout: 2 * time.Second,
+	}}
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions())
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	packetLoss := func() float64 {
+		md, err := scraper.scrapeTarget(context.Background(), 0)
+		require.NoError(t, err)
+		loss := -1.0
+		forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+			if m.Name() == "ping.packet_loss" {
+				loss = m.Gauge().DataPoints().At(0).DoubleValue()
+			}
+		})
+		return loss
+	}
+
+	assert.Zero(t, packetLoss())
+	require.NoError(t, responder.SetDown(true))
+	assert.Equal(t, 1.0, packetLoss())
+	require.NoError(t, responder.SetDown(false))
+	assert.Zero(t, packetLoss())
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"net"
+	"net/netip"
+	"sync"
+)
+
+// maxInternedIPs bounds the interned addresses, targets whose DNS answers
+// rotate through many addresses would grow the table forever otherwise
+const maxInternedIPs = 1 << 16
+
+// ipInterner formats each peer address once, so the data points of every
+// scrape share its string instead of formatting the address anew
+//
+// Attribute maps are not reused likewise: the generated MetricsBuilder creates
+// them with every data point and hands them to the pipeline on Emit.
+type ipInterner struct {
+	mu   sync.Mutex
+	strs map[netip.Addr]string
+}
+
+// String returns the interned form of addr.String()
+func (in *ipInterner) String(addr *net.IPAddr) string {
+	if addr == nil {
+		return addr.String()
+	}
+	key, ok := netip.AddrFromSlice(addr.IP)
+	if !ok {
+		return addr.String()
+	}
+	key = key.WithZone(addr.Zone)
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if str, ok := in.strs[key]; ok {
+		return str
+	}
+	if in.strs == nil || len(in.strs) >= maxInternedIPs {
+		in.strs = make(map[netip.Addr]string)
+	}
+	str := addr.String()
+	in.strs[key] = str
+	return str
+}
+
+// ipVersion returns the IP version of addr, 4 or 6, or 0 if addr is nil
+func ipVersion(addr *net.IPAddr) int64 {
+	switch {
+	case addr == nil:
+		return 0
+	case addr.IP.To4() != nil:
+		return 4
+	default:
+		return 6
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"net"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPInterner(t *testing.T) {
+	var in ipInterner
+
+	first := in.String(&net.IPAddr{IP: net.ParseIP("192.0.2.1")})
+	second := in.String(&net.IPAddr{IP: net.ParseIP("192.0.2.1")})
+	assert.Equal(t, "192.0.2.1", first)
+	// Both share the interned string
+	assert.Equal(t, unsafe.StringData(first), unsafe.StringData(second))
+
+	assert.Equal(t, "fe80::1%eth0", in.String(&net.IPAddr{IP: net.ParseIP("fe80::1"), Zone: "eth0"}))
+	assert.Equal(t, "fe80::1", in.String(&net.IPAddr{IP: net.ParseIP("fe80::1")}))
+	assert.Equal(t, "<nil>", in.String(nil))
+	assert.Len(t, in.strs, 3)
+}
+
+func TestIPVersion(t *testing.T) {
+	assert.Equal(t, int64(4), ipVersion(&net.IPAddr{IP: net.ParseIP("192.0.2.1")}))
+	assert.Equal(t, int64(6), ipVersion(&net.IPAddr{IP: net.ParseIP("2001:db8::1")}))
+	assert.Equal(t, int64(6), ipVersion(&net.IPAddr{IP: net.ParseIP("fe80::1"), Zone: "eth0"}))
+	assert.Zero(t, ipVersion(nil))
+}
@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package asn maps IP addresses to the autonomous system originating them, so
+// path changes can be read as provider changes.
+package asn // import "github.com/lukeod/pingcheckreceiver/internal/asn"
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned for addresses without a known origin AS, including
+// private and other non-routable addresses
+var ErrNotFound = errors.New("no origin AS found")
+
+// Resolver looks up the origin AS number of an address
+type Resolver interface {
+	Lookup(ctx context.Context, addr netip.Addr) (uint32, error)
+}
+
+// routable reports whether addr can have an origin AS at all
+func routable(addr netip.Addr) bool {
+	return addr.IsValid() && addr.IsGlobalUnicast() && !addr.IsPrivate()
+}
+
+// Database is a Resolver over a static prefix table
+type Database struct {
+	// Prefixes indexed by length, so lookups try the most specific length first
+	prefixes [129]map[netip.Prefix]uint32
+}
+
+var _ Resolver = (*Database)(nil)
+
+// LoadDatabase reads a prefix table from path, see ParseDatabase
+func LoadDatabase(path string) (*Database, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseDatabase(f)
+}
+
+// ParseDatabase reads a prefix table with one "prefix asn" pair per line,
+// separated by whitespace or a comma, e.g. "1.1.1.0/24 13335". The ASN may be
+// written with an AS prefix. Empty lines and lines starting with # are skipped.
+func ParseDatabase(r io.Reader) (*Database, error) {
+	db := &Database{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.FieldsFunc(text, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("line %d: expected prefix and asn", line)
+		}
+		prefix, err := netip.ParsePrefix(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		asn, err := parseASN(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		db.add(prefix, asn)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+func (db *Database) add(prefix netip.Prefix, asn uint32) {
+	prefix = prefix.Masked()
+	bits := prefix.Bits()
+	if prefix.Addr().Is4() {
+		// Index IPv4 prefixes by the length of their IPv4-mapped form, the
+		// map keys keep both families apart
+		bits += 96
+	}
+	if db.prefixes[bits] == nil {
+		db.prefixes[bits] = make(map[netip.Prefix]uint32)
+	}
+	db.prefixes[bits][prefix] = asn
+}
+
+// Lookup returns the AS of the longest prefix containing addr
+func (db *Database) Lookup(_ context.Context, addr netip.Addr) (uint32, error) {
+	addr = addr.Unmap()
+	if !routable(addr) {
+		return 0, ErrNotFound
+	}
+
+	maxBits, offset := 128, 0
+	if addr.Is4() {
+		maxBits, offset = 32, 96
+	}
+	for bits := maxBits; bits >= 0; bits-- {
+		m := db.prefixes[bits+offset]
+		if m == nil {
+			continue
+		}
+		prefix, _ := addr.Prefix(bits)
+		if asn, ok := m[prefix]; ok {
+			return asn, nil
+		}
+	}
+	return 0, ErrNotFound
+}
+
+// cymruTTL is how long answers from Team Cymru are cached
+const cymruTTL = time.Hour
+
+// Cymru is a Resolver querying the Team Cymru IP to ASN mapping over DNS
+type Cymru struct {
+	lookupTXT func(ctx context.Context, name string) ([]string, error)
+	now       func() time.Time
+
+	mu    sync.Mutex
+	cache map[netip.Addr]cymruEntry
+}
+
+type cymruEntry struct {
+	asn     uint32
+	err     error
+	expires time.Time
+}
+
+var _ Resolver = (*Cymru)(nil)
+
+// NewCymru returns a Resolver using resolver, or the system resolver if nil
+func NewCymru(resolver *net.Resolver) *Cymru {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	return &Cymru{
+		lookupTXT: resolver.LookupTXT,
+		now:       time.Now,
+		cache:     make(map[netip.Addr]cymruEntry),
+	}
+}
+
+// Lookup returns the origin AS of addr, caching answers and misses for an hour
+func (c *Cymru) Lookup(ctx context.Context, addr netip.Addr) (uint32, error) {
+	addr = addr.Unmap()
+	if !routable(addr) {
+		return 0, ErrNotFound
+	}
+
+	now := c.now()
+	c.mu.Lock()
+	entry, ok := c.cache[addr]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.asn, entry.err
+	}
+
+	asn, err := c.query(ctx, addr)
+	var dnsErr *net.DNSError
+	if err != nil && !errors.Is(err, ErrNotFound) && !(errors.As(err, &dnsErr) && dnsErr.IsNotFound) {
+		// Do not cache transient failures
+		return 0, err
+	}
+	if err != nil {
+		err = ErrNotFound
+	}
+
+	c.mu.Lock()
+	c.cache[addr] = cymruEntry{asn: asn, err: err, expires: now.Add(cymruTTL)}
+	c.mu.Unlock()
+	return asn, err
+}
+
+func (c *Cymru) query(ctx context.Context, addr netip.Addr) (uint32, error) {
+	records, err := c.lookupTXT(ctx, cymruName(addr))
+	if err != nil {
+		return 0, err
+	}
+	for _, record := range records {
+		// "13335 | 1.1.1.0/24 | US | apnic | 2011-08-11", multi-origin prefixes list several ASNs
+		fields := strings.Fields(strings.SplitN(record, "|", 2)[0])
+		if len(fields) == 0 {
+			continue
+		}
+		return parseASN(fields[0])
+	}
+	return 0, ErrNotFound
+}
+
+// cymruName returns the origin zone name of addr, e.g. 1.1.1.1.origin.asn.cymru.com
+func cymruName(addr netip.Addr) string {
+	var labels []string
+	if addr.Is4() {
+		b := addr.As4()
+		for i := len(b) - 1; i >= 0; i-- {
+			labels = append(labels, strconv.Itoa(int(b[i])))
+		}
+		return strings.Join(labels, ".") + ".origin.asn.cymru.com"
+	}
+	b := addr.As16()
+	for i := len(b) - 1; i >= 0; i-- {
+		labels = append(labels, strconv.FormatUint(uint64(b[i]&0xf), 16), strconv.FormatUint(uint64(b[i]>>4), 16))
+	}
+	return strings.Join(labels, ".") + ".origin6.asn.cymru.com"
+}
+
+func parseASN(s string) (uint32, error) {
+	s = strings.TrimPrefix(strings.ToUpper(s), "AS")
+	asn, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid asn %q", s)
+	}
+	return uint32(asn), nil
+}
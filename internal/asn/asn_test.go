@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package asn
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabase(t *testing.T) {
+	db, err := ParseDatabase(strings.NewReader(`# prefix asn
+1.1.1.0/24 13335
+1.0.0.0/8,AS64500
+8.8.8.0/24	AS15169
+
+2606:4700::/32 13335
+`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		addr     string
+		expected uint32
+		err      error
+	}{
+		{addr: "1.1.1.1", expected: 13335},
+		{addr: "1.2.3.4", expected: 64500},
+		{addr: "::ffff:8.8.8.8", expected: 15169},
+		{addr: "2606:4700::1111", expected: 13335},
+		{addr: "9.9.9.9", err: ErrNotFound},
+		{addr: "10.0.0.1", err: ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			asn, err := db.Lookup(context.Background(), netip.MustParseAddr(tt.addr))
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, asn)
+		})
+	}
+}
+
+func TestParseDatabaseErrors(t *testing.T) {
+	_, err := ParseDatabase(strings.NewReader("1.1.1.0/24"))
+	assert.EqualError(t, err, "line 1: expected prefix and asn")
+
+	_, err = ParseDatabase(strings.NewReader("\n1.1.1.0/24 cloudflare"))
+	assert.EqualError(t, err, `line 2: invalid asn "CLOUDFLARE"`)
+}
+
+func TestCymruName(t *testing.T) {
+	assert.Equal(t, "1.2.0.192.origin.asn.cymru.com", cymruName(netip.MustParseAddr("192.0.2.1")))
+	assert.Equal(t,
+		"1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.origin6.asn.cymru.com",
+		cymruName(netip.MustParseAddr("2001:db8::1")))
+}
+
+func TestCymru(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	queries := 0
+	c := NewCymru(nil)
+	c.now = func() time.Time { return now }
+	c.lookupTXT = func(_ context.Context, name string) ([]string, error) {
+		queries++
+		switch name {
+		case "1.1.1.1.origin.asn.cymru.com":
+			return []string{"13335 | 1.1.1.0/24 | AU | apnic | 2011-08-11"}, nil
+		case "8.8.8.8.origin.asn.cymru.com":
+			return []string{"15169 36040 | 8.8.8.0/24 | US | arin | 2023-12-28"}, nil
+		case "9.9.9.9.origin.asn.cymru.com":
+			return nil, errors.New("i/o timeout")
+		default:
+			return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+		}
+	}
+
+	asn, err := c.Lookup(context.Background(), netip.MustParseAddr("1.1.1.1"))
+	require.NoError(t, err)
+	assert.Equal(t, uint32(13335), asn)
+
+	// The first of several origins is used
+	asn, err = c.Lookup(context.Background(), netip.MustParseAddr("8.8.8.8"))
+	require.NoError(t, err)
+	assert.Equal(t, uint32(15169), asn)
+
+	_, err = c.Lookup(context.Background(), netip.MustParseAddr("203.0.113.1"))
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Private addresses are never queried
+	_, err = c.Lookup(context.Background(), netip.MustParseAddr("192.168.1.1"))
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, 3, queries)
+
+	// Answers and misses are cached, transient errors are not
+	_, _ = c.Lookup(context.Background(), netip.MustParseAddr("1.1.1.1"))
+	_, _ = c.Lookup(context.Background(), netip.MustParseAddr("203.0.113.1"))
+	assert.Equal(t, 3, queries)
+	_, err = c.Lookup(context.Background(), netip.MustParseAddr("9.9.9.9"))
+	assert.EqualError(t, err, "i/o timeout")
+	_, _ = c.Lookup(context.Background(), netip.MustParseAddr("9.9.9.9"))
+	assert.Equal(t, 5, queries)
+
+	now = now.Add(cymruTTL)
+	_, _ = c.Lookup(context.Background(), netip.MustParseAddr("1.1.1.1"))
+	assert.Equal(t, 6, queries)
+}
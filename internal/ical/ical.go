@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ical reads the events of iCalendar (RFC 5545) files, such as the
+// holiday calendars and change freezes teams publish. Only what is needed to
+// know when an event takes place is read; recurring events are not expanded.
+package ical // import "github.com/lukeod/pingcheckreceiver/internal/ical"
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event is an event of a calendar, taking place from Start up to End
+type Event struct {
+	Summary    string
+	Categories []string
+	Start      time.Time
+	End        time.Time
+}
+
+// Contains reports whether the event takes place at t
+func (e Event) Contains(t time.Time) bool {
+	return !t.Before(e.Start) && t.Before(e.End)
+}
+
+// Parse reads the events of the calendar in r. All-day and floating times
+// are taken in loc.
+func Parse(r io.Reader, loc *time.Location) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	var event *Event
+	var duration time.Duration
+	var allDay, hasEnd bool
+	for i, line := range lines {
+		name, params, value, ok := splitProperty(line)
+		if !ok {
+			continue
+		}
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			event, duration, allDay, hasEnd = &Event{}, 0, false, false
+		case event == nil:
+			// Properties of the calendar or of other components
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if event.Start.IsZero() {
+				return nil, fmt.Errorf("line %d: event without DTSTART", i+1)
+			}
+			switch {
+			case hasEnd:
+			case duration > 0:
+				event.End = event.Start.Add(duration)
+			case allDay:
+				// All-day events without an end last that day
+				event.End = event.Start.AddDate(0, 0, 1)
+			default:
+				event.End = event.Start
+			}
+			events = append(events, *event)
+			event = nil
+		case name == "SUMMARY":
+			event.Summary = unescape(value)
+		case name == "CATEGORIES":
+			for _, category := range splitList(value) {
+				if category = strings.TrimSpace(unescape(category)); category != "" {
+					event.Categories = append(event.Categories, category)
+				}
+			}
+		case name == "DTSTART":
+			if event.Start, allDay, err = parseTime(value, params, loc); err != nil {
+				return nil, fmt.Errorf("line %d: DTSTART: %w", i+1, err)
+			}
+		case name == "DTEND":
+			if event.End, _, err = parseTime(value, params, loc); err != nil {
+				return nil, fmt.Errorf("line %d: DTEND: %w", i+1, err)
+			}
+			hasEnd = true
+		case name == "DURATION":
+			if duration, err = parseDuration(value); err != nil {
+				return nil, fmt.Errorf("line %d: DURATION: %w", i+1, err)
+			}
+		}
+	}
+	return events, nil
+}
+
+// unfold returns the logical lines of r, joining those continued on lines
+// starting with a space or tab
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// splitProperty splits a content line into its upper-cased name, its
+// parameters and its value
+func splitProperty(line string) (string, map[string]string, string, bool) {
+	// Parameter values may be quoted and contain colons
+	colon, quoted := -1, false
+	for i, c := range line {
+		if c == '"' {
+			quoted = !quoted
+		} else if c == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return "", nil, "", false
+	}
+	parts := strings.Split(line[:colon], ";")
+	params := make(map[string]string, len(parts)-1)
+	for _, param := range parts[1:] {
+		if key, value, ok := strings.Cut(param, "="); ok {
+			params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+		}
+	}
+	return strings.ToUpper(parts[0]), params, line[colon+1:], true
+}
+
+// parseTime parses a DATE or DATE-TIME value, reporting whether it is a date
+func parseTime(value string, params map[string]string, loc *time.Location) (time.Time, bool, error) {
+	if params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", value, loc)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+	if tzid, ok := params["TZID"]; ok {
+		tz, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		loc = tz
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	return t, false, err
+}
+
+var durationPattern = regexp.MustCompile(`^\+?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseDuration parses a positive duration such as P1D or PT2H30M
+func parseDuration(value string) (time.Duration, error) {
+	m := durationPattern.FindStringSubmatch(value)
+	if m == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, fmt.Errorf("%q is not a duration", value)
+	}
+	var d time.Duration
+	for i, unit := range []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if m[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a duration", value)
+		}
+		d += time.Duration(n) * unit
+	}
+	return d, nil
+}
+
+// splitList splits a list value at the commas that are not escaped
+func splitList(value string) []string {
+	var items []string
+	start := 0
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case ',':
+			items = append(items, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(items, value[start:])
+}
+
+// unescape resolves the escaped characters of a text value
+func unescape(value string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	events, err := Parse(strings.NewReader(strings.ReplaceAll(`BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Example//Holidays//EN
+BEGIN:VEVENT
+UID:1
+SUMMARY:New Year's Day
+DTSTART;VALUE=DATE:20260101
+END:VEVENT
+BEGIN:VEVENT
+UID:2
+SUMMARY:Change freeze\, EMEA
+CATEGORIES:branch,core
+DTSTART;TZID=America/New_York:20261120T180000
+DTEND;TZID=America/New_York:20261130T060000
+END:VEVENT
+BEGIN:VEVENT
+UID:3
+SUMMARY:Datacenter maint
+ enance
+DTSTART:20260301T220000Z
+DURATION:PT4H
+END:VEVENT
+BEGIN:VEVENT
+UID:4
+SUMMARY:Local event
+DTSTART:20260401T090000
+DTEND:20260401T100000
+END:VEVENT
+END:VCALENDAR
+`, "\n", "\r\n")), berlin)
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	assert.Equal(t, []Event{
+		{
+			Summary: "New Year's Day",
+			Start:   time.Date(2026, 1, 1, 0, 0, 0, 0, berlin),
+			End:     time.Date(2026, 1, 2, 0, 0, 0, 0, berlin),
+		},
+		{
+			Summary:    "Change freeze, EMEA",
+			Categories: []string{"branch", "core"},
+			Start:      time.Date(2026, 11, 20, 18, 0, 0, 0, newYork),
+			End:        time.Date(2026, 11, 30, 6, 0, 0, 0, newYork),
+		},
+		{
+			Summary: "Datacenter maintenance",
+			Start:   time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC),
+			End:     time.Date(2026, 3, 2, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			Summary: "Local event",
+			Start:   time.Date(2026, 4, 1, 9, 0, 0, 0, berlin),
+			End:     time.Date(2026, 4, 1, 10, 0, 0, 0, berlin),
+		},
+	}, events)
+
+	assert.True(t, events[0].Contains(time.Date(2026, 1, 1, 23, 59, 0, 0, berlin)))
+	assert.False(t, events[0].Contains(time.Date(2026, 1, 2, 0, 0, 0, 0, berlin)))
+}
+
+func TestParseInvalid(t *testing.T) {
+	_, err := Parse(strings.NewReader("BEGIN:VEVENT\nSUMMARY:No start\nEND:VEVENT\n"), time.UTC)
+	assert.EqualError(t, err, "line 3: event without DTSTART")
+
+	_, err = Parse(strings.NewReader("BEGIN:VEVENT\nDTSTART:tomorrow\nEND:VEVENT\n"), time.UTC)
+	assert.ErrorContains(t, err, "line 2: DTSTART")
+
+	_, err = Parse(strings.NewReader("BEGIN:VEVENT\nDTSTART:20260101T000000Z\nDURATION:1 day\nEND:VEVENT\n"), time.UTC)
+	assert.EqualError(t, err, `line 3: DURATION: "1 day" is not a duration`)
+}
+
+func TestParseDuration(t *testing.T) {
+	for value, want := range map[string]time.Duration{
+		"P1D":      24 * time.Hour,
+		"P1W":      7 * 24 * time.Hour,
+		"PT2H30M":  150 * time.Minute,
+		"P1DT12H":  36 * time.Hour,
+		"+PT15S":   15 * time.Second,
+		"PT0S":     0,
+		"P2DT0H0M": 48 * time.Hour,
+	} {
+		got, err := parseDuration(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, want, got, value)
+	}
+	for _, value := range []string{"P", "PT", "-P1D", "1D"} {
+		_, err := parseDuration(value)
+		assert.Error(t, err, value)
+	}
+}
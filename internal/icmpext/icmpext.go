@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package icmpext reads ICMP extension objects (RFC 4884) quoted by routers in
+// time-exceeded and destination-unreachable replies, as received when tracing
+// a path.
+package icmpext // import "github.com/lukeod/pingcheckreceiver/internal/icmpext"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/icmp"
+)
+
+// Protocol numbers accepted by Labels
+const (
+	ProtocolICMP     = 1
+	ProtocolIPv6ICMP = 58
+)
+
+var errNotTTLReply = errors.New("not a time-exceeded or destination-unreachable message")
+
+// Label is an entry of the MPLS label stack of the packet that triggered the reply (RFC 4950)
+type Label struct {
+	Label  int
+	TC     int
+	Bottom bool
+	TTL    int
+}
+
+// String renders the entry like traceroute does, e.g. L=24001,TC=0,S=1,TTL=1
+func (l Label) String() string {
+	s := 0
+	if l.Bottom {
+		s = 1
+	}
+	return fmt.Sprintf("L=%d,TC=%d,S=%d,TTL=%d", l.Label, l.TC, s, l.TTL)
+}
+
+// Labels returns the MPLS label stack quoted in the ICMP message b, outermost
+// label first. proto is ProtocolICMP or ProtocolIPv6ICMP. Messages without
+// extensions, including those from routers predating RFC 4884, return no labels.
+func Labels(proto int, b []byte) ([]Label, error) {
+	msg, err := icmp.ParseMessage(proto, b)
+	if err != nil {
+		return nil, err
+	}
+
+	var exts []icmp.Extension
+	switch body := msg.Body.(type) {
+	case *icmp.TimeExceeded:
+		exts = body.Extensions
+	case *icmp.DstUnreach:
+		exts = body.Extensions
+	default:
+		return nil, errNotTTLReply
+	}
+
+	var labels []Label
+	for _, ext := range exts {
+		stack, ok := ext.(*icmp.MPLSLabelStack)
+		if !ok {
+			continue
+		}
+		for _, l := range stack.Labels {
+			labels = append(labels, Label{Label: l.Label, TC: l.TC, Bottom: l.S, TTL: l.TTL})
+		}
+	}
+	return labels, nil
+}
+
+// FormatLabels renders a label stack as a single attribute value, outermost label first
+func FormatLabels(labels []Label) string {
+	entries := make([]string, 0, len(labels))
+	for _, l := range labels {
+		entries = append(entries, l.String())
+	}
+	return strings.Join(entries, " ")
+}
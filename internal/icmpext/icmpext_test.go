@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package icmpext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+func TestLabels(t *testing.T) {
+	stack := &icmp.MPLSLabelStack{
+		Class: 1,
+		Type:  1,
+		Labels: []icmp.MPLSLabel{
+			{Label: 24001, TC: 0, S: false, TTL: 1},
+			{Label: 16004, TC: 5, S: true, TTL: 1},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		proto int
+		typ   icmp.Type
+	}{
+		{name: "ipv4", proto: ProtocolICMP, typ: ipv4.ICMPTypeTimeExceeded},
+		{name: "ipv6", proto: ProtocolIPv6ICMP, typ: ipv6.ICMPTypeTimeExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := icmp.Message{
+				Type: tt.typ,
+				Body: &icmp.TimeExceeded{
+					Data:       make([]byte, 28), // Quoted IP and ICMP headers
+					Extensions: []icmp.Extension{stack},
+				},
+			}
+			b, err := msg.Marshal(nil)
+			require.NoError(t, err)
+
+			labels, err := Labels(tt.proto, b)
+			require.NoError(t, err)
+			assert.Equal(t, []Label{
+				{Label: 24001, TC: 0, Bottom: false, TTL: 1},
+				{Label: 16004, TC: 5, Bottom: true, TTL: 1},
+			}, labels)
+			assert.Equal(t, "L=24001,TC=0,S=0,TTL=1 L=16004,TC=5,S=1,TTL=1", FormatLabels(labels))
+		})
+	}
+}
+
+func TestLabelsWithoutExtensions(t *testing.T) {
+	msg := icmp.Message{
+		Type: ipv4.ICMPTypeDestinationUnreachable,
+		Body: &icmp.DstUnreach{Data: make([]byte, 28)},
+	}
+	b, err := msg.Marshal(nil)
+	require.NoError(t, err)
+
+	labels, err := Labels(ProtocolICMP, b)
+	require.NoError(t, err)
+	assert.Empty(t, labels)
+}
+
+func TestLabelsEchoReply(t *testing.T) {
+	msg := icmp.Message{
+		Type: ipv4.ICMPTypeEchoReply,
+		Body: &icmp.Echo{ID: 1, Seq: 1},
+	}
+	b, err := msg.Marshal(nil)
+	require.NoError(t, err)
+
+	_, err = Labels(ProtocolICMP, b)
+	assert.ErrorIs(t, err, errNotTTLReply)
+}
@@ -27,46 +27,150 @@ func (ms *MetricConfig) Unmarshal(parser *confmap.Conf) error {
 
 // MetricsConfig provides config for ping metrics.
 type MetricsConfig struct {
-	PingDuration        MetricConfig `mapstructure:"ping.duration"`
-	PingDurationAvg     MetricConfig `mapstructure:"ping.duration.avg"`
-	PingDurationMax     MetricConfig `mapstructure:"ping.duration.max"`
-	PingDurationMin     MetricConfig `mapstructure:"ping.duration.min"`
-	PingDurationStddev  MetricConfig `mapstructure:"ping.duration.stddev"`
-	PingErrors          MetricConfig `mapstructure:"ping.errors"`
-	PingPacketLoss      MetricConfig `mapstructure:"ping.packet_loss"`
-	PingPacketsReceived MetricConfig `mapstructure:"ping.packets.received"`
-	PingPacketsSent     MetricConfig `mapstructure:"ping.packets.sent"`
+	ConnectivityState             MetricConfig `mapstructure:"connectivity.state"`
+	PingBusinessHoursDowntime     MetricConfig `mapstructure:"ping.business_hours.downtime"`
+	PingConsecutiveFailures       MetricConfig `mapstructure:"ping.consecutive_failures"`
+	PingDNSLookupDuration         MetricConfig `mapstructure:"ping.dns.lookup.duration"`
+	PingDowntime                  MetricConfig `mapstructure:"ping.downtime"`
+	PingDscpDuration              MetricConfig `mapstructure:"ping.dscp.duration"`
+	PingDscpPacketLoss            MetricConfig `mapstructure:"ping.dscp.packet_loss"`
+	PingDuration                  MetricConfig `mapstructure:"ping.duration"`
+	PingDurationAvg               MetricConfig `mapstructure:"ping.duration.avg"`
+	PingDurationHeatmap           MetricConfig `mapstructure:"ping.duration.heatmap"`
+	PingDurationMax               MetricConfig `mapstructure:"ping.duration.max"`
+	PingDurationMedian            MetricConfig `mapstructure:"ping.duration.median"`
+	PingDurationMin               MetricConfig `mapstructure:"ping.duration.min"`
+	PingDurationStddev            MetricConfig `mapstructure:"ping.duration.stddev"`
+	PingDurationTrimmedMean       MetricConfig `mapstructure:"ping.duration.trimmed_mean"`
+	PingErrors                    MetricConfig `mapstructure:"ping.errors"`
+	PingJitter                    MetricConfig `mapstructure:"ping.jitter"`
+	PingManagementPlaneResponding MetricConfig `mapstructure:"ping.management_plane.responding"`
+	PingPacketLoss                MetricConfig `mapstructure:"ping.packet_loss"`
+	PingPacketLossPercent         MetricConfig `mapstructure:"ping.packet_loss.percent"`
+	PingPacketsReceived           MetricConfig `mapstructure:"ping.packets.received"`
+	PingPacketsSent               MetricConfig `mapstructure:"ping.packets.sent"`
+	PingPathMtu                   MetricConfig `mapstructure:"ping.path_mtu"`
+	PingProbePanics               MetricConfig `mapstructure:"ping.probe.panics"`
+	PingReplyTTL                  MetricConfig `mapstructure:"ping.reply.ttl"`
+	PingScheduleDrift             MetricConfig `mapstructure:"ping.schedule.drift"`
+	PingSizeSweepDuration         MetricConfig `mapstructure:"ping.size_sweep.duration"`
+	PingSizeSweepPacketLoss       MetricConfig `mapstructure:"ping.size_sweep.packet_loss"`
+	PingSLAStatus                 MetricConfig `mapstructure:"ping.sla.status"`
+	PingStateTransitions          MetricConfig `mapstructure:"ping.state_transitions"`
+	PingTracerouteHopDuration     MetricConfig `mapstructure:"ping.traceroute.hop.duration"`
+	PingTracerouteHopPacketLoss   MetricConfig `mapstructure:"ping.traceroute.hop.packet_loss"`
+	PingTracerouteHopCount        MetricConfig `mapstructure:"ping.traceroute.hop_count"`
+	PingTunnelOverhead            MetricConfig `mapstructure:"ping.tunnel.overhead"`
+	PingTunnelPacketLossDelta     MetricConfig `mapstructure:"ping.tunnel.packet_loss_delta"`
 }
 
 func DefaultMetricsConfig() MetricsConfig {
 	return MetricsConfig{
+		ConnectivityState: MetricConfig{
+			Enabled: true,
+		},
+		PingBusinessHoursDowntime: MetricConfig{
+			Enabled: false,
+		},
+		PingConsecutiveFailures: MetricConfig{
+			Enabled: false,
+		},
+		PingDNSLookupDuration: MetricConfig{
+			Enabled: false,
+		},
+		PingDowntime: MetricConfig{
+			Enabled: false,
+		},
+		PingDscpDuration: MetricConfig{
+			Enabled: true,
+		},
+		PingDscpPacketLoss: MetricConfig{
+			Enabled: true,
+		},
 		PingDuration: MetricConfig{
 			Enabled: true,
 		},
 		PingDurationAvg: MetricConfig{
 			Enabled: true,
 		},
+		PingDurationHeatmap: MetricConfig{
+			Enabled: false,
+		},
 		PingDurationMax: MetricConfig{
 			Enabled: true,
 		},
+		PingDurationMedian: MetricConfig{
+			Enabled: true,
+		},
 		PingDurationMin: MetricConfig{
 			Enabled: true,
 		},
 		PingDurationStddev: MetricConfig{
 			Enabled: true,
 		},
+		PingDurationTrimmedMean: MetricConfig{
+			Enabled: false,
+		},
 		PingErrors: MetricConfig{
 			Enabled: false,
 		},
+		PingJitter: MetricConfig{
+			Enabled: false,
+		},
+		PingManagementPlaneResponding: MetricConfig{
+			Enabled: true,
+		},
 		PingPacketLoss: MetricConfig{
 			Enabled: true,
 		},
+		PingPacketLossPercent: MetricConfig{
+			Enabled: false,
+		},
 		PingPacketsReceived: MetricConfig{
 			Enabled: true,
 		},
 		PingPacketsSent: MetricConfig{
 			Enabled: true,
 		},
+		PingPathMtu: MetricConfig{
+			Enabled: true,
+		},
+		PingProbePanics: MetricConfig{
+			Enabled: true,
+		},
+		PingReplyTTL: MetricConfig{
+			Enabled: false,
+		},
+		PingScheduleDrift: MetricConfig{
+			Enabled: false,
+		},
+		PingSizeSweepDuration: MetricConfig{
+			Enabled: true,
+		},
+		PingSizeSweepPacketLoss: MetricConfig{
+			Enabled: true,
+		},
+		PingSLAStatus: MetricConfig{
+			Enabled: true,
+		},
+		PingStateTransitions: MetricConfig{
+			Enabled: false,
+		},
+		PingTracerouteHopDuration: MetricConfig{
+			Enabled: true,
+		},
+		PingTracerouteHopPacketLoss: MetricConfig{
+			Enabled: true,
+		},
+		PingTracerouteHopCount: MetricConfig{
+			Enabled: true,
+		},
+		PingTunnelOverhead: MetricConfig{
+			Enabled: true,
+		},
+		PingTunnelPacketLossDelta: MetricConfig{
+			Enabled: true,
+		},
 	}
 }
 
@@ -27,15 +27,41 @@ func TestMetricsBuilderConfig(t *testing.T) {
 			name: "all_set",
 			want: MetricsBuilderConfig{
 				Metrics: MetricsConfig{
-					PingDuration:        MetricConfig{Enabled: true},
-					PingDurationAvg:     MetricConfig{Enabled: true},
-					PingDurationMax:     MetricConfig{Enabled: true},
-					PingDurationMin:     MetricConfig{Enabled: true},
-					PingDurationStddev:  MetricConfig{Enabled: true},
-					PingErrors:          MetricConfig{Enabled: true},
-					PingPacketLoss:      MetricConfig{Enabled: true},
-					PingPacketsReceived: MetricConfig{Enabled: true},
-					PingPacketsSent:     MetricConfig{Enabled: true},
+					ConnectivityState:             MetricConfig{Enabled: true},
+					PingBusinessHoursDowntime:     MetricConfig{Enabled: true},
+					PingConsecutiveFailures:       MetricConfig{Enabled: true},
+					PingDNSLookupDuration:         MetricConfig{Enabled: true},
+					PingDowntime:                  MetricConfig{Enabled: true},
+					PingDscpDuration:              MetricConfig{Enabled: true},
+					PingDscpPacketLoss:            MetricConfig{Enabled: true},
+					PingDuration:                  MetricConfig{Enabled: true},
+					PingDurationAvg:               MetricConfig{Enabled: true},
+					PingDurationHeatmap:           MetricConfig{Enabled: true},
+					PingDurationMax:               MetricConfig{Enabled: true},
+					PingDurationMedian:            MetricConfig{Enabled: true},
+					PingDurationMin:               MetricConfig{Enabled: true},
+					PingDurationStddev:            MetricConfig{Enabled: true},
+					PingDurationTrimmedMean:       MetricConfig{Enabled: true},
+					PingErrors:                    MetricConfig{Enabled: true},
+					PingJitter:                    MetricConfig{Enabled: true},
+					PingManagementPlaneResponding: MetricConfig{Enabled: true},
+					PingPacketLoss:                MetricConfig{Enabled: true},
+					PingPacketLossPercent:         MetricConfig{Enabled: true},
+					PingPacketsReceived:           MetricConfig{Enabled: true},
+					PingPacketsSent:               MetricConfig{Enabled: true},
+					PingPathMtu:                   MetricConfig{Enabled: true},
+					PingProbePanics:               MetricConfig{Enabled: true},
+					PingReplyTTL:                  MetricConfig{Enabled: true},
+					PingScheduleDrift:             MetricConfig{Enabled: true},
+					PingSizeSweepDuration:         MetricConfig{Enabled: true},
+					PingSizeSweepPacketLoss:       MetricConfig{Enabled: true},
+					PingSLAStatus:                 MetricConfig{Enabled: true},
+					PingStateTransitions:          MetricConfig{Enabled: true},
+					PingTracerouteHopDuration:     MetricConfig{Enabled: true},
+					PingTracerouteHopPacketLoss:   MetricConfig{Enabled: true},
+					PingTracerouteHopCount:        MetricConfig{Enabled: true},
+					PingTunnelOverhead:            MetricConfig{Enabled: true},
+					PingTunnelPacketLossDelta:     MetricConfig{Enabled: true},
 				},
 			},
 		},
@@ -43,15 +69,41 @@ func TestMetricsBuilderConfig(t *testing.T) {
 			name: "none_set",
 			want: MetricsBuilderConfig{
 				Metrics: MetricsConfig{
-					PingDuration:        MetricConfig{Enabled: false},
-					PingDurationAvg:     MetricConfig{Enabled: false},
-					PingDurationMax:     MetricConfig{Enabled: false},
-					PingDurationMin:     MetricConfig{Enabled: false},
-					PingDurationStddev:  MetricConfig{Enabled: false},
-					PingErrors:          MetricConfig{Enabled: false},
-					PingPacketLoss:      MetricConfig{Enabled: false},
-					PingPacketsReceived: MetricConfig{Enabled: false},
-					PingPacketsSent:     MetricConfig{Enabled: false},
+					ConnectivityState:             MetricConfig{Enabled: false},
+					PingBusinessHoursDowntime:     MetricConfig{Enabled: false},
+					PingConsecutiveFailures:       MetricConfig{Enabled: false},
+					PingDNSLookupDuration:         MetricConfig{Enabled: false},
+					PingDowntime:                  MetricConfig{Enabled: false},
+					PingDscpDuration:              MetricConfig{Enabled: false},
+					PingDscpPacketLoss:            MetricConfig{Enabled: false},
+					PingDuration:                  MetricConfig{Enabled: false},
+					PingDurationAvg:               MetricConfig{Enabled: false},
+					PingDurationHeatmap:           MetricConfig{Enabled: false},
+					PingDurationMax:               MetricConfig{Enabled: false},
+					PingDurationMedian:            MetricConfig{Enabled: false},
+					PingDurationMin:               MetricConfig{Enabled: false},
+					PingDurationStddev:            MetricConfig{Enabled: false},
+					PingDurationTrimmedMean:       MetricConfig{Enabled: false},
+					PingErrors:                    MetricConfig{Enabled: false},
+					PingJitter:                    MetricConfig{Enabled: false},
+					PingManagementPlaneResponding: MetricConfig{Enabled: false},
+					PingPacketLoss:                MetricConfig{Enabled: false},
+					PingPacketLossPercent:         MetricConfig{Enabled: false},
+					PingPacketsReceived:           MetricConfig{Enabled: false},
+					PingPacketsSent:               MetricConfig{Enabled: false},
+					PingPathMtu:                   MetricConfig{Enabled: false},
+					PingProbePanics:               MetricConfig{Enabled: false},
+					PingReplyTTL:                  MetricConfig{Enabled: false},
+					PingScheduleDrift:             MetricConfig{Enabled: false},
+					PingSizeSweepDuration:         MetricConfig{Enabled: false},
+					PingSizeSweepPacketLoss:       MetricConfig{Enabled: false},
+					PingSLAStatus:                 MetricConfig{Enabled: false},
+					PingStateTransitions:          MetricConfig{Enabled: false},
+					PingTracerouteHopDuration:     MetricConfig{Enabled: false},
+					PingTracerouteHopPacketLoss:   MetricConfig{Enabled: false},
+					PingTracerouteHopCount:        MetricConfig{Enabled: false},
+					PingTunnelOverhead:            MetricConfig{Enabled: false},
+					PingTunnelPacketLossDelta:     MetricConfig{Enabled: false},
 				},
 			},
 		},
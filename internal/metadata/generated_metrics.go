@@ -21,6 +21,9 @@ const (
 	AttributeErrorTypeDNSFailure
 	AttributeErrorTypeNetworkUnreachable
 	AttributeErrorTypePermissionDenied
+	AttributeErrorTypeInterfaceDown
+	AttributeErrorTypeDeadlineExceeded
+	AttributeErrorTypePanic
 	AttributeErrorTypeUnknown
 )
 
@@ -35,6 +38,12 @@ func (av AttributeErrorType) String() string {
 		return "network_unreachable"
 	case AttributeErrorTypePermissionDenied:
 		return "permission_denied"
+	case AttributeErrorTypeInterfaceDown:
+		return "interface_down"
+	case AttributeErrorTypeDeadlineExceeded:
+		return "deadline_exceeded"
+	case AttributeErrorTypePanic:
+		return "panic"
 	case AttributeErrorTypeUnknown:
 		return "unknown"
 	}
@@ -47,71 +56,1578 @@ var MapAttributeErrorType = map[string]AttributeErrorType{
 	"dns_failure":         AttributeErrorTypeDNSFailure,
 	"network_unreachable": AttributeErrorTypeNetworkUnreachable,
 	"permission_denied":   AttributeErrorTypePermissionDenied,
+	"interface_down":      AttributeErrorTypeInterfaceDown,
+	"deadline_exceeded":   AttributeErrorTypeDeadlineExceeded,
+	"panic":               AttributeErrorTypePanic,
 	"unknown":             AttributeErrorTypeUnknown,
 }
 
+// AttributeState specifies the value state attribute.
+type AttributeState int
+
+const (
+	_ AttributeState = iota
+	AttributeStateFull
+	AttributeStatePortal
+	AttributeStateNone
+)
+
+// String returns the string representation of the AttributeState.
+func (av AttributeState) String() string {
+	switch av {
+	case AttributeStateFull:
+		return "full"
+	case AttributeStatePortal:
+		return "portal"
+	case AttributeStateNone:
+		return "none"
+	}
+	return ""
+}
+
+// MapAttributeState is a helper map of string to AttributeState attribute value.
+var MapAttributeState = map[string]AttributeState{
+	"full":   AttributeStateFull,
+	"portal": AttributeStatePortal,
+	"none":   AttributeStateNone,
+}
+
 var MetricsInfo = metricsInfo{
+	ConnectivityState: metricInfo{
+		Name: "connectivity.state",
+	},
+	PingBusinessHoursDowntime: metricInfo{
+		Name: "ping.business_hours.downtime",
+	},
+	PingConsecutiveFailures: metricInfo{
+		Name: "ping.consecutive_failures",
+	},
+	PingDNSLookupDuration: metricInfo{
+		Name: "ping.dns.lookup.duration",
+	},
+	PingDowntime: metricInfo{
+		Name: "ping.downtime",
+	},
+	PingDscpDuration: metricInfo{
+		Name: "ping.dscp.duration",
+	},
+	PingDscpPacketLoss: metricInfo{
+		Name: "ping.dscp.packet_loss",
+	},
 	PingDuration: metricInfo{
 		Name: "ping.duration",
 	},
 	PingDurationAvg: metricInfo{
 		Name: "ping.duration.avg",
 	},
+	PingDurationHeatmap: metricInfo{
+		Name: "ping.duration.heatmap",
+	},
 	PingDurationMax: metricInfo{
 		Name: "ping.duration.max",
 	},
+	PingDurationMedian: metricInfo{
+		Name: "ping.duration.median",
+	},
 	PingDurationMin: metricInfo{
 		Name: "ping.duration.min",
 	},
 	PingDurationStddev: metricInfo{
 		Name: "ping.duration.stddev",
 	},
+	PingDurationTrimmedMean: metricInfo{
+		Name: "ping.duration.trimmed_mean",
+	},
 	PingErrors: metricInfo{
 		Name: "ping.errors",
 	},
+	PingJitter: metricInfo{
+		Name: "ping.jitter",
+	},
+	PingManagementPlaneResponding: metricInfo{
+		Name: "ping.management_plane.responding",
+	},
 	PingPacketLoss: metricInfo{
 		Name: "ping.packet_loss",
 	},
+	PingPacketLossPercent: metricInfo{
+		Name: "ping.packet_loss.percent",
+	},
 	PingPacketsReceived: metricInfo{
 		Name: "ping.packets.received",
 	},
 	PingPacketsSent: metricInfo{
 		Name: "ping.packets.sent",
 	},
+	PingPathMtu: metricInfo{
+		Name: "ping.path_mtu",
+	},
+	PingProbePanics: metricInfo{
+		Name: "ping.probe.panics",
+	},
+	PingReplyTTL: metricInfo{
+		Name: "ping.reply.ttl",
+	},
+	PingScheduleDrift: metricInfo{
+		Name: "ping.schedule.drift",
+	},
+	PingSizeSweepDuration: metricInfo{
+		Name: "ping.size_sweep.duration",
+	},
+	PingSizeSweepPacketLoss: metricInfo{
+		Name: "ping.size_sweep.packet_loss",
+	},
+	PingSLAStatus: metricInfo{
+		Name: "ping.sla.status",
+	},
+	PingStateTransitions: metricInfo{
+		Name: "ping.state_transitions",
+	},
+	PingTracerouteHopDuration: metricInfo{
+		Name: "ping.traceroute.hop.duration",
+	},
+	PingTracerouteHopPacketLoss: metricInfo{
+		Name: "ping.traceroute.hop.packet_loss",
+	},
+	PingTracerouteHopCount: metricInfo{
+		Name: "ping.traceroute.hop_count",
+	},
+	PingTunnelOverhead: metricInfo{
+		Name: "ping.tunnel.overhead",
+	},
+	PingTunnelPacketLossDelta: metricInfo{
+		Name: "ping.tunnel.packet_loss_delta",
+	},
 }
 
 type metricsInfo struct {
-	PingDuration        metricInfo
-	PingDurationAvg     metricInfo
-	PingDurationMax     metricInfo
-	PingDurationMin     metricInfo
-	PingDurationStddev  metricInfo
-	PingErrors          metricInfo
-	PingPacketLoss      metricInfo
-	PingPacketsReceived metricInfo
-	PingPacketsSent     metricInfo
+	ConnectivityState             metricInfo
+	PingBusinessHoursDowntime     metricInfo
+	PingConsecutiveFailures       metricInfo
+	PingDNSLookupDuration         metricInfo
+	PingDowntime                  metricInfo
+	PingDscpDuration              metricInfo
+	PingDscpPacketLoss            metricInfo
+	PingDuration                  metricInfo
+	PingDurationAvg               metricInfo
+	PingDurationHeatmap           metricInfo
+	PingDurationMax               metricInfo
+	PingDurationMedian            metricInfo
+	PingDurationMin               metricInfo
+	PingDurationStddev            metricInfo
+	PingDurationTrimmedMean       metricInfo
+	PingErrors                    metricInfo
+	PingJitter                    metricInfo
+	PingManagementPlaneResponding metricInfo
+	PingPacketLoss                metricInfo
+	PingPacketLossPercent         metricInfo
+	PingPacketsReceived           metricInfo
+	PingPacketsSent               metricInfo
+	PingPathMtu                   metricInfo
+	PingProbePanics               metricInfo
+	PingReplyTTL                  metricInfo
+	PingScheduleDrift             metricInfo
+	PingSizeSweepDuration         metricInfo
+	PingSizeSweepPacketLoss       metricInfo
+	PingSLAStatus                 metricInfo
+	PingStateTransitions          metricInfo
+	PingTracerouteHopDuration     metricInfo
+	PingTracerouteHopPacketLoss   metricInfo
+	PingTracerouteHopCount        metricInfo
+	PingTunnelOverhead            metricInfo
+	PingTunnelPacketLossDelta     metricInfo
 }
 
 type metricInfo struct {
 	Name string
 }
 
-type metricPingDuration struct {
+type MetricAttributeOption interface {
+	apply(pmetric.NumberDataPoint)
+}
+
+type metricAttributeOptionFunc func(pmetric.NumberDataPoint)
+
+func (maof metricAttributeOptionFunc) apply(dp pmetric.NumberDataPoint) {
+	maof(dp)
+}
+
+func WithLocalNetworkOkMetricAttribute(localNetworkOkAttributeValue bool) MetricAttributeOption {
+	return metricAttributeOptionFunc(func(dp pmetric.NumberDataPoint) {
+		dp.Attributes().PutBool("local_network_ok", localNetworkOkAttributeValue)
+	})
+}
+
+func WithOsTypeMetricAttribute(osTypeAttributeValue string) MetricAttributeOption {
+	return metricAttributeOptionFunc(func(dp pmetric.NumberDataPoint) {
+		dp.Attributes().PutStr("os.type", osTypeAttributeValue)
+	})
+}
+
+func WithPassivelySeenMetricAttribute(passivelySeenAttributeValue bool) MetricAttributeOption {
+	return metricAttributeOptionFunc(func(dp pmetric.NumberDataPoint) {
+		dp.Attributes().PutBool("passively_seen", passivelySeenAttributeValue)
+	})
+}
+
+func WithTracerouteHopAsnMetricAttribute(tracerouteHopAsnAttributeValue int64) MetricAttributeOption {
+	return metricAttributeOptionFunc(func(dp pmetric.NumberDataPoint) {
+		dp.Attributes().PutInt("traceroute.hop.asn", tracerouteHopAsnAttributeValue)
+	})
+}
+
+func WithTracerouteHopMplsLabelsMetricAttribute(tracerouteHopMplsLabelsAttributeValue string) MetricAttributeOption {
+	return metricAttributeOptionFunc(func(dp pmetric.NumberDataPoint) {
+		dp.Attributes().PutStr("traceroute.hop.mpls_labels", tracerouteHopMplsLabelsAttributeValue)
+	})
+}
+
+type metricConnectivityState struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills connectivity.state metric with initial data.
+func (m *metricConnectivityState) init() {
+	m.data.SetName("connectivity.state")
+	m.data.SetDescription("Internet connectivity of the host, 1 for the current state and 0 otherwise (requires connectivity_check)")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricConnectivityState) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, stateAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("state", stateAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricConnectivityState) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricConnectivityState) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricConnectivityState(cfg MetricConfig) metricConnectivityState {
+	m := metricConnectivityState{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingBusinessHoursDowntime struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.business_hours.downtime metric with initial data.
+func (m *metricPingBusinessHoursDowntime) init() {
+	m.data.SetName("ping.business_hours.downtime")
+	m.data.SetDescription("Total time the target was down, split by the business hours of its calendar, reported for targets with a calendar only")
+	m.data.SetUnit("s")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingBusinessHoursDowntime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, businessHoursAttributeValue bool) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+	dp.Attributes().PutBool("business_hours", businessHoursAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingBusinessHoursDowntime) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingBusinessHoursDowntime) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingBusinessHoursDowntime(cfg MetricConfig) metricPingBusinessHoursDowntime {
+	m := metricPingBusinessHoursDowntime{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingConsecutiveFailures struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.consecutive_failures metric with initial data.
+func (m *metricPingConsecutiveFailures) init() {
+	m.data.SetName("ping.consecutive_failures")
+	m.data.SetDescription("Number of successive scrapes in which the target failed or lost every packet, 0 once it answers again")
+	m.data.SetUnit("{scrape}")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingConsecutiveFailures) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingConsecutiveFailures) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingConsecutiveFailures) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingConsecutiveFailures(cfg MetricConfig) metricPingConsecutiveFailures {
+	m := metricPingConsecutiveFailures{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingDNSLookupDuration struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.dns.lookup.duration metric with initial data.
+func (m *metricPingDNSLookupDuration) init() {
+	m.data.SetName("ping.dns.lookup.duration")
+	m.data.SetDescription("Time taken to resolve the hostname of the target before its probe, not reported for targets configured by address")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingDNSLookupDuration) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingDNSLookupDuration) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingDNSLookupDuration) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingDNSLookupDuration(cfg MetricConfig) metricPingDNSLookupDuration {
+	m := metricPingDNSLookupDuration{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingDowntime struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.downtime metric with initial data.
+func (m *metricPingDowntime) init() {
+	m.data.SetName("ping.downtime")
+	m.data.SetDescription("Total time the target was down, the interval before a scrape that failed or lost every packet counting as down")
+	m.data.SetUnit("s")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingDowntime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingDowntime) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingDowntime) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingDowntime(cfg MetricConfig) metricPingDowntime {
+	m := metricPingDowntime{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingDscpDuration struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.dscp.duration metric with initial data.
+func (m *metricPingDscpDuration) init() {
+	m.data.SetName("ping.dscp.duration")
+	m.data.SetDescription("Average round-trip time of the packets of one DSCP value, probed with dscp_values")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingDscpDuration) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64, dscpAttributeValue int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
+	dp.Attributes().PutInt("net.ip.version", netIPVersionAttributeValue)
+	dp.Attributes().PutInt("dscp", dscpAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingDscpDuration) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingDscpDuration) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingDscpDuration(cfg MetricConfig) metricPingDscpDuration {
+	m := metricPingDscpDuration{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingDscpPacketLoss struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.dscp.packet_loss metric with initial data.
+func (m *metricPingDscpPacketLoss) init() {
+	m.data.SetName("ping.dscp.packet_loss")
+	m.data.SetDescription("Ratio of packets of one DSCP value lost, probed with dscp_values")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingDscpPacketLoss) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64, dscpAttributeValue int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
+	dp.Attributes().PutInt("net.ip.version", netIPVersionAttributeValue)
+	dp.Attributes().PutInt("dscp", dscpAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingDscpPacketLoss) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingDscpPacketLoss) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingDscpPacketLoss(cfg MetricConfig) metricPingDscpPacketLoss {
+	m := metricPingDscpPacketLoss{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingDuration struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.duration metric with initial data.
+func (m *metricPingDuration) init() {
+	m.data.SetName("ping.duration")
+	m.data.SetDescription("Round-trip time for ping packets")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingDuration) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
+	dp.Attributes().PutInt("net.ip.version", netIPVersionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingDuration) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingDuration) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingDuration(cfg MetricConfig) metricPingDuration {
+	m := metricPingDuration{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingDurationAvg struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.duration.avg metric with initial data.
+func (m *metricPingDurationAvg) init() {
+	m.data.SetName("ping.duration.avg")
+	m.data.SetDescription("Average round-trip time")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingDurationAvg) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
+	dp.Attributes().PutInt("net.ip.version", netIPVersionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingDurationAvg) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingDurationAvg) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingDurationAvg(cfg MetricConfig) metricPingDurationAvg {
+	m := metricPingDurationAvg{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingDurationHeatmap struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.duration.heatmap metric with initial data.
+func (m *metricPingDurationHeatmap) init() {
+	m.data.SetName("ping.duration.heatmap")
+	m.data.SetDescription("Number of packets of the probe whose round-trip time fell into the bucket, reported for every bucket of heatmap.buckets")
+	m.data.SetUnit("{packet}")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingDurationHeatmap) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string, durationBucketAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+	dp.Attributes().PutStr("duration.bucket", durationBucketAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingDurationHeatmap) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingDurationHeatmap) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingDurationHeatmap(cfg MetricConfig) metricPingDurationHeatmap {
+	m := metricPingDurationHeatmap{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingDurationMax struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.duration.max metric with initial data.
+func (m *metricPingDurationMax) init() {
+	m.data.SetName("ping.duration.max")
+	m.data.SetDescription("Maximum round-trip time")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingDurationMax) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
+	dp.Attributes().PutInt("net.ip.version", netIPVersionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingDurationMax) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingDurationMax) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingDurationMax(cfg MetricConfig) metricPingDurationMax {
+	m := metricPingDurationMax{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingDurationMedian struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.duration.median metric with initial data.
+func (m *metricPingDurationMedian) init() {
+	m.data.SetName("ping.duration.median")
+	m.data.SetDescription("Median round-trip time")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingDurationMedian) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
+	dp.Attributes().PutInt("net.ip.version", netIPVersionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingDurationMedian) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingDurationMedian) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingDurationMedian(cfg MetricConfig) metricPingDurationMedian {
+	m := metricPingDurationMedian{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingDurationMin struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.duration.min metric with initial data.
+func (m *metricPingDurationMin) init() {
+	m.data.SetName("ping.duration.min")
+	m.data.SetDescription("Minimum round-trip time")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingDurationMin) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
+	dp.Attributes().PutInt("net.ip.version", netIPVersionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingDurationMin) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingDurationMin) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingDurationMin(cfg MetricConfig) metricPingDurationMin {
+	m := metricPingDurationMin{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingDurationStddev struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.duration.stddev metric with initial data.
+func (m *metricPingDurationStddev) init() {
+	m.data.SetName("ping.duration.stddev")
+	m.data.SetDescription("Standard deviation of round-trip times")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingDurationStddev) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
+	dp.Attributes().PutInt("net.ip.version", netIPVersionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingDurationStddev) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingDurationStddev) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingDurationStddev(cfg MetricConfig) metricPingDurationStddev {
+	m := metricPingDurationStddev{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingDurationTrimmedMean struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.duration.trimmed_mean metric with initial data.
+func (m *metricPingDurationTrimmedMean) init() {
+	m.data.SetName("ping.duration.trimmed_mean")
+	m.data.SetDescription("Mean round-trip time after dropping the fastest and slowest trimmed_mean_percent of samples")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingDurationTrimmedMean) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
+	dp.Attributes().PutInt("net.ip.version", netIPVersionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingDurationTrimmedMean) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingDurationTrimmedMean) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingDurationTrimmedMean(cfg MetricConfig) metricPingDurationTrimmedMean {
+	m := metricPingDurationTrimmedMean{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingErrors struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.errors metric with initial data.
+func (m *metricPingErrors) init() {
+	m.data.SetName("ping.errors")
+	m.data.SetDescription("Number of errors encountered")
+	m.data.SetUnit("{error}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityUnspecified)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingErrors) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64, errorTypeAttributeValue string, options ...MetricAttributeOption) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
+	dp.Attributes().PutInt("net.ip.version", netIPVersionAttributeValue)
+	dp.Attributes().PutStr("error.type", errorTypeAttributeValue)
+	for _, op := range options {
+		op.apply(dp)
+	}
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingErrors) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingErrors) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingErrors(cfg MetricConfig) metricPingErrors {
+	m := metricPingErrors{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingJitter struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.jitter metric with initial data.
+func (m *metricPingJitter) init() {
+	m.data.SetName("ping.jitter")
+	m.data.SetDescription("Mean absolute difference of the round-trip times of consecutive packets")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingJitter) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
+	dp.Attributes().PutInt("net.ip.version", netIPVersionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingJitter) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingJitter) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingJitter(cfg MetricConfig) metricPingJitter {
+	m := metricPingJitter{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingManagementPlaneResponding struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.management_plane.responding metric with initial data.
+func (m *metricPingManagementPlaneResponding) init() {
+	m.data.SetName("ping.management_plane.responding")
+	m.data.SetDescription("Whether the target answered an SNMP sysUpTime request after its ping failed or lost every packet, 1 if it did, checked with snmp")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingManagementPlaneResponding) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingManagementPlaneResponding) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingManagementPlaneResponding) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingManagementPlaneResponding(cfg MetricConfig) metricPingManagementPlaneResponding {
+	m := metricPingManagementPlaneResponding{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingPacketLoss struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.packet_loss metric with initial data.
+func (m *metricPingPacketLoss) init() {
+	m.data.SetName("ping.packet_loss")
+	m.data.SetDescription("Ratio of packets lost")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingPacketLoss) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
+	dp.Attributes().PutInt("net.ip.version", netIPVersionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingPacketLoss) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingPacketLoss) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingPacketLoss(cfg MetricConfig) metricPingPacketLoss {
+	m := metricPingPacketLoss{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingPacketLossPercent struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.packet_loss.percent metric with initial data.
+func (m *metricPingPacketLossPercent) init() {
+	m.data.SetName("ping.packet_loss.percent")
+	m.data.SetDescription("Percentage of packets lost")
+	m.data.SetUnit("%")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingPacketLossPercent) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
+	dp.Attributes().PutInt("net.ip.version", netIPVersionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingPacketLossPercent) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingPacketLossPercent) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingPacketLossPercent(cfg MetricConfig) metricPingPacketLossPercent {
+	m := metricPingPacketLossPercent{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingPacketsReceived struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.packets.received metric with initial data.
+func (m *metricPingPacketsReceived) init() {
+	m.data.SetName("ping.packets.received")
+	m.data.SetDescription("Number of packets received")
+	m.data.SetUnit("{packet}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityUnspecified)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingPacketsReceived) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64, replySourceMismatchAttributeValue bool) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
+	dp.Attributes().PutInt("net.ip.version", netIPVersionAttributeValue)
+	dp.Attributes().PutBool("reply.source_mismatch", replySourceMismatchAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingPacketsReceived) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingPacketsReceived) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingPacketsReceived(cfg MetricConfig) metricPingPacketsReceived {
+	m := metricPingPacketsReceived{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingPacketsSent struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.packets.sent metric with initial data.
+func (m *metricPingPacketsSent) init() {
+	m.data.SetName("ping.packets.sent")
+	m.data.SetDescription("Number of packets sent")
+	m.data.SetUnit("{packet}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityUnspecified)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingPacketsSent) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
+	dp.Attributes().PutInt("net.ip.version", netIPVersionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingPacketsSent) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingPacketsSent) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingPacketsSent(cfg MetricConfig) metricPingPacketsSent {
+	m := metricPingPacketsSent{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingPathMtu struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.path_mtu metric with initial data.
+func (m *metricPingPathMtu) init() {
+	m.data.SetName("ping.path_mtu")
+	m.data.SetDescription("Largest packet reaching the target without fragmentation including its IP and ICMP headers, probed with path_mtu_discovery")
+	m.data.SetUnit("By")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingPathMtu) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
+	dp.Attributes().PutInt("net.ip.version", netIPVersionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingPathMtu) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingPathMtu) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingPathMtu(cfg MetricConfig) metricPingPathMtu {
+	m := metricPingPathMtu{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingProbePanics struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.probe.panics metric with initial data.
+func (m *metricPingProbePanics) init() {
+	m.data.SetName("ping.probe.panics")
+	m.data.SetDescription("Number of probes of the target that panicked and were recovered, reported for targets once one of their probes panicked")
+	m.data.SetUnit("{panic}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingProbePanics) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingProbePanics) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingProbePanics) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingProbePanics(cfg MetricConfig) metricPingProbePanics {
+	m := metricPingProbePanics{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingReplyTTL struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.reply.ttl metric with initial data.
+func (m *metricPingReplyTTL) init() {
+	m.data.SetName("ping.reply.ttl")
+	m.data.SetDescription("TTL or hop limit of the latest reply, which changes along with the number of hops on the return path")
+	m.data.SetUnit("{hop}")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingReplyTTL) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
+	dp.Attributes().PutInt("net.ip.version", netIPVersionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingReplyTTL) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingReplyTTL) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingReplyTTL(cfg MetricConfig) metricPingReplyTTL {
+	m := metricPingReplyTTL{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingScheduleDrift struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	config   MetricConfig   // metric config provided by user.
 	capacity int            // max observed number of data points added to the metric.
 }
 
-// init fills ping.duration metric with initial data.
-func (m *metricPingDuration) init() {
-	m.data.SetName("ping.duration")
-	m.data.SetDescription("Round-trip time for ping packets")
+// init fills ping.schedule.drift metric with initial data.
+func (m *metricPingScheduleDrift) init() {
+	m.data.SetName("ping.schedule.drift")
+	m.data.SetDescription("Time the packets of the probe started to be sent after the tick of collection_interval it was scheduled at, one data point per probe")
 	m.data.SetUnit("ms")
 	m.data.SetEmptyGauge()
 	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
 }
 
-func (m *metricPingDuration) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string) {
+func (m *metricPingScheduleDrift) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string) {
 	if !m.config.Enabled {
 		return
 	}
@@ -120,18 +1636,17 @@ func (m *metricPingDuration) recordDataPoint(start pcommon.Timestamp, ts pcommon
 	dp.SetTimestamp(ts)
 	dp.SetDoubleValue(val)
 	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
-	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
 }
 
 // updateCapacity saves max length of data point slices that will be used for the slice capacity.
-func (m *metricPingDuration) updateCapacity() {
+func (m *metricPingScheduleDrift) updateCapacity() {
 	if m.data.Gauge().DataPoints().Len() > m.capacity {
 		m.capacity = m.data.Gauge().DataPoints().Len()
 	}
 }
 
 // emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
-func (m *metricPingDuration) emit(metrics pmetric.MetricSlice) {
+func (m *metricPingScheduleDrift) emit(metrics pmetric.MetricSlice) {
 	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
 		m.updateCapacity()
 		m.data.MoveTo(metrics.AppendEmpty())
@@ -139,8 +1654,8 @@ func (m *metricPingDuration) emit(metrics pmetric.MetricSlice) {
 	}
 }
 
-func newMetricPingDuration(cfg MetricConfig) metricPingDuration {
-	m := metricPingDuration{config: cfg}
+func newMetricPingScheduleDrift(cfg MetricConfig) metricPingScheduleDrift {
+	m := metricPingScheduleDrift{config: cfg}
 	if cfg.Enabled {
 		m.data = pmetric.NewMetric()
 		m.init()
@@ -148,22 +1663,22 @@ func newMetricPingDuration(cfg MetricConfig) metricPingDuration {
 	return m
 }
 
-type metricPingDurationAvg struct {
+type metricPingSizeSweepDuration struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	config   MetricConfig   // metric config provided by user.
 	capacity int            // max observed number of data points added to the metric.
 }
 
-// init fills ping.duration.avg metric with initial data.
-func (m *metricPingDurationAvg) init() {
-	m.data.SetName("ping.duration.avg")
-	m.data.SetDescription("Average round-trip time")
+// init fills ping.size_sweep.duration metric with initial data.
+func (m *metricPingSizeSweepDuration) init() {
+	m.data.SetName("ping.size_sweep.duration")
+	m.data.SetDescription("Average round-trip time of the packets of one size, probed with packet_sizes")
 	m.data.SetUnit("ms")
 	m.data.SetEmptyGauge()
 	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
 }
 
-func (m *metricPingDurationAvg) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string) {
+func (m *metricPingSizeSweepDuration) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64, packetSizeAttributeValue int64) {
 	if !m.config.Enabled {
 		return
 	}
@@ -173,17 +1688,19 @@ func (m *metricPingDurationAvg) recordDataPoint(start pcommon.Timestamp, ts pcom
 	dp.SetDoubleValue(val)
 	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
 	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
+	dp.Attributes().PutInt("net.ip.version", netIPVersionAttributeValue)
+	dp.Attributes().PutInt("packet.size", packetSizeAttributeValue)
 }
 
 // updateCapacity saves max length of data point slices that will be used for the slice capacity.
-func (m *metricPingDurationAvg) updateCapacity() {
+func (m *metricPingSizeSweepDuration) updateCapacity() {
 	if m.data.Gauge().DataPoints().Len() > m.capacity {
 		m.capacity = m.data.Gauge().DataPoints().Len()
 	}
 }
 
 // emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
-func (m *metricPingDurationAvg) emit(metrics pmetric.MetricSlice) {
+func (m *metricPingSizeSweepDuration) emit(metrics pmetric.MetricSlice) {
 	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
 		m.updateCapacity()
 		m.data.MoveTo(metrics.AppendEmpty())
@@ -191,8 +1708,8 @@ func (m *metricPingDurationAvg) emit(metrics pmetric.MetricSlice) {
 	}
 }
 
-func newMetricPingDurationAvg(cfg MetricConfig) metricPingDurationAvg {
-	m := metricPingDurationAvg{config: cfg}
+func newMetricPingSizeSweepDuration(cfg MetricConfig) metricPingSizeSweepDuration {
+	m := metricPingSizeSweepDuration{config: cfg}
 	if cfg.Enabled {
 		m.data = pmetric.NewMetric()
 		m.init()
@@ -200,22 +1717,22 @@ func newMetricPingDurationAvg(cfg MetricConfig) metricPingDurationAvg {
 	return m
 }
 
-type metricPingDurationMax struct {
+type metricPingSizeSweepPacketLoss struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	config   MetricConfig   // metric config provided by user.
 	capacity int            // max observed number of data points added to the metric.
 }
 
-// init fills ping.duration.max metric with initial data.
-func (m *metricPingDurationMax) init() {
-	m.data.SetName("ping.duration.max")
-	m.data.SetDescription("Maximum round-trip time")
-	m.data.SetUnit("ms")
+// init fills ping.size_sweep.packet_loss metric with initial data.
+func (m *metricPingSizeSweepPacketLoss) init() {
+	m.data.SetName("ping.size_sweep.packet_loss")
+	m.data.SetDescription("Ratio of packets of one size lost, probed with packet_sizes")
+	m.data.SetUnit("1")
 	m.data.SetEmptyGauge()
 	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
 }
 
-func (m *metricPingDurationMax) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string) {
+func (m *metricPingSizeSweepPacketLoss) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64, packetSizeAttributeValue int64) {
 	if !m.config.Enabled {
 		return
 	}
@@ -225,17 +1742,19 @@ func (m *metricPingDurationMax) recordDataPoint(start pcommon.Timestamp, ts pcom
 	dp.SetDoubleValue(val)
 	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
 	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
+	dp.Attributes().PutInt("net.ip.version", netIPVersionAttributeValue)
+	dp.Attributes().PutInt("packet.size", packetSizeAttributeValue)
 }
 
 // updateCapacity saves max length of data point slices that will be used for the slice capacity.
-func (m *metricPingDurationMax) updateCapacity() {
+func (m *metricPingSizeSweepPacketLoss) updateCapacity() {
 	if m.data.Gauge().DataPoints().Len() > m.capacity {
 		m.capacity = m.data.Gauge().DataPoints().Len()
 	}
 }
 
 // emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
-func (m *metricPingDurationMax) emit(metrics pmetric.MetricSlice) {
+func (m *metricPingSizeSweepPacketLoss) emit(metrics pmetric.MetricSlice) {
 	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
 		m.updateCapacity()
 		m.data.MoveTo(metrics.AppendEmpty())
@@ -243,8 +1762,8 @@ func (m *metricPingDurationMax) emit(metrics pmetric.MetricSlice) {
 	}
 }
 
-func newMetricPingDurationMax(cfg MetricConfig) metricPingDurationMax {
-	m := metricPingDurationMax{config: cfg}
+func newMetricPingSizeSweepPacketLoss(cfg MetricConfig) metricPingSizeSweepPacketLoss {
+	m := metricPingSizeSweepPacketLoss{config: cfg}
 	if cfg.Enabled {
 		m.data = pmetric.NewMetric()
 		m.init()
@@ -252,42 +1771,42 @@ func newMetricPingDurationMax(cfg MetricConfig) metricPingDurationMax {
 	return m
 }
 
-type metricPingDurationMin struct {
+type metricPingSLAStatus struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	config   MetricConfig   // metric config provided by user.
 	capacity int            // max observed number of data points added to the metric.
 }
 
-// init fills ping.duration.min metric with initial data.
-func (m *metricPingDurationMin) init() {
-	m.data.SetName("ping.duration.min")
-	m.data.SetDescription("Minimum round-trip time")
-	m.data.SetUnit("ms")
+// init fills ping.sla.status metric with initial data.
+func (m *metricPingSLAStatus) init() {
+	m.data.SetName("ping.sla.status")
+	m.data.SetDescription("Whether the scrape's result was within the target's SLA thresholds for the time of day, 1 if it was, checked with sla")
+	m.data.SetUnit("1")
 	m.data.SetEmptyGauge()
 	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
 }
 
-func (m *metricPingDurationMin) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string) {
+func (m *metricPingSLAStatus) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string, sLAWindowAttributeValue string) {
 	if !m.config.Enabled {
 		return
 	}
 	dp := m.data.Gauge().DataPoints().AppendEmpty()
 	dp.SetStartTimestamp(start)
 	dp.SetTimestamp(ts)
-	dp.SetDoubleValue(val)
+	dp.SetIntValue(val)
 	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
-	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
+	dp.Attributes().PutStr("sla.window", sLAWindowAttributeValue)
 }
 
 // updateCapacity saves max length of data point slices that will be used for the slice capacity.
-func (m *metricPingDurationMin) updateCapacity() {
+func (m *metricPingSLAStatus) updateCapacity() {
 	if m.data.Gauge().DataPoints().Len() > m.capacity {
 		m.capacity = m.data.Gauge().DataPoints().Len()
 	}
 }
 
 // emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
-func (m *metricPingDurationMin) emit(metrics pmetric.MetricSlice) {
+func (m *metricPingSLAStatus) emit(metrics pmetric.MetricSlice) {
 	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
 		m.updateCapacity()
 		m.data.MoveTo(metrics.AppendEmpty())
@@ -295,8 +1814,8 @@ func (m *metricPingDurationMin) emit(metrics pmetric.MetricSlice) {
 	}
 }
 
-func newMetricPingDurationMin(cfg MetricConfig) metricPingDurationMin {
-	m := metricPingDurationMin{config: cfg}
+func newMetricPingSLAStatus(cfg MetricConfig) metricPingSLAStatus {
+	m := metricPingSLAStatus{config: cfg}
 	if cfg.Enabled {
 		m.data = pmetric.NewMetric()
 		m.init()
@@ -304,22 +1823,75 @@ func newMetricPingDurationMin(cfg MetricConfig) metricPingDurationMin {
 	return m
 }
 
-type metricPingDurationStddev struct {
+type metricPingStateTransitions struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	config   MetricConfig   // metric config provided by user.
 	capacity int            // max observed number of data points added to the metric.
 }
 
-// init fills ping.duration.stddev metric with initial data.
-func (m *metricPingDurationStddev) init() {
-	m.data.SetName("ping.duration.stddev")
-	m.data.SetDescription("Standard deviation of round-trip times")
+// init fills ping.state_transitions metric with initial data.
+func (m *metricPingStateTransitions) init() {
+	m.data.SetName("ping.state_transitions")
+	m.data.SetDescription("Number of changes of the target between up and down, a scrape that failed or lost every packet being down")
+	m.data.SetUnit("{transition}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityUnspecified)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPingStateTransitions) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPingStateTransitions) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPingStateTransitions) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPingStateTransitions(cfg MetricConfig) metricPingStateTransitions {
+	m := metricPingStateTransitions{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPingTracerouteHopDuration struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills ping.traceroute.hop.duration metric with initial data.
+func (m *metricPingTracerouteHopDuration) init() {
+	m.data.SetName("ping.traceroute.hop.duration")
+	m.data.SetDescription("Average round-trip time of the packets of one hop of the latest traceroute, for the hops that answered")
 	m.data.SetUnit("ms")
 	m.data.SetEmptyGauge()
 	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
 }
 
-func (m *metricPingDurationStddev) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string) {
+func (m *metricPingTracerouteHopDuration) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, tracerouteHopAttributeValue int64, tracerouteHopIPAttributeValue string, options ...MetricAttributeOption) {
 	if !m.config.Enabled {
 		return
 	}
@@ -328,18 +1900,22 @@ func (m *metricPingDurationStddev) recordDataPoint(start pcommon.Timestamp, ts p
 	dp.SetTimestamp(ts)
 	dp.SetDoubleValue(val)
 	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
-	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
+	dp.Attributes().PutInt("traceroute.hop", tracerouteHopAttributeValue)
+	dp.Attributes().PutStr("traceroute.hop.ip", tracerouteHopIPAttributeValue)
+	for _, op := range options {
+		op.apply(dp)
+	}
 }
 
 // updateCapacity saves max length of data point slices that will be used for the slice capacity.
-func (m *metricPingDurationStddev) updateCapacity() {
+func (m *metricPingTracerouteHopDuration) updateCapacity() {
 	if m.data.Gauge().DataPoints().Len() > m.capacity {
 		m.capacity = m.data.Gauge().DataPoints().Len()
 	}
 }
 
 // emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
-func (m *metricPingDurationStddev) emit(metrics pmetric.MetricSlice) {
+func (m *metricPingTracerouteHopDuration) emit(metrics pmetric.MetricSlice) {
 	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
 		m.updateCapacity()
 		m.data.MoveTo(metrics.AppendEmpty())
@@ -347,8 +1923,8 @@ func (m *metricPingDurationStddev) emit(metrics pmetric.MetricSlice) {
 	}
 }
 
-func newMetricPingDurationStddev(cfg MetricConfig) metricPingDurationStddev {
-	m := metricPingDurationStddev{config: cfg}
+func newMetricPingTracerouteHopDuration(cfg MetricConfig) metricPingTracerouteHopDuration {
+	m := metricPingTracerouteHopDuration{config: cfg}
 	if cfg.Enabled {
 		m.data = pmetric.NewMetric()
 		m.init()
@@ -356,54 +1932,55 @@ func newMetricPingDurationStddev(cfg MetricConfig) metricPingDurationStddev {
 	return m
 }
 
-type metricPingErrors struct {
+type metricPingTracerouteHopPacketLoss struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	config   MetricConfig   // metric config provided by user.
 	capacity int            // max observed number of data points added to the metric.
 }
 
-// init fills ping.errors metric with initial data.
-func (m *metricPingErrors) init() {
-	m.data.SetName("ping.errors")
-	m.data.SetDescription("Number of errors encountered")
-	m.data.SetUnit("{error}")
-	m.data.SetEmptySum()
-	m.data.Sum().SetIsMonotonic(true)
-	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityUnspecified)
-	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+// init fills ping.traceroute.hop.packet_loss metric with initial data.
+func (m *metricPingTracerouteHopPacketLoss) init() {
+	m.data.SetName("ping.traceroute.hop.packet_loss")
+	m.data.SetDescription("Ratio of the packets of one hop of the latest traceroute that were not answered")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
 }
 
-func (m *metricPingErrors) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, errorTypeAttributeValue string) {
+func (m *metricPingTracerouteHopPacketLoss) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, tracerouteHopAttributeValue int64, tracerouteHopIPAttributeValue string, options ...MetricAttributeOption) {
 	if !m.config.Enabled {
 		return
 	}
-	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
 	dp.SetStartTimestamp(start)
 	dp.SetTimestamp(ts)
-	dp.SetIntValue(val)
+	dp.SetDoubleValue(val)
 	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
-	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
-	dp.Attributes().PutStr("error.type", errorTypeAttributeValue)
+	dp.Attributes().PutInt("traceroute.hop", tracerouteHopAttributeValue)
+	dp.Attributes().PutStr("traceroute.hop.ip", tracerouteHopIPAttributeValue)
+	for _, op := range options {
+		op.apply(dp)
+	}
 }
 
 // updateCapacity saves max length of data point slices that will be used for the slice capacity.
-func (m *metricPingErrors) updateCapacity() {
-	if m.data.Sum().DataPoints().Len() > m.capacity {
-		m.capacity = m.data.Sum().DataPoints().Len()
+func (m *metricPingTracerouteHopPacketLoss) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
 	}
 }
 
 // emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
-func (m *metricPingErrors) emit(metrics pmetric.MetricSlice) {
-	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+func (m *metricPingTracerouteHopPacketLoss) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
 		m.updateCapacity()
 		m.data.MoveTo(metrics.AppendEmpty())
 		m.init()
 	}
 }
 
-func newMetricPingErrors(cfg MetricConfig) metricPingErrors {
-	m := metricPingErrors{config: cfg}
+func newMetricPingTracerouteHopPacketLoss(cfg MetricConfig) metricPingTracerouteHopPacketLoss {
+	m := metricPingTracerouteHopPacketLoss{config: cfg}
 	if cfg.Enabled {
 		m.data = pmetric.NewMetric()
 		m.init()
@@ -411,42 +1988,42 @@ func newMetricPingErrors(cfg MetricConfig) metricPingErrors {
 	return m
 }
 
-type metricPingPacketLoss struct {
+type metricPingTracerouteHopCount struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	config   MetricConfig   // metric config provided by user.
 	capacity int            // max observed number of data points added to the metric.
 }
 
-// init fills ping.packet_loss metric with initial data.
-func (m *metricPingPacketLoss) init() {
-	m.data.SetName("ping.packet_loss")
-	m.data.SetDescription("Ratio of packets lost")
-	m.data.SetUnit("1")
+// init fills ping.traceroute.hop_count metric with initial data.
+func (m *metricPingTracerouteHopCount) init() {
+	m.data.SetName("ping.traceroute.hop_count")
+	m.data.SetDescription("Number of hops of the latest traceroute, up to the target if it answered, reported for targets with traceroute only")
+	m.data.SetUnit("{hop}")
 	m.data.SetEmptyGauge()
 	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
 }
 
-func (m *metricPingPacketLoss) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string) {
+func (m *metricPingTracerouteHopCount) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string, tracerouteReachedAttributeValue bool) {
 	if !m.config.Enabled {
 		return
 	}
 	dp := m.data.Gauge().DataPoints().AppendEmpty()
 	dp.SetStartTimestamp(start)
 	dp.SetTimestamp(ts)
-	dp.SetDoubleValue(val)
+	dp.SetIntValue(val)
 	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
-	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
+	dp.Attributes().PutBool("traceroute.reached", tracerouteReachedAttributeValue)
 }
 
 // updateCapacity saves max length of data point slices that will be used for the slice capacity.
-func (m *metricPingPacketLoss) updateCapacity() {
+func (m *metricPingTracerouteHopCount) updateCapacity() {
 	if m.data.Gauge().DataPoints().Len() > m.capacity {
 		m.capacity = m.data.Gauge().DataPoints().Len()
 	}
 }
 
 // emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
-func (m *metricPingPacketLoss) emit(metrics pmetric.MetricSlice) {
+func (m *metricPingTracerouteHopCount) emit(metrics pmetric.MetricSlice) {
 	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
 		m.updateCapacity()
 		m.data.MoveTo(metrics.AppendEmpty())
@@ -454,8 +2031,8 @@ func (m *metricPingPacketLoss) emit(metrics pmetric.MetricSlice) {
 	}
 }
 
-func newMetricPingPacketLoss(cfg MetricConfig) metricPingPacketLoss {
-	m := metricPingPacketLoss{config: cfg}
+func newMetricPingTracerouteHopCount(cfg MetricConfig) metricPingTracerouteHopCount {
+	m := metricPingTracerouteHopCount{config: cfg}
 	if cfg.Enabled {
 		m.data = pmetric.NewMetric()
 		m.init()
@@ -463,53 +2040,51 @@ func newMetricPingPacketLoss(cfg MetricConfig) metricPingPacketLoss {
 	return m
 }
 
-type metricPingPacketsReceived struct {
+type metricPingTunnelOverhead struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	config   MetricConfig   // metric config provided by user.
 	capacity int            // max observed number of data points added to the metric.
 }
 
-// init fills ping.packets.received metric with initial data.
-func (m *metricPingPacketsReceived) init() {
-	m.data.SetName("ping.packets.received")
-	m.data.SetDescription("Number of packets received")
-	m.data.SetUnit("{packet}")
-	m.data.SetEmptySum()
-	m.data.Sum().SetIsMonotonic(true)
-	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityUnspecified)
-	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+// init fills ping.tunnel.overhead metric with initial data.
+func (m *metricPingTunnelOverhead) init() {
+	m.data.SetName("ping.tunnel.overhead")
+	m.data.SetDescription("Average round-trip time through the tunnel minus that to its underlay address, probed with underlay")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
 }
 
-func (m *metricPingPacketsReceived) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string, netPeerIPAttributeValue string) {
+func (m *metricPingTunnelOverhead) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, tunnelUnderlayAttributeValue string) {
 	if !m.config.Enabled {
 		return
 	}
-	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
 	dp.SetStartTimestamp(start)
 	dp.SetTimestamp(ts)
-	dp.SetIntValue(val)
+	dp.SetDoubleValue(val)
 	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
-	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
+	dp.Attributes().PutStr("tunnel.underlay", tunnelUnderlayAttributeValue)
 }
 
 // updateCapacity saves max length of data point slices that will be used for the slice capacity.
-func (m *metricPingPacketsReceived) updateCapacity() {
-	if m.data.Sum().DataPoints().Len() > m.capacity {
-		m.capacity = m.data.Sum().DataPoints().Len()
+func (m *metricPingTunnelOverhead) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
 	}
 }
 
 // emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
-func (m *metricPingPacketsReceived) emit(metrics pmetric.MetricSlice) {
-	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+func (m *metricPingTunnelOverhead) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
 		m.updateCapacity()
 		m.data.MoveTo(metrics.AppendEmpty())
 		m.init()
 	}
 }
 
-func newMetricPingPacketsReceived(cfg MetricConfig) metricPingPacketsReceived {
-	m := metricPingPacketsReceived{config: cfg}
+func newMetricPingTunnelOverhead(cfg MetricConfig) metricPingTunnelOverhead {
+	m := metricPingTunnelOverhead{config: cfg}
 	if cfg.Enabled {
 		m.data = pmetric.NewMetric()
 		m.init()
@@ -517,53 +2092,51 @@ func newMetricPingPacketsReceived(cfg MetricConfig) metricPingPacketsReceived {
 	return m
 }
 
-type metricPingPacketsSent struct {
+type metricPingTunnelPacketLossDelta struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	config   MetricConfig   // metric config provided by user.
 	capacity int            // max observed number of data points added to the metric.
 }
 
-// init fills ping.packets.sent metric with initial data.
-func (m *metricPingPacketsSent) init() {
-	m.data.SetName("ping.packets.sent")
-	m.data.SetDescription("Number of packets sent")
-	m.data.SetUnit("{packet}")
-	m.data.SetEmptySum()
-	m.data.Sum().SetIsMonotonic(true)
-	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityUnspecified)
-	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+// init fills ping.tunnel.packet_loss_delta metric with initial data.
+func (m *metricPingTunnelPacketLossDelta) init() {
+	m.data.SetName("ping.tunnel.packet_loss_delta")
+	m.data.SetDescription("Ratio of packets lost through the tunnel minus that to its underlay address, probed with underlay")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
 }
 
-func (m *metricPingPacketsSent) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string, netPeerIPAttributeValue string) {
+func (m *metricPingTunnelPacketLossDelta) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, tunnelUnderlayAttributeValue string) {
 	if !m.config.Enabled {
 		return
 	}
-	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
 	dp.SetStartTimestamp(start)
 	dp.SetTimestamp(ts)
-	dp.SetIntValue(val)
+	dp.SetDoubleValue(val)
 	dp.Attributes().PutStr("net.peer.name", netPeerNameAttributeValue)
-	dp.Attributes().PutStr("net.peer.ip", netPeerIPAttributeValue)
+	dp.Attributes().PutStr("tunnel.underlay", tunnelUnderlayAttributeValue)
 }
 
 // updateCapacity saves max length of data point slices that will be used for the slice capacity.
-func (m *metricPingPacketsSent) updateCapacity() {
-	if m.data.Sum().DataPoints().Len() > m.capacity {
-		m.capacity = m.data.Sum().DataPoints().Len()
+func (m *metricPingTunnelPacketLossDelta) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
 	}
 }
 
 // emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
-func (m *metricPingPacketsSent) emit(metrics pmetric.MetricSlice) {
-	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+func (m *metricPingTunnelPacketLossDelta) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
 		m.updateCapacity()
 		m.data.MoveTo(metrics.AppendEmpty())
 		m.init()
 	}
 }
 
-func newMetricPingPacketsSent(cfg MetricConfig) metricPingPacketsSent {
-	m := metricPingPacketsSent{config: cfg}
+func newMetricPingTunnelPacketLossDelta(cfg MetricConfig) metricPingTunnelPacketLossDelta {
+	m := metricPingTunnelPacketLossDelta{config: cfg}
 	if cfg.Enabled {
 		m.data = pmetric.NewMetric()
 		m.init()
@@ -574,20 +2147,46 @@ func newMetricPingPacketsSent(cfg MetricConfig) metricPingPacketsSent {
 // MetricsBuilder provides an interface for scrapers to report metrics while taking care of all the transformations
 // required to produce metric representation defined in metadata and user config.
 type MetricsBuilder struct {
-	config                    MetricsBuilderConfig // config of the metrics builder.
-	startTime                 pcommon.Timestamp    // start time that will be applied to all recorded data points.
-	metricsCapacity           int                  // maximum observed number of metrics per resource.
-	metricsBuffer             pmetric.Metrics      // accumulates metrics data before emitting.
-	buildInfo                 component.BuildInfo  // contains version information.
-	metricPingDuration        metricPingDuration
-	metricPingDurationAvg     metricPingDurationAvg
-	metricPingDurationMax     metricPingDurationMax
-	metricPingDurationMin     metricPingDurationMin
-	metricPingDurationStddev  metricPingDurationStddev
-	metricPingErrors          metricPingErrors
-	metricPingPacketLoss      metricPingPacketLoss
-	metricPingPacketsReceived metricPingPacketsReceived
-	metricPingPacketsSent     metricPingPacketsSent
+	config                              MetricsBuilderConfig // config of the metrics builder.
+	startTime                           pcommon.Timestamp    // start time that will be applied to all recorded data points.
+	metricsCapacity                     int                  // maximum observed number of metrics per resource.
+	metricsBuffer                       pmetric.Metrics      // accumulates metrics data before emitting.
+	buildInfo                           component.BuildInfo  // contains version information.
+	metricConnectivityState             metricConnectivityState
+	metricPingBusinessHoursDowntime     metricPingBusinessHoursDowntime
+	metricPingConsecutiveFailures       metricPingConsecutiveFailures
+	metricPingDNSLookupDuration         metricPingDNSLookupDuration
+	metricPingDowntime                  metricPingDowntime
+	metricPingDscpDuration              metricPingDscpDuration
+	metricPingDscpPacketLoss            metricPingDscpPacketLoss
+	metricPingDuration                  metricPingDuration
+	metricPingDurationAvg               metricPingDurationAvg
+	metricPingDurationHeatmap           metricPingDurationHeatmap
+	metricPingDurationMax               metricPingDurationMax
+	metricPingDurationMedian            metricPingDurationMedian
+	metricPingDurationMin               metricPingDurationMin
+	metricPingDurationStddev            metricPingDurationStddev
+	metricPingDurationTrimmedMean       metricPingDurationTrimmedMean
+	metricPingErrors                    metricPingErrors
+	metricPingJitter                    metricPingJitter
+	metricPingManagementPlaneResponding metricPingManagementPlaneResponding
+	metricPingPacketLoss                metricPingPacketLoss
+	metricPingPacketLossPercent         metricPingPacketLossPercent
+	metricPingPacketsReceived           metricPingPacketsReceived
+	metricPingPacketsSent               metricPingPacketsSent
+	metricPingPathMtu                   metricPingPathMtu
+	metricPingProbePanics               metricPingProbePanics
+	metricPingReplyTTL                  metricPingReplyTTL
+	metricPingScheduleDrift             metricPingScheduleDrift
+	metricPingSizeSweepDuration         metricPingSizeSweepDuration
+	metricPingSizeSweepPacketLoss       metricPingSizeSweepPacketLoss
+	metricPingSLAStatus                 metricPingSLAStatus
+	metricPingStateTransitions          metricPingStateTransitions
+	metricPingTracerouteHopDuration     metricPingTracerouteHopDuration
+	metricPingTracerouteHopPacketLoss   metricPingTracerouteHopPacketLoss
+	metricPingTracerouteHopCount        metricPingTracerouteHopCount
+	metricPingTunnelOverhead            metricPingTunnelOverhead
+	metricPingTunnelPacketLossDelta     metricPingTunnelPacketLossDelta
 }
 
 // MetricBuilderOption applies changes to default metrics builder.
@@ -609,19 +2208,45 @@ func WithStartTime(startTime pcommon.Timestamp) MetricBuilderOption {
 }
 func NewMetricsBuilder(mbc MetricsBuilderConfig, settings receiver.Settings, options ...MetricBuilderOption) *MetricsBuilder {
 	mb := &MetricsBuilder{
-		config:                    mbc,
-		startTime:                 pcommon.NewTimestampFromTime(time.Now()),
-		metricsBuffer:             pmetric.NewMetrics(),
-		buildInfo:                 settings.BuildInfo,
-		metricPingDuration:        newMetricPingDuration(mbc.Metrics.PingDuration),
-		metricPingDurationAvg:     newMetricPingDurationAvg(mbc.Metrics.PingDurationAvg),
-		metricPingDurationMax:     newMetricPingDurationMax(mbc.Metrics.PingDurationMax),
-		metricPingDurationMin:     newMetricPingDurationMin(mbc.Metrics.PingDurationMin),
-		metricPingDurationStddev:  newMetricPingDurationStddev(mbc.Metrics.PingDurationStddev),
-		metricPingErrors:          newMetricPingErrors(mbc.Metrics.PingErrors),
-		metricPingPacketLoss:      newMetricPingPacketLoss(mbc.Metrics.PingPacketLoss),
-		metricPingPacketsReceived: newMetricPingPacketsReceived(mbc.Metrics.PingPacketsReceived),
-		metricPingPacketsSent:     newMetricPingPacketsSent(mbc.Metrics.PingPacketsSent),
+		config:                              mbc,
+		startTime:                           pcommon.NewTimestampFromTime(time.Now()),
+		metricsBuffer:                       pmetric.NewMetrics(),
+		buildInfo:                           settings.BuildInfo,
+		metricConnectivityState:             newMetricConnectivityState(mbc.Metrics.ConnectivityState),
+		metricPingBusinessHoursDowntime:     newMetricPingBusinessHoursDowntime(mbc.Metrics.PingBusinessHoursDowntime),
+		metricPingConsecutiveFailures:       newMetricPingConsecutiveFailures(mbc.Metrics.PingConsecutiveFailures),
+		metricPingDNSLookupDuration:         newMetricPingDNSLookupDuration(mbc.Metrics.PingDNSLookupDuration),
+		metricPingDowntime:                  newMetricPingDowntime(mbc.Metrics.PingDowntime),
+		metricPingDscpDuration:              newMetricPingDscpDuration(mbc.Metrics.PingDscpDuration),
+		metricPingDscpPacketLoss:            newMetricPingDscpPacketLoss(mbc.Metrics.PingDscpPacketLoss),
+		metricPingDuration:                  newMetricPingDuration(mbc.Metrics.PingDuration),
+		metricPingDurationAvg:               newMetricPingDurationAvg(mbc.Metrics.PingDurationAvg),
+		metricPingDurationHeatmap:           newMetricPingDurationHeatmap(mbc.Metrics.PingDurationHeatmap),
+		metricPingDurationMax:               newMetricPingDurationMax(mbc.Metrics.PingDurationMax),
+		metricPingDurationMedian:            newMetricPingDurationMedian(mbc.Metrics.PingDurationMedian),
+		metricPingDurationMin:               newMetricPingDurationMin(mbc.Metrics.PingDurationMin),
+		metricPingDurationStddev:            newMetricPingDurationStddev(mbc.Metrics.PingDurationStddev),
+		metricPingDurationTrimmedMean:       newMetricPingDurationTrimmedMean(mbc.Metrics.PingDurationTrimmedMean),
+		metricPingErrors:                    newMetricPingErrors(mbc.Metrics.PingErrors),
+		metricPingJitter:                    newMetricPingJitter(mbc.Metrics.PingJitter),
+		metricPingManagementPlaneResponding: newMetricPingManagementPlaneResponding(mbc.Metrics.PingManagementPlaneResponding),
+		metricPingPacketLoss:                newMetricPingPacketLoss(mbc.Metrics.PingPacketLoss),
+		metricPingPacketLossPercent:         newMetricPingPacketLossPercent(mbc.Metrics.PingPacketLossPercent),
+		metricPingPacketsReceived:           newMetricPingPacketsReceived(mbc.Metrics.PingPacketsReceived),
+		metricPingPacketsSent:               newMetricPingPacketsSent(mbc.Metrics.PingPacketsSent),
+		metricPingPathMtu:                   newMetricPingPathMtu(mbc.Metrics.PingPathMtu),
+		metricPingProbePanics:               newMetricPingProbePanics(mbc.Metrics.PingProbePanics),
+		metricPingReplyTTL:                  newMetricPingReplyTTL(mbc.Metrics.PingReplyTTL),
+		metricPingScheduleDrift:             newMetricPingScheduleDrift(mbc.Metrics.PingScheduleDrift),
+		metricPingSizeSweepDuration:         newMetricPingSizeSweepDuration(mbc.Metrics.PingSizeSweepDuration),
+		metricPingSizeSweepPacketLoss:       newMetricPingSizeSweepPacketLoss(mbc.Metrics.PingSizeSweepPacketLoss),
+		metricPingSLAStatus:                 newMetricPingSLAStatus(mbc.Metrics.PingSLAStatus),
+		metricPingStateTransitions:          newMetricPingStateTransitions(mbc.Metrics.PingStateTransitions),
+		metricPingTracerouteHopDuration:     newMetricPingTracerouteHopDuration(mbc.Metrics.PingTracerouteHopDuration),
+		metricPingTracerouteHopPacketLoss:   newMetricPingTracerouteHopPacketLoss(mbc.Metrics.PingTracerouteHopPacketLoss),
+		metricPingTracerouteHopCount:        newMetricPingTracerouteHopCount(mbc.Metrics.PingTracerouteHopCount),
+		metricPingTunnelOverhead:            newMetricPingTunnelOverhead(mbc.Metrics.PingTunnelOverhead),
+		metricPingTunnelPacketLossDelta:     newMetricPingTunnelPacketLossDelta(mbc.Metrics.PingTunnelPacketLossDelta),
 	}
 
 	for _, op := range options {
@@ -688,15 +2313,41 @@ func (mb *MetricsBuilder) EmitForResource(options ...ResourceMetricsOption) {
 	ils.Scope().SetName(ScopeName)
 	ils.Scope().SetVersion(mb.buildInfo.Version)
 	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricConnectivityState.emit(ils.Metrics())
+	mb.metricPingBusinessHoursDowntime.emit(ils.Metrics())
+	mb.metricPingConsecutiveFailures.emit(ils.Metrics())
+	mb.metricPingDNSLookupDuration.emit(ils.Metrics())
+	mb.metricPingDowntime.emit(ils.Metrics())
+	mb.metricPingDscpDuration.emit(ils.Metrics())
+	mb.metricPingDscpPacketLoss.emit(ils.Metrics())
 	mb.metricPingDuration.emit(ils.Metrics())
 	mb.metricPingDurationAvg.emit(ils.Metrics())
+	mb.metricPingDurationHeatmap.emit(ils.Metrics())
 	mb.metricPingDurationMax.emit(ils.Metrics())
+	mb.metricPingDurationMedian.emit(ils.Metrics())
 	mb.metricPingDurationMin.emit(ils.Metrics())
 	mb.metricPingDurationStddev.emit(ils.Metrics())
+	mb.metricPingDurationTrimmedMean.emit(ils.Metrics())
 	mb.metricPingErrors.emit(ils.Metrics())
+	mb.metricPingJitter.emit(ils.Metrics())
+	mb.metricPingManagementPlaneResponding.emit(ils.Metrics())
 	mb.metricPingPacketLoss.emit(ils.Metrics())
+	mb.metricPingPacketLossPercent.emit(ils.Metrics())
 	mb.metricPingPacketsReceived.emit(ils.Metrics())
 	mb.metricPingPacketsSent.emit(ils.Metrics())
+	mb.metricPingPathMtu.emit(ils.Metrics())
+	mb.metricPingProbePanics.emit(ils.Metrics())
+	mb.metricPingReplyTTL.emit(ils.Metrics())
+	mb.metricPingScheduleDrift.emit(ils.Metrics())
+	mb.metricPingSizeSweepDuration.emit(ils.Metrics())
+	mb.metricPingSizeSweepPacketLoss.emit(ils.Metrics())
+	mb.metricPingSLAStatus.emit(ils.Metrics())
+	mb.metricPingStateTransitions.emit(ils.Metrics())
+	mb.metricPingTracerouteHopDuration.emit(ils.Metrics())
+	mb.metricPingTracerouteHopPacketLoss.emit(ils.Metrics())
+	mb.metricPingTracerouteHopCount.emit(ils.Metrics())
+	mb.metricPingTunnelOverhead.emit(ils.Metrics())
+	mb.metricPingTunnelPacketLossDelta.emit(ils.Metrics())
 
 	for _, op := range options {
 		op.apply(rm)
@@ -718,49 +2369,179 @@ func (mb *MetricsBuilder) Emit(options ...ResourceMetricsOption) pmetric.Metrics
 	return metrics
 }
 
+// RecordConnectivityStateDataPoint adds a data point to connectivity.state metric.
+func (mb *MetricsBuilder) RecordConnectivityStateDataPoint(ts pcommon.Timestamp, val int64, stateAttributeValue AttributeState) {
+	mb.metricConnectivityState.recordDataPoint(mb.startTime, ts, val, stateAttributeValue.String())
+}
+
+// RecordPingBusinessHoursDowntimeDataPoint adds a data point to ping.business_hours.downtime metric.
+func (mb *MetricsBuilder) RecordPingBusinessHoursDowntimeDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, businessHoursAttributeValue bool) {
+	mb.metricPingBusinessHoursDowntime.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, businessHoursAttributeValue)
+}
+
+// RecordPingConsecutiveFailuresDataPoint adds a data point to ping.consecutive_failures metric.
+func (mb *MetricsBuilder) RecordPingConsecutiveFailuresDataPoint(ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string) {
+	mb.metricPingConsecutiveFailures.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue)
+}
+
+// RecordPingDNSLookupDurationDataPoint adds a data point to ping.dns.lookup.duration metric.
+func (mb *MetricsBuilder) RecordPingDNSLookupDurationDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string) {
+	mb.metricPingDNSLookupDuration.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue)
+}
+
+// RecordPingDowntimeDataPoint adds a data point to ping.downtime metric.
+func (mb *MetricsBuilder) RecordPingDowntimeDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string) {
+	mb.metricPingDowntime.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue)
+}
+
+// RecordPingDscpDurationDataPoint adds a data point to ping.dscp.duration metric.
+func (mb *MetricsBuilder) RecordPingDscpDurationDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64, dscpAttributeValue int64) {
+	mb.metricPingDscpDuration.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue, netIPVersionAttributeValue, dscpAttributeValue)
+}
+
+// RecordPingDscpPacketLossDataPoint adds a data point to ping.dscp.packet_loss metric.
+func (mb *MetricsBuilder) RecordPingDscpPacketLossDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64, dscpAttributeValue int64) {
+	mb.metricPingDscpPacketLoss.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue, netIPVersionAttributeValue, dscpAttributeValue)
+}
+
 // RecordPingDurationDataPoint adds a data point to ping.duration metric.
-func (mb *MetricsBuilder) RecordPingDurationDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string) {
-	mb.metricPingDuration.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue)
+func (mb *MetricsBuilder) RecordPingDurationDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	mb.metricPingDuration.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue, netIPVersionAttributeValue)
 }
 
 // RecordPingDurationAvgDataPoint adds a data point to ping.duration.avg metric.
-func (mb *MetricsBuilder) RecordPingDurationAvgDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string) {
-	mb.metricPingDurationAvg.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue)
+func (mb *MetricsBuilder) RecordPingDurationAvgDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	mb.metricPingDurationAvg.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue, netIPVersionAttributeValue)
+}
+
+// RecordPingDurationHeatmapDataPoint adds a data point to ping.duration.heatmap metric.
+func (mb *MetricsBuilder) RecordPingDurationHeatmapDataPoint(ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string, durationBucketAttributeValue string) {
+	mb.metricPingDurationHeatmap.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, durationBucketAttributeValue)
 }
 
 // RecordPingDurationMaxDataPoint adds a data point to ping.duration.max metric.
-func (mb *MetricsBuilder) RecordPingDurationMaxDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string) {
-	mb.metricPingDurationMax.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue)
+func (mb *MetricsBuilder) RecordPingDurationMaxDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	mb.metricPingDurationMax.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue, netIPVersionAttributeValue)
+}
+
+// RecordPingDurationMedianDataPoint adds a data point to ping.duration.median metric.
+func (mb *MetricsBuilder) RecordPingDurationMedianDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	mb.metricPingDurationMedian.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue, netIPVersionAttributeValue)
 }
 
 // RecordPingDurationMinDataPoint adds a data point to ping.duration.min metric.
-func (mb *MetricsBuilder) RecordPingDurationMinDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string) {
-	mb.metricPingDurationMin.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue)
+func (mb *MetricsBuilder) RecordPingDurationMinDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	mb.metricPingDurationMin.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue, netIPVersionAttributeValue)
 }
 
 // RecordPingDurationStddevDataPoint adds a data point to ping.duration.stddev metric.
-func (mb *MetricsBuilder) RecordPingDurationStddevDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string) {
-	mb.metricPingDurationStddev.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue)
+func (mb *MetricsBuilder) RecordPingDurationStddevDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	mb.metricPingDurationStddev.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue, netIPVersionAttributeValue)
+}
+
+// RecordPingDurationTrimmedMeanDataPoint adds a data point to ping.duration.trimmed_mean metric.
+func (mb *MetricsBuilder) RecordPingDurationTrimmedMeanDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	mb.metricPingDurationTrimmedMean.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue, netIPVersionAttributeValue)
 }
 
 // RecordPingErrorsDataPoint adds a data point to ping.errors metric.
-func (mb *MetricsBuilder) RecordPingErrorsDataPoint(ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, errorTypeAttributeValue AttributeErrorType) {
-	mb.metricPingErrors.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue, errorTypeAttributeValue.String())
+func (mb *MetricsBuilder) RecordPingErrorsDataPoint(ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64, errorTypeAttributeValue AttributeErrorType, options ...MetricAttributeOption) {
+	mb.metricPingErrors.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue, netIPVersionAttributeValue, errorTypeAttributeValue.String(), options...)
+}
+
+// RecordPingJitterDataPoint adds a data point to ping.jitter metric.
+func (mb *MetricsBuilder) RecordPingJitterDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	mb.metricPingJitter.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue, netIPVersionAttributeValue)
+}
+
+// RecordPingManagementPlaneRespondingDataPoint adds a data point to ping.management_plane.responding metric.
+func (mb *MetricsBuilder) RecordPingManagementPlaneRespondingDataPoint(ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string) {
+	mb.metricPingManagementPlaneResponding.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue)
 }
 
 // RecordPingPacketLossDataPoint adds a data point to ping.packet_loss metric.
-func (mb *MetricsBuilder) RecordPingPacketLossDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string) {
-	mb.metricPingPacketLoss.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue)
+func (mb *MetricsBuilder) RecordPingPacketLossDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	mb.metricPingPacketLoss.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue, netIPVersionAttributeValue)
+}
+
+// RecordPingPacketLossPercentDataPoint adds a data point to ping.packet_loss.percent metric.
+func (mb *MetricsBuilder) RecordPingPacketLossPercentDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	mb.metricPingPacketLossPercent.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue, netIPVersionAttributeValue)
 }
 
 // RecordPingPacketsReceivedDataPoint adds a data point to ping.packets.received metric.
-func (mb *MetricsBuilder) RecordPingPacketsReceivedDataPoint(ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string, netPeerIPAttributeValue string) {
-	mb.metricPingPacketsReceived.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue)
+func (mb *MetricsBuilder) RecordPingPacketsReceivedDataPoint(ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64, replySourceMismatchAttributeValue bool) {
+	mb.metricPingPacketsReceived.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue, netIPVersionAttributeValue, replySourceMismatchAttributeValue)
 }
 
 // RecordPingPacketsSentDataPoint adds a data point to ping.packets.sent metric.
-func (mb *MetricsBuilder) RecordPingPacketsSentDataPoint(ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string, netPeerIPAttributeValue string) {
-	mb.metricPingPacketsSent.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue)
+func (mb *MetricsBuilder) RecordPingPacketsSentDataPoint(ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	mb.metricPingPacketsSent.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue, netIPVersionAttributeValue)
+}
+
+// RecordPingPathMtuDataPoint adds a data point to ping.path_mtu metric.
+func (mb *MetricsBuilder) RecordPingPathMtuDataPoint(ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	mb.metricPingPathMtu.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue, netIPVersionAttributeValue)
+}
+
+// RecordPingProbePanicsDataPoint adds a data point to ping.probe.panics metric.
+func (mb *MetricsBuilder) RecordPingProbePanicsDataPoint(ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string) {
+	mb.metricPingProbePanics.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue)
+}
+
+// RecordPingReplyTTLDataPoint adds a data point to ping.reply.ttl metric.
+func (mb *MetricsBuilder) RecordPingReplyTTLDataPoint(ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64) {
+	mb.metricPingReplyTTL.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue, netIPVersionAttributeValue)
+}
+
+// RecordPingScheduleDriftDataPoint adds a data point to ping.schedule.drift metric.
+func (mb *MetricsBuilder) RecordPingScheduleDriftDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string) {
+	mb.metricPingScheduleDrift.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue)
+}
+
+// RecordPingSizeSweepDurationDataPoint adds a data point to ping.size_sweep.duration metric.
+func (mb *MetricsBuilder) RecordPingSizeSweepDurationDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64, packetSizeAttributeValue int64) {
+	mb.metricPingSizeSweepDuration.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue, netIPVersionAttributeValue, packetSizeAttributeValue)
+}
+
+// RecordPingSizeSweepPacketLossDataPoint adds a data point to ping.size_sweep.packet_loss metric.
+func (mb *MetricsBuilder) RecordPingSizeSweepPacketLossDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, netPeerIPAttributeValue string, netIPVersionAttributeValue int64, packetSizeAttributeValue int64) {
+	mb.metricPingSizeSweepPacketLoss.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, netPeerIPAttributeValue, netIPVersionAttributeValue, packetSizeAttributeValue)
+}
+
+// RecordPingSLAStatusDataPoint adds a data point to ping.sla.status metric.
+func (mb *MetricsBuilder) RecordPingSLAStatusDataPoint(ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string, sLAWindowAttributeValue string) {
+	mb.metricPingSLAStatus.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, sLAWindowAttributeValue)
+}
+
+// RecordPingStateTransitionsDataPoint adds a data point to ping.state_transitions metric.
+func (mb *MetricsBuilder) RecordPingStateTransitionsDataPoint(ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string) {
+	mb.metricPingStateTransitions.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue)
+}
+
+// RecordPingTracerouteHopDurationDataPoint adds a data point to ping.traceroute.hop.duration metric.
+func (mb *MetricsBuilder) RecordPingTracerouteHopDurationDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, tracerouteHopAttributeValue int64, tracerouteHopIPAttributeValue string, options ...MetricAttributeOption) {
+	mb.metricPingTracerouteHopDuration.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, tracerouteHopAttributeValue, tracerouteHopIPAttributeValue, options...)
+}
+
+// RecordPingTracerouteHopPacketLossDataPoint adds a data point to ping.traceroute.hop.packet_loss metric.
+func (mb *MetricsBuilder) RecordPingTracerouteHopPacketLossDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, tracerouteHopAttributeValue int64, tracerouteHopIPAttributeValue string, options ...MetricAttributeOption) {
+	mb.metricPingTracerouteHopPacketLoss.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, tracerouteHopAttributeValue, tracerouteHopIPAttributeValue, options...)
+}
+
+// RecordPingTracerouteHopCountDataPoint adds a data point to ping.traceroute.hop_count metric.
+func (mb *MetricsBuilder) RecordPingTracerouteHopCountDataPoint(ts pcommon.Timestamp, val int64, netPeerNameAttributeValue string, tracerouteReachedAttributeValue bool) {
+	mb.metricPingTracerouteHopCount.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, tracerouteReachedAttributeValue)
+}
+
+// RecordPingTunnelOverheadDataPoint adds a data point to ping.tunnel.overhead metric.
+func (mb *MetricsBuilder) RecordPingTunnelOverheadDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, tunnelUnderlayAttributeValue string) {
+	mb.metricPingTunnelOverhead.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, tunnelUnderlayAttributeValue)
+}
+
+// RecordPingTunnelPacketLossDeltaDataPoint adds a data point to ping.tunnel.packet_loss_delta metric.
+func (mb *MetricsBuilder) RecordPingTunnelPacketLossDeltaDataPoint(ts pcommon.Timestamp, val float64, netPeerNameAttributeValue string, tunnelUnderlayAttributeValue string) {
+	mb.metricPingTunnelPacketLossDelta.recordDataPoint(mb.startTime, ts, val, netPeerNameAttributeValue, tunnelUnderlayAttributeValue)
 }
 
 // Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
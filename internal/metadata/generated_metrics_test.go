@@ -61,38 +61,131 @@ func TestMetricsBuilder(t *testing.T) {
 
 			defaultMetricsCount++
 			allMetricsCount++
-			mb.RecordPingDurationDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val")
+			mb.RecordConnectivityStateDataPoint(ts, 1, AttributeStateFull)
+
+			allMetricsCount++
+			mb.RecordPingBusinessHoursDowntimeDataPoint(ts, 1, "net.peer.name-val", true)
+
+			allMetricsCount++
+			mb.RecordPingConsecutiveFailuresDataPoint(ts, 1, "net.peer.name-val")
+
+			allMetricsCount++
+			mb.RecordPingDNSLookupDurationDataPoint(ts, 1, "net.peer.name-val")
+
+			allMetricsCount++
+			mb.RecordPingDowntimeDataPoint(ts, 1, "net.peer.name-val")
 
 			defaultMetricsCount++
 			allMetricsCount++
-			mb.RecordPingDurationAvgDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val")
+			mb.RecordPingDscpDurationDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val", 14, 4)
 
 			defaultMetricsCount++
 			allMetricsCount++
-			mb.RecordPingDurationMaxDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val")
+			mb.RecordPingDscpPacketLossDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val", 14, 4)
 
 			defaultMetricsCount++
 			allMetricsCount++
-			mb.RecordPingDurationMinDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val")
+			mb.RecordPingDurationDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val", 14)
 
 			defaultMetricsCount++
 			allMetricsCount++
-			mb.RecordPingDurationStddevDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val")
+			mb.RecordPingDurationAvgDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val", 14)
+
+			allMetricsCount++
+			mb.RecordPingDurationHeatmapDataPoint(ts, 1, "net.peer.name-val", "duration.bucket-val")
 
+			defaultMetricsCount++
 			allMetricsCount++
-			mb.RecordPingErrorsDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val", AttributeErrorTypeTimeout)
+			mb.RecordPingDurationMaxDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val", 14)
 
 			defaultMetricsCount++
 			allMetricsCount++
-			mb.RecordPingPacketLossDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val")
+			mb.RecordPingDurationMedianDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val", 14)
 
 			defaultMetricsCount++
 			allMetricsCount++
-			mb.RecordPingPacketsReceivedDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val")
+			mb.RecordPingDurationMinDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val", 14)
 
 			defaultMetricsCount++
 			allMetricsCount++
-			mb.RecordPingPacketsSentDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val")
+			mb.RecordPingDurationStddevDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val", 14)
+
+			allMetricsCount++
+			mb.RecordPingDurationTrimmedMeanDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val", 14)
+
+			allMetricsCount++
+			mb.RecordPingErrorsDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val", 14, AttributeErrorTypeTimeout, WithLocalNetworkOkMetricAttribute(true), WithPassivelySeenMetricAttribute(true), WithOsTypeMetricAttribute("os.type-val"))
+
+			allMetricsCount++
+			mb.RecordPingJitterDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val", 14)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordPingManagementPlaneRespondingDataPoint(ts, 1, "net.peer.name-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordPingPacketLossDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val", 14)
+
+			allMetricsCount++
+			mb.RecordPingPacketLossPercentDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val", 14)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordPingPacketsReceivedDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val", 14, false)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordPingPacketsSentDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val", 14)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordPingPathMtuDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val", 14)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordPingProbePanicsDataPoint(ts, 1, "net.peer.name-val")
+
+			allMetricsCount++
+			mb.RecordPingReplyTTLDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val", 14)
+
+			allMetricsCount++
+			mb.RecordPingScheduleDriftDataPoint(ts, 1, "net.peer.name-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordPingSizeSweepDurationDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val", 14, 11)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordPingSizeSweepPacketLossDataPoint(ts, 1, "net.peer.name-val", "net.peer.ip-val", 14, 11)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordPingSLAStatusDataPoint(ts, 1, "net.peer.name-val", "sla.window-val")
+
+			allMetricsCount++
+			mb.RecordPingStateTransitionsDataPoint(ts, 1, "net.peer.name-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordPingTracerouteHopDurationDataPoint(ts, 1, "net.peer.name-val", 14, "traceroute.hop.ip-val", WithTracerouteHopAsnMetricAttribute(18), WithTracerouteHopMplsLabelsMetricAttribute("traceroute.hop.mpls_labels-val"))
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordPingTracerouteHopPacketLossDataPoint(ts, 1, "net.peer.name-val", 14, "traceroute.hop.ip-val", WithTracerouteHopAsnMetricAttribute(18), WithTracerouteHopMplsLabelsMetricAttribute("traceroute.hop.mpls_labels-val"))
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordPingTracerouteHopCountDataPoint(ts, 1, "net.peer.name-val", true)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordPingTunnelOverheadDataPoint(ts, 1, "net.peer.name-val", "tunnel.underlay-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordPingTunnelPacketLossDeltaDataPoint(ts, 1, "net.peer.name-val", "tunnel.underlay-val")
 
 			res := pcommon.NewResource()
 			metrics := mb.Emit(WithResource(res))
@@ -116,6 +209,136 @@ func TestMetricsBuilder(t *testing.T) {
 			validatedMetrics := make(map[string]bool)
 			for i := 0; i < ms.Len(); i++ {
 				switch ms.At(i).Name() {
+				case "connectivity.state":
+					assert.False(t, validatedMetrics["connectivity.state"], "Found a duplicate in the metrics slice: connectivity.state")
+					validatedMetrics["connectivity.state"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Internet connectivity of the host, 1 for the current state and 0 otherwise (requires connectivity_check)", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("state")
+					assert.True(t, ok)
+					assert.Equal(t, "full", attrVal.Str())
+				case "ping.business_hours.downtime":
+					assert.False(t, validatedMetrics["ping.business_hours.downtime"], "Found a duplicate in the metrics slice: ping.business_hours.downtime")
+					validatedMetrics["ping.business_hours.downtime"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "Total time the target was down, split by the business hours of its calendar, reported for targets with a calendar only", ms.At(i).Description())
+					assert.Equal(t, "s", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("business_hours")
+					assert.True(t, ok)
+					assert.True(t, attrVal.Bool())
+				case "ping.consecutive_failures":
+					assert.False(t, validatedMetrics["ping.consecutive_failures"], "Found a duplicate in the metrics slice: ping.consecutive_failures")
+					validatedMetrics["ping.consecutive_failures"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Number of successive scrapes in which the target failed or lost every packet, 0 once it answers again", ms.At(i).Description())
+					assert.Equal(t, "{scrape}", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
+				case "ping.dns.lookup.duration":
+					assert.False(t, validatedMetrics["ping.dns.lookup.duration"], "Found a duplicate in the metrics slice: ping.dns.lookup.duration")
+					validatedMetrics["ping.dns.lookup.duration"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Time taken to resolve the hostname of the target before its probe, not reported for targets configured by address", ms.At(i).Description())
+					assert.Equal(t, "ms", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
+				case "ping.downtime":
+					assert.False(t, validatedMetrics["ping.downtime"], "Found a duplicate in the metrics slice: ping.downtime")
+					validatedMetrics["ping.downtime"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "Total time the target was down, the interval before a scrape that failed or lost every packet counting as down", ms.At(i).Description())
+					assert.Equal(t, "s", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
+				case "ping.dscp.duration":
+					assert.False(t, validatedMetrics["ping.dscp.duration"], "Found a duplicate in the metrics slice: ping.dscp.duration")
+					validatedMetrics["ping.dscp.duration"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Average round-trip time of the packets of one DSCP value, probed with dscp_values", ms.At(i).Description())
+					assert.Equal(t, "ms", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.peer.ip")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.ip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.ip.version")
+					assert.True(t, ok)
+					assert.EqualValues(t, 14, attrVal.Int())
+					attrVal, ok = dp.Attributes().Get("dscp")
+					assert.True(t, ok)
+					assert.EqualValues(t, 4, attrVal.Int())
+				case "ping.dscp.packet_loss":
+					assert.False(t, validatedMetrics["ping.dscp.packet_loss"], "Found a duplicate in the metrics slice: ping.dscp.packet_loss")
+					validatedMetrics["ping.dscp.packet_loss"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Ratio of packets of one DSCP value lost, probed with dscp_values", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.peer.ip")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.ip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.ip.version")
+					assert.True(t, ok)
+					assert.EqualValues(t, 14, attrVal.Int())
+					attrVal, ok = dp.Attributes().Get("dscp")
+					assert.True(t, ok)
+					assert.EqualValues(t, 4, attrVal.Int())
 				case "ping.duration":
 					assert.False(t, validatedMetrics["ping.duration"], "Found a duplicate in the metrics slice: ping.duration")
 					validatedMetrics["ping.duration"] = true
@@ -134,6 +357,9 @@ func TestMetricsBuilder(t *testing.T) {
 					attrVal, ok = dp.Attributes().Get("net.peer.ip")
 					assert.True(t, ok)
 					assert.Equal(t, "net.peer.ip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.ip.version")
+					assert.True(t, ok)
+					assert.EqualValues(t, 14, attrVal.Int())
 				case "ping.duration.avg":
 					assert.False(t, validatedMetrics["ping.duration.avg"], "Found a duplicate in the metrics slice: ping.duration.avg")
 					validatedMetrics["ping.duration.avg"] = true
@@ -152,6 +378,27 @@ func TestMetricsBuilder(t *testing.T) {
 					attrVal, ok = dp.Attributes().Get("net.peer.ip")
 					assert.True(t, ok)
 					assert.Equal(t, "net.peer.ip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.ip.version")
+					assert.True(t, ok)
+					assert.EqualValues(t, 14, attrVal.Int())
+				case "ping.duration.heatmap":
+					assert.False(t, validatedMetrics["ping.duration.heatmap"], "Found a duplicate in the metrics slice: ping.duration.heatmap")
+					validatedMetrics["ping.duration.heatmap"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Number of packets of the probe whose round-trip time fell into the bucket, reported for every bucket of heatmap.buckets", ms.At(i).Description())
+					assert.Equal(t, "{packet}", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("duration.bucket")
+					assert.True(t, ok)
+					assert.Equal(t, "duration.bucket-val", attrVal.Str())
 				case "ping.duration.max":
 					assert.False(t, validatedMetrics["ping.duration.max"], "Found a duplicate in the metrics slice: ping.duration.max")
 					validatedMetrics["ping.duration.max"] = true
@@ -170,6 +417,30 @@ func TestMetricsBuilder(t *testing.T) {
 					attrVal, ok = dp.Attributes().Get("net.peer.ip")
 					assert.True(t, ok)
 					assert.Equal(t, "net.peer.ip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.ip.version")
+					assert.True(t, ok)
+					assert.EqualValues(t, 14, attrVal.Int())
+				case "ping.duration.median":
+					assert.False(t, validatedMetrics["ping.duration.median"], "Found a duplicate in the metrics slice: ping.duration.median")
+					validatedMetrics["ping.duration.median"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Median round-trip time", ms.At(i).Description())
+					assert.Equal(t, "ms", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.peer.ip")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.ip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.ip.version")
+					assert.True(t, ok)
+					assert.EqualValues(t, 14, attrVal.Int())
 				case "ping.duration.min":
 					assert.False(t, validatedMetrics["ping.duration.min"], "Found a duplicate in the metrics slice: ping.duration.min")
 					validatedMetrics["ping.duration.min"] = true
@@ -188,6 +459,9 @@ func TestMetricsBuilder(t *testing.T) {
 					attrVal, ok = dp.Attributes().Get("net.peer.ip")
 					assert.True(t, ok)
 					assert.Equal(t, "net.peer.ip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.ip.version")
+					assert.True(t, ok)
+					assert.EqualValues(t, 14, attrVal.Int())
 				case "ping.duration.stddev":
 					assert.False(t, validatedMetrics["ping.duration.stddev"], "Found a duplicate in the metrics slice: ping.duration.stddev")
 					validatedMetrics["ping.duration.stddev"] = true
@@ -206,6 +480,30 @@ func TestMetricsBuilder(t *testing.T) {
 					attrVal, ok = dp.Attributes().Get("net.peer.ip")
 					assert.True(t, ok)
 					assert.Equal(t, "net.peer.ip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.ip.version")
+					assert.True(t, ok)
+					assert.EqualValues(t, 14, attrVal.Int())
+				case "ping.duration.trimmed_mean":
+					assert.False(t, validatedMetrics["ping.duration.trimmed_mean"], "Found a duplicate in the metrics slice: ping.duration.trimmed_mean")
+					validatedMetrics["ping.duration.trimmed_mean"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Mean round-trip time after dropping the fastest and slowest trimmed_mean_percent of samples", ms.At(i).Description())
+					assert.Equal(t, "ms", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.peer.ip")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.ip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.ip.version")
+					assert.True(t, ok)
+					assert.EqualValues(t, 14, attrVal.Int())
 				case "ping.errors":
 					assert.False(t, validatedMetrics["ping.errors"], "Found a duplicate in the metrics slice: ping.errors")
 					validatedMetrics["ping.errors"] = true
@@ -226,9 +524,57 @@ func TestMetricsBuilder(t *testing.T) {
 					attrVal, ok = dp.Attributes().Get("net.peer.ip")
 					assert.True(t, ok)
 					assert.Equal(t, "net.peer.ip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.ip.version")
+					assert.True(t, ok)
+					assert.EqualValues(t, 14, attrVal.Int())
 					attrVal, ok = dp.Attributes().Get("error.type")
 					assert.True(t, ok)
 					assert.Equal(t, "timeout", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("local_network_ok")
+					assert.True(t, ok)
+					assert.True(t, attrVal.Bool())
+					attrVal, ok = dp.Attributes().Get("passively_seen")
+					assert.True(t, ok)
+					assert.True(t, attrVal.Bool())
+					attrVal, ok = dp.Attributes().Get("os.type")
+					assert.True(t, ok)
+					assert.Equal(t, "os.type-val", attrVal.Str())
+				case "ping.jitter":
+					assert.False(t, validatedMetrics["ping.jitter"], "Found a duplicate in the metrics slice: ping.jitter")
+					validatedMetrics["ping.jitter"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Mean absolute difference of the round-trip times of consecutive packets", ms.At(i).Description())
+					assert.Equal(t, "ms", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.peer.ip")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.ip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.ip.version")
+					assert.True(t, ok)
+					assert.EqualValues(t, 14, attrVal.Int())
+				case "ping.management_plane.responding":
+					assert.False(t, validatedMetrics["ping.management_plane.responding"], "Found a duplicate in the metrics slice: ping.management_plane.responding")
+					validatedMetrics["ping.management_plane.responding"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Whether the target answered an SNMP sysUpTime request after its ping failed or lost every packet, 1 if it did, checked with snmp", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
 				case "ping.packet_loss":
 					assert.False(t, validatedMetrics["ping.packet_loss"], "Found a duplicate in the metrics slice: ping.packet_loss")
 					validatedMetrics["ping.packet_loss"] = true
@@ -247,6 +593,30 @@ func TestMetricsBuilder(t *testing.T) {
 					attrVal, ok = dp.Attributes().Get("net.peer.ip")
 					assert.True(t, ok)
 					assert.Equal(t, "net.peer.ip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.ip.version")
+					assert.True(t, ok)
+					assert.EqualValues(t, 14, attrVal.Int())
+				case "ping.packet_loss.percent":
+					assert.False(t, validatedMetrics["ping.packet_loss.percent"], "Found a duplicate in the metrics slice: ping.packet_loss.percent")
+					validatedMetrics["ping.packet_loss.percent"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Percentage of packets lost", ms.At(i).Description())
+					assert.Equal(t, "%", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.peer.ip")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.ip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.ip.version")
+					assert.True(t, ok)
+					assert.EqualValues(t, 14, attrVal.Int())
 				case "ping.packets.received":
 					assert.False(t, validatedMetrics["ping.packets.received"], "Found a duplicate in the metrics slice: ping.packets.received")
 					validatedMetrics["ping.packets.received"] = true
@@ -267,6 +637,12 @@ func TestMetricsBuilder(t *testing.T) {
 					attrVal, ok = dp.Attributes().Get("net.peer.ip")
 					assert.True(t, ok)
 					assert.Equal(t, "net.peer.ip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.ip.version")
+					assert.True(t, ok)
+					assert.EqualValues(t, 14, attrVal.Int())
+					attrVal, ok = dp.Attributes().Get("reply.source_mismatch")
+					assert.True(t, ok)
+					assert.False(t, attrVal.Bool())
 				case "ping.packets.sent":
 					assert.False(t, validatedMetrics["ping.packets.sent"], "Found a duplicate in the metrics slice: ping.packets.sent")
 					validatedMetrics["ping.packets.sent"] = true
@@ -287,6 +663,274 @@ func TestMetricsBuilder(t *testing.T) {
 					attrVal, ok = dp.Attributes().Get("net.peer.ip")
 					assert.True(t, ok)
 					assert.Equal(t, "net.peer.ip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.ip.version")
+					assert.True(t, ok)
+					assert.EqualValues(t, 14, attrVal.Int())
+				case "ping.path_mtu":
+					assert.False(t, validatedMetrics["ping.path_mtu"], "Found a duplicate in the metrics slice: ping.path_mtu")
+					validatedMetrics["ping.path_mtu"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Largest packet reaching the target without fragmentation including its IP and ICMP headers, probed with path_mtu_discovery", ms.At(i).Description())
+					assert.Equal(t, "By", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.peer.ip")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.ip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.ip.version")
+					assert.True(t, ok)
+					assert.EqualValues(t, 14, attrVal.Int())
+				case "ping.probe.panics":
+					assert.False(t, validatedMetrics["ping.probe.panics"], "Found a duplicate in the metrics slice: ping.probe.panics")
+					validatedMetrics["ping.probe.panics"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "Number of probes of the target that panicked and were recovered, reported for targets once one of their probes panicked", ms.At(i).Description())
+					assert.Equal(t, "{panic}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
+				case "ping.reply.ttl":
+					assert.False(t, validatedMetrics["ping.reply.ttl"], "Found a duplicate in the metrics slice: ping.reply.ttl")
+					validatedMetrics["ping.reply.ttl"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "TTL or hop limit of the latest reply, which changes along with the number of hops on the return path", ms.At(i).Description())
+					assert.Equal(t, "{hop}", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.peer.ip")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.ip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.ip.version")
+					assert.True(t, ok)
+					assert.EqualValues(t, 14, attrVal.Int())
+				case "ping.schedule.drift":
+					assert.False(t, validatedMetrics["ping.schedule.drift"], "Found a duplicate in the metrics slice: ping.schedule.drift")
+					validatedMetrics["ping.schedule.drift"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Time the packets of the probe started to be sent after the tick of collection_interval it was scheduled at, one data point per probe", ms.At(i).Description())
+					assert.Equal(t, "ms", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
+				case "ping.size_sweep.duration":
+					assert.False(t, validatedMetrics["ping.size_sweep.duration"], "Found a duplicate in the metrics slice: ping.size_sweep.duration")
+					validatedMetrics["ping.size_sweep.duration"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Average round-trip time of the packets of one size, probed with packet_sizes", ms.At(i).Description())
+					assert.Equal(t, "ms", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.peer.ip")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.ip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.ip.version")
+					assert.True(t, ok)
+					assert.EqualValues(t, 14, attrVal.Int())
+					attrVal, ok = dp.Attributes().Get("packet.size")
+					assert.True(t, ok)
+					assert.EqualValues(t, 11, attrVal.Int())
+				case "ping.size_sweep.packet_loss":
+					assert.False(t, validatedMetrics["ping.size_sweep.packet_loss"], "Found a duplicate in the metrics slice: ping.size_sweep.packet_loss")
+					validatedMetrics["ping.size_sweep.packet_loss"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Ratio of packets of one size lost, probed with packet_sizes", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.peer.ip")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.ip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("net.ip.version")
+					assert.True(t, ok)
+					assert.EqualValues(t, 14, attrVal.Int())
+					attrVal, ok = dp.Attributes().Get("packet.size")
+					assert.True(t, ok)
+					assert.EqualValues(t, 11, attrVal.Int())
+				case "ping.sla.status":
+					assert.False(t, validatedMetrics["ping.sla.status"], "Found a duplicate in the metrics slice: ping.sla.status")
+					validatedMetrics["ping.sla.status"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Whether the scrape's result was within the target's SLA thresholds for the time of day, 1 if it was, checked with sla", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("sla.window")
+					assert.True(t, ok)
+					assert.Equal(t, "sla.window-val", attrVal.Str())
+				case "ping.state_transitions":
+					assert.False(t, validatedMetrics["ping.state_transitions"], "Found a duplicate in the metrics slice: ping.state_transitions")
+					validatedMetrics["ping.state_transitions"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "Number of changes of the target between up and down, a scrape that failed or lost every packet being down", ms.At(i).Description())
+					assert.Equal(t, "{transition}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityUnspecified, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
+				case "ping.traceroute.hop.duration":
+					assert.False(t, validatedMetrics["ping.traceroute.hop.duration"], "Found a duplicate in the metrics slice: ping.traceroute.hop.duration")
+					validatedMetrics["ping.traceroute.hop.duration"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Average round-trip time of the packets of one hop of the latest traceroute, for the hops that answered", ms.At(i).Description())
+					assert.Equal(t, "ms", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("traceroute.hop")
+					assert.True(t, ok)
+					assert.EqualValues(t, 14, attrVal.Int())
+					attrVal, ok = dp.Attributes().Get("traceroute.hop.ip")
+					assert.True(t, ok)
+					assert.Equal(t, "traceroute.hop.ip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("traceroute.hop.asn")
+					assert.True(t, ok)
+					assert.EqualValues(t, 18, attrVal.Int())
+					attrVal, ok = dp.Attributes().Get("traceroute.hop.mpls_labels")
+					assert.True(t, ok)
+					assert.Equal(t, "traceroute.hop.mpls_labels-val", attrVal.Str())
+				case "ping.traceroute.hop.packet_loss":
+					assert.False(t, validatedMetrics["ping.traceroute.hop.packet_loss"], "Found a duplicate in the metrics slice: ping.traceroute.hop.packet_loss")
+					validatedMetrics["ping.traceroute.hop.packet_loss"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Ratio of the packets of one hop of the latest traceroute that were not answered", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("traceroute.hop")
+					assert.True(t, ok)
+					assert.EqualValues(t, 14, attrVal.Int())
+					attrVal, ok = dp.Attributes().Get("traceroute.hop.ip")
+					assert.True(t, ok)
+					assert.Equal(t, "traceroute.hop.ip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("traceroute.hop.asn")
+					assert.True(t, ok)
+					assert.EqualValues(t, 18, attrVal.Int())
+					attrVal, ok = dp.Attributes().Get("traceroute.hop.mpls_labels")
+					assert.True(t, ok)
+					assert.Equal(t, "traceroute.hop.mpls_labels-val", attrVal.Str())
+				case "ping.traceroute.hop_count":
+					assert.False(t, validatedMetrics["ping.traceroute.hop_count"], "Found a duplicate in the metrics slice: ping.traceroute.hop_count")
+					validatedMetrics["ping.traceroute.hop_count"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Number of hops of the latest traceroute, up to the target if it answered, reported for targets with traceroute only", ms.At(i).Description())
+					assert.Equal(t, "{hop}", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("traceroute.reached")
+					assert.True(t, ok)
+					assert.True(t, attrVal.Bool())
+				case "ping.tunnel.overhead":
+					assert.False(t, validatedMetrics["ping.tunnel.overhead"], "Found a duplicate in the metrics slice: ping.tunnel.overhead")
+					validatedMetrics["ping.tunnel.overhead"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Average round-trip time through the tunnel minus that to its underlay address, probed with underlay", ms.At(i).Description())
+					assert.Equal(t, "ms", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("tunnel.underlay")
+					assert.True(t, ok)
+					assert.Equal(t, "tunnel.underlay-val", attrVal.Str())
+				case "ping.tunnel.packet_loss_delta":
+					assert.False(t, validatedMetrics["ping.tunnel.packet_loss_delta"], "Found a duplicate in the metrics slice: ping.tunnel.packet_loss_delta")
+					validatedMetrics["ping.tunnel.packet_loss_delta"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Ratio of packets lost through the tunnel minus that to its underlay address, probed with underlay", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("net.peer.name")
+					assert.True(t, ok)
+					assert.Equal(t, "net.peer.name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("tunnel.underlay")
+					assert.True(t, ok)
+					assert.Equal(t, "tunnel.underlay-val", attrVal.Str())
 				}
 			}
 		})
@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package snmp implements the single SNMPv2c request the receiver needs, a GET
+// of sysUpTime, to tell whether a device's management plane still answers.
+package snmp // import "github.com/lukeod/pingcheckreceiver/internal/snmp"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"time"
+)
+
+// BER tags of the message parts used
+const (
+	tagInteger     = 0x02
+	tagOctetString = 0x04
+	tagNull        = 0x05
+	tagOID         = 0x06
+	tagSequence    = 0x30
+	tagTimeTicks   = 0x43
+	tagGetRequest  = 0xa0
+	tagGetResponse = 0xa2
+)
+
+// version2c is the version field of SNMPv2c messages
+const version2c = 1
+
+// sysUpTimeOID is 1.3.6.1.2.1.1.3.0, BER encoded
+var sysUpTimeOID = []byte{0x2b, 6, 1, 2, 1, 1, 3, 0}
+
+// ErrNoSuchObject is returned when the agent does not expose sysUpTime
+var ErrNoSuchObject = errors.New("sysUpTime not available")
+
+// SysUpTime requests sysUpTime from the agent at addr, a host and port, with
+// community and returns the time since its network management was initialized
+func SysUpTime(ctx context.Context, addr, community string) (time.Duration, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", addr)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			return 0, err
+		}
+	}
+
+	requestID := rand.Int32()
+	if _, err = conn.Write(getRequest(community, requestID)); err != nil {
+		return 0, err
+	}
+	buf := make([]byte, 1500)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return 0, err
+		}
+		ticks, id, err := parseResponse(buf[:n])
+		if err != nil {
+			return 0, err
+		}
+		// A late answer to an earlier request
+		if id != requestID {
+			continue
+		}
+		return time.Duration(ticks) * 10 * time.Millisecond, nil
+	}
+}
+
+// getRequest encodes a GET of sysUpTime
+func getRequest(community string, requestID int32) []byte {
+	varBind := tlv(tagSequence, append(tlv(tagOID, sysUpTimeOID), tlv(tagNull, nil)...))
+	pdu := tlv(tagGetRequest, concat(
+		integer(int64(requestID)),
+		integer(0), // error-status
+		integer(0), // error-index
+		tlv(tagSequence, varBind),
+	))
+	return tlv(tagSequence, concat(
+		integer(version2c),
+		tlv(tagOctetString, []byte(community)),
+		pdu,
+	))
+}
+
+// parseResponse returns the sysUpTime ticks and request ID of a GET response
+func parseResponse(b []byte) (uint32, int32, error) {
+	msg, err := expect(&b, tagSequence)
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, err = expect(&msg, tagInteger); err != nil {
+		return 0, 0, err
+	}
+	if _, err = expect(&msg, tagOctetString); err != nil {
+		return 0, 0, err
+	}
+	pdu, err := expect(&msg, tagGetResponse)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var fields [3]int64
+	for i := range fields {
+		v, err := expect(&pdu, tagInteger)
+		if err != nil {
+			return 0, 0, err
+		}
+		fields[i] = decodeInt(v)
+	}
+	requestID := int32(fields[0])
+	if fields[1] != 0 {
+		return 0, requestID, fmt.Errorf("agent returned error-status %d", fields[1])
+	}
+
+	varBinds, err := expect(&pdu, tagSequence)
+	if err != nil {
+		return 0, requestID, err
+	}
+	varBind, err := expect(&varBinds, tagSequence)
+	if err != nil {
+		return 0, requestID, err
+	}
+	if _, err = expect(&varBind, tagOID); err != nil {
+		return 0, requestID, err
+	}
+	tag, value, _, err := readTLV(varBind)
+	if err != nil {
+		return 0, requestID, err
+	}
+	if tag != tagTimeTicks {
+		// noSuchObject, noSuchInstance or a value of another type
+		return 0, requestID, ErrNoSuchObject
+	}
+	return uint32(decodeInt(value)), requestID, nil
+}
+
+// expect reads a value with the given tag off the front of b
+func expect(b *[]byte, tag byte) ([]byte, error) {
+	t, value, rest, err := readTLV(*b)
+	if err != nil {
+		return nil, err
+	}
+	if t != tag {
+		return nil, fmt.Errorf("expected tag 0x%02x, got 0x%02x", tag, t)
+	}
+	*b = rest
+	return value, nil
+}
+
+// readTLV splits the first value off b. Agents are not held to DER, so long
+// form lengths are accepted even where the short form would do.
+func readTLV(b []byte) (tag byte, value, rest []byte, err error) {
+	if len(b) < 2 {
+		return 0, nil, nil, errors.New("truncated message")
+	}
+	tag, length, b := b[0], int(b[1]), b[2:]
+	if length&0x80 != 0 {
+		n := length & 0x7f
+		if n == 0 || n > 4 || len(b) < n {
+			return 0, nil, nil, errors.New("invalid length")
+		}
+		length = 0
+		for _, c := range b[:n] {
+			length = length<<8 | int(c)
+		}
+		b = b[n:]
+	}
+	if length < 0 || length > len(b) {
+		return 0, nil, nil, errors.New("truncated message")
+	}
+	return tag, b[:length], b[length:], nil
+}
+
+// decodeInt decodes a two's complement big-endian integer. TimeTicks are
+// unsigned, their leading zero byte keeps them positive.
+func decodeInt(b []byte) int64 {
+	var v int64
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		v = -1
+	}
+	for _, c := range b {
+		v = v<<8 | int64(c)
+	}
+	return v
+}
+
+// integer encodes v as an INTEGER
+func integer(v int64) []byte {
+	b := []byte{byte(v)}
+	for v > 0x7f || v < -0x80 {
+		v >>= 8
+		b = append([]byte{byte(v)}, b...)
+	}
+	return tlv(tagInteger, b)
+}
+
+// tlv encodes a value with the given tag
+func tlv(tag byte, value []byte) []byte {
+	n := len(value)
+	if n < 0x80 {
+		return append([]byte{tag, byte(n)}, value...)
+	}
+	var length []byte
+	for ; n > 0; n >>= 8 {
+		length = append([]byte{byte(n)}, length...)
+	}
+	return append(append([]byte{tag, 0x80 | byte(len(length))}, length...), value...)
+}
+
+func concat(parts ...[]byte) []byte {
+	var b []byte
+	for _, p := range parts {
+		b = append(b, p...)
+	}
+	return b
+}
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmp
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// response encodes a GET response carrying value as the sysUpTime varbind
+func response(requestID int32, errorStatus int64, value []byte) []byte {
+	varBind := tlv(tagSequence, append(tlv(tagOID, sysUpTimeOID), value...))
+	pdu := tlv(tagGetResponse, concat(
+		integer(int64(requestID)),
+		integer(errorStatus),
+		integer(0),
+		tlv(tagSequence, varBind),
+	))
+	return tlv(tagSequence, concat(integer(version2c), tlv(tagOctetString, []byte("public")), pdu))
+}
+
+// requestID returns the request ID of a GET request
+func requestID(t *testing.T, b []byte) int32 {
+	msg, err := expect(&b, tagSequence)
+	require.NoError(t, err)
+	_, err = expect(&msg, tagInteger)
+	require.NoError(t, err)
+	community, err := expect(&msg, tagOctetString)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(community))
+	pdu, err := expect(&msg, tagGetRequest)
+	require.NoError(t, err)
+	id, err := expect(&pdu, tagInteger)
+	require.NoError(t, err)
+	return int32(decodeInt(id))
+}
+
+// agent answers every request with the responses built by respond, in order
+func agent(t *testing.T, respond ...func(id int32) []byte) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 1500)
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		id := requestID(t, buf[:n])
+		for _, r := range respond {
+			_, _ = conn.WriteTo(r(id), addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func TestSysUpTime(t *testing.T) {
+	addr := agent(t,
+		// Answer to an earlier request
+		func(id int32) []byte { return response(id+1, 0, tlv(tagTimeTicks, []byte{1})) },
+		func(id int32) []byte { return response(id, 0, tlv(tagTimeTicks, []byte{0, 0x80, 0, 0, 0})) },
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	uptime, err := SysUpTime(ctx, addr, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0x80000000)*10*time.Millisecond, uptime)
+}
+
+func TestSysUpTimeErrors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	addr := agent(t, func(id int32) []byte { return response(id, 0, tlv(0x80, nil)) })
+	_, err := SysUpTime(ctx, addr, "secret")
+	assert.ErrorIs(t, err, ErrNoSuchObject)
+
+	addr = agent(t, func(id int32) []byte { return response(id, 2, tlv(tagNull, nil)) })
+	_, err = SysUpTime(ctx, addr, "secret")
+	assert.EqualError(t, err, "agent returned error-status 2")
+
+	// Nobody answers
+	addr = agent(t)
+	short, cancelShort := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancelShort()
+	_, err = SysUpTime(short, addr, "secret")
+	assert.Error(t, err)
+}
+
+func TestReadTLV(t *testing.T) {
+	// Long form length where the short form would do
+	tag, value, rest, err := readTLV([]byte{tagInteger, 0x81, 0x01, 0x05, 0xff})
+	require.NoError(t, err)
+	assert.Equal(t, byte(tagInteger), tag)
+	assert.Equal(t, []byte{0x05}, value)
+	assert.Equal(t, []byte{0xff}, rest)
+
+	_, _, _, err = readTLV([]byte{tagInteger, 0x05, 0x01})
+	assert.EqualError(t, err, "truncated message")
+}
+
+func TestInteger(t *testing.T) {
+	for _, v := range []int64{0, 1, 127, 128, 255, 256, -1, -128, -129, 1 << 31, -(1 << 31)} {
+		b := integer(v)
+		value, err := expect(&b, tagInteger)
+		require.NoError(t, err)
+		assert.Equal(t, v, decodeInt(value), "value %d", v)
+	}
+	assert.Len(t, tlv(tagOctetString, make([]byte, 300)), 304)
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"encoding"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"go.uber.org/multierr"
+)
+
+// maxSuggestionDistance is the largest number of edits between an unknown key
+// and the known key suggested for it
+const maxSuggestionDistance = 2
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// checkKeys returns an error for every key of raw, and of the blocks nested
+// in it, that no option of t is decoded from. Decoding rejects those as well,
+// the errors here name the closest known key, e.g. interval for intervall.
+func checkKeys(raw map[string]any, t reflect.Type, path string) error {
+	fields := optionFields(t)
+	var err error
+	for _, key := range slices.Sorted(maps.Keys(raw)) {
+		ft, ok := fields[key]
+		if !ok {
+			err = multierr.Append(err, fmt.Errorf("%s%s", path, unknownKey(key, slices.Collect(maps.Keys(fields)))))
+			continue
+		}
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		switch value := raw[key].(type) {
+		case map[string]any:
+			if isBlock(ft) {
+				err = multierr.Append(err, checkKeys(value, ft, path+key+": "))
+			}
+		case []any:
+			if ft.Kind() != reflect.Slice || !isBlock(ft.Elem()) {
+				continue
+			}
+			for i, elem := range value {
+				if m, ok := elem.(map[string]any); ok {
+					err = multierr.Append(err, checkKeys(m, ft.Elem(), fmt.Sprintf("%s%s[%d]: ", path, key, i)))
+				}
+			}
+		}
+	}
+	return err
+}
+
+// isBlock reports whether t is a struct decoded option by option, rather than
+// from a single value such as a component ID
+func isBlock(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// optionFields returns the types of the options of struct t by key,
+// including those of squashed structs
+func optionFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		switch {
+		case name == "-":
+		case slices.Contains(strings.Split(opts, ","), "squash"):
+			maps.Copy(fields, optionFields(field.Type))
+		case name == "":
+			fields[strings.ToLower(field.Name)] = field.Type
+		default:
+			fields[name] = field.Type
+		}
+	}
+	return fields
+}
+
+// unknownKey describes key as unknown, suggesting the closest of known
+func unknownKey(key string, known []string) string {
+	best, bestDistance := "", maxSuggestionDistance+1
+	for _, candidate := range slices.Sorted(slices.Values(known)) {
+		if d := editDistance(key, candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	if best == "" {
+		return fmt.Sprintf("unknown key %q", key)
+	}
+	return fmt.Sprintf("unknown key %q, did you mean %q?", key, best)
+}
+
+// editDistance returns the Levenshtein distance of a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
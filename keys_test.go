@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestUnmarshalUnknownKeys(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	err := cfg.Unmarshal(confmap.NewFromStringMap(map[string]any{
+		"colection_interval": "30s",
+		"frobnicate":         true,
+		"metrics": map[string]any{
+			"ping.duration.avgg": map[string]any{"enabled": false},
+		},
+		"targets": []any{
+			map[string]any{"endpoint": "192.0.2.1"},
+			map[string]any{
+				"endpoint":  "192.0.2.2",
+				"intervall": "1s",
+				// Labels and metric overrides are keyed freely
+				"labels":  map[string]any{"intervall": "x"},
+				"metrics": map[string]any{"ping.duration.avg": map[string]any{"enabled": false}},
+				"sla": map[string]any{
+					"max_los":   0.1,
+					"schedules": []any{map[string]any{"name": "night", "strat": "22:00"}},
+				},
+			},
+		},
+		"passive_check": map[string]any{"extension": "flows"},
+	}))
+	require.Error(t, err)
+	assert.Equal(t, `unknown key "colection_interval", did you mean "collection_interval"?; `+
+		`unknown key "frobnicate"; `+
+		`metrics: unknown key "ping.duration.avgg", did you mean "ping.duration.avg"?; `+
+		`targets[1]: unknown key "intervall", did you mean "interval"?; `+
+		`targets[1]: sla: unknown key "max_los", did you mean "max_loss"?; `+
+		`targets[1]: sla: schedules[0]: unknown key "strat", did you mean "start"?`, err.Error())
+}
+
+func TestUnmarshalKnownKeys(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	require.NoError(t, cfg.Unmarshal(confmap.NewFromStringMap(map[string]any{
+		"collection_interval": "30s",
+		"targets": []any{map[string]any{
+			"endpoint": "192.0.2.1",
+			"sla": map[string]any{
+				"schedules": []any{map[string]any{"name": "night", "days": []any{"mon"}, "start": "22:00", "end": "06:00"}},
+			},
+		}},
+		"passive_check": map[string]any{"extension": "flows/edge"},
+	})))
+	assert.Equal(t, "22:00", cfg.Targets[0].SLA.Schedules[0].Start)
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("interval", "interval"))
+	assert.Equal(t, 1, editDistance("intervall", "interval"))
+	assert.Equal(t, 2, editDistance("strat", "start"))
+	assert.Equal(t, 3, editDistance("", "abc"))
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"maps"
+	"slices"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+)
+
+// reservedAttributes are the data point attributes of metadata.yaml, labels
+// cannot replace them
+var reservedAttributes = map[string]bool{
+	"net.peer.name":              true,
+	"net.peer.ip":                true,
+	"net.ip.version":             true,
+	"business_hours":             true,
+	"calendar.event":             true,
+	"dscp":                       true,
+	"duration.bucket":            true,
+	"error.type":                 true,
+	"local_network_ok":           true,
+	"passively_seen":             true,
+	"os.type":                    true,
+	"sla.window":                 true,
+	"tunnel.underlay":            true,
+	"packet.size":                true,
+	"reply.source_mismatch":      true,
+	"state":                      true,
+	"traceroute.reached":         true,
+	"traceroute.hop":             true,
+	"traceroute.hop.ip":          true,
+	"traceroute.hop.asn":         true,
+	"traceroute.hop.mpls_labels": true,
+	overflowAttribute:            true,
+}
+
+// validateLabels checks that labels neither are empty nor replace attributes
+// of the receiver
+func validateLabels(labels map[string]string) error {
+	var err error
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		switch {
+		case key == "":
+			err = multierr.Append(err, invalid("", CodeRequired, "key cannot be empty"))
+		case reservedAttributes[key]:
+			err = multierr.Append(err, invalid(key, CodeConflict, "%s is an attribute of the receiver", key))
+		}
+	}
+	return within("labels", err)
+}
+
+// attributedSlice is implemented by the data point slices of every metric type
+type attributedSlice[T interface{ Attributes() pcommon.Map }] interface {
+	Len() int
+	At(int) T
+}
+
+// putLabels adds labels to the attributes of every data point of md
+func putLabels(md pmetric.Metrics, labels map[string]string) {
+	if len(labels) == 0 {
+		return
+	}
+	keys := slices.Sorted(maps.Keys(labels))
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		switch m.Type() {
+		case pmetric.MetricTypeGauge:
+			putSliceLabels(m.Gauge().DataPoints(), keys, labels)
+		case pmetric.MetricTypeSum:
+			putSliceLabels(m.Sum().DataPoints(), keys, labels)
+		case pmetric.MetricTypeHistogram:
+			putSliceLabels(m.Histogram().DataPoints(), keys, labels)
+		case pmetric.MetricTypeExponentialHistogram:
+			putSliceLabels(m.ExponentialHistogram().DataPoints(), keys, labels)
+		case pmetric.MetricTypeSummary:
+			putSliceLabels(m.Summary().DataPoints(), keys, labels)
+		}
+	})
+}
+
+func putSliceLabels[T interface{ Attributes() pcommon.Map }](dps attributedSlice[T], keys []string, labels map[string]string) {
+	for i := 0; i < dps.Len(); i++ {
+		attrs := dps.At(i).Attributes()
+		for _, key := range keys {
+			attrs.PutStr(key, labels[key])
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+)
+
+func TestReservedAttributesCoverMetadata(t *testing.T) {
+	cm, err := confmaptest.LoadConf("metadata.yaml")
+	require.NoError(t, err)
+	attrs, err := cm.Sub("attributes")
+	require.NoError(t, err)
+	for _, name := range slices.Sorted(maps.Keys(attrs.ToStringMap())) {
+		assert.True(t, reservedAttributes[name], "attribute %s is not reserved", name)
+	}
+}
+
+func TestValidateLabels(t *testing.T) {
+	assert.NoError(t, validateLabels(nil))
+	assert.NoError(t, validateLabels(map[string]string{"datacenter": "us-east"}))
+	assert.EqualError(t, validateLabels(map[string]string{"": "x", "net.peer.ip": "10.0.0.1"}),
+		"labels: key cannot be empty; labels: net.peer.ip is an attribute of the receiver")
+}
+
+func TestScraperLabels(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{
+		Endpoint: "192.0.2.1",
+		Count:    4,
+		Labels:   map[string]string{"datacenter": "us-east", "tier": "core-router"},
+	}}
+
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(pingchecktest.NewProber())))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	md, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	require.Positive(t, md.DataPointCount())
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		var attrs []map[string]any
+		switch m.Type() {
+		case pmetric.MetricTypeGauge:
+			for i := 0; i < m.Gauge().DataPoints().Len(); i++ {
+				attrs = append(attrs, m.Gauge().DataPoints().At(i).Attributes().AsRaw())
+			}
+		case pmetric.MetricTypeSum:
+			for i := 0; i < m.Sum().DataPoints().Len(); i++ {
+				attrs = append(attrs, m.Sum().DataPoints().At(i).Attributes().AsRaw())
+			}
+		}
+		for _, a := range attrs {
+			assert.Equal(t, "us-east", a["datacenter"], m.Name())
+			assert.Equal(t, "core-router", a["tier"], m.Name())
+			assert.Equal(t, "192.0.2.1", a["net.peer.name"], m.Name())
+		}
+	})
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"maps"
+	"slices"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// Lifecycle events, set as the event field of lifecycle log records
+const (
+	targetAdded          = "added"
+	targetRemoved        = "removed"
+	targetCreationFailed = "creation_failed"
+)
+
+// lifecycleLog logs targets being added, removed or failing creation, so the
+// churn of discovered targets can be followed without diffing configs. Pingers
+// recreated successfully are not churn and are not logged again.
+type lifecycleLog struct {
+	logger *zap.Logger
+
+	mu     sync.Mutex
+	active map[string]bool
+	// Reason of the latest failed creation of each endpoint, a retry failing
+	// the same way is not logged again
+	failures map[string]string
+}
+
+func newLifecycleLog(logger *zap.Logger) *lifecycleLog {
+	return &lifecycleLog{
+		logger:   logger.Named("lifecycle"),
+		active:   make(map[string]bool),
+		failures: make(map[string]string),
+	}
+}
+
+// added records that a pinger was created for endpoint
+func (l *lifecycleLog) added(endpoint string, pinger prober.Pinger) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.active[endpoint] {
+		return
+	}
+	l.active[endpoint] = true
+	delete(l.failures, endpoint)
+
+	fields := []zap.Field{zap.String("event", targetAdded), zap.String("endpoint", endpoint)}
+	if r, ok := pinger.(prober.Resolved); ok && r.IPAddr() != nil {
+		fields = append(fields, zap.String("ip", r.IPAddr().String()))
+	}
+	l.logger.Info("Target added", fields...)
+}
+
+// failed records that no pinger could be created for endpoint, it is added
+// again once creation succeeds
+func (l *lifecycleLog) failed(endpoint string, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	reason := err.Error()
+	if l.failures[endpoint] == reason {
+		return
+	}
+	delete(l.active, endpoint)
+	l.failures[endpoint] = reason
+	l.logger.Error("Target creation failed",
+		zap.String("event", targetCreationFailed),
+		zap.String("endpoint", endpoint),
+		zap.String("reason", reason))
+}
+
+// removeAll records that every target, created or not, was removed
+func (l *lifecycleLog) removeAll() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	endpoints := maps.Clone(l.active)
+	for endpoint := range l.failures {
+		endpoints[endpoint] = true
+	}
+	for _, endpoint := range slices.Sorted(maps.Keys(endpoints)) {
+		l.logger.Info("Target removed",
+			zap.String("event", targetRemoved),
+			zap.String("endpoint", endpoint))
+	}
+	clear(l.active)
+	clear(l.failures)
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+)
+
+func TestLifecycleLog(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	l := newLifecycleLog(zap.New(core))
+
+	l.failed("192.0.2.1", errors.New("no such host"))
+	// A retry failing the same way is not logged again
+	l.failed("192.0.2.1", errors.New("no such host"))
+	l.added("192.0.2.1", nil)
+	l.added("192.0.2.1", nil)
+	l.added("192.0.2.2", nil)
+	l.removeAll()
+
+	var events []string
+	for _, entry := range logs.All() {
+		assert.Equal(t, "lifecycle", entry.LoggerName)
+		ctx := entry.ContextMap()
+		events = append(events, ctx["event"].(string)+" "+ctx["endpoint"].(string))
+	}
+	assert.Equal(t, []string{
+		"creation_failed 192.0.2.1",
+		"added 192.0.2.1",
+		"added 192.0.2.2",
+		"removed 192.0.2.1",
+		"removed 192.0.2.2",
+	}, events)
+	assert.Equal(t, "no such host", logs.FilterMessage("Target creation failed").All()[0].ContextMap()["reason"])
+}
+
+func TestScraperLifecycle(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 1}, {Endpoint: "192.0.2.2", Count: 1}}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetCreateError("192.0.2.2", errors.New("no route"))
+	core, logs := observer.New(zap.InfoLevel)
+	settings := receivertest.NewNopSettings(metadata.Type)
+	settings.Logger = zap.New(core)
+	scraper := newScraper(cfg, settings, newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	added := logs.FilterMessage("Target added").All()
+	require.Len(t, added, 1)
+	assert.Equal(t, "192.0.2.1", added[0].ContextMap()["endpoint"])
+	assert.Equal(t, "192.0.2.1", added[0].ContextMap()["ip"])
+	failed := logs.FilterMessage("Target creation failed").All()
+	require.Len(t, failed, 1)
+	assert.Equal(t, "no route", failed[0].ContextMap()["reason"])
+
+	// The target is added once creation succeeds on a later scrape
+	fakeProber.SetCreateError("192.0.2.2", nil)
+	_, err := scraper.scrapeTarget(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Len(t, logs.FilterMessage("Target added").All(), 2)
+
+	require.NoError(t, scraper.shutdown(context.Background()))
+	assert.Len(t, logs.FilterMessage("Target removed").All(), 2)
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// fdHeadroom is left for the collector's own files and connections
+	fdHeadroom = 64
+
+	// memoryPerEndpoint roughly bounds what a concurrent probe holds: its
+	// goroutine, socket buffers and metrics builder
+	memoryPerEndpoint = 64 << 10
+)
+
+// hostLimits are the resource limits the receiver runs under, zero if unknown
+type hostLimits struct {
+	// openFiles is the soft limit of open file descriptors
+	openFiles uint64
+
+	// memory is the memory limit of the cgroup in bytes
+	memory uint64
+
+	// cpus is the CPU quota of the cgroup
+	cpus float64
+}
+
+// readHostLimits reads the limits of the process and its cgroup
+func readHostLimits() hostLimits {
+	limits := readCgroupLimits("/sys/fs/cgroup")
+	limits.openFiles = openFilesLimit()
+	return limits
+}
+
+// readCgroupLimits reads the memory and CPU limits of cgroup v2, or v1 if
+// mounted at root instead
+func readCgroupLimits(root string) hostLimits {
+	var limits hostLimits
+	if v, ok := readCgroupValue(filepath.Join(root, "memory.max")); ok {
+		limits.memory = uint64(v)
+	} else if v, ok := readCgroupValue(filepath.Join(root, "memory", "memory.limit_in_bytes")); ok && v < math.MaxInt64/2 {
+		// Without a limit v1 reports a value near the maximum
+		limits.memory = uint64(v)
+	}
+
+	if data, err := os.ReadFile(filepath.Join(root, "cpu.max")); err == nil {
+		// Quota and period, e.g. "200000 100000", or "max 100000" without a quota
+		fields := strings.Fields(string(data))
+		if len(fields) == 2 {
+			quota, qErr := strconv.ParseFloat(fields[0], 64)
+			period, pErr := strconv.ParseFloat(fields[1], 64)
+			if qErr == nil && pErr == nil && period > 0 {
+				limits.cpus = quota / period
+			}
+		}
+	} else {
+		quota, qOK := readCgroupValue(filepath.Join(root, "cpu", "cpu.cfs_quota_us"))
+		period, pOK := readCgroupValue(filepath.Join(root, "cpu", "cpu.cfs_period_us"))
+		if qOK && pOK && quota > 0 && period > 0 {
+			limits.cpus = float64(quota) / float64(period)
+		}
+	}
+	return limits
+}
+
+// readCgroupValue reads a numeric cgroup file, which is "max" without a limit
+func readCgroupValue(path string) (int64, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	v, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	return v, err == nil && v > 0
+}
+
+// check compares the limits with what probing endpoints concurrently needs.
+// Running out of file descriptors fails every probe of a scrape, so it is an
+// error, the other limits only slow probing down and are logged.
+func (l hostLimits) check(endpoints int, logger *zap.Logger) error {
+	if need := uint64(endpoints) + fdHeadroom; l.openFiles > 0 && need > l.openFiles {
+		return fmt.Errorf("probing %d endpoints concurrently needs about %d file descriptors, but the limit is %d: raise it (ulimit -n) or configure fewer targets",
+			endpoints, need, l.openFiles)
+	}
+
+	if need := uint64(endpoints) * memoryPerEndpoint; l.memory > 0 && need > l.memory/2 {
+		logger.Warn("Probing all targets may need a large share of the memory limit",
+			zap.Int("endpoints", endpoints),
+			zap.String("estimated", formatBytes(need)),
+			zap.String("limit", formatBytes(l.memory)))
+	}
+
+	if procs := runtime.GOMAXPROCS(0); l.cpus > 0 && float64(procs) > math.Ceil(l.cpus) {
+		logger.Warn("GOMAXPROCS exceeds the CPU quota, set GOMAXPROCS to the quota to avoid throttling",
+			zap.Int("gomaxprocs", procs),
+			zap.Float64("cpu_quota", l.cpus))
+	}
+	return nil
+}
+
+// formatBytes renders n in MiB, e.g. 64.0MiB
+func formatBytes(n uint64) string {
+	return fmt.Sprintf("%.1fMiB", float64(n)/(1<<20))
+}
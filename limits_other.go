@@ -0,0 +1,11 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !unix
+
+package pingcheckreceiver
+
+// openFilesLimit is unknown where there is no file descriptor limit to read
+func openFilesLimit() uint64 {
+	return 0
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+)
+
+func writeCgroupFile(t *testing.T, root, name, content string) {
+	t.Helper()
+	path := filepath.Join(root, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestReadCgroupLimits(t *testing.T) {
+	v2 := t.TempDir()
+	writeCgroupFile(t, v2, "memory.max", "536870912\n")
+	writeCgroupFile(t, v2, "cpu.max", "150000 100000\n")
+	assert.Equal(t, hostLimits{memory: 512 << 20, cpus: 1.5}, readCgroupLimits(v2))
+
+	unlimited := t.TempDir()
+	writeCgroupFile(t, unlimited, "memory.max", "max\n")
+	writeCgroupFile(t, unlimited, "cpu.max", "max 100000\n")
+	assert.Equal(t, hostLimits{}, readCgroupLimits(unlimited))
+
+	v1 := t.TempDir()
+	writeCgroupFile(t, v1, "memory/memory.limit_in_bytes", "268435456\n")
+	writeCgroupFile(t, v1, "cpu/cpu.cfs_quota_us", "200000\n")
+	writeCgroupFile(t, v1, "cpu/cpu.cfs_period_us", "100000\n")
+	assert.Equal(t, hostLimits{memory: 256 << 20, cpus: 2}, readCgroupLimits(v1))
+
+	v1Unlimited := t.TempDir()
+	writeCgroupFile(t, v1Unlimited, "memory/memory.limit_in_bytes", "9223372036854771712\n")
+	writeCgroupFile(t, v1Unlimited, "cpu/cpu.cfs_quota_us", "-1\n")
+	writeCgroupFile(t, v1Unlimited, "cpu/cpu.cfs_period_us", "100000\n")
+	assert.Equal(t, hostLimits{}, readCgroupLimits(v1Unlimited))
+
+	assert.Equal(t, hostLimits{}, readCgroupLimits(t.TempDir()))
+}
+
+func TestHostLimitsCheck(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	logger := zap.New(core)
+
+	assert.NoError(t, hostLimits{}.check(10_000, logger))
+	assert.NoError(t, hostLimits{openFiles: 1024}.check(960, logger))
+	assert.EqualError(t, hostLimits{openFiles: 1024}.check(961, logger),
+		"probing 961 endpoints concurrently needs about 1025 file descriptors, but the limit is 1024: raise it (ulimit -n) or configure fewer targets")
+	assert.Zero(t, logs.Len())
+
+	// 10k targets need about 625MiB, more than half of 1GiB
+	require.NoError(t, hostLimits{memory: 1 << 30}.check(10_000, logger))
+	warnings := logs.TakeAll()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "625.0MiB", warnings[0].ContextMap()["estimated"])
+	assert.Equal(t, "1024.0MiB", warnings[0].ContextMap()["limit"])
+
+	require.NoError(t, hostLimits{cpus: 0.5}.check(1, logger))
+	if runtime.GOMAXPROCS(0) > 1 {
+		assert.Equal(t, 1, logs.FilterMessage("GOMAXPROCS exceeds the CPU quota, set GOMAXPROCS to the quota to avoid throttling").Len())
+	}
+}
+
+func TestScraperStartChecksLimits(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{
+		{Endpoint: "192.0.2.1", Count: 4},
+		{Endpoint: "192.0.2.1", Count: 4, Group: "duplicate"},
+		{Endpoint: "192.0.2.2", Count: 4},
+	}
+
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(pingchecktest.NewProber())))
+	scraper.readLimits = func() hostLimits { return hostLimits{openFiles: fdHeadroom + 1} }
+	err := scraper.start(context.Background(), componenttest.NewNopHost())
+	// Targets of the same endpoint share a socket
+	assert.EqualError(t, err, "probing 2 endpoints concurrently needs about 66 file descriptors, but the limit is 65: raise it (ulimit -n) or configure fewer targets")
+	require.NoError(t, scraper.shutdown(context.Background()))
+}
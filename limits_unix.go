@@ -0,0 +1,19 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build unix
+
+package pingcheckreceiver
+
+import (
+	"syscall"
+)
+
+// openFilesLimit returns the soft limit of open file descriptors
+func openFilesLimit() uint64 {
+	var rlimit syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &rlimit); err != nil {
+		return 0
+	}
+	return uint64(rlimit.Cur)
+}
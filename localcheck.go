@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// localCheckTTL is how long a local check result is shared by failing targets,
+// so a scrape with many failures checks the local stack once
+const localCheckTTL = 5 * time.Second
+
+// localChecker probes loopback and the default gateway to tell local faults
+// apart from unreachable targets
+type localChecker struct {
+	cfg        LocalCheckConfig
+	prober     prober.Prober
+	privileged bool
+	clock      prober.Clock
+	gateway    func() (net.IP, error)
+
+	mu        sync.Mutex
+	checkedAt time.Time
+	ok        bool
+}
+
+func newLocalChecker(cfg LocalCheckConfig, p prober.Prober, privileged bool, clock prober.Clock) *localChecker {
+	return &localChecker{
+		cfg:        cfg,
+		prober:     p,
+		privileged: privileged,
+		clock:      clock,
+		gateway:    defaultGateway,
+	}
+}
+
+// networkOK reports whether the local stack and the default gateway answer
+func (c *localChecker) networkOK(ctx context.Context) bool {
+	// Concurrent callers wait for a single check and share its result
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	if !c.checkedAt.IsZero() && now.Sub(c.checkedAt) < localCheckTTL {
+		return c.ok
+	}
+	c.ok = c.check(ctx)
+	c.checkedAt = now
+	return c.ok
+}
+
+func (c *localChecker) check(ctx context.Context) bool {
+	endpoints := []string{"127.0.0.1"}
+	gateway := c.cfg.Gateway
+	if gateway == "" {
+		if ip, err := c.gateway(); err == nil {
+			gateway = ip.String()
+		}
+	}
+	if gateway != "" {
+		endpoints = append(endpoints, gateway)
+	}
+
+	for _, endpoint := range endpoints {
+		if !c.reachable(ctx, endpoint) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *localChecker) reachable(ctx context.Context, endpoint string) bool {
+	pinger, err := c.prober.NewPinger(prober.PingerConfig{
+		Endpoint:   endpoint,
+		Count:      1,
+		Timeout:    c.cfg.Timeout,
+		Interval:   c.cfg.Timeout,
+		Privileged: c.privileged,
+	})
+	if err != nil {
+		return false
+	}
+	defer pinger.Stop()
+
+	stats, err := pinger.Run(ctx)
+	return err == nil && stats.PacketsRecv > 0
+}
+
+var errNoDefaultRoute = errors.New("no default route")
+
+// parseRouteTable returns the IPv4 default gateway from a Linux /proc/net/route table
+func parseRouteTable(r io.Reader) (net.IP, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Scan() // Skip the header
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// Iface Destination Gateway Flags ...
+		if len(fields) < 4 || fields[1] != "00000000" {
+			continue
+		}
+		raw, err := hex.DecodeString(fields[2])
+		if err != nil || len(raw) != net.IPv4len {
+			continue
+		}
+		// The kernel prints addresses in host byte order, which is little endian on all supported platforms
+		ip := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ip, binary.LittleEndian.Uint32(raw))
+		if ip.IsUnspecified() {
+			continue
+		}
+		return ip, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, errNoDefaultRoute
+}
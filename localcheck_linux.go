@@ -0,0 +1,21 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package pingcheckreceiver
+
+import (
+	"net"
+	"os"
+)
+
+// defaultGateway returns the IPv4 default gateway of the host
+func defaultGateway() (net.IP, error) {
+	f, err := os.Open("/proc/net/route")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseRouteTable(f)
+}
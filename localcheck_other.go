@@ -0,0 +1,16 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux
+
+package pingcheckreceiver
+
+import (
+	"errors"
+	"net"
+)
+
+// defaultGateway is only detected on Linux, elsewhere set local_check.gateway
+func defaultGateway() (net.IP, error) {
+	return nil, errors.ErrUnsupported
+}
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/scraper/scraperhelper"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+func TestParseRouteTable(t *testing.T) {
+	table := `Iface	Destination	Gateway 	Flags	RefCnt	Use	Metric	Mask		MTU	Window	IRTT
+eth0	0000FEA9	00000000	0001	0	0	1000	0000FFFF	0	0	0
+eth0	0002A8C0	00000000	0001	0	0	100	00FFFFFF	0	0	0
+eth0	00000000	0102A8C0	0003	0	0	100	00000000	0	0	0
+`
+	ip, err := parseRouteTable(strings.NewReader(table))
+	require.NoError(t, err)
+	assert.Equal(t, "192.168.2.1", ip.String())
+
+	_, err = parseRouteTable(strings.NewReader(strings.Join(strings.Split(table, "\n")[:3], "\n")))
+	assert.ErrorIs(t, err, errNoDefaultRoute)
+}
+
+func TestLocalCheckerNetworkOK(t *testing.T) {
+	clock := pingchecktest.NewClock(time.Unix(1_700_000_000, 0))
+	fakeProber := pingchecktest.NewProber()
+	checker := newLocalChecker(LocalCheckConfig{Enabled: true, Timeout: time.Second}, fakeProber, false, clock)
+	checker.gateway = func() (net.IP, error) {
+		return net.IPv4(192, 168, 2, 1), nil
+	}
+
+	assert.True(t, checker.networkOK(context.Background()))
+	assert.Equal(t, 1, fakeProber.Runs("127.0.0.1"))
+	assert.Equal(t, 1, fakeProber.Runs("192.168.2.1"))
+
+	// Results are shared for a while
+	fakeProber.SetResult("192.168.2.1", prober.Statistics{PacketsSent: 1})
+	assert.True(t, checker.networkOK(context.Background()))
+	assert.Equal(t, 1, fakeProber.Runs("192.168.2.1"))
+
+	clock.Advance(localCheckTTL)
+	assert.False(t, checker.networkOK(context.Background()))
+
+	// A configured gateway takes precedence over the routing table
+	checker.cfg.Gateway = "10.0.0.1"
+	clock.Advance(localCheckTTL)
+	assert.True(t, checker.networkOK(context.Background()))
+	assert.Equal(t, 1, fakeProber.Runs("10.0.0.1"))
+
+	// Without a gateway only loopback is checked
+	checker.cfg.Gateway = ""
+	checker.gateway = func() (net.IP, error) {
+		return nil, errNoDefaultRoute
+	}
+	fakeProber.SetRunError("127.0.0.1", errors.New("operation not permitted"))
+	clock.Advance(localCheckTTL)
+	assert.False(t, checker.networkOK(context.Background()))
+}
+
+func TestScraperLocalCheck(t *testing.T) {
+	cfg := &Config{
+		ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Targets:              []Target{{Endpoint: "192.0.2.1"}},
+		LocalCheck:           LocalCheckConfig{Enabled: true, Gateway: "10.0.0.1", Timeout: time.Second},
+	}
+	cfg.Metrics.PingErrors.Enabled = true
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetRunError("192.0.2.1", errors.New("timeout"))
+	fakeProber.SetRunError("10.0.0.1", errors.New("timeout"))
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	metrics, err := scraper.scrapeTarget(context.Background(), 0)
+	require.Error(t, err)
+	require.NoError(t, scraper.shutdown(context.Background()))
+
+	m := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, "ping.errors", m.Name())
+	localOK, ok := m.Sum().DataPoints().At(0).Attributes().Get("local_network_ok")
+	require.True(t, ok)
+	assert.False(t, localOK.Bool())
+}
+
+func TestScraperWithoutLocalCheck(t *testing.T) {
+	cfg := &Config{
+		ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Targets:              []Target{{Endpoint: "192.0.2.1"}},
+	}
+	cfg.Metrics.PingErrors.Enabled = true
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetRunError("192.0.2.1", errors.New("timeout"))
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	metrics, err := scraper.scrapeTarget(context.Background(), 0)
+	require.Error(t, err)
+	require.NoError(t, scraper.shutdown(context.Background()))
+
+	// Nothing is known about the local network without the check
+	m := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, "ping.errors", m.Name())
+	_, ok := m.Sum().DataPoints().At(0).Attributes().Get("local_network_ok")
+	assert.False(t, ok)
+}
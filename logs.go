@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+)
+
+// stateChanged is the event of log records about a target going up or down
+const stateChanged = "state_changed"
+
+// Values of the state attribute of stateChanged records
+const (
+	stateUp   = "up"
+	stateDown = "down"
+)
+
+// scrapeLogs reports a log record for every target whose latest probe failed
+// or lost packets, and for every target that went up or down. Probes are shared with the metrics receiver of the same
+// config, so logs do not add probes of their own.
+func (s *pingScraper) scrapeLogs(ctx context.Context) (plog.Logs, error) {
+	outcomes := s.latestOutcomes(ctx, pipeline.SignalLogs)
+	ld := plog.NewLogs()
+	observed := pcommon.NewTimestampFromTime(s.clock.Now())
+	for i, o := range outcomes {
+		if o == nil {
+			continue
+		}
+		failed := !o.suppressed && !o.healthy()
+		traced := o.trace != nil && s.cfg.Targets[i].Traceroute.Log
+		if !failed && o.sourceChange == nil && !o.transitioned && !traced {
+			continue
+		}
+		rl := ld.ResourceLogs().AppendEmpty()
+		s.resources[i].CopyTo(rl.Resource())
+		sl := rl.ScopeLogs().AppendEmpty()
+		sl.Scope().SetName(metadata.ScopeName)
+		if o.sourceChange != nil {
+			recordSourceChangeLog(sl.LogRecords().AppendEmpty(), s.cfg.Targets[i], o.sourceChange, observed)
+		}
+		if o.transitioned {
+			s.recordStateChangeLog(sl.LogRecords().AppendEmpty(), s.cfg.Targets[i], o, observed)
+		}
+		if failed {
+			s.recordOutcomeLog(sl.LogRecords().AppendEmpty(), s.cfg.Targets[i], o, observed)
+		}
+		if traced {
+			s.recordTracerouteLog(sl.LogRecords().AppendEmpty(), s.cfg.Targets[i], o.trace, observed)
+		}
+	}
+	return ld, nil
+}
+
+// recordSourceChangeLog describes in lr the source address change found
+// before a probe, whose pingers were recreated for it
+func recordSourceChangeLog(lr plog.LogRecord, target Target, change *sourceChange, observed pcommon.Timestamp) {
+	lr.SetObservedTimestamp(observed)
+	lr.SetTimestamp(observed)
+	lr.SetSeverityNumber(plog.SeverityNumberInfo)
+	lr.SetSeverityText(plog.SeverityNumberInfo.String())
+	lr.SetEventName(sourceChanged)
+	lr.Body().SetStr("Source address changed")
+
+	attrs := lr.Attributes()
+	attrs.PutStr("net.peer.name", target.Endpoint)
+	attrs.PutStr("source.previous", change.previous.String())
+	attrs.PutStr("source.current", change.current.String())
+}
+
+// recordStateChangeLog describes in lr the target going up or down with
+// probe outcome o
+func (s *pingScraper) recordStateChangeLog(lr plog.LogRecord, target Target, o *probeOutcome, observed pcommon.Timestamp) {
+	lr.SetObservedTimestamp(observed)
+	lr.SetTimestamp(observed)
+	lr.SetEventName(stateChanged)
+
+	attrs := lr.Attributes()
+	attrs.PutStr("net.peer.name", target.Endpoint)
+	if o.stats != nil && o.stats.IPAddr != nil {
+		attrs.PutStr("net.peer.ip", s.ips.String(o.stats.IPAddr))
+	}
+	attrs.PutInt("ping.consecutive_failures", int64(o.failures))
+	if o.failures == 0 {
+		lr.SetSeverityNumber(plog.SeverityNumberInfo)
+		lr.SetSeverityText(plog.SeverityNumberInfo.String())
+		lr.Body().SetStr("Target is reachable")
+		attrs.PutStr("state", stateUp)
+		return
+	}
+
+	lr.SetSeverityNumber(plog.SeverityNumberWarn)
+	lr.SetSeverityText(plog.SeverityNumberWarn.String())
+	lr.Body().SetStr("Target is unreachable")
+	attrs.PutStr("state", stateDown)
+	if o.errorType != 0 {
+		attrs.PutStr("error.type", o.errorType.String())
+	}
+}
+
+// recordOutcomeLog describes a failed or lossy probe in lr
+func (s *pingScraper) recordOutcomeLog(lr plog.LogRecord, target Target, o *probeOutcome, observed pcommon.Timestamp) {
+	lr.SetObservedTimestamp(observed)
+	lr.SetSeverityNumber(plog.SeverityNumberWarn)
+	lr.SetSeverityText(plog.SeverityNumberWarn.String())
+
+	attrs := lr.Attributes()
+	attrs.PutStr("net.peer.name", target.Endpoint)
+	if o.errorType != 0 {
+		attrs.PutStr("error.type", o.errorType.String())
+	}
+	if o.managementOK != nil {
+		attrs.PutBool("management_plane.responding", *o.managementOK)
+	}
+	if s.flows != nil {
+		attrs.PutBool("passively_seen", o.passivelySeen)
+	}
+	if o.err != nil {
+		lr.SetTimestamp(observed)
+		lr.Body().SetStr("Ping failed")
+		attrs.PutStr("error.message", o.err.Error())
+		return
+	}
+
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(o.finished))
+	lr.Body().SetStr("Ping lost packets")
+	attrs.PutStr("net.peer.ip", s.ips.String(o.stats.IPAddr))
+	attrs.PutInt("ping.packets.sent", int64(o.stats.PacketsSent))
+	attrs.PutInt("ping.packets.received", int64(o.stats.PacketsRecv))
+	attrs.PutDouble("ping.packet_loss", o.stats.PacketLoss/100.0)
+}
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+func TestScrapeLogs(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{
+		{Endpoint: "192.0.2.1", Count: 4},
+		{Endpoint: "192.0.2.2", Count: 4},
+		{Endpoint: "192.0.2.3", Count: 4},
+	}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetResult("192.0.2.2", prober.Statistics{PacketsSent: 4, PacketsRecv: 3, PacketLoss: 25})
+	fakeProber.SetRunError("192.0.2.3", errors.New("i/o timeout"))
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	ld, err := scraper.scrapeLogs(context.Background())
+	require.NoError(t, err)
+
+	// Healthy targets are not logged
+	require.Equal(t, 2, ld.LogRecordCount())
+
+	loss := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "Ping lost packets", loss.Body().Str())
+	assert.Equal(t, plog.SeverityNumberWarn, loss.SeverityNumber())
+	assert.Equal(t, map[string]any{
+		"net.peer.name":         "192.0.2.2",
+		"net.peer.ip":           "192.0.2.2",
+		"ping.packets.sent":     int64(4),
+		"ping.packets.received": int64(3),
+		"ping.packet_loss":      0.25,
+	}, loss.Attributes().AsRaw())
+
+	failure := ld.ResourceLogs().At(1).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "Ping failed", failure.Body().Str())
+	assert.Equal(t, map[string]any{
+		"net.peer.name": "192.0.2.3",
+		"error.type":    "timeout",
+		"error.message": "ping failed: i/o timeout",
+	}, failure.Attributes().AsRaw())
+}
+
+func TestScrapeLogsStateChanges(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4}}
+
+	fakeProber := pingchecktest.NewProber()
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	events := func() []plog.LogRecord {
+		ld, err := scraper.scrapeLogs(context.Background())
+		require.NoError(t, err)
+		var records []plog.LogRecord
+		for i := 0; i < ld.ResourceLogs().Len(); i++ {
+			lrs := ld.ResourceLogs().At(i).ScopeLogs().At(0).LogRecords()
+			for j := 0; j < lrs.Len(); j++ {
+				if lrs.At(j).EventName() == stateChanged {
+					records = append(records, lrs.At(j))
+				}
+			}
+		}
+		return records
+	}
+
+	// The first probe has nothing to compare with
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 4, PacketsRecv: 4})
+	assert.Empty(t, events())
+
+	fakeProber.SetRunError("192.0.2.1", errors.New("i/o timeout"))
+	down := events()
+	require.Len(t, down, 1)
+	assert.Equal(t, "Target is unreachable", down[0].Body().Str())
+	assert.Equal(t, plog.SeverityNumberWarn, down[0].SeverityNumber())
+	assert.Equal(t, map[string]any{
+		"net.peer.name":             "192.0.2.1",
+		"state":                     "down",
+		"error.type":                "timeout",
+		"ping.consecutive_failures": int64(1),
+	}, down[0].Attributes().AsRaw())
+
+	// Staying down is no change
+	assert.Empty(t, events())
+
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 4, PacketsRecv: 4})
+	up := events()
+	require.Len(t, up, 1)
+	assert.Equal(t, "Target is reachable", up[0].Body().Str())
+	assert.Equal(t, plog.SeverityNumberInfo, up[0].SeverityNumber())
+	assert.Equal(t, map[string]any{
+		"net.peer.name":             "192.0.2.1",
+		"net.peer.ip":               "192.0.2.1",
+		"state":                     "up",
+		"ping.consecutive_failures": int64(0),
+	}, up[0].Attributes().AsRaw())
+}
+
+func TestScrapeLogsSharesProbesWithMetrics(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4}}
+
+	fakeProber := pingchecktest.NewProber()
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	_, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	_, err = scraper.scrapeLogs(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, fakeProber.Runs("192.0.2.1"))
+}
+
+func TestCreateLogsReceiverSharesScraper(t *testing.T) {
+	fo := newFactoryOptions(WithProber(pingchecktest.NewProber()), WithClock(pingchecktest.NewClock(time.Unix(1_700_000_000, 0))))
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1"}}
+	settings := receivertest.NewNopSettings(metadata.Type)
+
+	metricsReceiver, err := fo.createMetricsReceiver(context.Background(), settings, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	logsReceiver, err := fo.createLogsReceiver(context.Background(), settings, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	shared := fo.scrapers.scrapers[cfg]
+	require.NotNil(t, shared)
+	assert.Equal(t, map[pipeline.Signal]bool{pipeline.SignalMetrics: true, pipeline.SignalLogs: true}, shared.signals)
+
+	require.NoError(t, metricsReceiver.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, logsReceiver.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, metricsReceiver.Shutdown(context.Background()))
+	require.NoError(t, logsReceiver.Shutdown(context.Background()))
+	assert.Empty(t, fo.scrapers.scrapers)
+}
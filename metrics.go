@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+)
+
+// targetMetrics returns the receiver's metrics config with the overrides of
+// target applied, metrics the target does not mention keep their setting
+func (cfg *Config) targetMetrics(target Target) (metadata.MetricsConfig, error) {
+	metrics := cfg.Metrics
+	if len(target.Metrics) == 0 {
+		return metrics, nil
+	}
+
+	known := confmap.New()
+	if err := known.Marshal(metrics); err != nil {
+		return metrics, err
+	}
+	overrides := make(map[string]any, len(target.Metrics))
+	for _, name := range slices.Sorted(maps.Keys(target.Metrics)) {
+		if !known.IsSet(name) {
+			return metrics, fmt.Errorf("metrics: unknown metric %q", name)
+		}
+		overrides[name] = map[string]any{"enabled": target.Metrics[name].Enabled}
+	}
+	if err := confmap.NewFromStringMap(overrides).Unmarshal(&metrics); err != nil {
+		return metrics, fmt.Errorf("metrics: %w", err)
+	}
+	return metrics, nil
+}
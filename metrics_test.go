@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+func TestTargetMetrics(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+
+	metrics, err := cfg.targetMetrics(Target{Endpoint: "192.0.2.1"})
+	require.NoError(t, err)
+	assert.Equal(t, cfg.Metrics, metrics)
+
+	metrics, err = cfg.targetMetrics(Target{
+		Endpoint: "192.0.2.1",
+		Metrics: map[string]metadata.MetricConfig{
+			"ping.duration.min":          {Enabled: false},
+			"ping.duration.trimmed_mean": {Enabled: true},
+		},
+	})
+	require.NoError(t, err)
+	assert.False(t, metrics.PingDurationMin.Enabled)
+	assert.True(t, metrics.PingDurationTrimmedMean.Enabled)
+	assert.Equal(t, cfg.Metrics.PingDurationMax, metrics.PingDurationMax)
+	// The receiver's config is left as it is
+	assert.True(t, cfg.Metrics.PingDurationMin.Enabled)
+
+	_, err = cfg.targetMetrics(Target{
+		Endpoint: "192.0.2.1",
+		Metrics:  map[string]metadata.MetricConfig{"ping.rtt": {Enabled: true}},
+	})
+	assert.EqualError(t, err, `metrics: unknown metric "ping.rtt"`)
+}
+
+func TestScraperTargetMetrics(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics.PingDurationAvg.Enabled = false
+	cfg.Targets = []Target{
+		{Endpoint: "192.0.2.1", Count: 4},
+		{
+			Endpoint: "192.0.2.2",
+			Count:    4,
+			Metrics: map[string]metadata.MetricConfig{
+				"ping.duration.avg": {Enabled: true},
+				"ping.packets.sent": {Enabled: false},
+			},
+		},
+	}
+
+	fakeProber := pingchecktest.NewProber()
+	for _, target := range cfg.Targets {
+		fakeProber.SetResult(target.Endpoint, prober.Statistics{PacketsSent: 4, PacketsRecv: 4, AvgRtt: 10 * time.Millisecond})
+	}
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	names := func(i int) []string {
+		md, err := scraper.scrapeTarget(context.Background(), i)
+		require.NoError(t, err)
+		var names []string
+		ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+		for j := 0; j < ms.Len(); j++ {
+			names = append(names, ms.At(j).Name())
+		}
+		return names
+	}
+
+	first := names(0)
+	assert.NotContains(t, first, "ping.duration.avg")
+	assert.Contains(t, first, "ping.packets.sent")
+
+	second := names(1)
+	assert.Contains(t, second, "ping.duration.avg")
+	assert.NotContains(t, second, "ping.packets.sent")
+}
+
+func TestScraperTargetMetricsRecordRtts(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{
+		{Endpoint: "192.0.2.1", Count: 4},
+		{
+			Endpoint: "192.0.2.2",
+			Count:    4,
+			Metrics:  map[string]metadata.MetricConfig{"ping.duration.trimmed_mean": {Enabled: true}},
+		},
+	}
+
+	fakeProber := pingchecktest.NewProber()
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	// Only the pinger of the target reporting trimmed_mean keeps samples
+	pingerCfg, ok := fakeProber.PingerConfig("192.0.2.1")
+	require.True(t, ok)
+	assert.False(t, pingerCfg.RecordRtts)
+	pingerCfg, ok = fakeProber.PingerConfig("192.0.2.2")
+	require.True(t, ok)
+	assert.True(t, pingerCfg.RecordRtts)
+}
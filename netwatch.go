@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// defaultRouteProbe is used to find the egress interface of hostname targets
+// without resolving them, which would fail anyway while the interface is down
+var defaultRouteProbe = net.IPv4(8, 8, 8, 8)
+
+// interfaceDownError marks a failure that coincided with the local egress interface being down
+type interfaceDownError struct {
+	iface string
+	err   error
+}
+
+func (e *interfaceDownError) Error() string {
+	if e.iface == "" {
+		return fmt.Sprintf("no egress interface: %v", e.err)
+	}
+	return fmt.Sprintf("egress interface %s down: %v", e.iface, e.err)
+}
+
+func (e *interfaceDownError) Unwrap() error {
+	return e.err
+}
+
+// linkMonitor tracks the state of the host's network interfaces so probe
+// failures can be related to the local egress interface going down
+type linkMonitor struct {
+	clock      prober.Clock
+	interfaces func() ([]net.Interface, error)
+	egress     func(ip net.IP) (string, error)
+
+	mu       sync.Mutex
+	watching bool
+	up       map[string]bool
+	lastDown map[string]time.Time
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newLinkMonitor(clock prober.Clock) *linkMonitor {
+	return &linkMonitor{
+		clock:      clock,
+		interfaces: net.Interfaces,
+		egress:     egressInterface,
+		up:         make(map[string]bool),
+		lastDown:   make(map[string]time.Time),
+		cancel:     func() {},
+	}
+}
+
+// start takes a first snapshot and watches for link changes. Where watching is
+// not supported the interfaces are checked on demand instead.
+func (m *linkMonitor) start(logger *zap.Logger) {
+	m.refresh()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := subscribeLinks(ctx)
+	if err != nil {
+		cancel()
+		logger.Debug("Cannot watch network interfaces, checking them on demand", zap.Error(err))
+		return
+	}
+
+	m.mu.Lock()
+	m.watching = true
+	m.cancel = cancel
+	m.mu.Unlock()
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		for range events {
+			m.refresh()
+		}
+	}()
+}
+
+// stop stops watching for link changes
+func (m *linkMonitor) stop() {
+	m.mu.Lock()
+	cancel := m.cancel
+	m.mu.Unlock()
+
+	cancel()
+	m.wg.Wait()
+}
+
+// refresh records the current state of all interfaces
+func (m *linkMonitor) refresh() {
+	ifaces, err := m.interfaces()
+	if err != nil {
+		return
+	}
+	now := m.clock.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	seen := make(map[string]bool, len(ifaces))
+	for _, iface := range ifaces {
+		up := iface.Flags&net.FlagUp != 0 && iface.Flags&net.FlagRunning != 0
+		seen[iface.Name] = true
+		m.up[iface.Name] = up
+		if !up {
+			m.lastDown[iface.Name] = now
+		}
+	}
+	// Interfaces that disappeared, e.g. unplugged USB adapters, are down
+	for name := range m.up {
+		if !seen[name] {
+			m.up[name] = false
+			m.lastDown[name] = now
+		}
+	}
+}
+
+// egressDown reports whether the interface used to reach endpoint is down, or
+// went down at any point since the given time
+func (m *linkMonitor) egressDown(endpoint string, since time.Time) (string, bool) {
+	m.mu.Lock()
+	watching := m.watching
+	m.mu.Unlock()
+	if !watching {
+		m.refresh()
+	}
+
+	ip := net.ParseIP(endpoint)
+	if ip == nil {
+		ip = defaultRouteProbe
+	}
+	name, err := m.egress(ip)
+	if err != nil {
+		// No route at all, which is what a downed interface leaves behind
+		return "", true
+	}
+	if name == "" {
+		return "", false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if up, ok := m.up[name]; ok && !up {
+		return name, true
+	}
+	if last, ok := m.lastDown[name]; ok && !last.Before(since) {
+		return name, true
+	}
+	return name, false
+}
+
+// egressInterface returns the name of the interface the kernel routes ip through
+func egressInterface(ip net.IP) (string, error) {
+	local, err := sourceAddress(ip)
+	if err != nil {
+		return "", err
+	}
+
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return "", nil
+	}
+	for _, iface := range ifaces {
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.Equal(local) {
+				return iface.Name, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// sourceAddress returns the local address the kernel picks to reach ip.
+// Connecting a UDP socket selects a route without sending anything.
+func sourceAddress(ip net.IP) (net.IP, error) {
+	conn, err := net.DialUDP("udp", nil, &net.UDPAddr{IP: ip, Port: 9})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).IP, nil
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package pingcheckreceiver
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// socketConstants are the constants sockets may be opened with
+var socketConstants = map[string]uint64{
+	"AF_INET":       unix.AF_INET,
+	"AF_INET6":      unix.AF_INET6,
+	"AF_NETLINK":    unix.AF_NETLINK,
+	"SOCK_STREAM":   unix.SOCK_STREAM,
+	"SOCK_DGRAM":    unix.SOCK_DGRAM,
+	"SOCK_RAW":      unix.SOCK_RAW,
+	"SOCK_CLOEXEC":  unix.SOCK_CLOEXEC,
+	"SOCK_NONBLOCK": unix.SOCK_NONBLOCK,
+}
+
+// socketArg evaluates a socket argument made of the constants above
+func socketArg(t *testing.T, expr ast.Expr) uint64 {
+	switch e := expr.(type) {
+	case *ast.SelectorExpr:
+		value, ok := socketConstants[e.Sel.Name]
+		if !ok {
+			t.Errorf("socket argument %s is not known", e.Sel.Name)
+		}
+		return value
+	case *ast.BinaryExpr:
+		if e.Op == token.OR {
+			return socketArg(t, e.X) | socketArg(t, e.Y)
+		}
+	case *ast.ParenExpr:
+		return socketArg(t, e.X)
+	}
+	t.Errorf("socket argument %T cannot be evaluated", expr)
+	return 0
+}
+
+// TestSeccompCoversSockets checks that the published seccomp profiles allow
+// every socket the receiver opens
+func TestSeccompCoversSockets(t *testing.T) {
+	// ICMP, TCP and DNS sockets of the standard library and pro-bing, raw ones
+	// only in privileged mode, and netlink of net.Interfaces
+	sockets := [][2]uint64{
+		{unix.AF_INET, unix.SOCK_DGRAM}, {unix.AF_INET6, unix.SOCK_DGRAM},
+		{unix.AF_INET, unix.SOCK_STREAM}, {unix.AF_INET6, unix.SOCK_STREAM},
+		{unix.AF_NETLINK, unix.SOCK_RAW},
+	}
+
+	// and those opened directly
+	files, err := filepath.Glob("*.go")
+	require.NoError(t, err)
+	proberFiles, err := filepath.Glob(filepath.Join("prober", "*.go"))
+	require.NoError(t, err)
+	direct := 0
+	fset := token.NewFileSet()
+	for _, path := range append(files, proberFiles...) {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		require.NoError(t, err)
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "Socket" || len(call.Args) < 2 {
+				return true
+			}
+			if pkg, ok := sel.X.(*ast.Ident); !ok || (pkg.Name != "unix" && pkg.Name != "syscall") {
+				return true
+			}
+			sockets = append(sockets, [2]uint64{socketArg(t, call.Args[0]), socketArg(t, call.Args[1])})
+			direct++
+			return true
+		})
+	}
+	// subscribeLinks opens one at least
+	require.Positive(t, direct)
+
+	for _, privileged := range []bool{false, true} {
+		profile := prober.Seccomp(privileged)
+		for _, socket := range sockets {
+			assert.True(t, profile.AllowsSocket(socket[0], socket[1]), "privileged %t, domain %d, type %#x", privileged, socket[0], socket[1])
+		}
+	}
+	assert.True(t, prober.Seccomp(true).AllowsSocket(unix.AF_INET, unix.SOCK_RAW))
+	assert.True(t, prober.Seccomp(true).AllowsSocket(unix.AF_INET6, unix.SOCK_RAW))
+}
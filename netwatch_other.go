@@ -0,0 +1,16 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"errors"
+)
+
+// subscribeLinks is only implemented on Linux, elsewhere interfaces are checked on demand
+func subscribeLinks(_ context.Context) (<-chan struct{}, error) {
+	return nil, errors.ErrUnsupported
+}
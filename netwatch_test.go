@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+)
+
+// fakeLinks is a linkMonitor over a fixed set of interfaces that all route through eth0
+type fakeLinks struct {
+	*linkMonitor
+	ifaces []net.Interface
+	route  error
+}
+
+func newFakeLinks(clock *pingchecktest.Clock) *fakeLinks {
+	f := &fakeLinks{
+		linkMonitor: newLinkMonitor(clock),
+		ifaces: []net.Interface{
+			{Name: "lo", Flags: net.FlagUp | net.FlagRunning | net.FlagLoopback},
+			{Name: "eth0", Flags: net.FlagUp | net.FlagRunning},
+		},
+	}
+	f.interfaces = func() ([]net.Interface, error) {
+		return f.ifaces, nil
+	}
+	f.egress = func(net.IP) (string, error) {
+		return "eth0", f.route
+	}
+	return f
+}
+
+func (f *fakeLinks) setUp(name string, up bool) {
+	for i := range f.ifaces {
+		if f.ifaces[i].Name == name {
+			f.ifaces[i].Flags &^= net.FlagUp | net.FlagRunning
+			if up {
+				f.ifaces[i].Flags |= net.FlagUp | net.FlagRunning
+			}
+		}
+	}
+}
+
+func TestLinkMonitorEgressDown(t *testing.T) {
+	clock := pingchecktest.NewClock(time.Unix(1_700_000_000, 0))
+	links := newFakeLinks(clock)
+	links.refresh()
+
+	started := clock.Now()
+	iface, down := links.egressDown("192.0.2.1", started)
+	assert.Equal(t, "eth0", iface)
+	assert.False(t, down)
+
+	// Down right now
+	clock.Advance(time.Second)
+	links.setUp("eth0", false)
+	_, down = links.egressDown("192.0.2.1", started)
+	assert.True(t, down)
+
+	// Flapped during the probe
+	clock.Advance(time.Second)
+	links.setUp("eth0", true)
+	_, down = links.egressDown("192.0.2.1", started)
+	assert.True(t, down)
+
+	// Up for the whole probe
+	clock.Advance(time.Second)
+	_, down = links.egressDown("example.com", clock.Now())
+	assert.False(t, down)
+
+	// No route left at all
+	links.route = errors.New("connect: network is unreachable")
+	iface, down = links.egressDown("192.0.2.1", clock.Now())
+	assert.Empty(t, iface)
+	assert.True(t, down)
+}
+
+func TestLinkMonitorRemovedInterfaceIsDown(t *testing.T) {
+	clock := pingchecktest.NewClock(time.Unix(1_700_000_000, 0))
+	links := newFakeLinks(clock)
+	links.refresh()
+
+	links.ifaces = links.ifaces[:1]
+	_, down := links.egressDown("192.0.2.1", clock.Now())
+	assert.True(t, down)
+}
+
+func TestLinkMonitorStartStop(t *testing.T) {
+	links := newLinkMonitor(pingchecktest.NewClock(time.Now()))
+	links.start(zap.NewNop())
+	links.stop()
+
+	links.mu.Lock()
+	defer links.mu.Unlock()
+	require.NotEmpty(t, links.up)
+}
+
+func TestInterfaceDownError(t *testing.T) {
+	cause := errors.New("timeout")
+	err := &interfaceDownError{iface: "wlan0", err: cause}
+	assert.EqualError(t, err, "egress interface wlan0 down: timeout")
+	assert.ErrorIs(t, err, cause)
+	assert.EqualError(t, &interfaceDownError{err: cause}, "no egress interface: timeout")
+}
@@ -0,0 +1,313 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+)
+
+// overflowAttribute marks the data points aggregated by the aggregate_overflow policy
+const overflowAttribute = "otel.metric.overflow"
+
+// newOverflowConsumer passes at most cfg.MaxDatapoints data points of a
+// collection cycle on to next. Data points beyond the limit are handled by
+// cfg.Policy, logged and counted in the receiver's own telemetry rather than
+// being truncated silently.
+func newOverflowConsumer(next consumer.Metrics, cfg OverflowConfig, settings receiver.Settings) (consumer.Metrics, error) {
+	overflowed, err := settings.MeterProvider.Meter(metadata.ScopeName).Int64Counter(
+		"otelcol_receiver_ping_overflow_data_points",
+		metric.WithDescription("Data points beyond overflow.max_datapoints, dropped or aggregated"),
+		metric.WithUnit("{datapoint}"))
+	if err != nil {
+		return nil, err
+	}
+
+	policy := cmp.Or(cfg.Policy, overflowDropOldest)
+	attrs := metric.WithAttributes(attribute.String("policy", policy))
+	return consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		if excess := applyOverflow(md, cfg.MaxDatapoints, policy); excess > 0 {
+			overflowed.Add(ctx, int64(excess), attrs)
+			settings.Logger.Warn("Data point limit exceeded",
+				zap.Int("max_datapoints", cfg.MaxDatapoints),
+				zap.Int("overflow", excess),
+				zap.String("policy", policy))
+		}
+		return next.ConsumeMetrics(ctx, md)
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+}
+
+// applyOverflow removes the data points of md beyond maxDataPoints according
+// to policy and returns how many there were. drop_oldest drops the data points
+// with the oldest timestamps. aggregate_overflow keeps the data points of the
+// first targets and merges the others into one data point per gauge or sum,
+// marked with otel.metric.overflow, keeping room for those within the limit.
+// Histograms and summaries beyond it are dropped, the receiver emits none.
+func applyOverflow(md pmetric.Metrics, maxDataPoints int, policy string) int {
+	total := md.DataPointCount()
+	excess := total - maxDataPoints
+	if maxDataPoints <= 0 || excess <= 0 {
+		return 0
+	}
+
+	drop := make([]bool, total)
+	var agg *overflowAggregator
+	if policy == overflowAggregate {
+		keep := aggregateKeep(md, maxDataPoints)
+		for i := keep; i < total; i++ {
+			drop[i] = true
+		}
+		excess = total - keep
+		agg = &overflowAggregator{points: make(map[string]*overflowPoint), limit: maxDataPoints - keep}
+	} else {
+		stamps := dataPointTimestamps(md)
+		order := make([]int, total)
+		for i := range order {
+			order[i] = i
+		}
+		slices.SortStableFunc(order, func(a, b int) int {
+			return cmp.Compare(stamps[a], stamps[b])
+		})
+		for _, i := range order[:excess] {
+			drop[i] = true
+		}
+	}
+
+	removeDataPoints(md, drop, agg)
+	if agg != nil {
+		agg.appendTo(md)
+	}
+	return excess
+}
+
+// aggregateKeep returns how many of the first data points of md
+// aggregate_overflow keeps, so that they and one aggregated data point per
+// metric name of the rest fit into maxDataPoints together
+func aggregateKeep(md pmetric.Metrics, maxDataPoints int) int {
+	names := aggregatedNames(md)
+	// aggregates[i] is the number of aggregated data points if i are kept
+	aggregates := make([]int, len(names)+1)
+	seen := make(map[string]bool)
+	for i := len(names) - 1; i >= 0; i-- {
+		aggregates[i] = aggregates[i+1]
+		if names[i] != "" && !seen[names[i]] {
+			seen[names[i]] = true
+			aggregates[i]++
+		}
+	}
+	for keep := maxDataPoints; keep > 0; keep-- {
+		if keep+aggregates[keep] <= maxDataPoints {
+			return keep
+		}
+	}
+	return 0
+}
+
+// aggregatedNames returns the metric name of every data point of md, in
+// order, or "" for those that are not aggregated
+func aggregatedNames(md pmetric.Metrics) []string {
+	names := make([]string, 0, md.DataPointCount())
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		name := ""
+		if m.Type() == pmetric.MetricTypeGauge || m.Type() == pmetric.MetricTypeSum {
+			name = m.Name()
+		}
+		for range dataPointCount(m) {
+			names = append(names, name)
+		}
+	})
+	return names
+}
+
+// dataPointTimestamps returns the timestamps of the data points of md, in order
+func dataPointTimestamps(md pmetric.Metrics) []pcommon.Timestamp {
+	stamps := make([]pcommon.Timestamp, 0, md.DataPointCount())
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		switch m.Type() {
+		case pmetric.MetricTypeGauge:
+			stamps = appendTimestamps(stamps, m.Gauge().DataPoints())
+		case pmetric.MetricTypeSum:
+			stamps = appendTimestamps(stamps, m.Sum().DataPoints())
+		case pmetric.MetricTypeHistogram:
+			stamps = appendTimestamps(stamps, m.Histogram().DataPoints())
+		case pmetric.MetricTypeExponentialHistogram:
+			stamps = appendTimestamps(stamps, m.ExponentialHistogram().DataPoints())
+		case pmetric.MetricTypeSummary:
+			stamps = appendTimestamps(stamps, m.Summary().DataPoints())
+		}
+	})
+	return stamps
+}
+
+// dataPointSlice is implemented by the data point slices of every metric type
+type dataPointSlice[T interface{ Timestamp() pcommon.Timestamp }] interface {
+	Len() int
+	At(int) T
+}
+
+func appendTimestamps[T interface{ Timestamp() pcommon.Timestamp }](stamps []pcommon.Timestamp, dps dataPointSlice[T]) []pcommon.Timestamp {
+	for i := 0; i < dps.Len(); i++ {
+		stamps = append(stamps, dps.At(i).Timestamp())
+	}
+	return stamps
+}
+
+// removeDataPoints removes the data points of md whose index is set in drop,
+// handing removed gauge and sum data points to agg if not nil. Metrics, scopes
+// and resources left without data points are removed as well.
+func removeDataPoints(md pmetric.Metrics, drop []bool, agg *overflowAggregator) {
+	i := 0
+	next := func() bool {
+		d := drop[i]
+		i++
+		return d
+	}
+	forEachMetric(md, func(sm pmetric.ScopeMetrics, m pmetric.Metric) {
+		removeNumber := func(dp pmetric.NumberDataPoint) bool {
+			if !next() {
+				return false
+			}
+			if agg != nil {
+				agg.add(sm, m, dp)
+			}
+			return true
+		}
+		switch m.Type() {
+		case pmetric.MetricTypeGauge:
+			m.Gauge().DataPoints().RemoveIf(removeNumber)
+		case pmetric.MetricTypeSum:
+			m.Sum().DataPoints().RemoveIf(removeNumber)
+		case pmetric.MetricTypeHistogram:
+			m.Histogram().DataPoints().RemoveIf(func(pmetric.HistogramDataPoint) bool { return next() })
+		case pmetric.MetricTypeExponentialHistogram:
+			m.ExponentialHistogram().DataPoints().RemoveIf(func(pmetric.ExponentialHistogramDataPoint) bool { return next() })
+		case pmetric.MetricTypeSummary:
+			m.Summary().DataPoints().RemoveIf(func(pmetric.SummaryDataPoint) bool { return next() })
+		}
+	})
+
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				return dataPointCount(m) == 0
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+}
+
+// forEachMetric calls f with every metric of md and its scope
+func forEachMetric(md pmetric.Metrics, f func(pmetric.ScopeMetrics, pmetric.Metric)) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				f(sms.At(j), ms.At(k))
+			}
+		}
+	}
+}
+
+// overflowAggregator merges overflowing data points by metric name. Gauges
+// are averaged and sums are added up. Metrics beyond the first limit names are
+// dropped, if the limit leaves no room for them.
+type overflowAggregator struct {
+	limit int
+
+	// Scope of the first overflowing data point, copied as md is modified
+	scope     pcommon.InstrumentationScope
+	schemaURL string
+
+	names  []string
+	points map[string]*overflowPoint
+}
+
+type overflowPoint struct {
+	// metric without data points, copied from the first overflowing one
+	metric  pmetric.Metric
+	isGauge bool
+
+	count   int
+	sum     float64
+	intSum  int64
+	allInts bool
+	start   pcommon.Timestamp
+	last    pcommon.Timestamp
+}
+
+func (a *overflowAggregator) add(sm pmetric.ScopeMetrics, m pmetric.Metric, dp pmetric.NumberDataPoint) {
+	if len(a.names) == 0 {
+		a.scope = pcommon.NewInstrumentationScope()
+		sm.Scope().CopyTo(a.scope)
+		a.schemaURL = sm.SchemaUrl()
+	}
+	p, ok := a.points[m.Name()]
+	if !ok {
+		if len(a.names) >= a.limit {
+			return
+		}
+		p = &overflowPoint{metric: pmetric.NewMetric(), allInts: true, start: dp.StartTimestamp()}
+		copyDataPoints(m, p.metric, 0, 0)
+		p.isGauge = m.Type() == pmetric.MetricTypeGauge
+		a.points[m.Name()] = p
+		a.names = append(a.names, m.Name())
+	}
+
+	p.count++
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		p.intSum += dp.IntValue()
+		p.sum += float64(dp.IntValue())
+	case pmetric.NumberDataPointValueTypeDouble:
+		p.allInts = false
+		p.sum += dp.DoubleValue()
+	}
+	p.start = min(p.start, dp.StartTimestamp())
+	p.last = max(p.last, dp.Timestamp())
+}
+
+// appendTo adds the aggregated data points to md under a resource of their own
+func (a *overflowAggregator) appendTo(md pmetric.Metrics) {
+	if len(a.names) == 0 {
+		return
+	}
+	sm := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	a.scope.CopyTo(sm.Scope())
+	sm.SetSchemaUrl(a.schemaURL)
+	for _, name := range a.names {
+		p := a.points[name]
+		m := sm.Metrics().AppendEmpty()
+		p.metric.MoveTo(m)
+
+		var dp pmetric.NumberDataPoint
+		if p.isGauge {
+			dp = m.Gauge().DataPoints().AppendEmpty()
+			dp.SetDoubleValue(p.sum / float64(p.count))
+		} else {
+			dp = m.Sum().DataPoints().AppendEmpty()
+			if p.allInts {
+				dp.SetIntValue(p.intSum)
+			} else {
+				dp.SetDoubleValue(p.sum)
+			}
+		}
+		dp.SetStartTimestamp(p.start)
+		dp.SetTimestamp(p.last)
+		dp.Attributes().PutBool(overflowAttribute, true)
+	}
+}
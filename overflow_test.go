@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+)
+
+func TestApplyOverflowDropOldest(t *testing.T) {
+	md := newTestMetrics([]string{"192.0.2.1", "192.0.2.2"}, 2)
+	// The second endpoint was scraped first
+	setTimestamps(md.ResourceMetrics().At(0), 200)
+	setTimestamps(md.ResourceMetrics().At(1), 100)
+
+	assert.Equal(t, 0, applyOverflow(md, 0, overflowDropOldest))
+	assert.Equal(t, 0, applyOverflow(md, 6, overflowDropOldest))
+	assert.Equal(t, 4, applyOverflow(md, 2, overflowDropOldest))
+
+	require.Equal(t, 2, md.DataPointCount())
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	name, _ := md.ResourceMetrics().At(0).Resource().Attributes().Get("net.peer.name")
+	assert.Equal(t, "192.0.2.1", name.Str())
+}
+
+func TestApplyOverflowAggregate(t *testing.T) {
+	md := newTestMetrics([]string{"192.0.2.1", "192.0.2.2", "192.0.2.3"}, 1)
+	md.ResourceMetrics().At(2).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).SetDoubleValue(10)
+	setTimestamps(md.ResourceMetrics().At(2), 300)
+
+	assert.Equal(t, 4, applyOverflow(md, 4, overflowAggregate))
+	assert.Equal(t, 4, md.DataPointCount())
+
+	// The first resource is kept as is, leaving room for the aggregated data
+	// points of the second and third targets
+	rms := md.ResourceMetrics()
+	require.Equal(t, 2, rms.Len())
+	assert.Equal(t, 2, rms.At(0).ScopeMetrics().At(0).Metrics().Len())
+
+	overflow := rms.At(1)
+	assert.Equal(t, 0, overflow.Resource().Attributes().Len())
+	sm := overflow.ScopeMetrics().At(0)
+	assert.Equal(t, "github.com/lukeod/pingcheckreceiver", sm.Scope().Name())
+	require.Equal(t, 2, sm.Metrics().Len())
+
+	duration := sm.Metrics().At(0)
+	assert.Equal(t, "ping.duration", duration.Name())
+	assert.Equal(t, "ms", duration.Unit())
+	assert.InDelta(t, 5.0, duration.Gauge().DataPoints().At(0).DoubleValue(), 0.001)
+
+	sent := sm.Metrics().At(1)
+	assert.Equal(t, "ping.packets.sent", sent.Name())
+	require.Equal(t, pmetric.MetricTypeSum, sent.Type())
+	assert.True(t, sent.Sum().IsMonotonic())
+	dp := sent.Sum().DataPoints().At(0)
+	assert.Equal(t, int64(8), dp.IntValue())
+	assert.Equal(t, pcommon.Timestamp(300), dp.Timestamp())
+	flag, _ := dp.Attributes().Get(overflowAttribute)
+	assert.True(t, flag.Bool())
+}
+
+func TestApplyOverflowAggregateWithinLimit(t *testing.T) {
+	for maxDataPoints := 1; maxDataPoints < 8; maxDataPoints++ {
+		md := newTestMetrics([]string{"192.0.2.1", "192.0.2.2", "192.0.2.3"}, 2)
+		histogram := md.ResourceMetrics().At(2).ScopeMetrics().At(0).Metrics().AppendEmpty()
+		histogram.SetName("ping.rtt.histogram")
+		histogram.SetEmptyHistogram().DataPoints().AppendEmpty()
+		total := md.DataPointCount()
+
+		excess := applyOverflow(md, maxDataPoints, overflowAggregate)
+		assert.LessOrEqual(t, md.DataPointCount(), maxDataPoints, "max_datapoints %d", maxDataPoints)
+		assert.Positive(t, excess)
+		if maxDataPoints >= 2 {
+			// Both metrics are aggregated, the histogram is dropped
+			assert.Equal(t, maxDataPoints, md.DataPointCount())
+			assert.Equal(t, total-(maxDataPoints-2), excess)
+		}
+	}
+}
+
+func TestOverflowConsumer(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	defer func() { require.NoError(t, tel.Shutdown(context.Background())) }()
+	core, logs := observer.New(zap.WarnLevel)
+	settings := receivertest.NewNopSettings(metadata.Type)
+	settings.TelemetrySettings = tel.NewTelemetrySettings()
+	settings.Logger = zap.New(core)
+
+	sink := new(consumertest.MetricsSink)
+	overflow, err := newOverflowConsumer(sink, OverflowConfig{MaxDatapoints: 3}, settings)
+	require.NoError(t, err)
+	require.NoError(t, overflow.ConsumeMetrics(context.Background(), newTestMetrics([]string{"192.0.2.1"}, 2)))
+	require.NoError(t, overflow.ConsumeMetrics(context.Background(), newTestMetrics([]string{"192.0.2.1", "192.0.2.2"}, 2)))
+
+	require.Len(t, sink.AllMetrics(), 2)
+	assert.Equal(t, 3, sink.AllMetrics()[0].DataPointCount())
+	assert.Equal(t, 3, sink.AllMetrics()[1].DataPointCount())
+
+	entries := logs.FilterMessage("Data point limit exceeded").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, int64(3), entries[0].ContextMap()["overflow"])
+
+	m, err := tel.GetMetric("otelcol_receiver_ping_overflow_data_points")
+	require.NoError(t, err)
+	sum := m.Data.(metricdata.Sum[int64])
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, int64(3), sum.DataPoints[0].Value)
+	policy, _ := sum.DataPoints[0].Attributes.Value("policy")
+	assert.Equal(t, overflowDropOldest, policy.AsString())
+}
+
+// setTimestamps sets the timestamp of every data point of rm
+func setTimestamps(rm pmetric.ResourceMetrics, ts pcommon.Timestamp) {
+	ms := rm.ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		m := ms.At(i)
+		var dps pmetric.NumberDataPointSlice
+		if m.Type() == pmetric.MetricTypeGauge {
+			dps = m.Gauge().DataPoints()
+		} else {
+			dps = m.Sum().DataPoints()
+		}
+		for j := 0; j < dps.Len(); j++ {
+			dps.At(j).SetTimestamp(ts)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// packetSizeAttribute is the attribute of the payload size of probe packets
+const packetSizeAttribute = "packet.size"
+
+// putPacketSize sets packet.size to the payload size of the regular probe on
+// the data points of md that do not carry one of their own, e.g. those of the
+// size sweep. The default size is not reported.
+func putPacketSize(md pmetric.Metrics, size int) {
+	if size == 0 {
+		return
+	}
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		switch m.Type() {
+		case pmetric.MetricTypeGauge:
+			putSlicePacketSize(m.Gauge().DataPoints(), size)
+		case pmetric.MetricTypeSum:
+			putSlicePacketSize(m.Sum().DataPoints(), size)
+		case pmetric.MetricTypeHistogram:
+			putSlicePacketSize(m.Histogram().DataPoints(), size)
+		case pmetric.MetricTypeExponentialHistogram:
+			putSlicePacketSize(m.ExponentialHistogram().DataPoints(), size)
+		}
+	})
+}
+
+func putSlicePacketSize[T interface{ Attributes() pcommon.Map }](dps attributedSlice[T], size int) {
+	for i := 0; i < dps.Len(); i++ {
+		attrs := dps.At(i).Attributes()
+		if _, ok := attrs.Get(packetSizeAttribute); !ok {
+			attrs.PutInt(packetSizeAttribute, int64(size))
+		}
+	}
+}
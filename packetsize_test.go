@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+func TestScraperPacketSize(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{
+		{Endpoint: "192.0.2.1", Count: 4, PacketSize: 1400, PacketSizes: []int{64}},
+		{Endpoint: "192.0.2.2", Count: 4},
+	}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 4, PacketsRecv: 4})
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	pingerCfg, ok := fakeProber.PingerConfig("192.0.2.1")
+	require.True(t, ok)
+	assert.Equal(t, 1400, pingerCfg.Size)
+
+	md, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	sizes := map[string]int64{}
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		var attrs pcommon.Map
+		switch m.Type() {
+		case pmetric.MetricTypeGauge:
+			attrs = m.Gauge().DataPoints().At(0).Attributes()
+		case pmetric.MetricTypeSum:
+			attrs = m.Sum().DataPoints().At(0).Attributes()
+		default:
+			return
+		}
+		size, ok := attrs.Get("packet.size")
+		require.True(t, ok, m.Name())
+		sizes[m.Name()] = size.Int()
+	})
+	// The size sweep keeps the size of its packets
+	assert.Equal(t, int64(1400), sizes["ping.packets.sent"])
+	assert.Equal(t, int64(64), sizes["ping.size_sweep.duration"])
+
+	// The default size is not reported
+	md, err = scraper.scrapeTarget(context.Background(), 1)
+	require.NoError(t, err)
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		if m.Type() == pmetric.MetricTypeSum {
+			_, ok := m.Sum().DataPoints().At(0).Attributes().Get("packet.size")
+			assert.False(t, ok, m.Name())
+		}
+	})
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// probePanicError is a panic recovered while probing a target
+type probePanicError struct {
+	value any
+}
+
+func (e *probePanicError) Error() string {
+	return fmt.Sprintf("probe panicked: %v", e.value)
+}
+
+// probeSafely probes target, turning a panic of the probing libraries, e.g.
+// on a malformed endpoint, into a failed outcome so the other targets and
+// the collector carry on
+func (s *pingScraper) probeSafely(ctx context.Context, target Target) (o *probeOutcome) {
+	defer func() {
+		if r := recover(); r != nil {
+			o = &probeOutcome{
+				err:       fmt.Errorf("ping failed: %w", s.recovered(target.Endpoint, r)),
+				errorType: metadata.AttributeErrorTypePanic,
+			}
+		}
+	}()
+	return s.probe(ctx, target)
+}
+
+// newPingerSafely creates the pinger of target, turning a panic into an error
+func (s *pingScraper) newPingerSafely(target Target) (pinger prober.Pinger, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			pinger, err = nil, s.recovered(target.Endpoint, r)
+		}
+	}()
+	return s.newPinger(target)
+}
+
+// recovered counts and logs the panic r of a probe of endpoint, and drops the
+// pingers of endpoint as they may have been left in any state. The next scrape
+// creates them anew.
+func (s *pingScraper) recovered(endpoint string, r any) error {
+	s.logger.Error("Probe panicked, recovered",
+		zap.String("endpoint", endpoint),
+		zap.Any("panic", r),
+		zap.StackSkip("stack", 2))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stateLocked(endpoint).panics++
+	s.dropPingersLocked(endpoint)
+	return &probePanicError{value: r}
+}
+
+// recordPanics records how many probes of the i-th target panicked so far,
+// once one did, deltas only counting those since the previous emit. The
+// target's builder must be held.
+func (s *pingScraper) recordPanics(mb *metadata.MetricsBuilder, metrics metadata.MetricsConfig, i int) {
+	if !metrics.PingProbePanics.Enabled {
+		return
+	}
+	target := s.cfg.Targets[i]
+	s.mu.RLock()
+	var panics int
+	if state, ok := s.states[target.Endpoint]; ok {
+		panics = state.panics
+	}
+	s.mu.RUnlock()
+	if panics == 0 {
+		return
+	}
+	reported := panics
+	if s.cfg.CounterTemporality == temporalityDelta {
+		b := s.builders[i]
+		reported -= b.reportedPanics
+		b.reportedPanics = panics
+	}
+	mb.RecordPingProbePanicsDataPoint(pcommon.NewTimestampFromTime(s.clock.Now()), int64(reported), target.Endpoint)
+}
@@ -0,0 +1,228 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// probePanics returns the ping.probe.panics data point of md, -1 if none
+func probePanics(md pmetric.Metrics) int64 {
+	panics := int64(-1)
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		if m.Name() == "ping.probe.panics" {
+			panics = m.Sum().DataPoints().At(0).IntValue()
+		}
+	})
+	return panics
+}
+
+// errorTypes returns the error.type of the ping.errors data points of md
+func errorTypes(md pmetric.Metrics) []string {
+	var types []string
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		if m.Name() != "ping.errors" {
+			return
+		}
+		for i := 0; i < m.Sum().DataPoints().Len(); i++ {
+			v, _ := m.Sum().DataPoints().At(i).Attributes().Get("error.type")
+			types = append(types, v.Str())
+		}
+	})
+	return types
+}
+
+func TestScraperProbePanic(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics.PingErrors.Enabled = true
+	cfg.Targets = []Target{
+		{Endpoint: "192.0.2.1", Count: 4},
+		{Endpoint: "192.0.2.2", Count: 4},
+	}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetRunPanic("192.0.2.1", "index out of range [3] with length 3")
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	// The panic fails the probe of its own target only
+	md, err := scraper.scrapeTarget(context.Background(), 0)
+	assert.EqualError(t, err, "target 192.0.2.1: ping failed: probe panicked: index out of range [3] with length 3")
+	assert.Equal(t, int64(1), probePanics(md))
+	assert.Equal(t, []string{"panic"}, errorTypes(md))
+
+	md, err = scraper.scrapeTarget(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-1), probePanics(md))
+	assert.Empty(t, errorTypes(md))
+
+	// The pinger is recreated once the target probes cleanly again
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 4, PacketsRecv: 4})
+	md, err = scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), probePanics(md))
+	assert.Equal(t, 2, fakeProber.Runs("192.0.2.1"))
+	_, err = scraper.scrapeTarget(context.Background(), 1)
+	require.NoError(t, err)
+}
+
+func TestScraperProbePanicDelta(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.CounterTemporality = temporalityDelta
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4}}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetRunPanic("192.0.2.1", "index out of range [3] with length 3")
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	// Every delta only counts the panics since the previous scrape
+	for range 2 {
+		md, err := scraper.scrapeTarget(context.Background(), 0)
+		require.Error(t, err)
+		assert.Equal(t, int64(1), probePanics(md))
+	}
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 4, PacketsRecv: 4})
+	md, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), probePanics(md))
+}
+
+func TestScraperCreatePanic(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{
+		{Endpoint: "192.0.2.1", Count: 4},
+		{Endpoint: "192.0.2.2", Count: 4},
+	}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetCreatePanic("192.0.2.1", "nil pointer dereference")
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+
+	// Start carries on without the pinger, and every scrape retries it
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	md, err := scraper.scrapeTarget(context.Background(), 0)
+	assert.EqualError(t, err, "target 192.0.2.1: pinger not found for target: 192.0.2.1: probe panicked: nil pointer dereference")
+	assert.Equal(t, int64(2), probePanics(md))
+	md, err = scraper.scrapeTarget(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-1), probePanics(md))
+}
+
+func TestScrapeLogsProbePanic(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4}}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetRunPanic("192.0.2.1", "boom")
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	ld, err := scraper.scrapeLogs(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, ld.LogRecordCount())
+	attrs := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw()
+	assert.Equal(t, "panic", attrs["error.type"])
+	assert.Equal(t, "ping failed: probe panicked: boom", attrs["error.message"])
+}
+
+// panickingTracer is a prober whose traces panic
+type panickingTracer struct {
+	*pingchecktest.Prober
+}
+
+func (panickingTracer) Trace(context.Context, prober.TraceConfig) (*prober.Trace, error) {
+	panic("slice bounds out of range")
+}
+
+func TestScraperTraceroutePanic(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Privileged = true
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4, Traceroute: &TracerouteConfig{}}}
+
+	clock := pingchecktest.NewClock(time.Unix(1_700_000_000, 0))
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type),
+		newFactoryOptions(WithProber(panickingTracer{pingchecktest.NewProber()}), WithClock(clock)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	// Traces run in the background, outside of the probe
+	_, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	scraper.bgWG.Wait()
+	md, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), probePanics(md))
+
+	// and the next one starts once the interval elapsed
+	clock.Advance(defaultTracerouteInterval)
+	_, err = scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	scraper.bgWG.Wait()
+	md, err = scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), probePanics(md))
+}
+
+// TestScraperChaos probes a fleet whose probes fail in every way at once, the
+// healthy targets must report as usual
+func TestScraperChaos(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	fakeProber := pingchecktest.NewProber()
+	for i := range 12 {
+		endpoint := fmt.Sprintf("192.0.2.%d", i+1)
+		cfg.Targets = append(cfg.Targets, Target{Endpoint: endpoint, Count: 4})
+		switch i % 4 {
+		case 0:
+			fakeProber.SetRunPanic(endpoint, fmt.Sprintf("chaos %d", i))
+		case 1:
+			fakeProber.SetCreatePanic(endpoint, errors.New("chaos"))
+		case 2:
+			fakeProber.SetRunError(endpoint, errors.New("i/o timeout"))
+		}
+	}
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	for range 3 {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := scraper.scrapeLogs(context.Background())
+			assert.NoError(t, err)
+		}()
+		for i, target := range cfg.Targets {
+			md, err := scraper.scrapeTarget(context.Background(), i)
+			if i%4 == 3 {
+				assert.NoError(t, err, target.Endpoint)
+				assert.Equal(t, int64(-1), probePanics(md))
+			} else {
+				assert.Error(t, err, target.Endpoint)
+			}
+		}
+		wg.Wait()
+	}
+}
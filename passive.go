@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// FlowSource is implemented by components that observe traffic passively,
+// e.g. from NetFlow, IPFIX or conntrack. Asked about failing targets, it
+// tells hosts that only block ICMP from hosts that are actually down.
+type FlowSource interface {
+	// SeenSince reports whether traffic from ip was observed since the given time
+	SeenSince(ctx context.Context, ip net.IP, since time.Time) (bool, error)
+}
+
+// flowSource returns the FlowSource to ask about failing targets, the
+// passive_check extension if configured, or nil if there is none
+func (s *pingScraper) flowSource(host component.Host) (FlowSource, error) {
+	id := s.cfg.PassiveCheck.Extension
+	if id == nil {
+		return s.flows, nil
+	}
+	ext, ok := host.GetExtensions()[*id]
+	if !ok {
+		return nil, fmt.Errorf("passive_check: extension %s not found", id)
+	}
+	flows, ok := ext.(FlowSource)
+	if !ok {
+		return nil, fmt.Errorf("passive_check: extension %s is not a flow source", id)
+	}
+	return flows, nil
+}
+
+// passivelySeen reports whether the flow source saw traffic from target
+// within passive_check's window before a probe that started at started and
+// failed. Targets are not seen if there is no flow source or it cannot tell.
+func (s *pingScraper) passivelySeen(ctx context.Context, target Target, stats *prober.Statistics, started time.Time) bool {
+	if s.flows == nil {
+		return false
+	}
+
+	s.mu.RLock()
+	pinger := s.pingers[target.Endpoint]
+	s.mu.RUnlock()
+
+	ip := net.ParseIP(target.Endpoint)
+	if stats != nil && stats.IPAddr != nil {
+		ip = stats.IPAddr.IP
+	} else if r, ok := pinger.(prober.Resolved); ok && r.IPAddr() != nil {
+		ip = r.IPAddr().IP
+	}
+	if ip == nil {
+		return false
+	}
+
+	seen, err := s.flows.SeenSince(ctx, ip, started.Add(-s.cfg.PassiveCheck.Window))
+	if err != nil {
+		s.logger.Debug("Flow source lookup failed",
+			zap.String("endpoint", target.Endpoint),
+			zap.Error(err))
+		return false
+	}
+	return seen
+}
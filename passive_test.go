@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// fakeFlows is a FlowSource extension that saw traffic from a fixed set of addresses
+type fakeFlows struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	mu    sync.Mutex
+	seen  map[string]bool
+	since []time.Time
+}
+
+func (f *fakeFlows) SeenSince(_ context.Context, ip net.IP, since time.Time) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.since = append(f.since, since)
+	if ip.String() == "192.0.2.3" {
+		return false, errors.New("flow table unavailable")
+	}
+	return f.seen[ip.String()], nil
+}
+
+// extensionHost is a component.Host with the given extensions
+type extensionHost struct {
+	extensions map[component.ID]component.Component
+}
+
+func (h extensionHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+// passivelySeenErrors returns the passively_seen attribute of the ping.errors data point of md
+func passivelySeenErrors(t *testing.T, md pmetric.Metrics) bool {
+	var seen *bool
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		if m.Name() == "ping.errors" {
+			v, ok := m.Sum().DataPoints().At(0).Attributes().Get("passively_seen")
+			require.True(t, ok)
+			b := v.Bool()
+			seen = &b
+		}
+	})
+	require.NotNil(t, seen, "no ping.errors data point")
+	return *seen
+}
+
+func TestScraperPassiveCheck(t *testing.T) {
+	id := component.MustNewID("flows")
+	cfg := createDefaultConfig().(*Config)
+	cfg.PassiveCheck.Extension = &id
+	cfg.PassiveCheck.Window = time.Minute
+	cfg.Metrics.PingErrors.Enabled = true
+	cfg.Targets = []Target{
+		{Endpoint: "192.0.2.1", Count: 4},
+		{Endpoint: "192.0.2.2", Count: 4},
+		{Endpoint: "192.0.2.3", Count: 4},
+	}
+
+	clock := pingchecktest.NewClock(time.Unix(1_700_000_000, 0))
+	fakeProber := pingchecktest.NewProber()
+	for _, target := range cfg.Targets {
+		fakeProber.SetRunError(target.Endpoint, errors.New("i/o timeout"))
+	}
+	flows := &fakeFlows{seen: map[string]bool{"192.0.2.1": true}}
+	host := extensionHost{extensions: map[component.ID]component.Component{id: flows}}
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber), WithClock(clock)))
+	require.NoError(t, scraper.start(context.Background(), host))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	var seen []bool
+	for i := range cfg.Targets {
+		md, err := scraper.scrapeTarget(context.Background(), i)
+		require.Error(t, err)
+		seen = append(seen, passivelySeenErrors(t, md))
+	}
+	// Lookup failures do not count as seen
+	assert.Equal(t, []bool{true, false, false}, seen)
+	for _, since := range flows.since {
+		assert.Equal(t, clock.Now().Add(-time.Minute), since)
+	}
+}
+
+func TestScraperWithoutPassiveCheck(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics.PingErrors.Enabled = true
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4}}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetRunError("192.0.2.1", errors.New("i/o timeout"))
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	// Without a flow source nothing is known about the traffic of the target
+	md, err := scraper.scrapeTarget(context.Background(), 0)
+	require.Error(t, err)
+	found := false
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		if m.Name() == "ping.errors" {
+			found = true
+			_, ok := m.Sum().DataPoints().At(0).Attributes().Get("passively_seen")
+			assert.False(t, ok)
+		}
+	})
+	assert.True(t, found)
+}
+
+func TestScraperPassiveCheckLogs(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics.PingErrors.Enabled = true
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4}}
+
+	// Loss without an error is not recorded in ping.errors, but is logged
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 4, PacketLoss: 100})
+	flows := &fakeFlows{seen: map[string]bool{"192.0.2.1": true}}
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber), WithFlowSource(flows)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	ld, err := scraper.scrapeLogs(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, ld.LogRecordCount())
+	seen, ok := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Get("passively_seen")
+	require.True(t, ok)
+	assert.True(t, seen.Bool())
+}
+
+func TestScraperPassiveCheckExtension(t *testing.T) {
+	id := component.MustNewID("flows")
+	cfg := createDefaultConfig().(*Config)
+	cfg.PassiveCheck.Extension = &id
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4}}
+
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(pingchecktest.NewProber())))
+	err := scraper.start(context.Background(), componenttest.NewNopHost())
+	assert.EqualError(t, err, "passive_check: extension flows not found")
+
+	type otherExtension struct {
+		component.StartFunc
+		component.ShutdownFunc
+	}
+	scraper = newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(pingchecktest.NewProber())))
+	host := extensionHost{extensions: map[component.ID]component.Component{id: otherExtension{}}}
+	err = scraper.start(context.Background(), host)
+	assert.EqualError(t, err, "passive_check: extension flows is not a flow source")
+}
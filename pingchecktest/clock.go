@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pingchecktest provides deterministic test doubles, golden-file
+// helpers and a loopback responder for programs embedding the ping receiver.
+//
+// The responder answers TCP probes only. The kernel answers ICMP echo requests
+// to local addresses itself, so ICMP loss, timeouts and error categorization
+// cannot be exercised end to end with it; use Prober for those instead.
+package pingchecktest // import "github.com/lukeod/pingcheckreceiver/pingchecktest"
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a manually advanced prober.Clock
+type Clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewClock returns a Clock frozen at the given time
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+// Now returns the clock's current time
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Set moves the clock to the given time
+func (c *Clock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// Advance moves the clock forward by d
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
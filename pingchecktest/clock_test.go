@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingchecktest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+func TestClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var clock prober.Clock = NewClock(start)
+	assert.Equal(t, start, clock.Now())
+
+	clock.(*Clock).Advance(time.Minute)
+	assert.Equal(t, start.Add(time.Minute), clock.Now())
+
+	clock.(*Clock).Set(start)
+	assert.Equal(t, start, clock.Now())
+}
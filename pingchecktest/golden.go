@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingchecktest // import "github.com/lukeod/pingcheckreceiver/pingchecktest"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// ReadMetrics reads a golden file written by WriteMetrics
+func ReadMetrics(path string) (pmetric.Metrics, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return pmetric.Metrics{}, err
+	}
+	unmarshaler := &pmetric.JSONUnmarshaler{}
+	return unmarshaler.UnmarshalMetrics(data)
+}
+
+// WriteMetrics writes md to path as indented OTLP JSON, creating parent directories as needed
+func WriteMetrics(path string, md pmetric.Metrics) error {
+	marshaler := &pmetric.JSONMarshaler{}
+	data, err := marshaler.MarshalMetrics(md)
+	if err != nil {
+		return err
+	}
+	var indented bytes.Buffer
+	if err = json.Indent(&indented, data, "", "  "); err != nil {
+		return err
+	}
+	indented.WriteByte('\n')
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, indented.Bytes(), 0o600)
+}
+
+// CompareOption relaxes the comparison done by CompareMetrics
+type CompareOption func(*compareOptions)
+
+type compareOptions struct {
+	ignoreTimestamps bool
+	ignoreValues     map[string]bool
+}
+
+// IgnoreTimestamps ignores start and end timestamps of all data points
+func IgnoreTimestamps() CompareOption {
+	return func(o *compareOptions) {
+		o.ignoreTimestamps = true
+	}
+}
+
+// IgnoreMetricValues ignores data point values of the named metrics, e.g.
+// round-trip times that vary between runs
+func IgnoreMetricValues(names ...string) CompareOption {
+	return func(o *compareOptions) {
+		for _, name := range names {
+			o.ignoreValues[name] = true
+		}
+	}
+}
+
+// CompareMetrics returns an error describing the differences between expected
+// and actual. Metric and data point order is not significant.
+func CompareMetrics(expected, actual pmetric.Metrics, options ...CompareOption) error {
+	opts := compareOptions{ignoreValues: make(map[string]bool)}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	expectedJSON, err := normalize(expected, opts)
+	if err != nil {
+		return err
+	}
+	actualJSON, err := normalize(actual, opts)
+	if err != nil {
+		return err
+	}
+	if diff := cmp.Diff(expectedJSON, actualJSON); diff != "" {
+		return fmt.Errorf("metrics mismatch (-expected +actual):\n%s", diff)
+	}
+	return nil
+}
+
+// normalize returns a generic JSON representation of a sorted, relaxed copy of md
+func normalize(md pmetric.Metrics, opts compareOptions) (any, error) {
+	md2 := pmetric.NewMetrics()
+	md.CopyTo(md2)
+
+	rms := md2.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				normalizeMetric(ms.At(k), opts)
+			}
+			ms.Sort(func(a, b pmetric.Metric) bool {
+				return a.Name() < b.Name()
+			})
+		}
+	}
+
+	marshaler := &pmetric.JSONMarshaler{}
+	data, err := marshaler.MarshalMetrics(md2)
+	if err != nil {
+		return nil, err
+	}
+	var generic any
+	err = json.Unmarshal(data, &generic)
+	return generic, err
+}
+
+func normalizeMetric(m pmetric.Metric, opts compareOptions) {
+	var dps pmetric.NumberDataPointSlice
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps = m.Gauge().DataPoints()
+	case pmetric.MetricTypeSum:
+		dps = m.Sum().DataPoints()
+	default:
+		return
+	}
+
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		if opts.ignoreTimestamps {
+			dp.SetStartTimestamp(0)
+			dp.SetTimestamp(0)
+		}
+		if opts.ignoreValues[m.Name()] {
+			switch dp.ValueType() {
+			case pmetric.NumberDataPointValueTypeDouble:
+				dp.SetDoubleValue(0)
+			case pmetric.NumberDataPointValueTypeInt:
+				dp.SetIntValue(0)
+			}
+		}
+		sortAttributes(dp.Attributes())
+	}
+
+	dps.Sort(func(a, b pmetric.NumberDataPoint) bool {
+		return attributesKey(a.Attributes()) < attributesKey(b.Attributes())
+	})
+}
+
+func sortedKeys(attrs pcommon.Map) []string {
+	keys := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+	return keys
+}
+
+// sortAttributes rewrites attrs in key order so insertion order does not matter
+func sortAttributes(attrs pcommon.Map) {
+	sorted := pcommon.NewMap()
+	for _, k := range sortedKeys(attrs) {
+		v, _ := attrs.Get(k)
+		v.CopyTo(sorted.PutEmpty(k))
+	}
+	sorted.CopyTo(attrs)
+}
+
+// attributesKey renders attributes in a stable order for sorting
+func attributesKey(attrs pcommon.Map) string {
+	keys := sortedKeys(attrs)
+
+	var buf bytes.Buffer
+	for _, k := range keys {
+		v, _ := attrs.Get(k)
+		fmt.Fprintf(&buf, "%s=%s;", k, v.AsString())
+	}
+	return buf.String()
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingchecktest
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func newGauge(md pmetric.Metrics, name string, ts pcommon.Timestamp, points map[string]float64) {
+	var sm pmetric.ScopeMetrics
+	if md.ResourceMetrics().Len() == 0 {
+		sm = md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	} else {
+		sm = md.ResourceMetrics().At(0).ScopeMetrics().At(0)
+	}
+	m := sm.Metrics().AppendEmpty()
+	m.SetName(name)
+	dps := m.SetEmptyGauge().DataPoints()
+	for peer, val := range points {
+		dp := dps.AppendEmpty()
+		dp.SetTimestamp(ts)
+		dp.SetDoubleValue(val)
+		dp.Attributes().PutStr("net.peer.ip", peer)
+		dp.Attributes().PutStr("net.peer.name", peer)
+	}
+}
+
+func TestGoldenRoundTrip(t *testing.T) {
+	md := pmetric.NewMetrics()
+	newGauge(md, "ping.packet_loss", 1, map[string]float64{"192.0.2.1": 1})
+
+	path := filepath.Join(t.TempDir(), "nested", "expected.json")
+	require.NoError(t, WriteMetrics(path, md))
+
+	read, err := ReadMetrics(path)
+	require.NoError(t, err)
+	assert.NoError(t, CompareMetrics(md, read))
+
+	_, err = ReadMetrics(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestCompareMetrics(t *testing.T) {
+	expected := pmetric.NewMetrics()
+	newGauge(expected, "ping.duration.avg", 1, map[string]float64{"192.0.2.1": 10})
+	newGauge(expected, "ping.packet_loss", 1, map[string]float64{"192.0.2.1": 0, "192.0.2.2": 1})
+
+	// Same content, different metric order and timestamps
+	actual := pmetric.NewMetrics()
+	newGauge(actual, "ping.packet_loss", 2, map[string]float64{"192.0.2.2": 1, "192.0.2.1": 0})
+	newGauge(actual, "ping.duration.avg", 2, map[string]float64{"192.0.2.1": 12})
+
+	err := CompareMetrics(expected, actual)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "metrics mismatch")
+
+	assert.Error(t, CompareMetrics(expected, actual, IgnoreTimestamps()))
+	assert.NoError(t, CompareMetrics(expected, actual, IgnoreTimestamps(), IgnoreMetricValues("ping.duration.avg")))
+
+	// Value differences in metrics that are not ignored are reported
+	newGauge(actual, "ping.packet_loss", 2, map[string]float64{"192.0.2.3": 1})
+	assert.Error(t, CompareMetrics(expected, actual, IgnoreTimestamps(), IgnoreMetricValues("ping.duration.avg")))
+}
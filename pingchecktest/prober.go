@@ -0,0 +1,282 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingchecktest // import "github.com/lukeod/pingcheckreceiver/pingchecktest"
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// Prober is a prober.Prober that returns canned results without touching the network.
+// Targets without a configured result report all packets received with zero RTT.
+type Prober struct {
+	mu           sync.Mutex
+	results      map[string]prober.Statistics
+	runErrors    map[string]error
+	createErrors map[string]error
+	runPanics    map[string]any
+	createPanics map[string]any
+	configs      map[string]prober.PingerConfig
+	runs         map[string]int
+	pathMTUs     map[string]int
+	traces       map[string]prober.Trace
+	traceErrors  map[string]error
+	traceConfigs map[string]prober.TraceConfig
+	socketErrors map[socketMode]error
+	checked      []string
+}
+
+var (
+	_ prober.Prober        = (*Prober)(nil)
+	_ prober.SocketChecker = (*Prober)(nil)
+	_ prober.Tracer        = (*Prober)(nil)
+)
+
+// NewProber returns an empty fake Prober
+func NewProber() *Prober {
+	return &Prober{
+		results:      make(map[string]prober.Statistics),
+		runErrors:    make(map[string]error),
+		createErrors: make(map[string]error),
+		runPanics:    make(map[string]any),
+		createPanics: make(map[string]any),
+		configs:      make(map[string]prober.PingerConfig),
+		runs:         make(map[string]int),
+		pathMTUs:     make(map[string]int),
+		traces:       make(map[string]prober.Trace),
+		traceErrors:  make(map[string]error),
+		traceConfigs: make(map[string]prober.TraceConfig),
+		socketErrors: make(map[socketMode]error),
+	}
+}
+
+// SetResult sets the statistics returned by every run against endpoint
+func (p *Prober) SetResult(endpoint string, stats prober.Statistics) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.results[endpoint] = stats
+	delete(p.runErrors, endpoint)
+	delete(p.runPanics, endpoint)
+}
+
+// SetRunError makes every run against endpoint fail with err
+func (p *Prober) SetRunError(endpoint string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.runErrors[endpoint] = err
+}
+
+// SetRunPanic makes every run against endpoint panic with v, as a bug in a
+// probing library would
+func (p *Prober) SetRunPanic(endpoint string, v any) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.runPanics[endpoint] = v
+}
+
+// SetCreatePanic makes creating a pinger for endpoint panic with v
+func (p *Prober) SetCreatePanic(endpoint string, v any) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.createPanics[endpoint] = v
+}
+
+// SetPathMTU makes packets to endpoint with the Don't Fragment flag get lost
+// if they are larger than mtu bytes, with their IP and ICMP headers
+func (p *Prober) SetPathMTU(endpoint string, mtu int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pathMTUs[endpoint] = mtu
+}
+
+// SetCreateError makes creating a pinger for endpoint fail with err
+func (p *Prober) SetCreateError(endpoint string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.createErrors[endpoint] = err
+}
+
+// SetTrace sets the path returned by every trace of endpoint. Endpoints
+// without one are reached at the first hop.
+func (p *Prober) SetTrace(endpoint string, trace prober.Trace) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.traces[endpoint] = trace
+	delete(p.traceErrors, endpoint)
+}
+
+// SetTraceError makes every trace of endpoint fail with err
+func (p *Prober) SetTraceError(endpoint string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.traceErrors[endpoint] = err
+}
+
+// TraceConfig returns the config of the last trace of endpoint
+func (p *Prober) TraceConfig(endpoint string) (prober.TraceConfig, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	cfg, ok := p.traceConfigs[endpoint]
+	return cfg, ok
+}
+
+// Trace implements prober.Tracer
+func (p *Prober) Trace(ctx context.Context, cfg prober.TraceConfig) (*prober.Trace, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.traceConfigs[cfg.Endpoint] = cfg
+	if err := p.traceErrors[cfg.Endpoint]; err != nil {
+		return nil, err
+	}
+	trace, ok := p.traces[cfg.Endpoint]
+	if !ok {
+		addr := cfg.IPAddr
+		if addr == nil {
+			addr = &net.IPAddr{IP: net.ParseIP(cfg.Endpoint)}
+		}
+		trace = prober.Trace{
+			IPAddr:  addr,
+			Hops:    []prober.Hop{{TTL: 1, Addr: addr, Sent: cfg.Queries, Rtts: make([]time.Duration, cfg.Queries)}},
+			Reached: true,
+		}
+	}
+	// Hand out copies so callers may modify the result
+	trace.Hops = append([]prober.Hop(nil), trace.Hops...)
+	return &trace, nil
+}
+
+// socketMode is a kind of socket checked by CheckSocket
+type socketMode struct {
+	network    string
+	privileged bool
+}
+
+// SetSocketError makes checking sockets of network, prober.NetworkIPv4 or
+// prober.NetworkIPv6, in the given mode fail with err
+func (p *Prober) SetSocketError(network string, privileged bool, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.socketErrors[socketMode{network: network, privileged: privileged}] = err
+}
+
+// CheckedSockets returns the networks whose sockets were checked, in order
+func (p *Prober) CheckedSockets() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.checked...)
+}
+
+// CheckSocket implements prober.SocketChecker
+func (p *Prober) CheckSocket(network string, privileged bool) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.checked = append(p.checked, network)
+	return p.socketErrors[socketMode{network: network, privileged: privileged}]
+}
+
+// PingerConfig returns the config the last pinger for endpoint was created with
+func (p *Prober) PingerConfig(endpoint string) (prober.PingerConfig, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	cfg, ok := p.configs[endpoint]
+	return cfg, ok
+}
+
+// Runs returns how many runs were made against endpoint
+func (p *Prober) Runs(endpoint string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.runs[endpoint]
+}
+
+// NewPinger implements prober.Prober
+func (p *Prober) NewPinger(cfg prober.PingerConfig) (prober.Pinger, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if v, ok := p.createPanics[cfg.Endpoint]; ok {
+		panic(v)
+	}
+	if err := p.createErrors[cfg.Endpoint]; err != nil {
+		return nil, err
+	}
+	p.configs[cfg.Endpoint] = cfg
+	f := &pinger{prober: p, endpoint: cfg.Endpoint, count: cfg.Count, ipaddr: cfg.IPAddr, size: cfg.Size, df: cfg.DoNotFragment}
+	if ip := net.ParseIP(cfg.Endpoint); f.ipaddr == nil && ip != nil {
+		f.ipaddr = &net.IPAddr{IP: ip}
+	}
+	return f, nil
+}
+
+type pinger struct {
+	prober   *Prober
+	endpoint string
+	count    int
+	ipaddr   *net.IPAddr
+	size     int
+	df       bool
+}
+
+func (f *pinger) Run(ctx context.Context) (*prober.Statistics, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	p := f.prober
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.runs[f.endpoint]++
+	if v, ok := p.runPanics[f.endpoint]; ok {
+		panic(v)
+	}
+	if err := p.runErrors[f.endpoint]; err != nil {
+		return nil, err
+	}
+
+	stats, ok := p.results[f.endpoint]
+	if !ok {
+		stats = prober.Statistics{
+			PacketsSent: f.count,
+			PacketsRecv: f.count,
+		}
+	}
+	if stats.IPAddr == nil {
+		stats.IPAddr = &net.IPAddr{IP: net.ParseIP(f.endpoint)}
+		if f.ipaddr != nil {
+			stats.IPAddr = f.ipaddr
+		}
+	}
+	if mtu, ok := p.pathMTUs[f.endpoint]; ok && f.df && f.packetSize(stats.IPAddr) > mtu {
+		stats = prober.Statistics{IPAddr: stats.IPAddr, PacketsSent: f.count, PacketLoss: 100}
+	}
+	// Hand out copies so callers may modify the result
+	stats.Rtts = append([]time.Duration(nil), stats.Rtts...)
+	stats.Packets = append([]prober.Packet(nil), stats.Packets...)
+	return &stats, nil
+}
+
+func (f *pinger) Stop() {}
+
+// IPAddr implements prober.Resolved, hostnames are not resolved unless their
+// address is given in the config
+func (f *pinger) IPAddr() *net.IPAddr {
+	return f.ipaddr
+}
+
+// packetSize returns the size of the packets of f sent to addr, with their
+// IP and ICMP headers
+func (f *pinger) packetSize(addr *net.IPAddr) int {
+	size := max(f.size, prober.MinSize) + 8
+	if addr.IP.To4() == nil {
+		return size + 40
+	}
+	return size + 20
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingchecktest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+func TestProberDefaultResult(t *testing.T) {
+	p := NewProber()
+	pinger, err := p.NewPinger(prober.PingerConfig{Endpoint: "192.0.2.1", Count: 3})
+	require.NoError(t, err)
+
+	stats, err := pinger.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.PacketsSent)
+	assert.Equal(t, 3, stats.PacketsRecv)
+	assert.Equal(t, "192.0.2.1", stats.IPAddr.String())
+	assert.Equal(t, 1, p.Runs("192.0.2.1"))
+
+	cfg, ok := p.PingerConfig("192.0.2.1")
+	require.True(t, ok)
+	assert.Equal(t, 3, cfg.Count)
+}
+
+func TestProberConfiguredResults(t *testing.T) {
+	p := NewProber()
+	p.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 2, PacketsRecv: 1, Rtts: []time.Duration{time.Millisecond}})
+	p.SetRunError("192.0.2.2", errors.New("timeout"))
+	p.SetCreateError("192.0.2.3", errors.New("no such host"))
+
+	pinger, err := p.NewPinger(prober.PingerConfig{Endpoint: "192.0.2.1"})
+	require.NoError(t, err)
+	stats, err := pinger.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.PacketsRecv)
+
+	// Results are copies
+	stats.Rtts[0] = time.Hour
+	stats, err = pinger.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, time.Millisecond, stats.Rtts[0])
+
+	pinger, err = p.NewPinger(prober.PingerConfig{Endpoint: "192.0.2.2"})
+	require.NoError(t, err)
+	_, err = pinger.Run(context.Background())
+	assert.EqualError(t, err, "timeout")
+
+	_, err = p.NewPinger(prober.PingerConfig{Endpoint: "192.0.2.3"})
+	assert.EqualError(t, err, "no such host")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = pinger.Run(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestProberPanics(t *testing.T) {
+	p := NewProber()
+	p.SetRunPanic("192.0.2.1", "index out of range")
+	p.SetCreatePanic("192.0.2.2", "nil map")
+
+	pinger, err := p.NewPinger(prober.PingerConfig{Endpoint: "192.0.2.1"})
+	require.NoError(t, err)
+	assert.PanicsWithValue(t, "index out of range", func() { _, _ = pinger.Run(context.Background()) })
+	assert.PanicsWithValue(t, "nil map", func() { _, _ = p.NewPinger(prober.PingerConfig{Endpoint: "192.0.2.2"}) })
+
+	// The prober stays usable after a panic
+	p.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 1, PacketsRecv: 1})
+	stats, err := pinger.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.PacketsRecv)
+}
+
+func TestProberCheckSocket(t *testing.T) {
+	p := NewProber()
+	p.SetSocketError(prober.NetworkIPv6, false, errors.New("permission denied"))
+
+	assert.NoError(t, p.CheckSocket(prober.NetworkIPv4, false))
+	assert.EqualError(t, p.CheckSocket(prober.NetworkIPv6, false), "permission denied")
+	assert.NoError(t, p.CheckSocket(prober.NetworkIPv6, true))
+	assert.Equal(t, []string{prober.NetworkIPv4, prober.NetworkIPv6, prober.NetworkIPv6}, p.CheckedSockets())
+
+	pinger, err := p.NewPinger(prober.PingerConfig{Endpoint: "2001:db8::1"})
+	require.NoError(t, err)
+	assert.Equal(t, "2001:db8::1", pinger.(prober.Resolved).IPAddr().String())
+	pinger, err = p.NewPinger(prober.PingerConfig{Endpoint: "example.com"})
+	require.NoError(t, err)
+	assert.Nil(t, pinger.(prober.Resolved).IPAddr())
+}
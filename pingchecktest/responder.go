@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingchecktest // import "github.com/lukeod/pingcheckreceiver/pingchecktest"
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+)
+
+// Responder answers TCP probes on a loopback port, so the real probing engine
+// can be tested end to end without privileges. While down, connections are
+// refused and counted as lost packets. It does not answer ICMP probes.
+type Responder struct {
+	mu       sync.Mutex
+	listener net.Listener
+	port     int
+	accepted int
+	closed   bool
+	wg       sync.WaitGroup
+}
+
+// NewResponder starts a Responder on a free loopback port
+func NewResponder() (*Responder, error) {
+	r := &Responder{}
+	if err := r.listen("127.0.0.1:0"); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Endpoint returns the address to configure as the target's endpoint
+func (r *Responder) Endpoint() string {
+	return "127.0.0.1"
+}
+
+// Port returns the port to configure as the target's port
+func (r *Responder) Port() int {
+	return r.port
+}
+
+// Accepted returns the number of connections accepted so far
+func (r *Responder) Accepted() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.accepted
+}
+
+// SetDown refuses connections while down is true, and answers them again on
+// the same port once it is false
+func (r *Responder) SetDown(down bool) error {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return errors.New("responder is closed")
+	}
+	listener := r.listener
+	if down {
+		r.listener = nil
+	}
+	r.mu.Unlock()
+
+	switch {
+	case down && listener != nil:
+		err := listener.Close()
+		r.wg.Wait()
+		return err
+	case !down && listener == nil:
+		return r.listen(fmt.Sprintf("127.0.0.1:%d", r.port))
+	}
+	return nil
+}
+
+// Close stops the Responder
+func (r *Responder) Close() error {
+	r.mu.Lock()
+	listener := r.listener
+	r.listener = nil
+	r.closed = true
+	r.mu.Unlock()
+
+	var err error
+	if listener != nil {
+		err = listener.Close()
+	}
+	r.wg.Wait()
+	return err
+}
+
+// listen accepts connections on addr until the listener is closed
+func (r *Responder) listen(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	r.listener = listener
+	r.port = listener.Addr().(*net.TCPAddr).Port
+	r.mu.Unlock()
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			r.mu.Lock()
+			r.accepted++
+			r.mu.Unlock()
+			conn.Close()
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingchecktest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+func TestResponder(t *testing.T) {
+	r, err := NewResponder()
+	require.NoError(t, err)
+	defer func() { require.NoError(t, r.Close()) }()
+
+	pinger, err := prober.NewICMPProber().NewPinger(prober.PingerConfig{
+		Endpoint: r.Endpoint(),
+		Protocol: prober.ProtocolTCP,
+		Port:     r.Port(),
+		Count:    2,
+		Timeout:  5 * time.Second,
+		Interval: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer pinger.Stop()
+
+	stats, err := pinger.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.PacketsRecv)
+	assert.Zero(t, stats.PacketLoss)
+	// The kernel completes connections before they are accepted
+	require.Eventually(t, func() bool { return r.Accepted() == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	// Refused connections are lost packets
+	require.NoError(t, r.SetDown(true))
+	stats, err = pinger.Run(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, stats.PacketsRecv)
+	assert.Equal(t, float64(100), stats.PacketLoss)
+	assert.Equal(t, 2, r.Accepted())
+
+	// and are answered on the same port again once the responder is back up
+	require.NoError(t, r.SetDown(false))
+	stats, err = pinger.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.PacketsRecv)
+	assert.Eventually(t, func() bool { return r.Accepted() == 4 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestResponderClosed(t *testing.T) {
+	r, err := NewResponder()
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.EqualError(t, r.SetDown(false), "responder is closed")
+}
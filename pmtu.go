@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"cmp"
+	"context"
+	"net"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// Header sizes added to the payload of an ICMP echo request
+const (
+	icmpHeaderSize = 8
+	ipv4HeaderSize = 20
+	ipv6HeaderSize = 40
+)
+
+// Packets sent for each size tried by the path MTU search, and how long they
+// are waited for by default
+const (
+	pathMTUProbeCount   = 2
+	pathMTUProbeTimeout = time.Second
+)
+
+// discoverPathMTU searches the largest packet, with its headers, that reaches
+// target at addr with the Don't Fragment flag set, up to path_mtu_max. Sizes
+// are tried in turn, the largest first as most paths carry it, then halving
+// the range. It returns 0 if not even the smallest packet got through.
+func (s *pingScraper) discoverPathMTU(ctx context.Context, target Target, addr *net.IPAddr) int {
+	overhead := icmpHeaderSize + ipv4HeaderSize
+	if ipVersion(addr) == 6 {
+		overhead = icmpHeaderSize + ipv6HeaderSize
+	}
+	hi := cmp.Or(target.PathMTUMax, defaultPathMTU) - overhead
+	lo := prober.MinSize
+	if hi < lo {
+		return 0
+	}
+	if s.reachesUnfragmented(ctx, target, hi) {
+		return hi + overhead
+	}
+	if !s.reachesUnfragmented(ctx, target, lo) {
+		return 0
+	}
+	// lo gets through and hi does not
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		if s.reachesUnfragmented(ctx, target, mid) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo + overhead
+}
+
+// reachesUnfragmented reports whether a packet with a payload of size bytes
+// and the Don't Fragment flag set is answered by target. The pinger is not
+// kept, the search tries a different size every time.
+func (s *pingScraper) reachesUnfragmented(ctx context.Context, target Target, size int) bool {
+	target.Count = pathMTUProbeCount
+	target.Duration = 0
+	target.Timeout = 0
+	target.RunTimeout = cmp.Or(target.PacketTimeout, pathMTUProbeTimeout) * pathMTUProbeCount
+	target.Prewarm = false
+	target.DiscardFirst = false
+	pinger, err := s.newVariantPinger(target, variantKey{size: size, dontFragment: true})
+	if err != nil {
+		s.logger.Debug("Path MTU probe failed",
+			zap.String("endpoint", target.Endpoint),
+			zap.Int("size", size),
+			zap.Error(err))
+		return false
+	}
+	defer pinger.Stop()
+	stats, err := s.run(ctx, target, pinger)
+	return err == nil && stats.PacketsRecv > 0
+}
+
+// recordPathMTU records the path MTU found for target, if any
+func (s *pingScraper) recordPathMTU(mb *metadata.MetricsBuilder, metrics metadata.MetricsConfig, target Target, o *probeOutcome) {
+	if o.pathMTU == 0 || !metrics.PingPathMtu.Enabled {
+		return
+	}
+	mb.RecordPingPathMtuDataPoint(pcommon.NewTimestampFromTime(o.finished), int64(o.pathMTU),
+		target.Endpoint, s.ips.String(o.stats.IPAddr), ipVersion(o.stats.IPAddr))
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// pathMTUs returns the values of the ping.path_mtu data points of md
+func pathMTUs(md pmetric.Metrics) []int64 {
+	var values []int64
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		if m.Name() == "ping.path_mtu" {
+			values = append(values, m.Gauge().DataPoints().At(0).IntValue())
+		}
+	})
+	return values
+}
+
+func TestScraperPathMTU(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		mtu      int
+		max      int
+		expected []int64
+	}{
+		{name: "full size", endpoint: "192.0.2.1", mtu: 1500, expected: []int64{1500}},
+		{name: "tunnel", endpoint: "192.0.2.1", mtu: 1420, expected: []int64{1420}},
+		{name: "jumbo", endpoint: "192.0.2.1", mtu: 9000, max: 9000, expected: []int64{9000}},
+		{name: "capped", endpoint: "192.0.2.1", mtu: 9000, expected: []int64{1500}},
+		{name: "ipv6", endpoint: "2001:db8::1", mtu: 1280, expected: []int64{1280}},
+		{name: "smallest", endpoint: "192.0.2.1", mtu: 52, expected: []int64{52}},
+		{name: "none", endpoint: "192.0.2.1", mtu: 40},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Targets = []Target{{Endpoint: tt.endpoint, Count: 4, PathMTUDiscovery: true, PathMTUMax: tt.max}}
+
+			fakeProber := pingchecktest.NewProber()
+			fakeProber.SetPathMTU(tt.endpoint, tt.mtu)
+			scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+			require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+			defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+			md, err := scraper.scrapeTarget(context.Background(), 0)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, pathMTUs(md))
+		})
+	}
+}
+
+func TestScraperPathMTUSkipped(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4}, {Endpoint: "192.0.2.2", Count: 4, PathMTUDiscovery: true}}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetResult("192.0.2.2", prober.Statistics{PacketsSent: 4, PacketLoss: 100})
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	// Targets not opted in and targets not answering are not searched
+	for i := range cfg.Targets {
+		md, err := scraper.scrapeTarget(context.Background(), i)
+		require.NoError(t, err)
+		assert.Empty(t, pathMTUs(md))
+		assert.Equal(t, 1, fakeProber.Runs(cfg.Targets[i].Endpoint))
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"regexp"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// validMetricPrefix matches dot separated namespaces, e.g. corp.netmon
+var validMetricPrefix = regexp.MustCompile(`^[a-zA-Z][0-9a-zA-Z_]*(\.[a-zA-Z][0-9a-zA-Z_]*)*$`)
+
+// newPrefixingConsumer passes metrics on to next with prefix prepended to
+// every metric name, so all scrapers of the receiver are renamed alike
+func newPrefixingConsumer(next consumer.Metrics, prefix string) (consumer.Metrics, error) {
+	return consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		prefixMetricNames(md, prefix)
+		return next.ConsumeMetrics(ctx, md)
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+}
+
+// prefixMetricNames renames the metrics of md from e.g. ping.duration to
+// corp.netmon.ping.duration for prefix corp.netmon
+func prefixMetricNames(md pmetric.Metrics, prefix string) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				ms.At(k).SetName(prefix + "." + ms.At(k).Name())
+			}
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestPrefixingConsumer(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	prefixing, err := newPrefixingConsumer(sink, "corp.netmon")
+	require.NoError(t, err)
+
+	require.NoError(t, prefixing.ConsumeMetrics(context.Background(), newTestMetrics([]string{"192.0.2.1"}, 1)))
+	require.Len(t, sink.AllMetrics(), 1)
+	ms := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	assert.Equal(t, "corp.netmon.ping.duration", ms.At(0).Name())
+	assert.Equal(t, "corp.netmon.ping.packets.sent", ms.At(1).Name())
+}
+
+func TestValidMetricPrefix(t *testing.T) {
+	for _, prefix := range []string{"corp", "corp.netmon", "corp_1.net_mon"} {
+		assert.True(t, validMetricPrefix.MatchString(prefix), prefix)
+	}
+	for _, prefix := range []string{".corp", "corp.", "corp..netmon", "1corp", "corp-netmon"} {
+		assert.False(t, validMetricPrefix.MatchString(prefix), prefix)
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prober // import "github.com/lukeod/pingcheckreceiver/prober"
+
+import (
+	"math"
+	"net"
+	"slices"
+	"sync"
+	"time"
+)
+
+// collector computes the statistics of a run from its packets, skipping the
+// first skip sequence numbers of the burst
+type collector struct {
+	skip       int
+	ipaddr     *net.IPAddr
+	recordRtts bool
+
+	// continuous leaves out the last packet if it is unanswered, since a run
+	// cut off by its timeout cannot tell it apart from a packet in flight
+	continuous bool
+
+	// packetTimeout counts replies arriving later than this as lost
+	packetTimeout time.Duration
+
+	// strict rejects replies from other addresses or of another length than
+	// replyLen, see PingerConfig.StrictReplies
+	strict   bool
+	replyLen int
+
+	// recordPackets keeps every packet, see PingerConfig.RecordPackets
+	recordPackets bool
+
+	mu           sync.Mutex
+	sent         int
+	rtts         []time.Duration
+	packets      []Packet
+	mismatches   int
+	replyTTL     int
+	lastSeq      int
+	lastAnswered bool
+}
+
+func newCollector(ipaddr *net.IPAddr, skip int, recordRtts bool) *collector {
+	return &collector{ipaddr: ipaddr, skip: skip, recordRtts: recordRtts}
+}
+
+func (c *collector) onSend(seq int) {
+	if seq < c.skip {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sent++
+	c.lastSeq = seq
+	c.lastAnswered = false
+	if c.recordPackets {
+		c.packets = append(c.packets, Packet{Seq: seq, Sent: time.Now()})
+	}
+}
+
+// accepts reports whether a reply of nbytes from src is counted
+func (c *collector) accepts(src *net.IPAddr, nbytes int) bool {
+	return !c.strict || (!c.sourceMismatch(src) && nbytes == c.replyLen)
+}
+
+// onRecv records a reply from src, which is nil if unknown. It reports
+// whether the reply was counted.
+func (c *collector) onRecv(seq int, rtt time.Duration, src *net.IPAddr) bool {
+	if seq < c.skip || (c.packetTimeout > 0 && rtt > c.packetTimeout) {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rtts = append(c.rtts, rtt)
+	if c.sourceMismatch(src) {
+		c.mismatches++
+	}
+	if seq == c.lastSeq {
+		c.lastAnswered = true
+	}
+	// Replies mostly answer one of the latest packets
+	for i := len(c.packets) - 1; i >= 0; i-- {
+		if c.packets[i].Seq == seq {
+			c.packets[i].Received, c.packets[i].Rtt = true, rtt
+			break
+		}
+	}
+	return true
+}
+
+// onReplyTTL records the TTL of a reply counted, negative if unknown
+func (c *collector) onReplyTTL(ttl int) {
+	if ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.replyTTL = ttl
+}
+
+// sourceMismatch reports whether a reply from src came from another address
+// than the probed one, e.g. a NAT or proxy answering for the target
+func (c *collector) sourceMismatch(src *net.IPAddr) bool {
+	return src != nil && c.ipaddr != nil && !src.IP.Equal(c.ipaddr.IP)
+}
+
+// truncate treats the run as cut off by a deadline, leaving out the last
+// packet if it is unanswered. It reports whether any packet was sent.
+func (c *collector) truncate() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.continuous = true
+	return c.sent > 0
+}
+
+// statistics summarizes the collected packets the same way pro-bing does
+func (c *collector) statistics() *Statistics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sent := c.sent
+	if c.continuous && sent > 0 && !c.lastAnswered {
+		sent--
+	}
+	stats := &Statistics{
+		IPAddr:      c.ipaddr,
+		PacketsSent: sent,
+		PacketsRecv: len(c.rtts),
+		Mismatches:  c.mismatches,
+		ReplyTTL:    c.replyTTL,
+	}
+	if sent > 0 {
+		stats.PacketLoss = float64(sent-len(c.rtts)) / float64(sent) * 100
+	}
+	if c.recordPackets {
+		stats.Packets = append([]Packet(nil), c.packets[:min(sent, len(c.packets))]...)
+	}
+	if len(c.rtts) == 0 {
+		return stats
+	}
+	if c.recordRtts {
+		stats.Rtts = append([]time.Duration(nil), c.rtts...)
+	}
+
+	var sum time.Duration
+	stats.MinRtt = c.rtts[0]
+	for _, rtt := range c.rtts {
+		sum += rtt
+		stats.MinRtt = min(stats.MinRtt, rtt)
+		stats.MaxRtt = max(stats.MaxRtt, rtt)
+	}
+	stats.AvgRtt = sum / time.Duration(len(c.rtts))
+
+	sorted := slices.Clone(c.rtts)
+	slices.Sort(sorted)
+	if mid := len(sorted) / 2; len(sorted)%2 == 1 {
+		stats.MedianRtt = sorted[mid]
+	} else {
+		stats.MedianRtt = (sorted[mid-1] + sorted[mid]) / 2
+	}
+
+	var sumSquares float64
+	for _, rtt := range c.rtts {
+		d := float64(rtt - stats.AvgRtt)
+		sumSquares += d * d
+	}
+	stats.StdDevRtt = time.Duration(math.Sqrt(sumSquares / float64(len(c.rtts))))
+	return stats
+}
@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prober
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectorStatistics(t *testing.T) {
+	ipaddr := &net.IPAddr{IP: net.ParseIP("192.0.2.1")}
+	c := newCollector(ipaddr, 0, false)
+	for seq := 0; seq < 4; seq++ {
+		c.onSend(seq)
+	}
+	c.onRecv(0, 10*time.Millisecond, nil)
+	c.onRecv(1, 30*time.Millisecond, nil)
+	c.onRecv(3, 20*time.Millisecond, nil)
+
+	assert.Equal(t, &Statistics{
+		IPAddr:      ipaddr,
+		PacketsSent: 4,
+		PacketsRecv: 3,
+		PacketLoss:  25,
+		MinRtt:      10 * time.Millisecond,
+		MaxRtt:      30 * time.Millisecond,
+		AvgRtt:      20 * time.Millisecond,
+		MedianRtt:   20 * time.Millisecond,
+		StdDevRtt:   8164965 * time.Nanosecond,
+	}, c.statistics())
+}
+
+func TestCollectorSkip(t *testing.T) {
+	c := newCollector(nil, 1, true)
+	for seq := 0; seq < 3; seq++ {
+		c.onSend(seq)
+	}
+	// A cold first packet is excluded
+	c.onRecv(0, 90*time.Millisecond, nil)
+	c.onRecv(1, 10*time.Millisecond, nil)
+	c.onRecv(2, 10*time.Millisecond, nil)
+
+	stats := c.statistics()
+	assert.Equal(t, 2, stats.PacketsSent)
+	assert.Equal(t, 2, stats.PacketsRecv)
+	assert.Equal(t, 10*time.Millisecond, stats.MaxRtt)
+	assert.Equal(t, []time.Duration{10 * time.Millisecond, 10 * time.Millisecond}, stats.Rtts)
+	assert.Zero(t, stats.StdDevRtt)
+}
+
+func TestCollectorContinuous(t *testing.T) {
+	c := newCollector(nil, 0, false)
+	c.continuous = true
+	for seq := 0; seq < 3; seq++ {
+		c.onSend(seq)
+	}
+	c.onRecv(0, 10*time.Millisecond, nil)
+	c.onRecv(1, 10*time.Millisecond, nil)
+
+	// The last packet was still in flight when the run ended
+	stats := c.statistics()
+	assert.Equal(t, 2, stats.PacketsSent)
+	assert.Zero(t, stats.PacketLoss)
+
+	c.onSend(3)
+	c.onRecv(3, 10*time.Millisecond, nil)
+	stats = c.statistics()
+	assert.Equal(t, 4, stats.PacketsSent)
+	assert.Equal(t, float64(25), stats.PacketLoss)
+}
+
+func TestCollectorPackets(t *testing.T) {
+	c := newCollector(nil, 1, false)
+	c.recordPackets = true
+	c.continuous = true
+	for seq := 0; seq < 4; seq++ {
+		c.onSend(seq)
+	}
+	c.onRecv(2, 20*time.Millisecond, nil)
+	c.onRecv(1, 10*time.Millisecond, nil)
+
+	// Skipped packets and the one still in flight are left out
+	stats := c.statistics()
+	require.Len(t, stats.Packets, 2)
+	assert.Equal(t, 1, stats.Packets[0].Seq)
+	assert.True(t, stats.Packets[0].Received)
+	assert.Equal(t, 10*time.Millisecond, stats.Packets[0].Rtt)
+	assert.Equal(t, 20*time.Millisecond, stats.Packets[1].Rtt)
+	assert.False(t, stats.Packets[1].Sent.Before(stats.Packets[0].Sent))
+
+	// Not recorded unless asked for
+	c = newCollector(nil, 0, true)
+	c.onSend(0)
+	assert.Nil(t, c.statistics().Packets)
+}
+
+func TestCollectorSourceMismatch(t *testing.T) {
+	ipaddr := &net.IPAddr{IP: net.ParseIP("192.0.2.1")}
+	c := newCollector(ipaddr, 0, false)
+	for seq := range 3 {
+		c.onSend(seq)
+	}
+	// Unprivileged sockets report IPv4 sources in their 16 byte form
+	c.onRecv(0, 10*time.Millisecond, &net.IPAddr{IP: net.ParseIP("192.0.2.1").To16()})
+	c.onRecv(1, 10*time.Millisecond, &net.IPAddr{IP: net.ParseIP("198.51.100.1")})
+	c.onRecv(2, 10*time.Millisecond, nil)
+
+	stats := c.statistics()
+	assert.Equal(t, 3, stats.PacketsRecv)
+	assert.Equal(t, 1, stats.Mismatches)
+}
+
+func TestCollectorStrict(t *testing.T) {
+	ipaddr := &net.IPAddr{IP: net.ParseIP("192.0.2.1")}
+	c := newCollector(ipaddr, 0, false)
+	assert.True(t, c.accepts(&net.IPAddr{IP: net.ParseIP("198.51.100.1")}, 8))
+
+	c.strict = true
+	c.replyLen = 32
+	assert.True(t, c.accepts(ipaddr, 32))
+	assert.False(t, c.accepts(&net.IPAddr{IP: net.ParseIP("198.51.100.1")}, 32))
+	// Truncated payloads are rejected
+	assert.False(t, c.accepts(ipaddr, 28))
+}
+
+func TestCollectorTruncate(t *testing.T) {
+	c := newCollector(nil, 0, false)
+	assert.False(t, c.truncate())
+
+	for seq := range 3 {
+		c.onSend(seq)
+	}
+	c.onRecv(0, 10*time.Millisecond, nil)
+	assert.True(t, c.truncate())
+
+	stats := c.statistics()
+	assert.Equal(t, 2, stats.PacketsSent)
+	assert.Equal(t, 1, stats.PacketsRecv)
+	assert.InDelta(t, 50.0, stats.PacketLoss, 0.001)
+}
+
+func TestCollectorPacketTimeout(t *testing.T) {
+	c := newCollector(nil, 0, false)
+	c.packetTimeout = 100 * time.Millisecond
+	c.onSend(0)
+	c.onSend(1)
+	c.onRecv(0, 20*time.Millisecond, nil)
+	// A reply later than the packet timeout counts as lost
+	c.onRecv(1, 150*time.Millisecond, nil)
+
+	stats := c.statistics()
+	assert.Equal(t, 1, stats.PacketsRecv)
+	assert.Equal(t, float64(50), stats.PacketLoss)
+	assert.Equal(t, 20*time.Millisecond, stats.MaxRtt)
+}
+
+func TestCollectorMedian(t *testing.T) {
+	c := newCollector(nil, 0, false)
+	for seq, rtt := range []time.Duration{40, 10, 300, 20} {
+		c.onSend(seq)
+		c.onRecv(seq, rtt*time.Millisecond, nil)
+	}
+	// Even sample counts average the two middle samples
+	assert.Equal(t, 30*time.Millisecond, c.statistics().MedianRtt)
+
+	c.onSend(4)
+	c.onRecv(4, 5*time.Millisecond, nil)
+	assert.Equal(t, 20*time.Millisecond, c.statistics().MedianRtt)
+}
+
+func TestCollectorNoReplies(t *testing.T) {
+	c := newCollector(nil, 0, true)
+	c.onSend(0)
+
+	stats := c.statistics()
+	assert.Equal(t, float64(100), stats.PacketLoss)
+	assert.Zero(t, stats.MinRtt)
+}
+
+func TestCollectorReplyTTL(t *testing.T) {
+	c := newCollector(nil, 1, false)
+	c.packetTimeout = 50 * time.Millisecond
+	for seq := range 4 {
+		c.onSend(seq)
+	}
+	// Skipped and late replies do not count
+	assert.False(t, c.onRecv(0, 10*time.Millisecond, nil))
+	assert.True(t, c.onRecv(1, 10*time.Millisecond, nil))
+	c.onReplyTTL(57)
+	assert.False(t, c.onRecv(2, 90*time.Millisecond, nil))
+	// Nor do replies of unknown TTL
+	assert.True(t, c.onRecv(3, 10*time.Millisecond, nil))
+	c.onReplyTTL(-1)
+
+	assert.Equal(t, 57, c.statistics().ReplyTTL)
+	assert.Zero(t, newCollector(nil, 0, false).statistics().ReplyTTL)
+}
@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prober // import "github.com/lukeod/pingcheckreceiver/prober"
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	probing "github.com/prometheus-community/pro-bing"
+	"go.uber.org/zap"
+)
+
+// ErrRawSocketsUnavailable is returned for privileged mode in udponly builds
+var ErrRawSocketsUnavailable = errors.New("privileged mode is not available, the prober was built with the udponly tag")
+
+// MinSize is the smallest payload, it holds the send time and tracker of a packet
+const MinSize = 24
+
+// icmpEchoHeaderLen is the length of an echo message without its payload
+const icmpEchoHeaderLen = 8
+
+type icmpProber struct{}
+
+// NewICMPProber returns a Prober that sends ICMP echo requests using pro-bing,
+// or connects to a TCP port for configs of ProtocolTCP
+func NewICMPProber() Prober {
+	return icmpProber{}
+}
+
+// NewPinger resolves the endpoint once unless its address is given; the
+// address is reused for every run
+func (icmpProber) NewPinger(cfg PingerConfig) (Pinger, error) {
+	if cfg.Protocol == ProtocolTCP {
+		return newTCPPinger(cfg)
+	}
+	if cfg.Privileged && !rawSockets {
+		return nil, ErrRawSocketsUnavailable
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = zap.NewNop()
+	}
+	if cfg.IPAddr != nil {
+		return &icmpPinger{cfg: cfg, ipaddr: cfg.IPAddr}, nil
+	}
+	pinger := probing.New(cfg.Endpoint)
+	pinger.SetNetwork(cfg.Network)
+	if err := pinger.Resolve(); err != nil {
+		return nil, err
+	}
+	return &icmpPinger{cfg: cfg, ipaddr: pinger.IPAddr()}, nil
+}
+
+type icmpPinger struct {
+	cfg    PingerConfig
+	ipaddr *net.IPAddr
+
+	mu      sync.Mutex
+	current *probing.Pinger
+	stopped bool
+}
+
+// Run sends the configured burst, preceded by a discarded pre-warm probe if enabled
+func (p *icmpPinger) Run(ctx context.Context) (*Statistics, error) {
+	if p.cfg.Prewarm {
+		// Let ARP/ND resolve before the measured burst, so the first packet does
+		// not carry the resolution delay. The outcome does not matter.
+		timeout := p.cfg.Timeout
+		if p.cfg.Interval > 0 && p.cfg.Interval < timeout {
+			timeout = p.cfg.Interval
+		}
+		if _, err := p.run(ctx, 1, timeout, 0); err != nil && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+	if p.cfg.DiscardFirst {
+		// Send one extra packet so count packets remain measured
+		count := p.cfg.Count
+		if count > 0 {
+			count++
+		}
+		return p.run(ctx, count, p.cfg.Timeout, 1)
+	}
+	return p.run(ctx, p.cfg.Count, p.cfg.Timeout, 0)
+}
+
+// run creates a fresh pro-bing pinger for every run, since a pro-bing pinger
+// cannot be restarted once it has finished. The first skip packets are
+// excluded from the statistics. A count of 0 sends packets until timeout.
+func (p *icmpPinger) run(ctx context.Context, count int, timeout time.Duration, skip int) (*Statistics, error) {
+	pinger := probing.New(p.cfg.Endpoint)
+	pinger.SetIPAddr(p.ipaddr)
+	pinger.Count = count
+	pinger.Timeout = timeout
+	pinger.Interval = p.cfg.Interval
+	pinger.SetPrivileged(p.cfg.Privileged)
+	if p.cfg.Size > 0 {
+		pinger.Size = p.cfg.Size
+	}
+	// DSCP is the upper 6 bits of the traffic class
+	if p.cfg.MarkDSCP {
+		pinger.SetTrafficClass(uint8(p.cfg.DSCP << 2))
+	}
+	pinger.SetDoNotFragment(p.cfg.DoNotFragment)
+	if p.cfg.TTL > 0 {
+		pinger.TTL = p.cfg.TTL
+	}
+
+	// Samples are kept by the collector, and only for the packets of this run
+	pinger.RecordRtts = false
+
+	collector := newCollector(p.ipaddr, skip, p.cfg.RecordRtts)
+	collector.packetTimeout = p.cfg.PacketTimeout
+	collector.recordPackets = p.cfg.RecordPackets
+	// A continuous run ends at the timeout, possibly right after a send
+	collector.continuous = count == 0
+	collector.strict = p.cfg.StrictReplies
+	collector.replyLen = icmpEchoHeaderLen + pinger.Size
+	pinger.OnSend = func(pkt *probing.Packet) {
+		collector.onSend(pkt.Seq)
+	}
+	pinger.OnRecv = func(pkt *probing.Packet) {
+		// Replies must carry the run's tracker to get here, strict mode also
+		// rejects those a middlebox answered or truncated
+		if !collector.accepts(pkt.IPAddr, pkt.Nbytes) {
+			p.cfg.Logger.Debug("Rejected reply",
+				zap.String("endpoint", p.cfg.Endpoint),
+				zap.Int("seq", pkt.Seq),
+				zap.String("source", pkt.Addr),
+				zap.Int("bytes", pkt.Nbytes))
+			return
+		}
+		if collector.onRecv(pkt.Seq, pkt.Rtt, pkt.IPAddr) {
+			collector.onReplyTTL(pkt.TTL)
+		}
+		p.cfg.Logger.Debug("Received packet",
+			zap.String("endpoint", p.cfg.Endpoint),
+			zap.Int("seq", pkt.Seq),
+			zap.Duration("rtt", pkt.Rtt),
+			zap.Int("ttl", pkt.TTL))
+	}
+
+	p.mu.Lock()
+	if p.stopped {
+		p.mu.Unlock()
+		return nil, context.Canceled
+	}
+	p.current = pinger
+	p.mu.Unlock()
+
+	err := pinger.RunWithContext(ctx)
+
+	p.mu.Lock()
+	p.current = nil
+	p.mu.Unlock()
+
+	if err != nil {
+		// Cut off by the deadline of the scrape, report the packets sent so far
+		if errors.Is(err, context.DeadlineExceeded) && collector.truncate() {
+			return collector.statistics(), nil
+		}
+		return nil, err
+	}
+	return collector.statistics(), nil
+}
+
+// Stop aborts the current run, if any, and prevents further runs
+func (p *icmpPinger) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.stopped = true
+	if p.current != nil {
+		p.current.Stop()
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prober
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestICMPProberNewPingerResolveError(t *testing.T) {
+	_, err := NewICMPProber().NewPinger(PingerConfig{Endpoint: "unresolvable.invalid"})
+	assert.Error(t, err)
+}
+
+func TestICMPProberNewPingerNetwork(t *testing.T) {
+	pinger, err := NewICMPProber().NewPinger(PingerConfig{Endpoint: "127.0.0.1", Network: NetworkIPv4})
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", pinger.(Resolved).IPAddr().String())
+
+	// An address of the other family is not resolved
+	_, err = NewICMPProber().NewPinger(PingerConfig{Endpoint: "127.0.0.1", Network: NetworkIPv6})
+	assert.Error(t, err)
+}
+
+func TestICMPPingerRunRepeatedly(t *testing.T) {
+	pinger, err := NewICMPProber().NewPinger(PingerConfig{
+		Endpoint: "127.0.0.1",
+		Count:    1,
+		Timeout:  time.Second,
+		Interval: 10 * time.Millisecond,
+		// Raw sockets are available when running as root, e.g. in CI containers
+		Privileged: rawSockets && os.Geteuid() == 0,
+	})
+	require.NoError(t, err)
+	defer pinger.Stop()
+
+	// A pinger must be reusable across scrapes
+	for i := 0; i < 2; i++ {
+		stats, err := pinger.Run(context.Background())
+		if err != nil {
+			t.Skipf("ICMP not permitted in this environment: %v", err)
+		}
+		assert.Equal(t, 1, stats.PacketsSent)
+		assert.Equal(t, "127.0.0.1", stats.IPAddr.String())
+	}
+}
+
+func TestICMPPingerDoNotFragment(t *testing.T) {
+	pinger, err := NewICMPProber().NewPinger(PingerConfig{
+		Endpoint:      "127.0.0.1",
+		Count:         1,
+		Timeout:       time.Second,
+		Interval:      10 * time.Millisecond,
+		Privileged:    rawSockets && os.Geteuid() == 0,
+		Size:          1000,
+		DoNotFragment: true,
+	})
+	require.NoError(t, err)
+	defer pinger.Stop()
+
+	// Loopback carries packets far larger than 1000 bytes unfragmented
+	stats, err := pinger.Run(context.Background())
+	if err != nil {
+		t.Skipf("ICMP with Don't Fragment not permitted in this environment: %v", err)
+	}
+	assert.Equal(t, 1, stats.PacketsRecv)
+}
+
+func TestICMPPingerTTL(t *testing.T) {
+	pinger, err := NewICMPProber().NewPinger(PingerConfig{
+		Endpoint:   "127.0.0.1",
+		Count:      1,
+		Timeout:    time.Second,
+		Interval:   10 * time.Millisecond,
+		Privileged: rawSockets && os.Geteuid() == 0,
+		TTL:        3,
+	})
+	require.NoError(t, err)
+	defer pinger.Stop()
+
+	// Loopback replies are sent with the default TTL of the host
+	stats, err := pinger.Run(context.Background())
+	if err != nil {
+		t.Skipf("ICMP not permitted in this environment: %v", err)
+	}
+	require.Equal(t, 1, stats.PacketsRecv)
+	if stats.ReplyTTL == 0 {
+		t.Skip("Reply TTL not available in this environment")
+	}
+	assert.Greater(t, stats.ReplyTTL, 3)
+}
+
+func TestICMPPingerPrewarm(t *testing.T) {
+	pinger, err := NewICMPProber().NewPinger(PingerConfig{
+		Endpoint:   "127.0.0.1",
+		Count:      2,
+		Timeout:    time.Second,
+		Interval:   10 * time.Millisecond,
+		Privileged: rawSockets && os.Geteuid() == 0,
+		Prewarm:    true,
+	})
+	require.NoError(t, err)
+	defer pinger.Stop()
+
+	stats, err := pinger.Run(context.Background())
+	if err != nil {
+		t.Skipf("ICMP not permitted in this environment: %v", err)
+	}
+	// The pre-warm probe is not counted
+	assert.Equal(t, 2, stats.PacketsSent)
+	assert.Equal(t, 2, stats.PacketsRecv)
+}
+
+func TestICMPPingerDiscardFirst(t *testing.T) {
+	pinger, err := NewICMPProber().NewPinger(PingerConfig{
+		Endpoint:     "127.0.0.1",
+		Count:        2,
+		Timeout:      time.Second,
+		Interval:     10 * time.Millisecond,
+		Privileged:   rawSockets && os.Geteuid() == 0,
+		DiscardFirst: true,
+	})
+	require.NoError(t, err)
+	defer pinger.Stop()
+
+	stats, err := pinger.Run(context.Background())
+	if err != nil {
+		t.Skipf("ICMP not permitted in this environment: %v", err)
+	}
+	assert.Equal(t, 2, stats.PacketsSent)
+	assert.Equal(t, 2, stats.PacketsRecv)
+	assert.Positive(t, stats.AvgRtt)
+}
+
+func TestICMPPingerContinuous(t *testing.T) {
+	pinger, err := NewICMPProber().NewPinger(PingerConfig{
+		Endpoint:   "127.0.0.1",
+		Timeout:    time.Second,
+		Interval:   100 * time.Millisecond,
+		Privileged: rawSockets && os.Geteuid() == 0,
+	})
+	require.NoError(t, err)
+	defer pinger.Stop()
+
+	stats, err := pinger.Run(context.Background())
+	if err != nil {
+		t.Skipf("ICMP not permitted in this environment: %v", err)
+	}
+	// Packets are sent for the whole timeout
+	assert.GreaterOrEqual(t, stats.PacketsSent, 5)
+	assert.Equal(t, stats.PacketsSent, stats.PacketsRecv)
+}
+
+func TestICMPPingerStop(t *testing.T) {
+	pinger, err := NewICMPProber().NewPinger(PingerConfig{Endpoint: "127.0.0.1"})
+	require.NoError(t, err)
+
+	pinger.Stop()
+	_, err = pinger.Run(context.Background())
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestICMPProberTrace(t *testing.T) {
+	if !rawSockets || os.Geteuid() != 0 {
+		t.Skip("Traces require raw sockets")
+	}
+	trace, err := NewICMPProber().(Tracer).Trace(context.Background(), TraceConfig{
+		Endpoint:   "127.0.0.1",
+		Privileged: true,
+		MaxHops:    4,
+		Queries:    2,
+		Timeout:    time.Second,
+	})
+	if err != nil {
+		t.Skipf("Raw ICMP not permitted in this environment: %v", err)
+	}
+	// Loopback is reached at the first hop
+	assert.True(t, trace.Reached)
+	require.Len(t, trace.Hops, 1)
+	hop := trace.Hops[0]
+	assert.Equal(t, 1, hop.TTL)
+	assert.Equal(t, 2, hop.Sent)
+	assert.Len(t, hop.Rtts, 2)
+	assert.Equal(t, "127.0.0.1", hop.Addr.String())
+}
+
+func TestICMPProberTraceUnprivileged(t *testing.T) {
+	_, err := NewICMPProber().(Tracer).Trace(context.Background(), TraceConfig{Endpoint: "127.0.0.1", MaxHops: 4, Queries: 1})
+	assert.ErrorIs(t, err, ErrTraceUnprivileged)
+}
@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package prober defines the probing engine used by the ping receiver. It is
+// exported so that programs embedding the receiver can substitute their own
+// implementation, e.g. the fakes in the pingchecktest package.
+package prober // import "github.com/lukeod/pingcheckreceiver/prober"
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Protocols of PingerConfig
+const (
+	ProtocolICMP = "icmp"
+	ProtocolTCP  = "tcp"
+)
+
+// PingerConfig defines how a single target is probed
+type PingerConfig struct {
+	// Endpoint to probe (hostname or IP)
+	Endpoint string
+
+	// IPAddr the endpoint resolved to, the endpoint is resolved by the
+	// pinger if nil
+	IPAddr *net.IPAddr
+
+	// Network the endpoint is resolved in. NetworkIPv4 or NetworkIPv6 forces
+	// an address family, otherwise the endpoint resolves to either.
+	Network string
+
+	// Protocol to probe with, ProtocolICMP if empty. ProtocolTCP measures
+	// how long connections to Port take to establish, a failed connection
+	// counts as a lost packet.
+	Protocol string
+
+	// Port connected to with ProtocolTCP
+	Port int
+
+	// Number of packets to send per run, 0 sends packets until Timeout
+	Count int
+
+	// Timeout for a whole run
+	Timeout time.Duration
+
+	// PacketTimeout is how long to wait for each reply, 0 waits until the run ends
+	PacketTimeout time.Duration
+
+	// Interval between packets
+	Interval time.Duration
+
+	// Privileged mode for raw ICMP sockets
+	Privileged bool
+
+	// Size of the payload of every packet in bytes, at least MinSize.
+	// 0 sends MinSize bytes.
+	Size int
+
+	// DSCP value set on every packet if MarkDSCP is, 0 is best effort.
+	// Unmarked ICMP packets keep pro-bing's CS6 (network control) marking.
+	DSCP     int
+	MarkDSCP bool
+
+	// DoNotFragment sets the Don't Fragment flag, so packets larger than the
+	// path MTU are dropped rather than fragmented. Linux only.
+	DoNotFragment bool
+
+	// TTL is the TTL or hop limit of every packet, 0 uses the default of 64
+	TTL int
+
+	// StrictReplies rejects replies from another address than the probed one
+	// or echoing only part of the payload, they count as lost
+	StrictReplies bool
+
+	// Prewarm sends a probe before every run that is excluded from its statistics
+	Prewarm bool
+
+	// DiscardFirst sends one extra packet per run and excludes the first from its statistics
+	DiscardFirst bool
+
+	// RecordRtts returns individual round-trip times in Statistics.Rtts
+	RecordRtts bool
+
+	// RecordPackets returns every packet sent in Statistics.Packets
+	RecordPackets bool
+
+	// Logger for per-packet debug output
+	Logger *zap.Logger
+}
+
+// Statistics represent the outcome of a finished run
+type Statistics struct {
+	// IPAddr is the address of the host being probed
+	IPAddr *net.IPAddr
+
+	// PacketsSent is the number of packets sent
+	PacketsSent int
+
+	// PacketsRecv is the number of packets received
+	PacketsRecv int
+
+	// Mismatches is the number of received packets answered from another
+	// address than IPAddr, e.g. by a NAT or proxy responding for the target
+	Mismatches int
+
+	// ReplyTTL is the TTL or hop limit of the latest reply received, 0 if
+	// unknown, e.g. with a TCP probe
+	ReplyTTL int
+
+	// PacketLoss is the percentage of packets lost
+	PacketLoss float64
+
+	// Rtts holds individual round-trip times, if recorded
+	Rtts []time.Duration
+
+	// Packets holds the packets sent in the order they were sent, if recorded
+	Packets []Packet
+
+	// MinRtt is the minimum round-trip time
+	MinRtt time.Duration
+
+	// MaxRtt is the maximum round-trip time
+	MaxRtt time.Duration
+
+	// AvgRtt is the average round-trip time
+	AvgRtt time.Duration
+
+	// MedianRtt is the median round-trip time
+	MedianRtt time.Duration
+
+	// StdDevRtt is the standard deviation of the round-trip times
+	StdDevRtt time.Duration
+}
+
+// Packet is a packet sent during a run and its reply, if any
+type Packet struct {
+	// Seq is the sequence number of the packet
+	Seq int
+
+	// Sent is when the packet was sent
+	Sent time.Time
+
+	// Received reports whether the packet was answered, Rtt is its
+	// round-trip time if it was
+	Received bool
+	Rtt      time.Duration
+}
+
+// Prober creates pingers for configured targets
+type Prober interface {
+	// NewPinger prepares probing of a single target. It returns an error if
+	// the target cannot be probed at all, e.g. because it does not resolve.
+	NewPinger(cfg PingerConfig) (Pinger, error)
+}
+
+// Pinger probes a single target. Run is called once per scrape.
+type Pinger interface {
+	// Run sends a burst of packets and blocks until it finished or ctx is done
+	Run(ctx context.Context) (*Statistics, error)
+
+	// Stop aborts an in-flight run and releases resources
+	Stop()
+}
+
+// Clock abstracts wall-clock time so tests can control timestamps
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is a Clock backed by time.Now
+type SystemClock struct{}
+
+// Now returns the current local time
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !udponly
+
+package prober // import "github.com/lukeod/pingcheckreceiver/prober"
+
+// rawSockets reports whether privileged mode may open raw ICMP sockets
+const rawSockets = true
@@ -0,0 +1,10 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build udponly
+
+package prober // import "github.com/lukeod/pingcheckreceiver/prober"
+
+// rawSockets is off in udponly builds, e.g. for mobile apps built with
+// gomobile, which cannot open raw sockets. Probes use datagram sockets only.
+const rawSockets = false
@@ -0,0 +1,20 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build udponly
+
+package prober
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUDPOnlyRejectsPrivileged(t *testing.T) {
+	_, err := NewICMPProber().NewPinger(PingerConfig{Endpoint: "127.0.0.1", Privileged: true})
+	assert.ErrorIs(t, err, ErrRawSocketsUnavailable)
+
+	err = NewICMPProber().(SocketChecker).CheckSocket(NetworkIPv4, true)
+	assert.ErrorIs(t, err, ErrRawSocketsUnavailable)
+}
@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prober // import "github.com/lukeod/pingcheckreceiver/prober"
+
+import (
+	"slices"
+)
+
+// Linux values of socket arguments, which differ on other platforms
+const (
+	linuxAFInet     = 2
+	linuxAFInet6    = 10
+	linuxAFNetlink  = 16
+	linuxSockStream = 1
+	linuxSockDgram  = 2
+	linuxSockRaw    = 3
+)
+
+// SeccompProfile is an OCI seccomp profile, as used by Docker, containerd and Kubernetes
+type SeccompProfile struct {
+	DefaultAction string        `json:"defaultAction"`
+	Architectures []string      `json:"architectures"`
+	Syscalls      []SeccompRule `json:"syscalls"`
+}
+
+// SeccompRule applies Action to the system calls Names whose arguments match all of Args
+type SeccompRule struct {
+	Names  []string     `json:"names"`
+	Action string       `json:"action"`
+	Args   []SeccompArg `json:"args,omitempty"`
+}
+
+// SeccompArg compares argument Index, for SCMP_CMP_MASKED_EQ Value is the
+// mask and ValueTwo the expected value
+type SeccompArg struct {
+	Index    uint   `json:"index"`
+	Value    uint64 `json:"value"`
+	ValueTwo uint64 `json:"valueTwo"`
+	Op       string `json:"op"`
+}
+
+// runtimeSyscalls are made by the Go runtime and the collector around the prober
+var runtimeSyscalls = []string{
+	"arch_prctl", "brk", "clock_gettime", "clock_nanosleep", "clone", "clone3",
+	"close", "epoll_create1", "epoll_ctl", "epoll_pwait", "eventfd2", "exit",
+	"exit_group", "fcntl", "fstat", "futex", "getpid", "getrandom", "getrlimit",
+	"gettid", "madvise", "mincore", "mmap", "mprotect", "munmap", "nanosleep",
+	"newfstatat", "openat", "pipe2", "prlimit64", "read", "readlinkat",
+	"rt_sigaction", "rt_sigprocmask", "rt_sigreturn", "sched_getaffinity",
+	"sched_yield", "set_robust_list", "set_tid_address", "sigaltstack",
+	"tgkill", "uname", "write",
+}
+
+// probeSyscalls are made by probes and hostname resolution, besides socket
+var probeSyscalls = []string{
+	"bind", "connect", "getpeername", "getsockname", "getsockopt", "recvfrom",
+	"recvmsg", "sendmsg", "sendto", "setsockopt",
+}
+
+// Syscalls returns the Linux system calls probing makes, in addition to those
+// of the Go runtime. Privileged mode makes the same calls, but opens raw
+// sockets, which Seccomp restricts.
+func Syscalls() []string {
+	return slices.Sorted(slices.Values(append([]string{"socket"}, probeSyscalls...)))
+}
+
+// Seccomp returns a profile denying every system call the receiver does not
+// make in the given probing mode. socket is only allowed for IPv4 and IPv6,
+// and for raw sockets only in privileged mode, and for the raw netlink sockets
+// that list and watch the host's interfaces. Components of the collector
+// other than the receiver, e.g. exporters, may need further system calls.
+func Seccomp(privileged bool) SeccompProfile {
+	names := slices.Sorted(slices.Values(append(slices.Clone(runtimeSyscalls), probeSyscalls...)))
+	names = slices.Compact(names)
+	profile := SeccompProfile{
+		DefaultAction: "SCMP_ACT_ERRNO",
+		Architectures: []string{"SCMP_ARCH_X86_64", "SCMP_ARCH_AARCH64"},
+		Syscalls:      []SeccompRule{{Names: names, Action: "SCMP_ACT_ALLOW"}},
+	}
+
+	// Datagram sockets carry unprivileged probes and DNS, stream sockets DNS over TCP
+	types := []uint64{linuxSockDgram, linuxSockStream}
+	if privileged {
+		types = append(types, linuxSockRaw)
+	}
+	for _, domain := range []uint64{linuxAFInet, linuxAFInet6} {
+		for _, typ := range types {
+			profile.Syscalls = append(profile.Syscalls, SeccompRule{
+				Names:  []string{"socket"},
+				Action: "SCMP_ACT_ALLOW",
+				Args: []SeccompArg{
+					{Index: 0, Value: domain, Op: "SCMP_CMP_EQ"},
+					// Ignore SOCK_NONBLOCK and SOCK_CLOEXEC
+					{Index: 1, Value: 0xf, ValueTwo: typ, Op: "SCMP_CMP_MASKED_EQ"},
+				},
+			})
+		}
+	}
+	// Netlink sockets are always raw, they need no privileges
+	profile.Syscalls = append(profile.Syscalls, SeccompRule{
+		Names:  []string{"socket"},
+		Action: "SCMP_ACT_ALLOW",
+		Args: []SeccompArg{
+			{Index: 0, Value: linuxAFNetlink, Op: "SCMP_CMP_EQ"},
+			{Index: 1, Value: 0xf, ValueTwo: linuxSockRaw, Op: "SCMP_CMP_MASKED_EQ"},
+		},
+	})
+	return profile
+}
+
+// AllowsSocket reports whether the profile allows opening a socket of the
+// given Linux domain and type, which may carry flags such as SOCK_CLOEXEC
+func (p SeccompProfile) AllowsSocket(domain, typ uint64) bool {
+	for _, rule := range p.Syscalls {
+		if rule.Action != "SCMP_ACT_ALLOW" || !slices.Contains(rule.Names, "socket") {
+			continue
+		}
+		if slices.ContainsFunc(rule.Args, func(arg SeccompArg) bool {
+			value := domain
+			if arg.Index == 1 {
+				value = typ
+			}
+			switch arg.Op {
+			case "SCMP_CMP_EQ":
+				return value != arg.Value
+			case "SCMP_CMP_MASKED_EQ":
+				return value&arg.Value != arg.ValueTwo
+			}
+			return true
+		}) {
+			continue
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prober
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateProfiles = flag.Bool("update", false, "update the seccomp profiles in testdata")
+
+func TestSyscalls(t *testing.T) {
+	syscalls := Syscalls()
+	assert.Contains(t, syscalls, "socket")
+	assert.Contains(t, syscalls, "sendto")
+	assert.IsIncreasing(t, syscalls)
+}
+
+func TestSeccomp(t *testing.T) {
+	unprivileged := Seccomp(false)
+	privileged := Seccomp(true)
+	assert.Equal(t, "SCMP_ACT_ERRNO", unprivileged.DefaultAction)
+	assert.Equal(t, unprivileged.Syscalls[0], privileged.Syscalls[0])
+
+	// Raw sockets are only allowed in privileged mode
+	rawSockets := func(p SeccompProfile) int {
+		n := 0
+		for _, rule := range p.Syscalls {
+			if len(rule.Args) == 2 && rule.Args[1].ValueTwo == linuxSockRaw {
+				n++
+			}
+		}
+		return n
+	}
+	assert.Equal(t, 1, rawSockets(unprivileged))
+	assert.Equal(t, 3, rawSockets(privileged))
+
+	// Netlink lists and watches interfaces in either mode
+	for _, p := range []SeccompProfile{unprivileged, privileged} {
+		assert.True(t, p.AllowsSocket(linuxAFNetlink, linuxSockRaw|0x80000))
+		assert.True(t, p.AllowsSocket(linuxAFInet6, linuxSockDgram))
+		assert.False(t, p.AllowsSocket(linuxAFNetlink, linuxSockDgram))
+		assert.False(t, p.AllowsSocket(1, linuxSockStream))
+	}
+	assert.False(t, unprivileged.AllowsSocket(linuxAFInet, linuxSockRaw))
+	assert.True(t, privileged.AllowsSocket(linuxAFInet, linuxSockRaw))
+}
+
+// TestSeccompProfiles keeps the published profiles in testdata up to date,
+// regenerate them with go test -run TestSeccompProfiles -update
+func TestSeccompProfiles(t *testing.T) {
+	for name, privileged := range map[string]bool{"unprivileged": false, "privileged": true} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join("testdata", "seccomp_"+name+".json")
+			got, err := json.MarshalIndent(Seccomp(privileged), "", "  ")
+			require.NoError(t, err)
+			got = append(got, '\n')
+			if *updateProfiles {
+				require.NoError(t, os.WriteFile(path, got, 0o600))
+			}
+			want, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, string(want), string(got))
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prober // import "github.com/lukeod/pingcheckreceiver/prober"
+
+import (
+	"fmt"
+	"net"
+	"runtime"
+
+	"golang.org/x/net/icmp"
+)
+
+// Address families of SocketChecker
+const (
+	NetworkIPv4 = "ip4"
+	NetworkIPv6 = "ip6"
+)
+
+// SocketChecker is implemented by probers that can verify up front that the
+// host permits the sockets they probe with
+type SocketChecker interface {
+	// CheckSocket opens and closes an ICMP socket of network, NetworkIPv4 or
+	// NetworkIPv6. The error describes how to grant the missing permission.
+	CheckSocket(network string, privileged bool) error
+}
+
+// Resolved is implemented by pingers whose endpoint was resolved on creation
+type Resolved interface {
+	// IPAddr returns the address that is probed
+	IPAddr() *net.IPAddr
+}
+
+// CheckSocket implements SocketChecker with the sockets pro-bing listens on
+func (icmpProber) CheckSocket(network string, privileged bool) error {
+	if privileged && !rawSockets {
+		return ErrRawSocketsUnavailable
+	}
+	var proto, address string
+	switch {
+	case network == NetworkIPv6 && privileged:
+		proto, address = "ip6:ipv6-icmp", "::"
+	case network == NetworkIPv6:
+		proto, address = "udp6", "::"
+	case privileged:
+		proto, address = "ip4:icmp", "0.0.0.0"
+	default:
+		proto, address = "udp4", "0.0.0.0"
+	}
+
+	conn, err := icmp.ListenPacket(proto, address)
+	if err != nil {
+		return fmt.Errorf("cannot open %s ICMP socket: %w; %s", network, err, socketHint(runtime.GOOS, network, privileged))
+	}
+	return conn.Close()
+}
+
+// socketHint tells how to permit the ICMP sockets of network in the given
+// mode on goos
+func socketHint(goos, network string, privileged bool) string {
+	switch {
+	case goos == "windows" && network == NetworkIPv6:
+		// Raw ICMPv6 sockets also fail if IPv6 is disabled in the registry or
+		// unbound from every adapter
+		return "Windows only supports raw ICMP sockets, run the collector as Administrator with IPv6 enabled on the host"
+	case goos == "windows":
+		return "Windows only supports raw ICMP sockets, run the collector as Administrator"
+	case privileged:
+		return "raw sockets require root or CAP_NET_RAW, or set privileged: false"
+	case goos == "linux":
+		return "unprivileged ICMP requires the collector's group in net.ipv4.ping_group_range, or set privileged: true"
+	default:
+		return "set privileged: true and run the collector as root"
+	}
+}
+
+// IPAddr implements Resolved
+func (p *icmpPinger) IPAddr() *net.IPAddr {
+	return p.ipaddr
+}
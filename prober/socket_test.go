@@ -0,0 +1,27 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prober
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSocketHint(t *testing.T) {
+	assert.Contains(t, socketHint("windows", NetworkIPv4, true), "Administrator")
+	assert.Contains(t, socketHint("windows", NetworkIPv6, true), "IPv6 enabled")
+	assert.Contains(t, socketHint("linux", NetworkIPv4, true), "CAP_NET_RAW")
+	assert.Contains(t, socketHint("linux", NetworkIPv4, false), "net.ipv4.ping_group_range")
+	assert.Contains(t, socketHint("darwin", NetworkIPv6, false), "privileged: true")
+}
+
+func TestICMPPingerIPAddr(t *testing.T) {
+	pinger, err := NewICMPProber().NewPinger(PingerConfig{Endpoint: "::1"})
+	require.NoError(t, err)
+	resolved, ok := pinger.(Resolved)
+	require.True(t, ok)
+	assert.Equal(t, "::1", resolved.IPAddr().String())
+}
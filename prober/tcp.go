@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prober // import "github.com/lukeod/pingcheckreceiver/prober"
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// tcpPinger measures how long TCP connections to a port take to establish,
+// for networks that block ICMP. Connections are closed once established.
+type tcpPinger struct {
+	cfg    PingerConfig
+	ipaddr *net.IPAddr
+
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	stopped bool
+}
+
+// newTCPPinger resolves the endpoint once unless its address is given; the
+// address is reused for every run
+func newTCPPinger(cfg PingerConfig) (Pinger, error) {
+	network := "ip"
+	if cfg.Network == NetworkIPv4 || cfg.Network == NetworkIPv6 {
+		network = cfg.Network
+	}
+	ipaddr := cfg.IPAddr
+	if ipaddr == nil {
+		var err error
+		if ipaddr, err = net.ResolveIPAddr(network, cfg.Endpoint); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = zap.NewNop()
+	}
+	return &tcpPinger{cfg: cfg, ipaddr: ipaddr}, nil
+}
+
+// Run connects the configured number of times, preceded by a discarded
+// pre-warm connection if enabled
+func (p *tcpPinger) Run(ctx context.Context) (*Statistics, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	p.mu.Lock()
+	if p.stopped {
+		p.mu.Unlock()
+		return nil, context.Canceled
+	}
+	p.cancel = cancel
+	p.mu.Unlock()
+	defer func() {
+		p.mu.Lock()
+		p.cancel = nil
+		p.mu.Unlock()
+	}()
+
+	if p.cfg.Prewarm {
+		timeout := p.cfg.Timeout
+		if p.cfg.Interval > 0 && p.cfg.Interval < timeout {
+			timeout = p.cfg.Interval
+		}
+		// The outcome does not matter
+		_, _ = p.connect(ctx, timeout)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+	if p.cfg.DiscardFirst {
+		// Connect once more so count connections remain measured
+		count := p.cfg.Count
+		if count > 0 {
+			count++
+		}
+		return p.run(ctx, count, 1)
+	}
+	return p.run(ctx, p.cfg.Count, 0)
+}
+
+// run connects count times, Interval apart, excluding the first skip
+// connections from the statistics. A count of 0 connects until Timeout.
+func (p *tcpPinger) run(ctx context.Context, count, skip int) (*Statistics, error) {
+	collector := newCollector(p.ipaddr, skip, p.cfg.RecordRtts)
+	collector.packetTimeout = p.cfg.PacketTimeout
+	collector.recordPackets = p.cfg.RecordPackets
+	// A continuous run ends at the timeout, possibly mid-connection
+	collector.continuous = count == 0
+
+	runCtx, cancel := context.WithTimeout(ctx, p.cfg.Timeout)
+	defer cancel()
+
+	next := time.Now()
+	for seq := 0; count == 0 || seq < count; seq++ {
+		if seq > 0 {
+			next = next.Add(p.cfg.Interval)
+			wait := time.NewTimer(time.Until(next))
+			select {
+			case <-runCtx.Done():
+				wait.Stop()
+			case <-wait.C:
+			}
+			if runCtx.Err() != nil {
+				break
+			}
+		}
+
+		collector.onSend(seq)
+		rtt, err := p.connect(runCtx, p.cfg.PacketTimeout)
+		if err != nil {
+			p.cfg.Logger.Debug("Connection failed",
+				zap.String("endpoint", p.cfg.Endpoint),
+				zap.Int("seq", seq),
+				zap.Error(err))
+		} else {
+			collector.onRecv(seq, rtt, nil)
+			p.cfg.Logger.Debug("Connection established",
+				zap.String("endpoint", p.cfg.Endpoint),
+				zap.Int("seq", seq),
+				zap.Duration("rtt", rtt))
+		}
+		if runCtx.Err() != nil {
+			break
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		// Cut off by the deadline of the scrape, report the connections so far
+		if errors.Is(err, context.DeadlineExceeded) && collector.truncate() {
+			return collector.statistics(), nil
+		}
+		return nil, err
+	}
+	return collector.statistics(), nil
+}
+
+// connect establishes and closes a connection, returning how long it took to
+// establish. A timeout of 0 waits as long as ctx allows.
+func (p *tcpPinger) connect(ctx context.Context, timeout time.Duration) (time.Duration, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	var dialer net.Dialer
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(p.ipaddr.String(), strconv.Itoa(p.cfg.Port)))
+	if err != nil {
+		return 0, err
+	}
+	rtt := time.Since(start)
+	conn.Close()
+	return rtt, nil
+}
+
+// Stop aborts the current run, if any, and prevents further runs
+func (p *tcpPinger) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.stopped = true
+	if p.cancel != nil {
+		p.cancel()
+	}
+}
+
+// IPAddr implements Resolved
+func (p *tcpPinger) IPAddr() *net.IPAddr {
+	return p.ipaddr
+}
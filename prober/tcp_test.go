@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prober
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listen accepts connections on a loopback port until the test ends and
+// returns the port and the number of connections accepted
+func listen(t *testing.T) (int, *atomic.Int32) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	var accepted atomic.Int32
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			accepted.Add(1)
+			conn.Close()
+		}
+	}()
+	return ln.Addr().(*net.TCPAddr).Port, &accepted
+}
+
+func TestTCPPingerRun(t *testing.T) {
+	port, accepted := listen(t)
+	pinger, err := NewICMPProber().NewPinger(PingerConfig{
+		Endpoint:   "127.0.0.1",
+		Protocol:   ProtocolTCP,
+		Port:       port,
+		Count:      3,
+		Timeout:    5 * time.Second,
+		Interval:   10 * time.Millisecond,
+		RecordRtts: true,
+	})
+	require.NoError(t, err)
+	defer pinger.Stop()
+	assert.Equal(t, "127.0.0.1", pinger.(Resolved).IPAddr().String())
+
+	// A pinger must be reusable across scrapes
+	for range 2 {
+		stats, err := pinger.Run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 3, stats.PacketsSent)
+		assert.Equal(t, 3, stats.PacketsRecv)
+		assert.Zero(t, stats.PacketLoss)
+		assert.Len(t, stats.Rtts, 3)
+		assert.Positive(t, stats.MaxRtt)
+	}
+	assert.Eventually(t, func() bool { return accepted.Load() == 6 }, time.Second, 10*time.Millisecond)
+}
+
+func TestTCPPingerClosedPort(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := ln.Addr().(*net.TCPAddr).Port
+	require.NoError(t, ln.Close())
+
+	pinger, err := NewICMPProber().NewPinger(PingerConfig{
+		Endpoint: "127.0.0.1",
+		Protocol: ProtocolTCP,
+		Port:     port,
+		Count:    2,
+		Timeout:  5 * time.Second,
+	})
+	require.NoError(t, err)
+	defer pinger.Stop()
+
+	// Refused connections are lost packets, not a failed run
+	stats, err := pinger.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.PacketsSent)
+	assert.Zero(t, stats.PacketsRecv)
+	assert.Equal(t, 100.0, stats.PacketLoss)
+}
+
+func TestTCPPingerPrewarmAndDiscardFirst(t *testing.T) {
+	port, accepted := listen(t)
+	pinger, err := NewICMPProber().NewPinger(PingerConfig{
+		Endpoint:     "127.0.0.1",
+		Protocol:     ProtocolTCP,
+		Port:         port,
+		Count:        2,
+		Timeout:      5 * time.Second,
+		Prewarm:      true,
+		DiscardFirst: true,
+	})
+	require.NoError(t, err)
+	defer pinger.Stop()
+
+	stats, err := pinger.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.PacketsSent)
+	assert.Equal(t, 2, stats.PacketsRecv)
+	// The pre-warm and the discarded connection were made nonetheless
+	assert.Eventually(t, func() bool { return accepted.Load() == 4 }, time.Second, 10*time.Millisecond)
+}
+
+func TestTCPPingerContinuous(t *testing.T) {
+	port, _ := listen(t)
+	pinger, err := NewICMPProber().NewPinger(PingerConfig{
+		Endpoint: "127.0.0.1",
+		Protocol: ProtocolTCP,
+		Port:     port,
+		Timeout:  200 * time.Millisecond,
+		Interval: 50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer pinger.Stop()
+
+	stats, err := pinger.Run(context.Background())
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, stats.PacketsSent, 2)
+	assert.Equal(t, stats.PacketsSent, stats.PacketsRecv)
+}
+
+func TestTCPPingerStop(t *testing.T) {
+	port, _ := listen(t)
+	pinger, err := NewICMPProber().NewPinger(PingerConfig{
+		Endpoint: "127.0.0.1",
+		Protocol: ProtocolTCP,
+		Port:     port,
+		Timeout:  time.Minute,
+		Interval: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	done := make(chan error)
+	go func() {
+		_, err := pinger.Run(context.Background())
+		done <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	pinger.Stop()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop did not abort the run")
+	}
+
+	_, err = pinger.Run(context.Background())
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestTCPProberNewPingerResolveError(t *testing.T) {
+	_, err := NewICMPProber().NewPinger(PingerConfig{Endpoint: "unresolvable.invalid", Protocol: ProtocolTCP, Port: 80})
+	assert.Error(t, err)
+}
+
+func TestTCPProberNewPingerNetwork(t *testing.T) {
+	pinger, err := NewICMPProber().NewPinger(PingerConfig{Endpoint: "::1", Protocol: ProtocolTCP, Port: 80, Network: NetworkIPv6})
+	require.NoError(t, err)
+	assert.Equal(t, "::1", pinger.(Resolved).IPAddr().String())
+
+	_, err = NewICMPProber().NewPinger(PingerConfig{Endpoint: "::1", Protocol: ProtocolTCP, Port: 80, Network: NetworkIPv4})
+	assert.Error(t, err)
+}
@@ -0,0 +1,275 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prober // import "github.com/lukeod/pingcheckreceiver/prober"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"os"
+	"time"
+
+	probing "github.com/prometheus-community/pro-bing"
+	"go.uber.org/zap"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+// ErrTraceUnprivileged is returned for traces in unprivileged mode, datagram
+// ICMP sockets do not receive the time-exceeded replies of routers
+var ErrTraceUnprivileged = errors.New("traceroute requires privileged mode")
+
+// Protocol numbers of ICMP and ICMPv6, as icmp.ParseMessage expects them
+const (
+	protocolICMP     = 1
+	protocolIPv6ICMP = 58
+)
+
+// Tracer is implemented by probers that can trace the path to a target
+type Tracer interface {
+	// Trace sends echo requests of increasing TTL to the target until it
+	// answers or MaxHops is reached
+	Trace(ctx context.Context, cfg TraceConfig) (*Trace, error)
+}
+
+// TraceConfig defines how the path to a single target is traced
+type TraceConfig struct {
+	// Endpoint to trace (hostname or IP)
+	Endpoint string
+
+	// IPAddr the endpoint resolved to, the endpoint is resolved if nil
+	IPAddr *net.IPAddr
+
+	// Network the endpoint is resolved in, see PingerConfig.Network
+	Network string
+
+	// Privileged mode for raw ICMP sockets, traces fail without it
+	Privileged bool
+
+	// MaxHops is the largest TTL sent
+	MaxHops int
+
+	// Queries is the number of packets sent per hop
+	Queries int
+
+	// Timeout is how long to wait for the reply to each packet
+	Timeout time.Duration
+
+	// Logger for per-hop debug output
+	Logger *zap.Logger
+}
+
+// Trace is the path to a target
+type Trace struct {
+	// IPAddr is the address of the target
+	IPAddr *net.IPAddr
+
+	// Hops holds a hop per TTL sent, starting at 1
+	Hops []Hop
+
+	// Reached reports whether the target answered, at the last hop
+	Reached bool
+}
+
+// Hop is the outcome of the packets sent with one TTL
+type Hop struct {
+	// TTL of the packets
+	TTL int
+
+	// Addr is the address that answered, nil if no packet was answered
+	Addr *net.IPAddr
+
+	// Sent is the number of packets sent
+	Sent int
+
+	// Rtts are the round-trip times of the packets answered
+	Rtts []time.Duration
+
+	// Reply is the ICMP message of the latest reply, e.g. to read the MPLS
+	// labels quoted in its extensions
+	Reply []byte
+}
+
+// Trace implements Tracer with raw ICMP sockets
+func (icmpProber) Trace(ctx context.Context, cfg TraceConfig) (*Trace, error) {
+	if !cfg.Privileged {
+		return nil, ErrTraceUnprivileged
+	}
+	if !rawSockets {
+		return nil, ErrRawSocketsUnavailable
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = zap.NewNop()
+	}
+	ipaddr := cfg.IPAddr
+	if ipaddr == nil {
+		pinger := probing.New(cfg.Endpoint)
+		pinger.SetNetwork(cfg.Network)
+		if err := pinger.Resolve(); err != nil {
+			return nil, err
+		}
+		ipaddr = pinger.IPAddr()
+	}
+
+	t := &tracer{cfg: cfg, dst: ipaddr, id: rand.IntN(1 << 16)}
+	var err error
+	if ipaddr.IP.To4() != nil {
+		t.conn, err = icmp.ListenPacket("ip4:icmp", "0.0.0.0")
+		t.proto, t.echo, t.reply = protocolICMP, ipv4.ICMPTypeEcho, ipv4.ICMPTypeEchoReply
+	} else {
+		t.conn, err = icmp.ListenPacket("ip6:ipv6-icmp", "::")
+		t.proto, t.echo, t.reply = protocolIPv6ICMP, ipv6.ICMPTypeEchoRequest, ipv6.ICMPTypeEchoReply
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer t.conn.Close()
+
+	// Closing the socket ends a read waiting for a reply
+	stop := context.AfterFunc(ctx, func() { t.conn.Close() })
+	defer stop()
+
+	trace := &Trace{IPAddr: ipaddr}
+	for ttl := 1; ttl <= cfg.MaxHops && !trace.Reached; ttl++ {
+		hop, reached, err := t.hop(ttl)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err != nil {
+			return nil, err
+		}
+		cfg.Logger.Debug("Traced hop",
+			zap.String("endpoint", cfg.Endpoint),
+			zap.Int("ttl", ttl),
+			zap.Stringer("addr", hop.Addr),
+			zap.Int("replies", len(hop.Rtts)))
+		trace.Hops = append(trace.Hops, hop)
+		trace.Reached = reached
+	}
+	return trace, nil
+}
+
+// tracer sends the packets of a single trace
+type tracer struct {
+	cfg   TraceConfig
+	dst   *net.IPAddr
+	conn  *icmp.PacketConn
+	proto int
+	echo  icmp.Type
+	reply icmp.Type
+	id    int
+	seq   int
+}
+
+// hop sends the queries of ttl one after another, and reports whether the
+// target itself answered
+func (t *tracer) hop(ttl int) (Hop, bool, error) {
+	var err error
+	if t.proto == protocolICMP {
+		err = t.conn.IPv4PacketConn().SetTTL(ttl)
+	} else {
+		err = t.conn.IPv6PacketConn().SetHopLimit(ttl)
+	}
+	if err != nil {
+		return Hop{}, false, err
+	}
+
+	hop := Hop{TTL: ttl}
+	reached := false
+	for range t.cfg.Queries {
+		t.seq = (t.seq + 1) & 0xffff
+		msg := icmp.Message{
+			Type: t.echo,
+			Body: &icmp.Echo{ID: t.id, Seq: t.seq, Data: make([]byte, MinSize)},
+		}
+		b, err := msg.Marshal(nil)
+		if err != nil {
+			return Hop{}, false, err
+		}
+		sent := time.Now()
+		if _, err := t.conn.WriteTo(b, t.dst); err != nil {
+			return Hop{}, false, err
+		}
+		hop.Sent++
+
+		from, reply, fromTarget, err := t.await(sent.Add(t.cfg.Timeout))
+		if err != nil {
+			return Hop{}, false, err
+		}
+		if reply == nil {
+			continue
+		}
+		hop.Rtts = append(hop.Rtts, time.Since(sent))
+		hop.Addr, hop.Reply = from, reply
+		reached = reached || fromTarget
+	}
+	return hop, reached, nil
+}
+
+// await reads replies until the one to the latest packet arrives or the
+// deadline passes. It returns the address and message of the reply, nil if
+// none arrived, and whether the target itself sent it.
+func (t *tracer) await(deadline time.Time) (*net.IPAddr, []byte, bool, error) {
+	if err := t.conn.SetReadDeadline(deadline); err != nil {
+		return nil, nil, false, err
+	}
+	buf := make([]byte, 1500)
+	for {
+		n, peer, err := t.conn.ReadFrom(buf)
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			return nil, nil, false, nil
+		}
+		if err != nil {
+			return nil, nil, false, err
+		}
+		msg, err := icmp.ParseMessage(t.proto, buf[:n])
+		if err != nil {
+			continue
+		}
+		from, _ := peer.(*net.IPAddr)
+		var quoted []byte
+		switch body := msg.Body.(type) {
+		case *icmp.Echo:
+			if msg.Type == t.reply && body.ID == t.id && body.Seq == t.seq {
+				return from, append([]byte(nil), buf[:n]...), true, nil
+			}
+			continue
+		case *icmp.TimeExceeded:
+			quoted = body.Data
+		case *icmp.DstUnreach:
+			quoted = body.Data
+		default:
+			continue
+		}
+		if t.quotesLatest(quoted) {
+			// The target may refuse echo requests with an unreachable of its own
+			_, unreachable := msg.Body.(*icmp.DstUnreach)
+			fromTarget := unreachable && from != nil && from.IP.Equal(t.dst.IP)
+			return from, append([]byte(nil), buf[:n]...), fromTarget, nil
+		}
+	}
+}
+
+// quotesLatest reports whether quoted, the start of a packet quoted in an
+// ICMP error, is the latest echo request sent
+func (t *tracer) quotesLatest(quoted []byte) bool {
+	var headerLen int
+	if t.proto == protocolICMP {
+		if len(quoted) < ipv4.HeaderLen {
+			return false
+		}
+		headerLen = int(quoted[0]&0x0f) << 2
+	} else {
+		headerLen = ipv6.HeaderLen
+	}
+	// Type, code and checksum precede the identifier and sequence number
+	if len(quoted) < headerLen+8 {
+		return false
+	}
+	echo := quoted[headerLen:]
+	return int(binary.BigEndian.Uint16(echo[4:6])) == t.id && int(binary.BigEndian.Uint16(echo[6:8])) == t.seq
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"reflect"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const (
+	profileSmall  = "small"
+	profileMedium = "medium"
+	profileLarge  = "large"
+)
+
+// profilePreset holds the options a profile sets unless they are configured
+type profilePreset struct {
+	maxConcurrentProbes   int
+	maxDatapointsPerBatch int
+	healthyEmitEvery      int
+	recreateThreshold     int
+	reportOnChange        bool
+}
+
+// profiles are tuned for up to about 100, 1,000 and 20,000 targets
+var profiles = map[string]profilePreset{
+	profileSmall: {
+		recreateThreshold: 3,
+	},
+	profileMedium: {
+		maxDatapointsPerBatch: 10_000,
+		recreateThreshold:     3,
+	},
+	profileLarge: {
+		// Bounds sockets and goroutines, weightier targets are probed first
+		maxConcurrentProbes:   2_000,
+		maxDatapointsPerBatch: 5_000,
+		healthyEmitEvery:      5,
+		// Transient resolver errors are common across thousands of hostnames
+		recreateThreshold: 5,
+		reportOnChange:    true,
+	},
+}
+
+// apply sets every option of the preset
+func (p profilePreset) apply(cfg *Config) {
+	cfg.MaxConcurrentProbes = p.maxConcurrentProbes
+	cfg.MaxDatapointsPerBatch = p.maxDatapointsPerBatch
+	cfg.HealthyEmitEvery = p.healthyEmitEvery
+	cfg.RecreateThreshold = p.recreateThreshold
+	cfg.ReportOnChange.Enabled = p.reportOnChange
+}
+
+// Unmarshal decodes the config, then applies the preset of its profile to
+// the options that were not configured explicitly. Unknown keys are rejected
+// with the closest known key, so typos do not leave options at their default.
+func (cfg *Config) Unmarshal(conf *confmap.Conf) error {
+	if err := checkKeys(conf.ToStringMap(), reflect.TypeFor[Config](), ""); err != nil {
+		return err
+	}
+	if err := conf.Unmarshal(cfg); err != nil {
+		return err
+	}
+	// Unknown profiles fail validation
+	preset, ok := profiles[cfg.Profile]
+	if !ok {
+		return nil
+	}
+
+	if !conf.IsSet("max_concurrent_probes") {
+		cfg.MaxConcurrentProbes = preset.maxConcurrentProbes
+	}
+	if !conf.IsSet("max_datapoints_per_batch") {
+		cfg.MaxDatapointsPerBatch = preset.maxDatapointsPerBatch
+	}
+	if !conf.IsSet("healthy_emit_every") {
+		cfg.HealthyEmitEvery = preset.healthyEmitEvery
+	}
+	if !conf.IsSet("recreate_threshold") {
+		cfg.RecreateThreshold = preset.recreateThreshold
+	}
+	if !conf.IsSet("report_on_change::enabled") {
+		cfg.ReportOnChange.Enabled = preset.reportOnChange
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestConfigUnmarshalProfile(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	require.NoError(t, cfg.Unmarshal(confmap.NewFromStringMap(map[string]any{
+		"profile":            "large",
+		"healthy_emit_every": 2,
+		"targets":            []any{map[string]any{"endpoint": "192.0.2.1"}},
+	})))
+
+	assert.Equal(t, 2_000, cfg.MaxConcurrentProbes)
+	assert.Equal(t, 5_000, cfg.MaxDatapointsPerBatch)
+	assert.Equal(t, 5, cfg.RecreateThreshold)
+	assert.True(t, cfg.ReportOnChange.Enabled)
+	// Configured options take precedence
+	assert.Equal(t, 2, cfg.HealthyEmitEvery)
+	// Defaults of other options are kept
+	assert.Equal(t, defaultCount, cfg.Targets[0].Count)
+	assert.Equal(t, float64(10), cfg.TrimmedMeanPercent)
+	require.NoError(t, cfg.Validate())
+}
+
+func TestConfigUnmarshalWithoutProfile(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	require.NoError(t, cfg.Unmarshal(confmap.NewFromStringMap(map[string]any{
+		"targets": []any{map[string]any{"endpoint": "192.0.2.1"}},
+	})))
+	assert.Equal(t, createDefaultConfig().(*Config).MaxDatapointsPerBatch, cfg.MaxDatapointsPerBatch)
+	assert.Equal(t, 3, cfg.RecreateThreshold)
+
+	cfg = createDefaultConfig().(*Config)
+	require.NoError(t, cfg.Unmarshal(confmap.NewFromStringMap(map[string]any{"profile": "huge"})))
+	assert.ErrorContains(t, cfg.Validate(), `profile must be small, medium or large, got "huge"`)
+}
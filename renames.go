@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// Names renamed metrics are emitted under during their deprecation window
+const (
+	metricNamesBoth = "both"
+	metricNamesNew  = "new"
+	metricNamesOld  = "old"
+)
+
+// metricRenames maps the new name of every metric in its deprecation window
+// to its old name. A metric is listed here when it is renamed, so dashboards
+// keep working on the old name, and removed once the window ends.
+var metricRenames = map[string]string{}
+
+// newAliasingConsumer passes metrics on to next under the names mode selects
+// for the metrics renamed in renames
+func newAliasingConsumer(next consumer.Metrics, mode string, renames map[string]string) (consumer.Metrics, error) {
+	return consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		aliasMetricNames(md, mode, renames)
+		return next.ConsumeMetrics(ctx, md)
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+}
+
+// aliasMetricNames renames the metrics of md listed in renames back to their
+// old name for mode old, and adds a copy under the old name for mode both
+func aliasMetricNames(md pmetric.Metrics, mode string, renames map[string]string) {
+	if mode == metricNamesNew {
+		return
+	}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			// Copies are appended, so only the metrics emitted are visited
+			n := ms.Len()
+			for k := 0; k < n; k++ {
+				name := ms.At(k).Name()
+				old, ok := renames[name]
+				if !ok {
+					continue
+				}
+				if mode == metricNamesOld {
+					ms.At(k).SetName(old)
+					continue
+				}
+				alias := ms.AppendEmpty()
+				ms.At(k).CopyTo(alias)
+				alias.SetName(old)
+				alias.SetDescription("Deprecated, renamed to " + name)
+			}
+		}
+	}
+}
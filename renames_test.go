@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestAliasingConsumer(t *testing.T) {
+	renames := map[string]string{"ping.duration": "ping.rtt"}
+	for _, tt := range []struct {
+		mode     string
+		expected []string
+	}{
+		{mode: metricNamesBoth, expected: []string{"ping.duration", "ping.packets.sent", "ping.rtt"}},
+		{mode: metricNamesOld, expected: []string{"ping.rtt", "ping.packets.sent"}},
+		{mode: metricNamesNew, expected: []string{"ping.duration", "ping.packets.sent"}},
+	} {
+		t.Run(tt.mode, func(t *testing.T) {
+			sink := new(consumertest.MetricsSink)
+			aliasing, err := newAliasingConsumer(sink, tt.mode, renames)
+			require.NoError(t, err)
+
+			require.NoError(t, aliasing.ConsumeMetrics(context.Background(), newTestMetrics([]string{"192.0.2.1", "192.0.2.2"}, 2)))
+			require.Len(t, sink.AllMetrics(), 1)
+			rms := sink.AllMetrics()[0].ResourceMetrics()
+			for i := 0; i < rms.Len(); i++ {
+				ms := rms.At(i).ScopeMetrics().At(0).Metrics()
+				var names []string
+				for k := 0; k < ms.Len(); k++ {
+					names = append(names, ms.At(k).Name())
+				}
+				assert.Equal(t, tt.expected, names)
+			}
+		})
+	}
+}
+
+func TestAliasMetricNamesCopies(t *testing.T) {
+	md := newTestMetrics([]string{"192.0.2.1"}, 3)
+	aliasMetricNames(md, metricNamesBoth, map[string]string{"ping.duration": "ping.rtt"})
+
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 3, ms.Len())
+	alias := ms.At(2)
+	assert.Equal(t, "ping.rtt", alias.Name())
+	assert.Equal(t, "ms", alias.Unit())
+	assert.Equal(t, "Deprecated, renamed to ping.duration", alias.Description())
+	assert.Equal(t, 3, alias.Gauge().DataPoints().Len())
+	assert.Equal(t, "ping.duration", ms.At(0).Name())
+}
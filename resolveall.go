@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"maps"
+	"net/netip"
+	"slices"
+
+	"go.uber.org/zap"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// addressVariants looks up every address of the hostname of target and
+// returns a variant for each but the one pinger probes, in address order.
+// Pingers of addresses the hostname no longer resolves to are stopped.
+func (s *pingScraper) addressVariants(ctx context.Context, target Target, pinger prober.Pinger) []variantKey {
+	addrs, err := s.lookupIP(ctx, target.probeNetwork(), target.Endpoint)
+	if err != nil {
+		s.logger.Debug("DNS lookup of every address failed",
+			zap.String("endpoint", target.Endpoint),
+			zap.Error(err))
+		return nil
+	}
+
+	var primary netip.Addr
+	if r, ok := pinger.(prober.Resolved); ok && r.IPAddr() != nil {
+		primary, _ = netip.AddrFromSlice(r.IPAddr().IP)
+		primary = primary.Unmap()
+	}
+	current := make(map[netip.Addr]bool, len(addrs))
+	for _, addr := range addrs {
+		if addr = addr.Unmap(); addr != primary {
+			current[addr] = true
+		}
+	}
+
+	s.mu.Lock()
+	for key, p := range s.variants {
+		if key.endpoint == target.Endpoint && key.variant.addr.IsValid() && !current[key.variant.addr] {
+			p.Stop()
+			delete(s.variants, key)
+		}
+	}
+	s.mu.Unlock()
+
+	variants := make([]variantKey, 0, len(current))
+	for _, addr := range slices.SortedFunc(maps.Keys(current), netip.Addr.Compare) {
+		variants = append(variants, variantKey{addr: addr})
+	}
+	return variants
+}
+
+// recordAddresses records the results of probing the other addresses of
+// target into mb. They are recorded like the regular probe and set apart by
+// net.peer.ip.
+func (s *pingScraper) recordAddresses(mb *metadata.MetricsBuilder, metrics metadata.MetricsConfig, target Target, results []variantResult) {
+	for _, r := range results {
+		if r.variant.addr.IsValid() {
+			s.recordStats(mb, metrics, target, r.stats, r.finished)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// packetsSentByIP returns the ping.packets.sent data points of md by net.peer.ip
+func packetsSentByIP(md pmetric.Metrics) map[string]int64 {
+	sent := map[string]int64{}
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		if m.Name() != "ping.packets.sent" {
+			return
+		}
+		for i := 0; i < m.Sum().DataPoints().Len(); i++ {
+			dp := m.Sum().DataPoints().At(i)
+			ip, _ := dp.Attributes().Get("net.peer.ip")
+			sent[ip.Str()] = dp.IntValue()
+		}
+	})
+	return sent
+}
+
+func TestScraperResolveAll(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ResolveMode = resolveEveryScrape
+	cfg.Targets = []Target{{Endpoint: "anycast.example", Count: 4, ResolveAll: true}}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetResult("anycast.example", prober.Statistics{PacketsSent: 4, PacketsRecv: 4})
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	addrs := []netip.Addr{
+		netip.MustParseAddr("192.0.2.1"),
+		netip.MustParseAddr("2001:db8::1"),
+		netip.MustParseAddr("192.0.2.2"),
+	}
+	scraper.lookupIP = func(_ context.Context, network, _ string) ([]netip.Addr, error) {
+		assert.Equal(t, networkAny, network)
+		return addrs, nil
+	}
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	// The first address is probed regularly, the others as streams of their own
+	md, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"192.0.2.1": 4, "192.0.2.2": 4, "2001:db8::1": 4}, packetsSentByIP(md))
+
+	// Pingers of addresses no longer resolved to are stopped
+	addrs = addrs[:2]
+	md, err = scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"192.0.2.1": 4, "2001:db8::1": 4}, packetsSentByIP(md))
+	assert.Len(t, scraper.variants, 1)
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"text/template"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// resourceTemplate is a resource attribute whose value is evaluated per target
+type resourceTemplate struct {
+	key  string
+	tmpl *template.Template
+}
+
+// parseResourceAttributes parses the templates of resource_attributes, sorted
+// by key so every resource lists its attributes in the same order
+func parseResourceAttributes(attrs map[string]string) ([]resourceTemplate, error) {
+	templates := make([]resourceTemplate, 0, len(attrs))
+	for _, key := range slices.Sorted(maps.Keys(attrs)) {
+		tmpl, err := template.New(key).Parse(attrs[key])
+		if err != nil {
+			return nil, within("resource_attributes", within(key, asInvalid("", CodeInvalidFormat, err)))
+		}
+		templates = append(templates, resourceTemplate{key: key, tmpl: tmpl})
+	}
+	return templates, nil
+}
+
+// targetResource evaluates the resource attribute templates for target, e.g.
+// service.name: "probe-{{ .Group }}", and attaches the target's entity
+func targetResource(templates []resourceTemplate, target Target) (pcommon.Resource, error) {
+	res := pcommon.NewResource()
+	for _, t := range templates {
+		var value strings.Builder
+		if err := t.tmpl.Execute(&value, target); err != nil {
+			return res, fmt.Errorf("resource_attributes: %s: %w", t.key, err)
+		}
+		res.Attributes().PutStr(t.key, value.String())
+	}
+	if target.Entity != nil {
+		target.Entity.attach(res)
+	}
+	return res, nil
+}
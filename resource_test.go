@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+)
+
+func TestTargetResource(t *testing.T) {
+	templates, err := parseResourceAttributes(map[string]string{
+		"service.name":      "probe-{{ .Group }}",
+		"net.host.name":     "{{ .Endpoint }}",
+		"deployment.static": "prod",
+	})
+	require.NoError(t, err)
+
+	res, err := targetResource(templates, Target{Endpoint: "192.0.2.1", Group: "core"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"service.name":      "probe-core",
+		"net.host.name":     "192.0.2.1",
+		"deployment.static": "prod",
+	}, res.Attributes().AsRaw())
+
+	_, err = parseResourceAttributes(map[string]string{"service.name": "probe-{{ .Group"})
+	assert.ErrorContains(t, err, "resource_attributes: service.name:")
+
+	templates, err = parseResourceAttributes(map[string]string{"service.name": "{{ .Site }}"})
+	require.NoError(t, err)
+	_, err = targetResource(templates, Target{Endpoint: "192.0.2.1"})
+	assert.ErrorContains(t, err, "can't evaluate field Site")
+}
+
+func TestScraperResourceAttributes(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4, Group: "edge"}}
+	cfg.ResourceAttributes = map[string]string{"service.name": "probe-{{ .Group }}"}
+
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(pingchecktest.NewProber())))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	metrics, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	serviceName, ok := metrics.ResourceMetrics().At(0).Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "probe-edge", serviceName.Str())
+}
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+)
+
+// scheduledAt returns the tick of collection_interval a probe started at t
+// belongs to. The controller scrapes initial_delay after the receiver
+// started and every collection_interval from then, so the ticks are counted
+// from the start of the receiver.
+func (s *pingScraper) scheduledAt(t time.Time) time.Time {
+	first := s.startTime.AsTime().Add(s.cfg.InitialDelay)
+	if !t.After(first) || s.cfg.CollectionInterval <= 0 {
+		return first
+	}
+	return first.Add(t.Sub(first).Truncate(s.cfg.CollectionInterval))
+}
+
+// recordScheduleDrift records how long after its tick the packets of the
+// probe of target started to be sent
+func (s *pingScraper) recordScheduleDrift(mb *metadata.MetricsBuilder, metrics metadata.MetricsConfig, target Target, o *probeOutcome) {
+	if !metrics.PingScheduleDrift.Enabled || o.burstStarted.IsZero() {
+		return
+	}
+	mb.RecordPingScheduleDriftDataPoint(pcommon.NewTimestampFromTime(o.burstStarted), milliseconds(o.burstStarted.Sub(o.scheduled)), target.Endpoint)
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+)
+
+// scheduleDrifts returns the values of the ping.schedule.drift data points of md
+func scheduleDrifts(md pmetric.Metrics) []float64 {
+	var drifts []float64
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		if m.Name() == "ping.schedule.drift" {
+			drifts = append(drifts, m.Gauge().DataPoints().At(0).DoubleValue())
+		}
+	})
+	return drifts
+}
+
+func TestScheduledAt(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	cfg := createDefaultConfig().(*Config)
+	cfg.InitialDelay = time.Second
+	cfg.CollectionInterval = 10 * time.Second
+	s := &pingScraper{cfg: cfg, startTime: pcommon.NewTimestampFromTime(start)}
+	first := start.Add(time.Second)
+
+	assert.True(t, first.Equal(s.scheduledAt(start)))
+	assert.True(t, first.Equal(s.scheduledAt(first)))
+	assert.True(t, first.Equal(s.scheduledAt(first.Add(9999*time.Millisecond))))
+	assert.True(t, first.Add(20*time.Second).Equal(s.scheduledAt(first.Add(25*time.Second))))
+}
+
+func TestScraperScheduleDrift(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics.PingScheduleDrift.Enabled = true
+	cfg.CollectionInterval = 10 * time.Second
+	cfg.Targets = []Target{
+		{Endpoint: "192.0.2.1", Count: 4},
+		// Injected faults send no packets
+		{Endpoint: "192.0.2.2", Count: 4, FaultInjection: &FaultInjectionConfig{Error: "timeout"}},
+	}
+
+	start := time.Unix(1_700_000_000, 0)
+	clock := pingchecktest.NewClock(start)
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type),
+		newFactoryOptions(WithProber(pingchecktest.NewProber()), WithClock(clock)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	scrape := func() ([]float64, []float64) {
+		md, err := scraper.scrapeTarget(context.Background(), 0)
+		require.NoError(t, err)
+		faulty, _ := scraper.scrapeTarget(context.Background(), 1)
+		return scheduleDrifts(md), scheduleDrifts(faulty)
+	}
+
+	first := start.Add(cfg.InitialDelay)
+	clock.Set(first.Add(5 * time.Millisecond))
+	drifts, faulty := scrape()
+	assert.InDeltaSlice(t, []float64{5}, drifts, 1e-9)
+	assert.Empty(t, faulty)
+
+	// Each probe is measured against the tick it belongs to
+	clock.Set(first.Add(cfg.CollectionInterval + 1500*time.Microsecond))
+	drifts, _ = scrape()
+	assert.InDeltaSlice(t, []float64{1.5}, drifts, 1e-9)
+}
@@ -4,91 +4,338 @@
 package pingcheckreceiver
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"net"
+	"net/netip"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
-	probing "github.com/prometheus-community/pro-bing"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
+	"github.com/lukeod/pingcheckreceiver/internal/asn"
 	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/internal/snmp"
+	"github.com/lukeod/pingcheckreceiver/prober"
 )
 
 type pingScraper struct {
-	cfg      *Config
-	settings receiver.Settings
-	logger   *zap.Logger
-	mb       *metadata.MetricsBuilder
-	pingers  map[string]*probing.Pinger
-	mu       sync.RWMutex
+	cfg       *Config
+	settings  receiver.Settings
+	logger    *zap.Logger
+	prober    prober.Prober
+	clock     prober.Clock
+	startTime pcommon.Timestamp
+	pingers   map[string]prober.Pinger
+	variants  map[pingerVariant]prober.Pinger
+	states    map[string]*targetState
+	mu        sync.RWMutex
+
+	// Every target has its own scraper, they share a single start
+	startOnce sync.Once
+	startErr  error
+
+	// Interface state, nil unless interface checks are enabled
+	links *linkMonitor
+
+	// Local stack check, nil unless enabled
+	local *localChecker
+
+	// Source addresses of the targets, nil unless source_watch is enabled
+	sources *sourceWatch
+
+	// Fleet-wide incidents, nil unless first_responder is enabled
+	incident *incidentMode
+
+	// Events of the holiday calendar, nil unless holidays has a source
+	holidays *holidayCalendar
+
+	// One builder per target so targets can be recorded concurrently
+	builders []*targetBuilder
+
+	// Resource of each target, from the resource attribute templates
+	resources []pcommon.Resource
+
+	// Indexes of the targets by descending weight
+	probeOrder []int
+
+	// Metrics config of each target, with its overrides applied
+	metrics []metadata.MetricsConfig
+
+	// Ranges targets may be probed in
+	guard destinationGuard
+
+	// Peer addresses as recorded in attributes
+	ips ipInterner
+
+	// readLimits returns the resource limits of the host
+	readLimits func() hostLimits
+
+	// sendWake sends a Wake-on-LAN packet to a broadcast address
+	sendWake func(addr string, packet []byte) error
+
+	// Passively observed traffic, nil unless passive_check is configured
+	flows FlowSource
+
+	// Registrar the targets' health is published to, nil unless health_registry is configured
+	registrar HealthRegistrar
+
+	// lookupIP resolves hostnames for ping.dns.lookup.duration
+	lookupIP func(ctx context.Context, network, host string) ([]netip.Addr, error)
+
+	// sysUpTime requests sysUpTime from an SNMP agent
+	sysUpTime func(ctx context.Context, addr, community string) (time.Duration, error)
+
+	// Audit stream of probes, nil unless enabled
+	audit *auditLog
+
+	// Resolver annotating the traceroute hops of each endpoint with their
+	// origin AS, see traceroute.asn
+	asnResolvers map[string]asn.Resolver
+
+	// Targets claimed across the receivers of the factory, and the claim on
+	// each target, nil unless deduplicate is enabled. claimed is guarded by mu.
+	claims  *probeClaims
+	claimed []*probeClaim
+
+	// Budget of sockets and packets shared by the receivers of the process
+	budget *socketBudget
+
+	// Targets added and removed, see lifecycle.go
+	lifecycle *lifecycleLog
+
+	// privilegedFallback is set at start if only raw ICMP sockets can be opened
+	privilegedFallback bool
+
+	roundMu sync.Mutex
+	round   *scrapeRound
+
+	// Rounds still probing, waited for at shutdown
+	roundWG sync.WaitGroup
+
+	// Memory limit shedding is measured against, the memory in use, and the
+	// shedding of the latest round, guarded by roundMu
+	memoryLimit uint64
+	readMemory  func() uint64
+	shed        shedLevel
+
+	// booted is set once the first round started, see priority
+	booted bool
+
+	// Latest probe of each endpoint, shared by the receivers of every signal
+	outcomeMu sync.Mutex
+	outcomes  map[string]*sharedOutcome
+
+	// Background work (diagnostics) outlives a single scrape
+	bgCtx    context.Context
+	bgCancel context.CancelFunc
+	bgWG     sync.WaitGroup
+}
+
+// targetBuilder serializes recording into the metrics builder of one target
+type targetBuilder struct {
+	mu sync.Mutex
+	mb *metadata.MetricsBuilder
+
+	// lastEmit starts the window of delta sums
+	lastEmit pcommon.Timestamp
+
+	// Probe panics counted up to lastEmit, see recordPanics
+	reportedPanics int
+
+	// Healthy scrapes dropped since one was last emitted, see healthy_emit_every
+	emittedHealthy bool
+	skippedHealthy int
+
+	// Latest emitted result, see report_on_change
+	reported   *probeOutcome
+	reportedAt time.Time
+}
+
+// scrapeRound holds the results of probing every target once
+type scrapeRound struct {
+	results []chan targetResult
+	joined  []bool
+
+	// How far the detailed metrics of the round are shed, see shedding
+	shed shedLevel
+
+	// The deadline of the scrape that started the round, and the timeout it
+	// was given, which every probe of the round gets from when it starts
+	deadline time.Time
+	timeout  time.Duration
+}
+
+type targetResult struct {
+	metrics pmetric.Metrics
+	err     error
+}
+
+// targetState tracks per-target results across scrapes
+type targetState struct {
+	consecutiveFailures int
+	pingerFailures      int
+
+	// Whether the target was down in the last scrape, once observed
+	observed bool
+	down     bool
+
+	// When the target was last observed, and how long it was down in total
+	// and since the observation before, see updateState
+	observedAt   time.Time
+	downtime     downtime
+	lastDowntime downtime
+
+	// Address the hostname resolved to before the last probe, and when it
+	// was looked up, see resolve
+	resolved   netip.Addr
+	resolvedAt time.Time
+
+	// Whether a traceroute is running, when the latest started, and its
+	// result until a probe reports it, see checkTrace
+	tracing  bool
+	tracedAt time.Time
+	trace    *traceResult
+
+	// Probes that panicked, see probeSafely
+	panics int
 }
 
-func newScraper(cfg *Config, settings receiver.Settings) *pingScraper {
+func newScraper(cfg *Config, settings receiver.Settings, fo factoryOptions) *pingScraper {
+	bgCtx, bgCancel := context.WithCancel(context.Background())
 	return &pingScraper{
-		cfg:      cfg,
-		settings: settings,
-		logger:   settings.Logger,
-		pingers:  make(map[string]*probing.Pinger),
+		cfg:        cfg,
+		settings:   settings,
+		logger:     settings.Logger,
+		prober:     fo.prober,
+		clock:      fo.clock,
+		flows:      fo.flows,
+		registrar:  fo.registrar,
+		claims:     fo.claims,
+		budget:     fo.budget,
+		pingers:    make(map[string]prober.Pinger),
+		variants:   make(map[pingerVariant]prober.Pinger),
+		states:     make(map[string]*targetState),
+		outcomes:   make(map[string]*sharedOutcome),
+		readLimits: readHostLimits,
+		readMemory: heapInUse,
+		sendWake:   sendUDP,
+		lookupIP:   net.DefaultResolver.LookupNetIP,
+		sysUpTime:  snmp.SysUpTime,
+		lifecycle:  newLifecycleLog(settings.Logger),
+		bgCtx:      bgCtx,
+		bgCancel:   bgCancel,
 	}
 }
 
-// start initializes resources
+// start initializes resources, it is called once per target scraper but only runs once
 func (s *pingScraper) start(ctx context.Context, host component.Host) error {
-	s.mb = metadata.NewMetricsBuilder(s.cfg.MetricsBuilderConfig, s.settings)
+	s.startOnce.Do(func() {
+		s.startErr = s.doStart(ctx, host)
+	})
+	return s.startErr
+}
 
-	// Initialize pingers for all targets
-	for _, target := range s.cfg.Targets {
-		pinger, err := probing.NewPinger(target.Endpoint)
-		if err != nil {
-			s.logger.Error("Failed to create pinger",
-				zap.String("endpoint", target.Endpoint),
-				zap.Error(err))
-			continue // Skip this target but don't fail startup
-		}
+func (s *pingScraper) doStart(_ context.Context, host component.Host) error {
+	s.startTime = pcommon.NewTimestampFromTime(s.clock.Now())
 
-		// Apply default values if not set
-		if target.Count == 0 {
-			target.Count = 4
+	templates, err := parseResourceAttributes(s.cfg.ResourceAttributes)
+	if err != nil {
+		return err
+	}
+	if s.flows, err = s.flowSource(host); err != nil {
+		return err
+	}
+	if s.registrar, err = s.healthRegistrar(host); err != nil {
+		return err
+	}
+	if s.guard, err = parseDestinationGuard(s.cfg.AllowedCIDRs, s.cfg.DeniedCIDRs); err != nil {
+		return err
+	}
+	s.resources = make([]pcommon.Resource, len(s.cfg.Targets))
+	s.metrics = make([]metadata.MetricsConfig, len(s.cfg.Targets))
+	for i, target := range s.cfg.Targets {
+		if s.resources[i], err = targetResource(templates, target); err != nil {
+			return fmt.Errorf("target %s: %w", target.Endpoint, err)
 		}
-		if target.Timeout == 0 {
-			target.Timeout = 5 * time.Second
+		if s.metrics[i], err = s.cfg.targetMetrics(target); err != nil {
+			return fmt.Errorf("target %s: %w", target.Endpoint, err)
 		}
-		if target.Interval == 0 {
-			target.Interval = time.Second
+	}
+
+	s.probeOrder = make([]int, len(s.cfg.Targets))
+	for i := range s.probeOrder {
+		s.probeOrder[i] = i
+	}
+	slices.SortStableFunc(s.probeOrder, func(a, b int) int {
+		return cmp.Compare(s.cfg.Targets[b].Weight, s.cfg.Targets[a].Weight)
+	})
+
+	s.builders = make([]*targetBuilder, len(s.cfg.Targets))
+	for i := range s.builders {
+		mbc := s.cfg.MetricsBuilderConfig
+		mbc.Metrics = s.metrics[i]
+		s.builders[i] = &targetBuilder{
+			mb:       metadata.NewMetricsBuilder(mbc, s.settings, metadata.WithStartTime(s.startTime)),
+			lastEmit: s.startTime,
 		}
+	}
 
-		// Configure pinger
-		pinger.Count = target.Count
-		pinger.Timeout = target.Timeout
-		pinger.Interval = target.Interval
+	if err := s.checkLimits(); err != nil {
+		return err
+	}
 
-		// Platform-specific privilege configuration
-		if runtime.GOOS == "windows" {
-			// Windows requires privileged mode
-			pinger.SetPrivileged(true)
-			s.logger.Debug("Windows detected, using privileged mode",
-				zap.String("endpoint", target.Endpoint))
-		} else {
-			pinger.SetPrivileged(s.cfg.Privileged)
+	if s.cfg.Deduplicate {
+		s.claimed = make([]*probeClaim, len(s.cfg.Targets))
+		for i, target := range s.cfg.Targets {
+			s.claimed[i] = s.claims.claim(target)
 		}
+	}
+
+	if s.cfg.Shedding.Enabled {
+		s.startShedding()
+	}
 
-		// Prevent memory growth for long-running operations
-		pinger.RecordRtts = false
+	if s.asnResolvers, err = newASNResolvers(s.cfg.Targets); err != nil {
+		return fmt.Errorf("traceroute: %w", err)
+	}
 
-		// Set callbacks for debugging
-		pinger.OnRecv = func(pkt *probing.Packet) {
-			s.logger.Debug("Received packet",
-				zap.String("endpoint", target.Endpoint),
-				zap.Int("seq", pkt.Seq),
-				zap.Duration("rtt", pkt.Rtt))
+	if s.cfg.Audit.Enabled {
+		if s.audit, err = newAuditLog(s.cfg.Audit, s.logger); err != nil {
+			return fmt.Errorf("audit: %w", err)
+		}
+	}
+
+	// Initialize pingers for all targets
+	for _, target := range s.cfg.Targets {
+		pinger, err := s.newPingerSafely(target)
+		if err != nil {
+			s.lifecycle.failed(target.Endpoint, err)
+			continue // Skip this target but don't fail startup, creation is retried on scrape
+		}
+		s.lifecycle.added(target.Endpoint, pinger)
+
+		if target.FaultInjection != nil {
+			s.logger.Warn("Fault injection enabled, reported results are synthetic",
+				zap.String("endpoint", target.Endpoint))
+		}
+		if target.Timeout > 0 {
+			s.logger.Warn("The timeout option is deprecated, use run_timeout instead",
+				zap.String("endpoint", target.Endpoint))
 		}
 
 		s.mu.Lock()
@@ -96,15 +343,280 @@ func (s *pingScraper) start(ctx context.Context, host component.Host) error {
 		s.mu.Unlock()
 	}
 
+	// Probes keep running and report permission_denied, e.g. until capabilities are granted
+	if err := s.checkSockets(); err != nil {
+		s.logger.Error("ICMP sockets cannot be opened, probes will fail",
+			zap.String("platform", runtime.GOOS),
+			zap.Error(err))
+		componentstatus.ReportStatus(host, componentstatus.NewRecoverableErrorEvent(err))
+	}
+
+	if s.cfg.SelfTest {
+		s.runSelfTest(host)
+	}
+
+	if s.cfg.InterfaceCheck.Enabled {
+		s.links = newLinkMonitor(s.clock)
+		s.links.start(s.logger)
+	}
+	if s.cfg.LocalCheck.Enabled {
+		s.local = newLocalChecker(s.cfg.LocalCheck, s.prober, s.privileged(), s.clock)
+	}
+	if s.cfg.SourceWatch {
+		s.sources = newSourceWatch()
+	}
+	if s.cfg.FirstResponder.Enabled {
+		s.incident = newIncidentMode(s.cfg.FirstResponder, s.clock)
+	}
+	if s.cfg.Holidays.Source != "" {
+		s.startHolidays()
+	}
+
 	if len(s.pingers) == 0 {
-		return fmt.Errorf("no valid pingers could be created")
+		if !s.cfg.AllowEmptyTargets {
+			return fmt.Errorf("no valid pingers could be created")
+		}
+		s.logger.Warn("No valid pingers could be created, waiting for targets to become available")
 	}
 
+	if s.registrar != nil {
+		s.registrar.RegisterHealth(s.settings.ID, s)
+	}
 	return nil
 }
 
+// newPinger creates a pinger for target, applying default values
+func (s *pingScraper) newPinger(target Target) (prober.Pinger, error) {
+	return s.newVariantPinger(target, variantKey{})
+}
+
+// newVariantPinger creates a pinger for target sending the packets of
+// variant, the zero variant sends regular packets
+func (s *pingScraper) newVariantPinger(target Target, variant variantKey) (prober.Pinger, error) {
+	// Defaults are applied when the config is decoded, this resolves the
+	// duration and the deprecated timeout of targets assembled in code
+	target = target.effective()
+	if variant.underlay != "" {
+		target.Endpoint = variant.underlay
+	}
+	// Dual-stack targets are probed over IPv4, and over IPv6 as a variant
+	switch {
+	case variant.network != "":
+		target.Network = variant.network
+	case target.DualStack:
+		target.Network = prober.NetworkIPv4
+	}
+	if s.incident != nil && s.incident.active() {
+		target = s.incident.elevate(target)
+	}
+
+	if runtime.GOOS == "windows" {
+		s.logger.Debug("Windows detected, using privileged mode",
+			zap.String("endpoint", target.Endpoint))
+	}
+
+	// Hostnames looked up before the probe are not resolved again
+	var ipaddr *net.IPAddr
+	switch {
+	case variant.addr.IsValid():
+		ipaddr = &net.IPAddr{IP: variant.addr.AsSlice()}
+	case variant.underlay == "" && variant.network == "":
+		ipaddr = s.resolvedAddr(target.Endpoint)
+	}
+
+	pinger, err := s.prober.NewPinger(prober.PingerConfig{
+		Endpoint:      target.Endpoint,
+		IPAddr:        ipaddr,
+		Network:       target.Network,
+		Protocol:      target.Protocol,
+		Port:          target.Port,
+		Count:         target.Count,
+		Timeout:       target.RunTimeout,
+		PacketTimeout: target.PacketTimeout,
+		Interval:      target.Interval,
+		Privileged:    s.privileged(),
+		Size:          cmp.Or(variant.size, target.PacketSize),
+		DSCP:          variant.dscp,
+		MarkDSCP:      variant.marked,
+		DoNotFragment: variant.dontFragment,
+		TTL:           target.TTL,
+		StrictReplies: s.cfg.StrictReplies,
+		Prewarm:       target.Prewarm,
+		DiscardFirst:  target.DiscardFirst,
+		RecordRtts:    variant.regular() && s.recordRtts(target.Endpoint),
+		RecordPackets: variant.regular() && s.cfg.Traces.PacketSpans,
+		Logger:        s.logger,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Hostnames may resolve to forbidden ranges
+	if r, ok := pinger.(prober.Resolved); ok && r.IPAddr() != nil {
+		addr, _ := netip.AddrFromSlice(r.IPAddr().IP)
+		if err := s.guard.check(addr); err != nil {
+			pinger.Stop()
+			return nil, fmt.Errorf("refusing to probe %s: %w", target.Endpoint, err)
+		}
+	}
+	return pinger, nil
+}
+
+// recordRtts reports whether any metric enabled for endpoint is computed from
+// individual samples. Targets of the same endpoint share its probes.
+func (s *pingScraper) recordRtts(endpoint string) bool {
+	if s.cfg.DurationHistogram.Enabled {
+		return true
+	}
+	for i, target := range s.cfg.Targets {
+		if target.Endpoint == endpoint && (s.metrics[i].PingDurationTrimmedMean.Enabled || s.metrics[i].PingJitter.Enabled ||
+			s.metrics[i].PingDurationHeatmap.Enabled) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkLimits fails start if the host cannot probe every endpoint at once,
+// rather than letting probes fail mid-run
+func (s *pingScraper) checkLimits() error {
+	endpoints := make(map[string]bool)
+	for _, target := range s.cfg.Targets {
+		endpoints[target.Endpoint] = true
+	}
+	// Receivers of the process open their sockets alongside each other
+	sockets := s.budget.register(s, len(endpoints))
+	if err := s.readLimits().check(sockets, s.logger); err != nil {
+		s.budget.unregister(s)
+		return err
+	}
+	return nil
+}
+
+// checkSockets verifies that ICMP sockets of every address family in use can
+// be opened, so missing permissions are explained once at start rather than
+// only surfacing as failed probes
+func (s *pingScraper) checkSockets() error {
+	checker, ok := s.prober.(prober.SocketChecker)
+	if !ok {
+		return nil
+	}
+
+	// TCP probes need no ICMP socket
+	tcp := make(map[string]bool)
+	for _, target := range s.cfg.Targets {
+		tcp[target.Endpoint] = target.probeProtocol() == prober.ProtocolTCP
+	}
+
+	networks := make(map[string]bool)
+	s.mu.Lock()
+	for endpoint, pinger := range s.pingers {
+		if tcp[endpoint] {
+			continue
+		}
+		network := prober.NetworkIPv4
+		if r, ok := pinger.(prober.Resolved); ok && r.IPAddr() != nil && r.IPAddr().IP.To4() == nil {
+			network = prober.NetworkIPv6
+		}
+		networks[network] = true
+	}
+	s.mu.Unlock()
+
+	err := checkNetworks(checker, networks, s.privileged())
+	if err == nil || s.privileged() {
+		return err
+	}
+
+	// Some platforms refuse unprivileged datagram sockets in configurations
+	// raw sockets work in, e.g. macOS for some ICMPv6 setups
+	if checkNetworks(checker, networks, true) != nil {
+		return err
+	}
+	s.logger.Warn("Unprivileged ICMP sockets cannot be opened, falling back to privileged mode",
+		zap.String("platform", runtime.GOOS),
+		zap.Error(err))
+	s.privilegedFallback = true
+
+	// Pingers created so far use unprivileged sockets
+	s.mu.Lock()
+	for endpoint, pinger := range s.pingers {
+		pinger.Stop()
+		delete(s.pingers, endpoint)
+	}
+	s.mu.Unlock()
+	for _, target := range s.cfg.Targets {
+		// Failures are logged by pingerFor
+		_, _ = s.pingerFor(target)
+	}
+	return nil
+}
+
+// checkNetworks checks the sockets of every network in the given mode
+func checkNetworks(checker prober.SocketChecker, networks map[string]bool, privileged bool) error {
+	var err error
+	for _, network := range slices.Sorted(maps.Keys(networks)) {
+		err = multierr.Append(err, checker.CheckSocket(network, privileged))
+	}
+	return err
+}
+
+// privileged reports whether to use raw ICMP sockets, which Windows requires
+func (s *pingScraper) privileged() bool {
+	return s.cfg.Privileged || runtime.GOOS == "windows" || s.privilegedFallback
+}
+
+// pingerFor returns the pinger of target, creating it if that failed before
+func (s *pingScraper) pingerFor(target Target) (prober.Pinger, error) {
+	s.mu.RLock()
+	pinger, ok := s.pingers[target.Endpoint]
+	s.mu.RUnlock()
+	if ok {
+		return pinger, nil
+	}
+
+	pinger, err := s.newPingerSafely(target)
+	if err != nil {
+		s.lifecycle.failed(target.Endpoint, err)
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pingers == nil {
+		pinger.Stop()
+		return nil, errors.New("scraper is shut down")
+	}
+	if existing, ok := s.pingers[target.Endpoint]; ok {
+		pinger.Stop()
+		return existing, nil
+	}
+	s.pingers[target.Endpoint] = pinger
+	s.lifecycle.added(target.Endpoint, pinger)
+	return pinger, nil
+}
+
 // shutdown cleans up resources
 func (s *pingScraper) shutdown(ctx context.Context) error {
+	// Cancelling the background context also cancels the rounds in flight,
+	// which must be done with the claims before they are released
+	s.bgCancel()
+	s.bgWG.Wait()
+	s.roundWG.Wait()
+	if s.links != nil {
+		s.links.stop()
+	}
+	s.mu.Lock()
+	claimed := s.claimed
+	s.claimed = nil
+	s.mu.Unlock()
+	for _, pc := range claimed {
+		s.claims.release(pc)
+	}
+	s.budget.unregister(s)
+	if s.registrar != nil {
+		s.registrar.UnregisterHealth(s.settings.ID)
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -113,163 +625,687 @@ func (s *pingScraper) shutdown(ctx context.Context) error {
 		s.logger.Debug("Stopped pinger", zap.String("endpoint", endpoint))
 	}
 	s.pingers = nil
+	for _, pinger := range s.variants {
+		pinger.Stop()
+	}
+	s.variants = nil
+	s.lifecycle.removeAll()
 
+	if s.audit != nil {
+		return s.audit.close()
+	}
 	return nil
 }
 
-// scrape performs ping checks for all targets
-func (s *pingScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-	errChan := make(chan error, len(s.cfg.Targets))
+// scrapeTarget returns the metrics of the i-th target. The controller runs the
+// target scrapers one after another, so the first scraper of a collection
+// cycle probes every target concurrently and the others collect their result.
+func (s *pingScraper) scrapeTarget(ctx context.Context, i int) (pmetric.Metrics, error) {
+	r := s.joinRound(ctx, i)
+	select {
+	case res := <-r.results[i]:
+		return res.metrics, res.err
+	case <-ctx.Done():
+		res := s.missedResult(ctx, i, ctx.Err())
+		return res.metrics, res.err
+	}
+}
 
-	wg.Add(len(s.cfg.Targets))
-	for _, target := range s.cfg.Targets {
-		go func(t Target) {
-			defer wg.Done()
+// joinRound returns the round the i-th target should take its result from,
+// starting a new one if the target already took its result from the current one
+func (s *pingScraper) joinRound(ctx context.Context, i int) *scrapeRound {
+	s.roundMu.Lock()
+	defer s.roundMu.Unlock()
 
-			if err := s.pingTarget(ctx, t, &mu); err != nil {
-				select {
-				case errChan <- fmt.Errorf("target %s: %w", t.Endpoint, err):
-				case <-ctx.Done():
-				}
-			}
-		}(target)
+	if s.round == nil || s.round.joined[i] {
+		s.round = s.startRound(ctx)
 	}
+	s.round.joined[i] = true
+	return s.round
+}
 
-	// Wait for all pings to complete
+// startRound probes all targets concurrently
+func (s *pingScraper) startRound(ctx context.Context) *scrapeRound {
+	r := &scrapeRound{
+		results: make([]chan targetResult, len(s.cfg.Targets)),
+		joined:  make([]bool, len(s.cfg.Targets)),
+		shed:    s.shedLevel(),
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		r.deadline = deadline
+		r.timeout = time.Until(deadline)
+	}
+	for i := range s.cfg.Targets {
+		// Buffered so a result nobody waits for anymore does not leak the goroutine
+		r.results[i] = make(chan targetResult, 1)
+	}
+	tiers := [][]int{s.probeOrder}
+	if !s.booted {
+		// The first round after start probes critical targets first
+		s.booted = true
+		tiers = s.priorityTiers()
+	}
+	// The probes of other targets must not be cut short when the scrape that
+	// started the round is done, so they run on deadlines of their own
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(s.bgCtx, cancel)
+	s.roundWG.Add(1)
 	go func() {
-		wg.Wait()
-		close(errChan)
+		defer s.roundWG.Done()
+		defer stop()
+		defer cancel()
+		for _, tier := range tiers {
+			s.probeTier(ctx, r, tier)
+		}
 	}()
+	return r
+}
 
-	// Collect errors
-	var errs error
-	for err := range errChan {
-		errs = multierr.Append(errs, err)
-		s.logger.Warn("Ping failed", zap.Error(err))
+// probeTier probes the targets of r listed in tier and waits for their probes
+func (s *pingScraper) probeTier(ctx context.Context, r *scrapeRound, tier []int) {
+	if s.cfg.MaxConcurrentProbes > 0 {
+		s.dispatch(ctx, r, tier)
+		return
 	}
+	var wg sync.WaitGroup
+	wg.Add(len(tier))
+	for _, i := range tier {
+		go func() {
+			defer wg.Done()
+			s.runProbe(ctx, r, i)
+		}()
+	}
+	wg.Wait()
+}
 
-	return s.mb.Emit(), errs
+// priorityTiers returns the targets grouped by descending priority, each
+// group by descending weight
+func (s *pingScraper) priorityTiers() [][]int {
+	order := slices.Clone(s.probeOrder)
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(s.cfg.Targets[b].Priority, s.cfg.Targets[a].Priority)
+	})
+	var tiers [][]int
+	for len(order) > 0 {
+		priority := s.cfg.Targets[order[0]].Priority
+		n := 1
+		for n < len(order) && s.cfg.Targets[order[n]].Priority == priority {
+			n++
+		}
+		tiers = append(tiers, order[:n])
+		order = order[n:]
+	}
+	return tiers
 }
 
-func (s *pingScraper) pingTarget(ctx context.Context, target Target, mu *sync.Mutex) error {
-	s.mu.RLock()
-	pinger, ok := s.pingers[target.Endpoint]
-	s.mu.RUnlock()
+// dispatch probes the targets of r in order, by descending weight, at most
+// max_concurrent_probes at a time. Targets not started before the scrape that
+// started r is due are not probed, so a tight budget is spent on the
+// weightiest targets.
+func (s *pingScraper) dispatch(ctx context.Context, r *scrapeRound, order []int) {
+	queue, cancel := r.queueContext(ctx)
+	defer cancel()
+	sem := make(chan struct{}, s.cfg.MaxConcurrentProbes)
+	for _, i := range order {
+		if queue.Err() == nil {
+			select {
+			case sem <- struct{}{}:
+				go func() {
+					defer func() { <-sem }()
+					s.runProbe(ctx, r, i)
+				}()
+				continue
+			case <-queue.Done():
+			}
+		}
+		r.results[i] <- s.missedResult(queue, i, fmt.Errorf("not probed: %w", queue.Err()))
+	}
+	// Wait for the probes still running
+	for range cap(sem) {
+		sem <- struct{}{}
+	}
+}
 
-	if !ok {
-		return fmt.Errorf("pinger not found for target: %s", target.Endpoint)
+// runProbe probes the i-th target and hands its result to r
+func (s *pingScraper) runProbe(ctx context.Context, r *scrapeRound, i int) {
+	ctx, cancel := r.probeContext(ctx)
+	defer cancel()
+	md, err := s.probeTarget(ctx, i)
+	if r.shed.sheds(s.cfg.Targets[i]) {
+		shedDetails(md)
+	}
+	r.results[i] <- targetResult{metrics: md, err: err}
+}
+
+// probeTarget pings the i-th target and emits its metrics. Failures are reported
+// as partial scrape errors so the error metrics recorded for them are kept.
+func (s *pingScraper) probeTarget(ctx context.Context, i int) (pmetric.Metrics, error) {
+	if !s.probes(i) {
+		return pmetric.NewMetrics(), nil
 	}
+	target := s.cfg.Targets[i]
+	b := s.builders[i]
+	b.mu.Lock()
+	defer b.mu.Unlock()
 
-	// Run ping with native context support (pro-bing v0.7.0+)
-	err := pinger.RunWithContext(ctx)
+	o, err := s.pingTarget(ctx, i, b.mb, s.metrics[i])
+	md := b.mb.Emit(metadata.WithResource(s.resources[i]))
+	s.appendDurationHistogram(md, i, o)
+	putLabels(md, s.labels(i))
+	putPacketSize(md, target.PacketSize)
+	putLabels(md, s.holidayLabels(target))
+	if s.cfg.CounterTemporality == temporalityDelta {
+		setDeltaTemporality(md, b.lastEmit)
+		b.lastEmit = pcommon.NewTimestampFromTime(s.clock.Now())
+	}
+	if s.skipHealthy(b, target, o) || s.skipUnchanged(b, o) {
+		return pmetric.NewMetrics(), nil
+	}
 	if err != nil {
-		// Record error metrics if enabled
-		if s.cfg.Metrics.PingErrors.Enabled {
-			now := pcommon.NewTimestampFromTime(time.Now())
-			mu.Lock()
-			s.mb.RecordPingErrorsDataPoint(
-				now,
-				1,
-				target.Endpoint,
-				"", // IP will be empty on error
-				categorizeError(err),
-			)
-			mu.Unlock()
+		err = fmt.Errorf("target %s: %w", target.Endpoint, err)
+		s.logger.Warn("Ping failed", zap.Error(err))
+		return md, scrapererror.NewPartialScrapeError(err, resultMetricCount(s.metrics[i]))
+	}
+	return md, nil
+}
+
+// resultMetricCount returns the number of enabled metrics describing a successful ping
+func resultMetricCount(metrics metadata.MetricsConfig) int {
+	count := 0
+	for _, enabled := range []bool{
+		metrics.PingDurationMin.Enabled,
+		metrics.PingDurationMax.Enabled,
+		metrics.PingDurationAvg.Enabled,
+		metrics.PingDurationMedian.Enabled,
+		metrics.PingDurationStddev.Enabled,
+		metrics.PingPacketLoss.Enabled,
+		metrics.PingPacketLossPercent.Enabled,
+		metrics.PingPacketsSent.Enabled,
+		metrics.PingPacketsReceived.Enabled,
+	} {
+		if enabled {
+			count++
 		}
-		return fmt.Errorf("ping failed: %w", err)
 	}
+	return count
+}
 
-	stats := pinger.Statistics()
-	now := pcommon.NewTimestampFromTime(time.Now())
+// skipHealthy reports whether the metrics of outcome o are dropped because
+// only every healthy_emit_every-th healthy scrape of a target is emitted. A
+// scrape breaking the target's SLA is not healthy, even without loss. The
+// first healthy scrape after an unhealthy one is always emitted.
+func (s *pingScraper) skipHealthy(b *targetBuilder, target Target, o *probeOutcome) bool {
+	if s.cfg.HealthyEmitEvery <= 1 {
+		return false
+	}
+	healthy := o.healthy()
+	if healthy && target.SLA != nil {
+		_, healthy = target.SLA.withinSLA(o, s.clock.Now())
+	}
+	if !healthy {
+		b.emittedHealthy = false
+		b.skippedHealthy = 0
+		return false
+	}
+	if b.emittedHealthy && b.skippedHealthy < s.cfg.HealthyEmitEvery-1 {
+		b.skippedHealthy++
+		return true
+	}
+	b.emittedHealthy = true
+	b.skippedHealthy = 0
+	return false
+}
 
-	// Record metrics with lock
-	mu.Lock()
-	defer mu.Unlock()
+// pingTarget records the metrics of target's latest probe outcome into mb
+func (s *pingScraper) pingTarget(ctx context.Context, i int, mb *metadata.MetricsBuilder, metrics metadata.MetricsConfig) (*probeOutcome, error) {
+	target := s.cfg.Targets[i]
+	o := s.outcome(ctx, pipeline.SignalMetrics, i)
+	if o.suppressed {
+		return o, nil
+	}
+	if o.errorType != 0 {
+		s.recordError(ctx, mb, metrics, target, o)
+	}
+	s.recordSweep(mb, metrics, target, o.variants)
+	s.recordDSCP(mb, metrics, target, o.variants)
+	s.recordTunnel(mb, metrics, target, o)
+	s.recordManagementPlane(mb, metrics, target, o)
+	s.recordSLA(mb, metrics, target, o)
+	s.recordState(mb, metrics, target, o)
+	s.recordPanics(mb, metrics, i)
+	s.recordBusinessHours(mb, metrics, target, o)
+	s.recordDNS(mb, metrics, target, o)
+	s.recordDualStack(mb, metrics, target, o.variants)
+	s.recordAddresses(mb, metrics, target, o.variants)
+	s.recordPathMTU(mb, metrics, target, o)
+	s.recordTraceroute(mb, metrics, target, o)
+	s.recordScheduleDrift(mb, metrics, target, o)
+	if o.err != nil {
+		return o, o.err
+	}
 
-	// Note: stats.Rtts will be empty since RecordRtts=false
-	// Recording only aggregate metrics which are always available
+	s.recordStats(mb, metrics, target, o.stats, o.finished)
+	s.recordHeatmap(mb, metrics, target, o)
+	return o, nil
+}
 
-	// Record individual RTT metrics if available (for future when RecordRtts might be enabled)
-	if s.cfg.Metrics.PingDuration.Enabled && len(stats.Rtts) > 0 {
+// recordStats records the round-trip times and packet counts of a probe of
+// target into mb
+func (s *pingScraper) recordStats(mb *metadata.MetricsBuilder, metrics metadata.MetricsConfig, target Target, stats *prober.Statistics, finished time.Time) {
+	now := pcommon.NewTimestampFromTime(finished)
+	ip := s.ips.String(stats.IPAddr)
+	version := ipVersion(stats.IPAddr)
+
+	// Samples are only kept for targets reporting a metric computed from them,
+	// see recordRtts, and are then reported one data point per packet as well
+	if metrics.PingDuration.Enabled && len(stats.Rtts) > 0 {
 		for _, rtt := range stats.Rtts {
-			s.mb.RecordPingDurationDataPoint(
+			mb.RecordPingDurationDataPoint(
 				now,
-				float64(rtt.Milliseconds()),
+				milliseconds(rtt),
 				target.Endpoint,
-				stats.IPAddr.String(),
+				ip,
+				version,
 			)
 		}
 	}
 
 	// Record aggregate metrics
-	if stats.MinRtt > 0 && s.cfg.Metrics.PingDurationMin.Enabled {
-		s.mb.RecordPingDurationMinDataPoint(
+	if stats.MinRtt > 0 && metrics.PingDurationMin.Enabled {
+		mb.RecordPingDurationMinDataPoint(
+			now,
+			milliseconds(stats.MinRtt),
+			target.Endpoint,
+			ip,
+			version,
+		)
+	}
+
+	if stats.MaxRtt > 0 && metrics.PingDurationMax.Enabled {
+		mb.RecordPingDurationMaxDataPoint(
+			now,
+			milliseconds(stats.MaxRtt),
+			target.Endpoint,
+			ip,
+			version,
+		)
+	}
+
+	if stats.AvgRtt > 0 && metrics.PingDurationAvg.Enabled {
+		mb.RecordPingDurationAvgDataPoint(
 			now,
-			float64(stats.MinRtt.Milliseconds()),
+			milliseconds(stats.AvgRtt),
 			target.Endpoint,
-			stats.IPAddr.String(),
+			ip,
+			version,
 		)
 	}
 
-	if stats.MaxRtt > 0 && s.cfg.Metrics.PingDurationMax.Enabled {
-		s.mb.RecordPingDurationMaxDataPoint(
+	if stats.MedianRtt > 0 && metrics.PingDurationMedian.Enabled {
+		mb.RecordPingDurationMedianDataPoint(
 			now,
-			float64(stats.MaxRtt.Milliseconds()),
+			milliseconds(stats.MedianRtt),
 			target.Endpoint,
-			stats.IPAddr.String(),
+			ip,
+			version,
 		)
 	}
 
-	if stats.AvgRtt > 0 && s.cfg.Metrics.PingDurationAvg.Enabled {
-		s.mb.RecordPingDurationAvgDataPoint(
+	if stats.StdDevRtt > 0 && metrics.PingDurationStddev.Enabled {
+		mb.RecordPingDurationStddevDataPoint(
 			now,
-			float64(stats.AvgRtt.Milliseconds()),
+			milliseconds(stats.StdDevRtt),
 			target.Endpoint,
-			stats.IPAddr.String(),
+			ip,
+			version,
 		)
 	}
 
-	if stats.StdDevRtt > 0 && s.cfg.Metrics.PingDurationStddev.Enabled {
-		s.mb.RecordPingDurationStddevDataPoint(
+	if len(stats.Rtts) > 0 && metrics.PingDurationTrimmedMean.Enabled {
+		mb.RecordPingDurationTrimmedMeanDataPoint(
 			now,
-			float64(stats.StdDevRtt.Milliseconds()),
+			milliseconds(trimmedMean(stats.Rtts, s.cfg.TrimmedMeanPercent)),
 			target.Endpoint,
-			stats.IPAddr.String(),
+			ip,
+			version,
+		)
+	}
+
+	if len(stats.Rtts) > 1 && metrics.PingJitter.Enabled {
+		mb.RecordPingJitterDataPoint(
+			now,
+			milliseconds(jitter(stats.Rtts)),
+			target.Endpoint,
+			ip,
+			version,
 		)
 	}
 
 	// Record packet loss as ratio (0.0 to 1.0)
-	if s.cfg.Metrics.PingPacketLoss.Enabled {
-		s.mb.RecordPingPacketLossDataPoint(
+	if metrics.PingPacketLoss.Enabled {
+		mb.RecordPingPacketLossDataPoint(
 			now,
 			stats.PacketLoss/100.0,
 			target.Endpoint,
-			stats.IPAddr.String(),
+			ip,
+			version,
+		)
+	}
+
+	// Record packet loss as percentage (0 to 100) for thresholds written against it
+	if metrics.PingPacketLossPercent.Enabled {
+		mb.RecordPingPacketLossPercentDataPoint(
+			now,
+			stats.PacketLoss,
+			target.Endpoint,
+			ip,
+			version,
 		)
 	}
 
 	// Record packet counts
-	if s.cfg.Metrics.PingPacketsSent.Enabled {
-		s.mb.RecordPingPacketsSentDataPoint(
+	if metrics.PingPacketsSent.Enabled {
+		mb.RecordPingPacketsSentDataPoint(
 			now,
 			int64(stats.PacketsSent),
 			target.Endpoint,
-			stats.IPAddr.String(),
+			ip,
+			version,
 		)
 	}
 
-	if s.cfg.Metrics.PingPacketsReceived.Enabled {
-		s.mb.RecordPingPacketsReceivedDataPoint(
+	// Replies answered by another address are counted apart, they do not
+	// prove the target is alive
+	if metrics.PingPacketsReceived.Enabled {
+		mb.RecordPingPacketsReceivedDataPoint(
 			now,
-			int64(stats.PacketsRecv),
+			int64(stats.PacketsRecv-stats.Mismatches),
 			target.Endpoint,
-			stats.IPAddr.String(),
+			ip,
+			version,
+			false,
 		)
+		if stats.Mismatches > 0 {
+			mb.RecordPingPacketsReceivedDataPoint(
+				now,
+				int64(stats.Mismatches),
+				target.Endpoint,
+				ip,
+				version,
+				true,
+			)
+		}
 	}
 
-	return nil
+	// The TTL of replies drops by one for every hop on the return path
+	if stats.ReplyTTL > 0 && metrics.PingReplyTTL.Enabled {
+		mb.RecordPingReplyTTLDataPoint(
+			now,
+			int64(stats.ReplyTTL),
+			target.Endpoint,
+			ip,
+			version,
+		)
+	}
+}
+
+// probe pings target once. Its outcome is shared by the receivers of every
+// signal, so it also carries the state updates of the probe.
+func (s *pingScraper) probe(ctx context.Context, target Target) *probeOutcome {
+	o := &probeOutcome{}
+	if e, ok := s.holidayEvent(target); ok && s.cfg.Holidays.Action == interfaceActionSuppress {
+		s.logger.Debug("Suppressed probe during holiday",
+			zap.String("endpoint", target.Endpoint),
+			zap.String("event", e.Summary))
+		s.skipDowntime(target.Endpoint)
+		o.suppressed = true
+		return o
+	}
+	s.checkIncident(target)
+	s.wakeIfAsleep(target)
+	if s.sources != nil {
+		o.sourceChange = s.checkSource(target)
+	}
+	if s.lookupEnabled(target.Endpoint) {
+		o.lookupDuration, o.resolved = s.resolve(ctx, target)
+	}
+	if target.Traceroute != nil {
+		o.trace = s.checkTrace(target)
+	}
+	pinger, err := s.pingerFor(target)
+	if err != nil {
+		o.err = fmt.Errorf("pinger not found for target: %s: %w", target.Endpoint, err)
+		return o
+	}
+
+	started := s.clock.Now()
+	o.started, o.scheduled = started, s.scheduledAt(started)
+	err = target.FaultInjection.injectedErr()
+	if err == nil {
+		o.stats, o.burstStarted, err = s.runBurst(ctx, target, pinger)
+		s.checkPinger(target.Endpoint, err)
+		s.auditProbe(target, o, err)
+		o.variants = s.probeVariants(ctx, target, targetVariants(target))
+	}
+	// The other family is probed whatever the outcome of the first
+	if target.DualStack {
+		o.variants = append(o.variants, s.probeVariants(ctx, target, []variantKey{{network: prober.NetworkIPv6}})...)
+	}
+	if target.ResolveAll {
+		o.variants = append(o.variants, s.probeVariants(ctx, target, s.addressVariants(ctx, target, pinger))...)
+	}
+	if err == nil && target.PathMTUDiscovery && o.stats.PacketsRecv > 0 {
+		o.pathMTU = s.discoverPathMTU(ctx, target, o.stats.IPAddr)
+	}
+	if err == nil {
+		target.FaultInjection.apply(o.stats)
+	}
+	o.finished = s.clock.Now()
+
+	if s.links != nil && (err != nil || o.stats.PacketsRecv == 0) {
+		if iface, down := s.links.egressDown(target.Endpoint, started); down {
+			if s.cfg.InterfaceCheck.Action == interfaceActionSuppress {
+				s.logger.Debug("Suppressed failure while egress interface is down",
+					zap.String("endpoint", target.Endpoint),
+					zap.String("interface", iface),
+					zap.Error(err))
+				o.suppressed = true
+				return o
+			}
+			if err == nil {
+				// Loss is not an error, tag it with an error data point of its own
+				o.errorType = metadata.AttributeErrorTypeInterfaceDown
+			} else {
+				err = &interfaceDownError{iface: iface, err: err}
+			}
+		}
+	}
+
+	if s.incident != nil && s.incident.active() && (err != nil || o.stats.PacketsRecv < o.stats.PacketsSent) {
+		s.logIncidentProbe(target, o.stats, err)
+	}
+
+	if err != nil {
+		o.errorType = categorizeError(err)
+		o.err = fmt.Errorf("ping failed: %w", err)
+		o.managementOK = s.checkManagementPlane(ctx, target)
+		o.passivelySeen = s.passivelySeen(ctx, target, nil, started)
+		o.failures, o.transitioned = s.updateState(target.Endpoint, true)
+		o.downtime, o.lastDowntime = s.downtimeOf(target.Endpoint)
+		return o
+	}
+
+	if o.stats.PacketsRecv == 0 {
+		o.managementOK = s.checkManagementPlane(ctx, target)
+		o.passivelySeen = s.passivelySeen(ctx, target, o.stats, started)
+	}
+	o.failures, o.transitioned = s.updateState(target.Endpoint, o.stats.PacketsRecv == 0)
+	o.downtime, o.lastDowntime = s.downtimeOf(target.Endpoint)
+	return o
+}
+
+// auditProbe records a probe of target in the audit stream if enabled
+func (s *pingScraper) auditProbe(target Target, o *probeOutcome, err error) {
+	if s.audit == nil {
+		return
+	}
+	r := auditRecord{Time: cmp.Or(o.burstStarted, o.started), Scheduled: o.scheduled, Endpoint: target.Endpoint}
+	if o.stats != nil {
+		r.IP = s.ips.String(o.stats.IPAddr)
+		r.PacketsSent = o.stats.PacketsSent
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	s.audit.record(r)
+}
+
+// recordError records a failed ping of target if error metrics are enabled
+func (s *pingScraper) recordError(ctx context.Context, mb *metadata.MetricsBuilder, metrics metadata.MetricsConfig, target Target, o *probeOutcome) {
+	if !metrics.PingErrors.Enabled {
+		return
+	}
+
+	// Whether the local network is fine, or the target was seen, is only
+	// known with the respective check
+	var options []metadata.MetricAttributeOption
+	if s.local != nil {
+		options = append(options, metadata.WithLocalNetworkOkMetricAttribute(s.local.networkOK(ctx)))
+	}
+	if s.flows != nil {
+		options = append(options, metadata.WithPassivelySeenMetricAttribute(o.passivelySeen))
+	}
+	// Which sockets need which privileges differs between platforms
+	if o.errorType == metadata.AttributeErrorTypePermissionDenied {
+		options = append(options, metadata.WithOsTypeMetricAttribute(runtime.GOOS))
+	}
+
+	mb.RecordPingErrorsDataPoint(
+		pcommon.NewTimestampFromTime(s.clock.Now()),
+		1,
+		target.Endpoint,
+		"", // IP will be empty on error
+		0,
+		o.errorType,
+		options...,
+	)
+}
+
+// updateState records whether a target was down in this scrape and kicks off
+// diagnostics once the configured number of consecutive failures is reached.
+// It returns the number of consecutive failures and whether the target went
+// up or down since the last scrape.
+func (s *pingScraper) updateState(endpoint string, down bool) (int, bool) {
+	s.mu.Lock()
+	state := s.stateLocked(endpoint)
+	transitioned := state.observed && state.down != down
+	// The interval before a scrape that finds the target down counts as
+	// downtime, the outage having begun at the earliest after the scrape
+	// before it
+	now := s.clock.Now()
+	state.lastDowntime = downtime{}
+	if state.observed && down {
+		state.lastDowntime = downtimeBetween(s.cfg.endpointCalendar(endpoint), state.observedAt, now)
+		state.downtime.total += state.lastDowntime.total
+		state.downtime.inHours += state.lastDowntime.inHours
+	}
+	state.observed, state.down, state.observedAt = true, down, now
+	if !down {
+		state.consecutiveFailures = 0
+		s.observeFleetLocked()
+		s.mu.Unlock()
+		return 0, transitioned
+	}
+	state.consecutiveFailures++
+	failures := state.consecutiveFailures
+	s.observeFleetLocked()
+	s.mu.Unlock()
+
+	// Run once per outage rather than on every failed scrape
+	if s.cfg.Diagnostics.Enabled && failures == s.cfg.Diagnostics.FailureThreshold {
+		s.bgWG.Add(1)
+		go func() {
+			defer s.bgWG.Done()
+			bundle := runDiagnostics(s.bgCtx, endpoint, s.cfg.Diagnostics, s.diagnosticTracer(endpoint))
+			logDiagnostics(s.logger, endpoint, failures, bundle)
+		}()
+	}
+	return failures, transitioned
+}
+
+// checkPinger counts consecutive DNS and socket errors of a target's pinger and
+// drops the pinger once they reach the recreate threshold, so the next scrape
+// creates a new one with fresh resolution and socket
+func (s *pingScraper) checkPinger(endpoint string, err error) {
+	if s.cfg.RecreateThreshold == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.stateLocked(endpoint)
+	if !isPingerError(err) {
+		state.pingerFailures = 0
+		return
+	}
+	state.pingerFailures++
+	if state.pingerFailures < s.cfg.RecreateThreshold {
+		return
+	}
+
+	s.logger.Warn("Recreating pinger after repeated failures",
+		zap.String("endpoint", endpoint),
+		zap.Int("failures", state.pingerFailures),
+		zap.Error(err))
+	state.pingerFailures = 0
+	if pinger, ok := s.pingers[endpoint]; ok {
+		pinger.Stop()
+		delete(s.pingers, endpoint)
+	}
+}
+
+// dropPingersLocked stops the pingers of endpoint, including those of its
+// variants, so the next probe creates them anew. s.mu must be held.
+func (s *pingScraper) dropPingersLocked(endpoint string) {
+	if pinger, ok := s.pingers[endpoint]; ok {
+		pinger.Stop()
+		delete(s.pingers, endpoint)
+	}
+	for key, pinger := range s.variants {
+		if key.endpoint == endpoint {
+			pinger.Stop()
+			delete(s.variants, key)
+		}
+	}
+}
+
+// stateLocked returns the state of endpoint, s.mu must be held
+func (s *pingScraper) stateLocked(endpoint string) *targetState {
+	state, ok := s.states[endpoint]
+	if !ok {
+		state = &targetState{}
+		s.states[endpoint] = state
+	}
+	return state
+}
+
+// isPingerError reports whether err hints at a pinger stuck with a stale
+// address or a broken socket rather than an unresponsive target
+func isPingerError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+	switch categorizeError(err) {
+	case metadata.AttributeErrorTypeDNSFailure, metadata.AttributeErrorTypeNetworkUnreachable:
+		return true
+	default:
+		return false
+	}
 }
 
 // categorizeError categorizes errors for metrics
@@ -278,9 +1314,21 @@ func categorizeError(err error) metadata.AttributeErrorType {
 		return metadata.AttributeErrorTypeUnknown
 	}
 
+	var injected *injectedError
+	if errors.As(err, &injected) {
+		return injected.errorType
+	}
+
+	var ifaceDown *interfaceDownError
+	if errors.As(err, &ifaceDown) {
+		return metadata.AttributeErrorTypeInterfaceDown
+	}
+
 	errMsg := strings.ToLower(err.Error())
 
 	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return metadata.AttributeErrorTypeDeadlineExceeded
 	case strings.Contains(errMsg, "timeout"):
 		return metadata.AttributeErrorTypeTimeout
 	case strings.Contains(errMsg, "no such host"):
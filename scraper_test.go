@@ -5,18 +5,29 @@ package pingcheckreceiver
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"runtime"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
 	"go.opentelemetry.io/collector/scraper/scraperhelper"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
 )
 
 func TestScraperStart(t *testing.T) {
@@ -25,15 +36,15 @@ func TestScraperStart(t *testing.T) {
 		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
 		Targets: []Target{
 			{
-				Endpoint: "localhost",
-				Count:    1,
-				Timeout:  time.Second,
+				Endpoint:   "localhost",
+				Count:      1,
+				RunTimeout: time.Second,
 			},
 		},
 		Privileged: false,
 	}
 
-	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type))
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions())
 	err := scraper.start(context.Background(), componenttest.NewNopHost())
 
 	// May fail if localhost cannot be resolved, but should not panic
@@ -49,7 +60,7 @@ func TestScraperShutdown(t *testing.T) {
 		Targets:              []Target{},
 	}
 
-	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type))
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions())
 	err := scraper.shutdown(context.Background())
 	assert.NoError(t, err)
 }
@@ -90,6 +101,11 @@ func TestCategorizeError(t *testing.T) {
 			err:      fmt.Errorf("operation not permitted"),
 			expected: metadata.AttributeErrorTypePermissionDenied,
 		},
+		{
+			name:     "deadline exceeded",
+			err:      fmt.Errorf("ping failed: %w", context.DeadlineExceeded),
+			expected: metadata.AttributeErrorTypeDeadlineExceeded,
+		},
 		{
 			name:     "unknown error",
 			err:      fmt.Errorf("something went wrong"),
@@ -105,6 +121,35 @@ func TestCategorizeError(t *testing.T) {
 	}
 }
 
+func TestScraperPermissionErrorsCarryPlatform(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics.PingErrors.Enabled = true
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4}, {Endpoint: "192.0.2.2", Count: 4}}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetRunError("192.0.2.1", errors.New("socket: operation not permitted"))
+	fakeProber.SetRunError("192.0.2.2", errors.New("i/o timeout"))
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	// Only permission errors depend on the platform
+	for i, want := range []string{runtime.GOOS, ""} {
+		md, err := scraper.scrapeTarget(context.Background(), i)
+		require.Error(t, err)
+		found := false
+		forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+			if m.Name() == "ping.errors" {
+				found = true
+				osType, ok := m.Sum().DataPoints().At(0).Attributes().Get("os.type")
+				assert.Equal(t, want != "", ok)
+				assert.Equal(t, want, osType.Str())
+			}
+		})
+		assert.True(t, found)
+	}
+}
+
 func TestScraperStartWithDefaults(t *testing.T) {
 	cfg := &Config{
 		ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
@@ -112,31 +157,95 @@ func TestScraperStartWithDefaults(t *testing.T) {
 		Targets: []Target{
 			{
 				Endpoint: "127.0.0.1",
-				// Leave Count, Timeout, Interval unset to test defaults
+				Count:    defaultCount,
+				// Leave Timeout, Interval unset to test defaults
 			},
 		},
 		Privileged: false,
 	}
 
-	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type))
-	err := scraper.start(context.Background(), componenttest.NewNopHost())
+	fakeProber := pingchecktest.NewProber()
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
 
-	// Should succeed with localhost IP
-	if err != nil {
-		// If error, check it's the expected one
-		assert.Contains(t, err.Error(), "no valid pingers")
-	} else {
-		// Verify defaults were applied
-		scraper.mu.RLock()
-		pinger, ok := scraper.pingers["127.0.0.1"]
-		scraper.mu.RUnlock()
+	// Verify defaults were applied
+	pingerCfg, ok := fakeProber.PingerConfig("127.0.0.1")
+	require.True(t, ok)
+	assert.Equal(t, defaultCount, pingerCfg.Count)
+	assert.Equal(t, 5*time.Second, pingerCfg.Timeout)
+	assert.Equal(t, time.Second, pingerCfg.Interval)
+	assert.False(t, pingerCfg.Prewarm)
+	assert.False(t, pingerCfg.RecordRtts)
+}
 
-		if ok {
-			assert.Equal(t, 4, pinger.Count)
-			assert.Equal(t, 5*time.Second, pinger.Timeout)
-			assert.Equal(t, time.Second, pinger.Interval)
-		}
+func TestScraperStartWithColdStartOptions(t *testing.T) {
+	cfg := &Config{
+		ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Targets:              []Target{{Endpoint: "192.168.1.1", Prewarm: true, DiscardFirst: true}},
+	}
+
+	fakeProber := pingchecktest.NewProber()
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	pingerCfg, ok := fakeProber.PingerConfig("192.168.1.1")
+	require.True(t, ok)
+	assert.True(t, pingerCfg.Prewarm)
+	assert.True(t, pingerCfg.DiscardFirst)
+}
+
+func TestScraperStartWithStrictReplies(t *testing.T) {
+	cfg := &Config{
+		ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Targets:              []Target{{Endpoint: "192.168.1.1"}},
+		StrictReplies:        true,
 	}
+
+	fakeProber := pingchecktest.NewProber()
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	pingerCfg, ok := fakeProber.PingerConfig("192.168.1.1")
+	require.True(t, ok)
+	assert.True(t, pingerCfg.StrictReplies)
+}
+
+func TestScraperStartWithDuration(t *testing.T) {
+	cfg := &Config{
+		ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Targets:              []Target{{Endpoint: "192.168.1.1", Duration: 30 * time.Second}},
+	}
+
+	fakeProber := pingchecktest.NewProber()
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	// The run is continuous and bounded by the duration
+	pingerCfg, ok := fakeProber.PingerConfig("192.168.1.1")
+	require.True(t, ok)
+	assert.Equal(t, 0, pingerCfg.Count)
+	assert.Equal(t, 30*time.Second, pingerCfg.Timeout)
+	assert.Equal(t, time.Second, pingerCfg.Interval)
+}
+
+func TestScraperStartWithDeprecatedTimeout(t *testing.T) {
+	cfg := &Config{
+		ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Targets:              []Target{{Endpoint: "192.168.1.1", Count: 2, Timeout: 2 * time.Second, PacketTimeout: 500 * time.Millisecond}},
+	}
+
+	fakeProber := pingchecktest.NewProber()
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	pingerCfg, ok := fakeProber.PingerConfig("192.168.1.1")
+	require.True(t, ok)
+	assert.Equal(t, 2*time.Second, pingerCfg.Timeout)
+	assert.Equal(t, 500*time.Millisecond, pingerCfg.PacketTimeout)
 }
 
 func TestScraperMultipleTargets(t *testing.T) {
@@ -145,20 +254,20 @@ func TestScraperMultipleTargets(t *testing.T) {
 		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
 		Targets: []Target{
 			{
-				Endpoint: "127.0.0.1",
-				Count:    1,
-				Timeout:  time.Second,
+				Endpoint:   "127.0.0.1",
+				Count:      1,
+				RunTimeout: time.Second,
 			},
 			{
-				Endpoint: "localhost",
-				Count:    2,
-				Timeout:  2 * time.Second,
+				Endpoint:   "localhost",
+				Count:      2,
+				RunTimeout: 2 * time.Second,
 			},
 		},
 		Privileged: false,
 	}
 
-	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type))
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions())
 	ctx := context.Background()
 
 	err := scraper.start(ctx, componenttest.NewNopHost())
@@ -182,38 +291,801 @@ func TestPingTargetMissingPinger(t *testing.T) {
 	cfg := &Config{
 		ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
 		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
-		Targets:              []Target{},
+		Targets: []Target{{
+			Endpoint:   "nonexistent.endpoint",
+			Count:      1,
+			RunTimeout: time.Second,
+		}, {
+			Endpoint: "192.0.2.1",
+		}},
 	}
 
-	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type))
-	scraper.mb = metadata.NewMetricsBuilder(cfg.MetricsBuilderConfig, scraper.settings)
-
-	target := Target{
-		Endpoint: "nonexistent.endpoint",
-		Count:    1,
-		Timeout:  time.Second,
-	}
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetCreateError("nonexistent.endpoint", errors.New("no such host"))
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	// The pinger of the first target cannot be created at start
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+	mb := metadata.NewMetricsBuilder(cfg.MetricsBuilderConfig, scraper.settings)
 
-	var mu sync.Mutex
-	err := scraper.pingTarget(context.Background(), target, &mu)
+	_, err := scraper.pingTarget(context.Background(), 0, mb, cfg.Metrics)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "pinger not found")
 }
 
-func TestScraperScrapeWithNoTargets(t *testing.T) {
+func TestScraperScrapeTargetRounds(t *testing.T) {
 	cfg := &Config{
 		ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
 		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
-		Targets:              []Target{},
+		Targets: []Target{
+			{Endpoint: "192.0.2.1"},
+			{Endpoint: "192.0.2.2"},
+		},
 	}
 
-	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type))
-	scraper.mb = metadata.NewMetricsBuilder(cfg.MetricsBuilderConfig, scraper.settings)
+	fakeProber := pingchecktest.NewProber()
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	// Starting once per target scraper only creates the pingers once
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
 
-	metrics, err := scraper.scrape(context.Background())
+	// The first target scraper of a cycle probes every target
+	_, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	_, err = scraper.scrapeTarget(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, fakeProber.Runs("192.0.2.1"))
+	assert.Equal(t, 1, fakeProber.Runs("192.0.2.2"))
 
-	// Should return empty metrics without error
-	assert.NoError(t, err)
-	assert.NotNil(t, metrics)
+	// Scraping a target again starts the next cycle
+	_, err = scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	_, err = scraper.scrapeTarget(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, fakeProber.Runs("192.0.2.1"))
+	assert.Equal(t, 2, fakeProber.Runs("192.0.2.2"))
+}
+
+// orderProber records the order in which endpoints are probed
+type orderProber struct {
+	mu    sync.Mutex
+	order []string
+}
+
+func (p *orderProber) NewPinger(cfg prober.PingerConfig) (prober.Pinger, error) {
+	return &orderPinger{prober: p, endpoint: cfg.Endpoint}, nil
+}
+
+type orderPinger struct {
+	prober   *orderProber
+	endpoint string
+}
+
+func (p *orderPinger) Run(context.Context) (*prober.Statistics, error) {
+	p.prober.mu.Lock()
+	defer p.prober.mu.Unlock()
+	p.prober.order = append(p.prober.order, p.endpoint)
+	return &prober.Statistics{PacketsSent: 1, PacketsRecv: 1, IPAddr: &net.IPAddr{IP: net.ParseIP(p.endpoint)}}, nil
+}
+
+func (p *orderPinger) Stop() {}
+
+func TestScraperMaxConcurrentProbesByWeight(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{
+		{Endpoint: "192.0.2.1", Count: 1},
+		{Endpoint: "192.0.2.2", Count: 1, Weight: 10},
+		{Endpoint: "192.0.2.3", Count: 1},
+		{Endpoint: "192.0.2.4", Count: 1, Weight: 5},
+	}
+	cfg.MaxConcurrentProbes = 1
+
+	p := &orderProber{}
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(p)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	for i := range cfg.Targets {
+		_, err := scraper.scrapeTarget(context.Background(), i)
+		require.NoError(t, err)
+	}
+	// Equal weights keep the configured order
+	assert.Equal(t, []string{"192.0.2.2", "192.0.2.4", "192.0.2.1", "192.0.2.3"}, p.order)
+}
+
+func TestScraperMaxConcurrentProbesDeadline(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 1}, {Endpoint: "192.0.2.2", Count: 1}}
+	cfg.MaxConcurrentProbes = 1
+
+	p := &orderProber{}
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(p)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now())
+	defer cancel()
+	r := scraper.startRound(ctx)
+	for i := range cfg.Targets {
+		res := <-r.results[i]
+		assert.EqualError(t, res.err, fmt.Sprintf("target %s: not probed: context deadline exceeded", cfg.Targets[i].Endpoint))
+	}
+	assert.Empty(t, p.order)
+}
+
+func TestScraperPriorityFirstRound(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{
+		{Endpoint: "192.0.2.1", Count: 1},
+		{Endpoint: "192.0.2.2", Count: 1, Priority: 1},
+		{Endpoint: "192.0.2.3", Count: 1, Priority: 2},
+	}
+
+	p := &orderProber{}
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(p)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	scrapeAll := func() {
+		for i := range cfg.Targets {
+			_, err := scraper.scrapeTarget(context.Background(), i)
+			require.NoError(t, err)
+		}
+	}
+	// Each priority is probed once the higher ones finished
+	scrapeAll()
+	assert.Equal(t, []string{"192.0.2.3", "192.0.2.2", "192.0.2.1"}, p.order)
+
+	// Later rounds probe every target at once
+	scrapeAll()
+	assert.Len(t, p.order, 6)
+	assert.Equal(t, [][]int{{2}, {1}, {0}}, scraper.priorityTiers())
+}
+
+func TestScraperAllowEmptyTargets(t *testing.T) {
+	cfg := &Config{
+		ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Targets:              []Target{{Endpoint: "service.internal"}},
+	}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetCreateError("service.internal", errors.New("no such host"))
+
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	assert.ErrorContains(t, scraper.start(context.Background(), componenttest.NewNopHost()), "no valid pingers")
+
+	cfg.AllowEmptyTargets = true
+	scraper = newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	_, err := scraper.scrapeTarget(context.Background(), 0)
+	assert.ErrorContains(t, err, "pinger not found")
+
+	// The pinger is created once the target can be resolved
+	fakeProber.SetCreateError("service.internal", nil)
+	metrics, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Positive(t, metrics.DataPointCount())
+	assert.Equal(t, 1, fakeProber.Runs("service.internal"))
+	require.NoError(t, scraper.shutdown(context.Background()))
+}
+
+func TestScraperRecreatesPingerAfterRepeatedFailures(t *testing.T) {
+	cfg := &Config{
+		ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Targets:              []Target{{Endpoint: "192.0.2.1"}},
+		RecreateThreshold:    2,
+	}
+
+	fakeProber := pingchecktest.NewProber()
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	original := scraper.pingers["192.0.2.1"]
+
+	// Timeouts point at the target, not the pinger
+	fakeProber.SetRunError("192.0.2.1", errors.New("timeout"))
+	for i := 0; i < 3; i++ {
+		_, err := scraper.scrapeTarget(context.Background(), 0)
+		require.Error(t, err)
+	}
+	assert.Same(t, original, scraper.pingers["192.0.2.1"])
+
+	fakeProber.SetRunError("192.0.2.1", errors.New("sendto: network is unreachable"))
+	_, err := scraper.scrapeTarget(context.Background(), 0)
+	require.Error(t, err)
+	assert.Same(t, original, scraper.pingers["192.0.2.1"])
+	_, err = scraper.scrapeTarget(context.Background(), 0)
+	require.Error(t, err)
+	assert.NotContains(t, scraper.pingers, "192.0.2.1")
+
+	// The next scrape recreates the pinger
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 4, PacketsRecv: 4})
+	_, err = scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	require.Contains(t, scraper.pingers, "192.0.2.1")
+	assert.NotSame(t, original, scraper.pingers["192.0.2.1"])
+}
+
+func TestIsPingerError(t *testing.T) {
+	assert.False(t, isPingerError(nil))
+	assert.False(t, isPingerError(errors.New("timeout")))
+	assert.True(t, isPingerError(errors.New("lookup example.invalid: no such host")))
+	assert.True(t, isPingerError(errors.New("network is unreachable")))
+	assert.True(t, isPingerError(&net.OpError{Op: "write", Err: errors.New("bad file descriptor")}))
+}
+
+func TestScraperInterfaceCheck(t *testing.T) {
+	tests := []struct {
+		name          string
+		action        string
+		expectErr     bool
+		expectMetrics map[string]int
+	}{
+		{
+			name:      "tag",
+			action:    interfaceActionTag,
+			expectErr: true,
+			expectMetrics: map[string]int{
+				"ping.errors": 1,
+			},
+		},
+		{
+			name:          "suppress",
+			action:        interfaceActionSuppress,
+			expectErr:     false,
+			expectMetrics: map[string]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+				MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+				Targets:              []Target{{Endpoint: "192.0.2.1"}},
+			}
+			cfg.Metrics.PingErrors.Enabled = true
+			cfg.InterfaceCheck = InterfaceCheckConfig{Enabled: true, Action: tt.action}
+
+			clock := pingchecktest.NewClock(time.Unix(1_700_000_000, 0))
+			fakeProber := pingchecktest.NewProber()
+			fakeProber.SetRunError("192.0.2.1", errors.New("timeout"))
+			scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type),
+				newFactoryOptions(WithProber(fakeProber), WithClock(clock)))
+			require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				require.NoError(t, scraper.shutdown(context.Background()))
+			}()
+
+			links := newFakeLinks(clock)
+			links.setUp("eth0", false)
+			links.refresh()
+			scraper.links.stop()
+			scraper.links = links.linkMonitor
+
+			metrics, err := scraper.scrapeTarget(context.Background(), 0)
+			if tt.expectErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "egress interface eth0 down")
+			} else {
+				require.NoError(t, err)
+			}
+
+			found := make(map[string]int)
+			rms := metrics.ResourceMetrics()
+			for i := 0; i < rms.Len(); i++ {
+				ms := rms.At(i).ScopeMetrics().At(0).Metrics()
+				for j := 0; j < ms.Len(); j++ {
+					m := ms.At(j)
+					found[m.Name()] = m.Sum().DataPoints().Len()
+					errorType, _ := m.Sum().DataPoints().At(0).Attributes().Get("error.type")
+					assert.Equal(t, "interface_down", errorType.Str())
+				}
+			}
+			assert.Equal(t, tt.expectMetrics, found)
+			if tt.action == interfaceActionSuppress {
+				// Suppressed failures do not count towards diagnostics
+				assert.NotContains(t, scraper.states, "192.0.2.1")
+			}
+		})
+	}
+}
+
+func TestScraperInterfaceCheckTagsLoss(t *testing.T) {
+	cfg := &Config{
+		ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Targets:              []Target{{Endpoint: "192.0.2.1"}},
+	}
+	cfg.Metrics.PingErrors.Enabled = true
+	cfg.InterfaceCheck = InterfaceCheckConfig{Enabled: true, Action: interfaceActionTag}
+
+	clock := pingchecktest.NewClock(time.Unix(1_700_000_000, 0))
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 4, PacketLoss: 100})
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type),
+		newFactoryOptions(WithProber(fakeProber), WithClock(clock)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	links := newFakeLinks(clock)
+	links.refresh()
+	scraper.links.stop()
+	scraper.links = links.linkMonitor
+
+	// Loss while the interface is up is reported as is
+	metrics, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, 3, metrics.MetricCount())
+
+	links.setUp("eth0", false)
+	links.refresh()
+	metrics, err = scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, 4, metrics.MetricCount())
+	require.NoError(t, scraper.shutdown(context.Background()))
+}
+
+func TestScraperTrimmedMean(t *testing.T) {
+	cfg := &Config{
+		ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Targets:              []Target{{Endpoint: "192.0.2.1"}},
+		TrimmedMeanPercent:   25,
+	}
+	cfg.Metrics.PingDurationTrimmedMean.Enabled = true
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{
+		PacketsSent: 4,
+		PacketsRecv: 4,
+		AvgRtt:      40 * time.Millisecond,
+		Rtts:        []time.Duration{10 * time.Millisecond, 12 * time.Millisecond, 15 * time.Millisecond, 124 * time.Millisecond},
+	})
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	// Samples are only requested when a metric needs them
+	pingerCfg, _ := fakeProber.PingerConfig("192.0.2.1")
+	assert.True(t, pingerCfg.RecordRtts)
+
+	metrics, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	found := false
+	samples := 0
+	for i := 0; i < ms.Len(); i++ {
+		switch ms.At(i).Name() {
+		case "ping.duration.trimmed_mean":
+			found = true
+			assert.Equal(t, 13.5, ms.At(i).Gauge().DataPoints().At(0).DoubleValue())
+		case "ping.duration":
+			samples = ms.At(i).Gauge().DataPoints().Len()
+		}
+	}
+	assert.True(t, found)
+	// The kept samples are reported as well
+	assert.Equal(t, 4, samples)
+}
+
+func TestScraperJitter(t *testing.T) {
+	cfg := &Config{
+		ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Targets:              []Target{{Endpoint: "192.0.2.1"}},
+	}
+	cfg.Metrics.PingJitter.Enabled = true
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{
+		PacketsSent: 4,
+		PacketsRecv: 4,
+		AvgRtt:      15 * time.Millisecond,
+		Rtts:        []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 15 * time.Millisecond, 15 * time.Millisecond},
+	})
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	pingerCfg, _ := fakeProber.PingerConfig("192.0.2.1")
+	assert.True(t, pingerCfg.RecordRtts)
+
+	metrics, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	found := false
+	for i := 0; i < ms.Len(); i++ {
+		if ms.At(i).Name() == "ping.jitter" {
+			found = true
+			assert.Equal(t, float64(5), ms.At(i).Gauge().DataPoints().At(0).DoubleValue())
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestScraperPacketLossPercent(t *testing.T) {
+	cfg := &Config{
+		ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Targets:              []Target{{Endpoint: "192.0.2.1"}},
+	}
+	cfg.Metrics.PingPacketLoss.Enabled = false
+	cfg.Metrics.PingPacketLossPercent.Enabled = true
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{
+		PacketsSent: 4,
+		PacketsRecv: 3,
+		PacketLoss:  25,
+	})
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	metrics, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+
+	values := make(map[string]float64)
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		if ms.At(i).Type() == pmetric.MetricTypeGauge {
+			values[ms.At(i).Name()] = ms.At(i).Gauge().DataPoints().At(0).DoubleValue()
+		}
+	}
+	assert.NotContains(t, values, "ping.packet_loss")
+	assert.Equal(t, float64(25), values["ping.packet_loss.percent"])
+}
+
+func TestScraperHealthyEmitEvery(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4}}
+	cfg.HealthyEmitEvery = 3
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 4, PacketsRecv: 4})
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	emitted := func() bool {
+		md, err := scraper.scrapeTarget(context.Background(), 0)
+		require.NoError(t, err)
+		return md.DataPointCount() > 0
+	}
+
+	// Every third healthy scrape is emitted
+	assert.Equal(t, []bool{true, false, false, true}, []bool{emitted(), emitted(), emitted(), emitted()})
+
+	// Loss is always emitted, and so is the recovery
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 4, PacketsRecv: 3, PacketLoss: 25})
+	assert.True(t, emitted())
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 4, PacketsRecv: 4})
+	assert.Equal(t, []bool{true, false}, []bool{emitted(), emitted()})
+}
+
+func TestScraperHealthyEmitEveryBreakingSLA(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4, SLA: &SLAConfig{MaxLatency: 50 * time.Millisecond, Timezone: "UTC"}}}
+	cfg.HealthyEmitEvery = 3
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 4, PacketsRecv: 4, AvgRtt: 10 * time.Millisecond})
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	slaStatus := func() int64 {
+		md, err := scraper.scrapeTarget(context.Background(), 0)
+		require.NoError(t, err)
+		status := int64(-1)
+		forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+			if m.Name() == "ping.sla.status" {
+				status = m.Gauge().DataPoints().At(0).IntValue()
+			}
+		})
+		return status
+	}
+	assert.Equal(t, []int64{1, -1}, []int64{slaStatus(), slaStatus()})
+
+	// A slow scrape without loss breaks the SLA, and is emitted every time
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 4, PacketsRecv: 4, AvgRtt: 80 * time.Millisecond})
+	assert.Equal(t, []int64{0, 0, 0}, []int64{slaStatus(), slaStatus(), slaStatus()})
+}
+
+func TestScraperChecksSockets(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	settings := receivertest.NewNopSettings(metadata.Type)
+	settings.Logger = zap.New(core)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{
+		{Endpoint: "192.0.2.1", Count: 4},
+		{Endpoint: "192.0.2.2", Count: 4},
+		{Endpoint: "2001:db8::1", Count: 4},
+	}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetSocketError(prober.NetworkIPv6, false, errors.New("cannot open ip6 ICMP socket: permission denied"))
+	fakeProber.SetSocketError(prober.NetworkIPv6, true, errors.New("cannot open ip6 ICMP socket: operation not permitted"))
+	scraper := newScraper(cfg, settings, newFactoryOptions(WithProber(fakeProber)))
+	// Start succeeds so probes report permission errors as they happen
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	require.NoError(t, scraper.start(context.Background(), host))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	// Privileged sockets are checked as a fallback
+	assert.Equal(t, []string{prober.NetworkIPv4, prober.NetworkIPv6, prober.NetworkIPv4, prober.NetworkIPv6}, fakeProber.CheckedSockets())
+	assert.False(t, scraper.privileged())
+	failures := logs.FilterMessage("ICMP sockets cannot be opened, probes will fail").All()
+	require.Len(t, failures, 1)
+	assert.Equal(t, "cannot open ip6 ICMP socket: permission denied", failures[0].ContextMap()["error"])
+
+	// and the component status tells the collector's health check
+	events := host.reported()
+	require.Len(t, events, 1)
+	assert.Equal(t, componentstatus.StatusRecoverableError, events[0].Status())
+	assert.EqualError(t, events[0].Err(), "cannot open ip6 ICMP socket: permission denied")
+}
+
+func TestScraperFallsBackToPrivilegedSockets(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	settings := receivertest.NewNopSettings(metadata.Type)
+	settings.Logger = zap.New(core)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "2001:db8::1", Count: 4}}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetSocketError(prober.NetworkIPv6, false, errors.New("cannot open ip6 ICMP socket: permission denied"))
+	scraper := newScraper(cfg, settings, newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	assert.True(t, scraper.privileged())
+	pingerCfg, ok := fakeProber.PingerConfig("2001:db8::1")
+	require.True(t, ok)
+	assert.True(t, pingerCfg.Privileged)
+
+	fallbacks := logs.FilterMessage("Unprivileged ICMP sockets cannot be opened, falling back to privileged mode").All()
+	require.Len(t, fallbacks, 1)
+	assert.Equal(t, runtime.GOOS, fallbacks[0].ContextMap()["platform"])
+	assert.Empty(t, logs.FilterMessage("ICMP sockets cannot be opened, probes will fail").All())
+}
+
+func TestScraperTCPTargets(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	settings := receivertest.NewNopSettings(metadata.Type)
+	settings.Logger = zap.New(core)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{
+		{Endpoint: "192.0.2.1", Count: 4, Protocol: prober.ProtocolTCP, Port: 443},
+		{Endpoint: "2001:db8::1", Count: 4},
+	}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetSocketError(prober.NetworkIPv4, false, errors.New("cannot open ip4 ICMP socket: permission denied"))
+	scraper := newScraper(cfg, settings, newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	pingerCfg, ok := fakeProber.PingerConfig("192.0.2.1")
+	require.True(t, ok)
+	assert.Equal(t, prober.ProtocolTCP, pingerCfg.Protocol)
+	assert.Equal(t, 443, pingerCfg.Port)
+
+	// Only ICMP targets need ICMP sockets
+	assert.Equal(t, []string{prober.NetworkIPv6}, fakeProber.CheckedSockets())
+	assert.Empty(t, logs.FilterMessage("ICMP sockets cannot be opened, probes will fail").All())
+}
+
+func TestScraperIPVersion(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{
+		{Endpoint: "dual.example.com", Count: 4, Network: prober.NetworkIPv6},
+		{Endpoint: "192.0.2.1", Count: 4},
+	}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetResult("dual.example.com", prober.Statistics{
+		IPAddr: &net.IPAddr{IP: net.ParseIP("2001:db8::1")}, PacketsSent: 4, PacketsRecv: 4, AvgRtt: time.Millisecond,
+	})
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{
+		IPAddr: &net.IPAddr{IP: net.ParseIP("192.0.2.1")}, PacketsSent: 4, PacketsRecv: 4, AvgRtt: time.Millisecond,
+	})
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	pingerCfg, ok := fakeProber.PingerConfig("dual.example.com")
+	require.True(t, ok)
+	assert.Equal(t, prober.NetworkIPv6, pingerCfg.Network)
+
+	for i, want := range []int64{6, 4} {
+		md, err := scraper.scrapeTarget(context.Background(), i)
+		require.NoError(t, err)
+		forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+			if m.Name() != "ping.duration.avg" {
+				return
+			}
+			version, ok := m.Gauge().DataPoints().At(0).Attributes().Get("net.ip.version")
+			require.True(t, ok)
+			assert.Equal(t, want, version.Int())
+		})
+	}
+}
+
+func TestUpdateStateRunsDiagnosticsOnce(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	settings := receivertest.NewNopSettings(metadata.Type)
+	settings.Logger = zap.New(core)
+
+	cfg := &Config{
+		ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Diagnostics: DiagnosticsConfig{
+			Enabled:          true,
+			FailureThreshold: 2,
+			Timeout:          time.Second,
+		},
+	}
+
+	scraper := newScraper(cfg, settings, newFactoryOptions())
+
+	scraper.updateState("192.0.2.1", true)
+	scraper.updateState("192.0.2.1", true)
+	scraper.updateState("192.0.2.1", true)
+	require.NoError(t, scraper.shutdown(context.Background()))
+
+	diagnostics := logs.FilterMessage("Target diagnostics").All()
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, int64(2), diagnostics[0].ContextMap()["consecutive_failures"])
+	assert.Contains(t, diagnostics[0].ContextMap(), "traceroute")
+	assert.Equal(t, 3, scraper.states["192.0.2.1"].consecutiveFailures)
+
+	// Recovery resets the counter
+	scraper.updateState("192.0.2.1", false)
+	assert.Equal(t, 0, scraper.states["192.0.2.1"].consecutiveFailures)
+}
+
+func TestScraperScrapeWithFakeProber(t *testing.T) {
+	cfg := &Config{
+		ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Targets: []Target{
+			{Endpoint: "192.0.2.1"},
+			{Endpoint: "192.0.2.2"},
+			{Endpoint: "unresolvable.invalid"},
+		},
+	}
+
+	clock := pingchecktest.NewClock(time.Unix(1_700_000_000, 0))
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{
+		PacketsSent: 4,
+		PacketsRecv: 3,
+		PacketLoss:  25,
+		MinRtt:      10 * time.Millisecond,
+		MaxRtt:      30 * time.Millisecond,
+		AvgRtt:      20 * time.Millisecond,
+		MedianRtt:   15250 * time.Microsecond,
+		StdDevRtt:   5500 * time.Microsecond,
+	})
+	fakeProber.SetRunError("192.0.2.2", errors.New("network is unreachable"))
+	fakeProber.SetCreateError("unresolvable.invalid", errors.New("no such host"))
+
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type),
+		newFactoryOptions(WithProber(fakeProber), WithClock(clock)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	clock.Advance(time.Minute)
+	metrics, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+
+	_, err = scraper.scrapeTarget(context.Background(), 1)
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.Contains(t, err.Error(), "target 192.0.2.2: ping failed: network is unreachable")
+
+	_, err = scraper.scrapeTarget(context.Background(), 2)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "target unresolvable.invalid: pinger not found")
+	assert.Equal(t, 1, fakeProber.Runs("192.0.2.1"))
+
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	values := make(map[string]float64)
+	for i := 0; i < ms.Len(); i++ {
+		m := ms.At(i)
+		var dp pmetric.NumberDataPoint
+		switch m.Type() {
+		case pmetric.MetricTypeGauge:
+			dp = m.Gauge().DataPoints().At(0)
+			values[m.Name()] = dp.DoubleValue()
+		case pmetric.MetricTypeSum:
+			dp = m.Sum().DataPoints().At(0)
+			values[m.Name()] = float64(dp.IntValue())
+		}
+		assert.Equal(t, pcommon.NewTimestampFromTime(clock.Now()), dp.Timestamp())
+		assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(1_700_000_000, 0)), dp.StartTimestamp())
+	}
+	assert.Equal(t, map[string]float64{
+		"ping.duration.min":     10,
+		"ping.duration.max":     30,
+		"ping.duration.avg":     20,
+		"ping.duration.median":  15.25,
+		"ping.duration.stddev":  5.5,
+		"ping.packet_loss":      0.25,
+		"ping.packets.sent":     4,
+		"ping.packets.received": 3,
+	}, values)
+}
+
+func TestScraperSourceMismatch(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4}}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 4, PacketsRecv: 4, Mismatches: 3})
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	metrics, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+
+	received := make(map[bool]int64)
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		if ms.At(i).Name() != "ping.packets.received" {
+			continue
+		}
+		dps := ms.At(i).Sum().DataPoints()
+		for j := 0; j < dps.Len(); j++ {
+			mismatch, _ := dps.At(j).Attributes().Get("reply.source_mismatch")
+			received[mismatch.Bool()] = dps.At(j).IntValue()
+		}
+	}
+	assert.Equal(t, map[bool]int64{false: 1, true: 3}, received)
+}
+
+func BenchmarkScrape100Targets(b *testing.B) { benchmarkScrape(b, 100) }
+
+func BenchmarkScrape1kTargets(b *testing.B) { benchmarkScrape(b, 1_000) }
+
+func BenchmarkScrape10kTargets(b *testing.B) { benchmarkScrape(b, 10_000) }
+
+// benchmarkScrape measures a collection cycle of n targets answered by the
+// fake prober, so only the receiver's own work is measured
+func benchmarkScrape(b *testing.B, n int) {
+	cfg := createDefaultConfig().(*Config)
+	fakeProber := pingchecktest.NewProber()
+	for i := range n {
+		endpoint := fmt.Sprintf("10.%d.%d.%d", i>>16&0xff, i>>8&0xff, i&0xff)
+		cfg.Targets = append(cfg.Targets, Target{Endpoint: endpoint, Count: 4})
+		fakeProber.SetResult(endpoint, prober.Statistics{
+			PacketsSent: 4,
+			PacketsRecv: 4,
+			IPAddr:      &net.IPAddr{IP: net.ParseIP(endpoint)},
+			MinRtt:      10 * time.Millisecond,
+			MaxRtt:      14 * time.Millisecond,
+			AvgRtt:      12 * time.Millisecond,
+			MedianRtt:   12 * time.Millisecond,
+			StdDevRtt:   time.Millisecond,
+		})
+	}
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(b, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(b, scraper.shutdown(context.Background())) }()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		for i := range cfg.Targets {
+			if _, err := scraper.scrapeTarget(context.Background(), i); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
 }
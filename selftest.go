@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.uber.org/zap"
+
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// Address and budget of the self-test probe
+const (
+	selfTestEndpoint = "127.0.0.1"
+	selfTestTimeout  = 2 * time.Second
+)
+
+var errSelfTestNoReply = errors.New("no reply")
+
+// runSelfTest probes loopback once in the background and reports the outcome
+// as the status of the receiver, so missing socket permissions show up right
+// after deployment instead of at the first collection interval
+func (s *pingScraper) runSelfTest(host component.Host) {
+	s.bgWG.Add(1)
+	go func() {
+		defer s.bgWG.Done()
+		rtt, err := s.selfTest(s.bgCtx)
+		if s.bgCtx.Err() != nil {
+			// Shut down meanwhile
+			return
+		}
+		if err != nil {
+			err = fmt.Errorf("self-test probe of %s failed: %w", selfTestEndpoint, err)
+			s.logger.Error("Self-test failed, probes will fail", zap.Bool("privileged", s.privileged()), zap.Error(err))
+			componentstatus.ReportStatus(host, componentstatus.NewRecoverableErrorEvent(err))
+			return
+		}
+		s.logger.Info("Self-test passed", zap.String("endpoint", selfTestEndpoint), zap.Duration("rtt", rtt))
+		componentstatus.ReportStatus(host, componentstatus.NewEvent(componentstatus.StatusOK))
+	}()
+}
+
+// selfTest sends a single packet to loopback, returning its round-trip time
+func (s *pingScraper) selfTest(ctx context.Context) (time.Duration, error) {
+	pinger, err := s.prober.NewPinger(prober.PingerConfig{
+		Endpoint:   selfTestEndpoint,
+		Count:      1,
+		Timeout:    selfTestTimeout,
+		Interval:   selfTestTimeout,
+		Privileged: s.privileged(),
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer pinger.Stop()
+
+	stats, err := pinger.Run(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if stats.PacketsRecv == 0 {
+		return 0, errSelfTestNoReply
+	}
+	return stats.AvgRtt, nil
+}
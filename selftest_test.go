@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// statusHost records the status events reported to it
+type statusHost struct {
+	component.Host
+	mu     sync.Mutex
+	events []*componentstatus.Event
+}
+
+func (h *statusHost) Report(event *componentstatus.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, event)
+}
+
+func (h *statusHost) reported() []*componentstatus.Event {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.events
+}
+
+func TestScraperSelfTest(t *testing.T) {
+	tests := []struct {
+		name   string
+		setup  func(*pingchecktest.Prober)
+		status componentstatus.Status
+		errMsg string
+	}{
+		{
+			name:   "passed",
+			setup:  func(*pingchecktest.Prober) {},
+			status: componentstatus.StatusOK,
+		},
+		{
+			name: "permission denied",
+			setup: func(p *pingchecktest.Prober) {
+				p.SetRunError(selfTestEndpoint, errors.New("socket: permission denied"))
+			},
+			status: componentstatus.StatusRecoverableError,
+			errMsg: "self-test probe of 127.0.0.1 failed: socket: permission denied",
+		},
+		{
+			name: "no reply",
+			setup: func(p *pingchecktest.Prober) {
+				p.SetResult(selfTestEndpoint, prober.Statistics{PacketsSent: 1})
+			},
+			status: componentstatus.StatusRecoverableError,
+			errMsg: "self-test probe of 127.0.0.1 failed: no reply",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Targets = []Target{{Endpoint: "192.0.2.1"}}
+			cfg.SelfTest = true
+
+			fake := pingchecktest.NewProber()
+			tt.setup(fake)
+			core, logs := observer.New(zap.InfoLevel)
+			settings := receivertest.NewNopSettings(metadata.Type)
+			settings.Logger = zap.New(core)
+			host := &statusHost{Host: componenttest.NewNopHost()}
+
+			scraper := newScraper(cfg, settings, newFactoryOptions(WithProber(fake)))
+			require.NoError(t, scraper.start(context.Background(), host))
+			defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+			require.Eventually(t, func() bool { return len(host.reported()) == 1 }, 5*time.Second, 10*time.Millisecond)
+			event := host.reported()[0]
+			assert.Equal(t, tt.status, event.Status())
+			if tt.errMsg == "" {
+				assert.NoError(t, event.Err())
+				assert.Equal(t, 1, logs.FilterMessage("Self-test passed").Len())
+			} else {
+				assert.EqualError(t, event.Err(), tt.errMsg)
+				assert.Equal(t, 1, logs.FilterMessage("Self-test failed, probes will fail").Len())
+			}
+			assert.Equal(t, 1, fake.Runs(selfTestEndpoint))
+		})
+	}
+}
+
+func TestScraperSelfTestDisabled(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1"}}
+
+	fake := pingchecktest.NewProber()
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fake)))
+	require.NoError(t, scraper.start(context.Background(), host))
+	require.NoError(t, scraper.shutdown(context.Background()))
+
+	assert.Empty(t, host.reported())
+	assert.Equal(t, 0, fake.Runs(selfTestEndpoint))
+}
@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// probeOutcome is the result of probing a target once
+type probeOutcome struct {
+	// When the probe started and finished, zero if it never ran
+	started  time.Time
+	finished time.Time
+	stats    *prober.Statistics
+
+	// Tick of collection_interval the probe belongs to, and when its packets
+	// started to be sent, zero if they were not, see ping.schedule.drift
+	scheduled    time.Time
+	burstStarted time.Time
+
+	// err is returned to the scraper, errorType is recorded as a ping.errors
+	// data point if set. Loss while the egress interface is down sets only
+	// the latter.
+	err       error
+	errorType metadata.AttributeErrorType
+
+	// Results of the packet sizes and DSCP values probed, if any
+	variants []variantResult
+
+	// Largest packet reaching the target unfragmented, 0 if not searched or
+	// none did, see path_mtu_discovery
+	pathMTU int
+
+	// Traceroute finished since the previous probe, nil if none, see traceroute
+	trace *traceResult
+
+	// How long resolving the hostname took before probing, if it was resolved
+	lookupDuration time.Duration
+	resolved       bool
+
+	// Change of the source address found before probing, see source_watch
+	sourceChange *sourceChange
+
+	// Whether the SNMP agent answered after the probe failed, see snmp
+	managementOK *bool
+
+	// Whether the flow source saw the target before the probe failed, see passive_check
+	passivelySeen bool
+
+	// Consecutive failures of the target including this probe, and whether
+	// it went up or down since the previous one
+	failures     int
+	transitioned bool
+
+	// Total downtime of the target, and the share of this probe, see updateState
+	downtime     downtime
+	lastDowntime downtime
+
+	// suppressed outcomes are dropped, the egress interface was down
+	suppressed bool
+}
+
+// healthy reports whether the probe succeeded and every packet was answered
+func (o *probeOutcome) healthy() bool {
+	return !o.suppressed && o.err == nil && o.errorType == 0 && o.stats.PacketsRecv == o.stats.PacketsSent
+}
+
+// sharedOutcome is a probe whose outcome is handed to the receivers of every signal
+type sharedOutcome struct {
+	done    chan struct{}
+	at      time.Time
+	outcome *probeOutcome
+
+	// Targets that already took the outcome, guarded by outcomeMu
+	consumed map[outcomeTaker]bool
+}
+
+// outcomeTaker is the i-th target of the receiver of a signal
+type outcomeTaker struct {
+	signal pipeline.Signal
+	i      int
+}
+
+// outcome returns the outcome of the i-th target for the receiver of signal.
+// A probe is shared with the other targets of its endpoint and with receivers
+// of other signals that have not taken its outcome yet, as long as it is less
+// than a collection interval old, so every signal sees every probe and an
+// endpoint is probed once per round rather than once per target and signal.
+func (s *pingScraper) outcome(ctx context.Context, signal pipeline.Signal, i int) *probeOutcome {
+	target := s.cfg.Targets[i]
+	taker := outcomeTaker{signal: signal, i: i}
+	s.outcomeMu.Lock()
+	shared := s.outcomes[target.Endpoint]
+	if shared == nil || shared.consumed[taker] || s.expired(shared) {
+		shared = &sharedOutcome{
+			done:     make(chan struct{}),
+			consumed: map[outcomeTaker]bool{taker: true},
+		}
+		s.outcomes[target.Endpoint] = shared
+		s.outcomeMu.Unlock()
+
+		shared.outcome = s.probeSafely(ctx, target)
+		shared.at = s.clock.Now()
+		close(shared.done)
+		return shared.outcome
+	}
+	shared.consumed[taker] = true
+	s.outcomeMu.Unlock()
+
+	select {
+	case <-shared.done:
+		return shared.outcome
+	case <-ctx.Done():
+		o := &probeOutcome{err: ctx.Err()}
+		if errors.Is(o.err, context.DeadlineExceeded) {
+			o.errorType = metadata.AttributeErrorTypeDeadlineExceeded
+		}
+		return o
+	}
+}
+
+// latestOutcomes returns the outcome of every target probed by this receiver
+// for the receiver of signal, probing them concurrently. Targets probed by
+// another receiver have none.
+func (s *pingScraper) latestOutcomes(ctx context.Context, signal pipeline.Signal) []*probeOutcome {
+	outcomes := make([]*probeOutcome, len(s.cfg.Targets))
+	var wg sync.WaitGroup
+	for i := range s.cfg.Targets {
+		if !s.probes(i) {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			outcomes[i] = s.outcome(ctx, signal, i)
+		}()
+	}
+	wg.Wait()
+	return outcomes
+}
+
+// expired reports whether a finished probe is too old to be shared
+func (s *pingScraper) expired(shared *sharedOutcome) bool {
+	select {
+	case <-shared.done:
+		return s.clock.Now().Sub(shared.at) >= s.cfg.CollectionInterval
+	default:
+		// Still probing
+		return false
+	}
+}
+
+// sharedScrapers hands out one probing engine per receiver config, so that a
+// receiver in pipelines of several signals probes its targets only once
+type sharedScrapers struct {
+	mu       sync.Mutex
+	scrapers map[*Config]*sharedScraper
+}
+
+type sharedScraper struct {
+	scraper *pingScraper
+	signals map[pipeline.Signal]bool
+}
+
+func newSharedScrapers() *sharedScrapers {
+	return &sharedScrapers{scrapers: make(map[*Config]*sharedScraper)}
+}
+
+// acquire returns the engine of cfg for a receiver of signal, and the function
+// releasing it when that receiver shuts down. The engine is shut down once
+// every receiver using it has released it.
+func (ss *sharedScrapers) acquire(cfg *Config, settings receiver.Settings, fo factoryOptions, signal pipeline.Signal) (*pingScraper, func(context.Context) error) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	shared, ok := ss.scrapers[cfg]
+	if !ok || shared.signals[signal] {
+		// A second receiver of the same signal gets an engine of its own, it
+		// would otherwise consume the outcomes of the first
+		shared = &sharedScraper{
+			scraper: newScraper(cfg, settings, fo),
+			signals: make(map[pipeline.Signal]bool),
+		}
+		if !ok {
+			ss.scrapers[cfg] = shared
+		}
+	}
+	shared.signals[signal] = true
+
+	var once sync.Once
+	release := func(ctx context.Context) error {
+		var err error
+		once.Do(func() {
+			ss.mu.Lock()
+			delete(shared.signals, signal)
+			last := len(shared.signals) == 0
+			if last && ss.scrapers[cfg] == shared {
+				delete(ss.scrapers, cfg)
+			}
+			ss.mu.Unlock()
+
+			if last {
+				err = shared.scraper.shutdown(ctx)
+			}
+		})
+		return err
+	}
+	return shared.scraper, release
+}
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+)
+
+func TestOutcomeSharedAcrossSignals(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1"}}
+
+	clock := pingchecktest.NewClock(time.Unix(1_700_000_000, 0))
+	fakeProber := pingchecktest.NewProber()
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber), WithClock(clock)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	metricsOutcome := scraper.outcome(context.Background(), pipeline.SignalMetrics, 0)
+	logsOutcome := scraper.outcome(context.Background(), pipeline.SignalLogs, 0)
+	assert.Same(t, metricsOutcome, logsOutcome)
+	assert.Equal(t, 1, fakeProber.Runs("192.0.2.1"))
+
+	// A signal that already took the outcome gets a new probe
+	scraper.outcome(context.Background(), pipeline.SignalMetrics, 0)
+	assert.Equal(t, 2, fakeProber.Runs("192.0.2.1"))
+
+	// Outcomes older than a collection interval are not shared
+	clock.Advance(cfg.CollectionInterval)
+	scraper.outcome(context.Background(), pipeline.SignalLogs, 0)
+	assert.Equal(t, 3, fakeProber.Runs("192.0.2.1"))
+}
+
+func TestSharedScrapers(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1"}}
+	settings := receivertest.NewNopSettings(metadata.Type)
+	fo := newFactoryOptions(WithProber(pingchecktest.NewProber()))
+
+	metricsScraper, releaseMetrics := fo.scrapers.acquire(cfg, settings, fo, pipeline.SignalMetrics)
+	logsScraper, releaseLogs := fo.scrapers.acquire(cfg, settings, fo, pipeline.SignalLogs)
+	assert.Same(t, metricsScraper, logsScraper)
+
+	// Another config, or another receiver of the same signal, gets its own engine
+	otherCfg := *cfg
+	other, releaseOther := fo.scrapers.acquire(&otherCfg, settings, fo, pipeline.SignalMetrics)
+	assert.NotSame(t, metricsScraper, other)
+	second, releaseSecond := fo.scrapers.acquire(cfg, settings, fo, pipeline.SignalMetrics)
+	assert.NotSame(t, metricsScraper, second)
+
+	require.NoError(t, metricsScraper.start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, releaseMetrics(context.Background()))
+	require.NoError(t, releaseMetrics(context.Background()))
+	assert.NotNil(t, metricsScraper.pingers, "still used by the logs receiver")
+
+	require.NoError(t, releaseLogs(context.Background()))
+	assert.Nil(t, metricsScraper.pingers)
+	_, ok := fo.scrapers.scrapers[cfg]
+	assert.False(t, ok)
+
+	require.NoError(t, releaseOther(context.Background()))
+	require.NoError(t, releaseSecond(context.Background()))
+	assert.Empty(t, fo.scrapers.scrapers)
+}
+
+func TestOutcomeSharedByTargetsOfEndpoint(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics.PingConsecutiveFailures.Enabled = true
+	cfg.Targets = []Target{
+		{Endpoint: "192.0.2.1", Count: 4},
+		{Endpoint: "192.0.2.1", Count: 4, Group: "duplicate"},
+	}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetRunError("192.0.2.1", errors.New("i/o timeout"))
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	// Every target of the endpoint takes the outcome of a single probe per round
+	for round := 1; round <= 2; round++ {
+		for i := range cfg.Targets {
+			md, err := scraper.scrapeTarget(context.Background(), i)
+			require.Error(t, err)
+			failures := int64(-1)
+			forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+				if m.Name() == "ping.consecutive_failures" {
+					failures = m.Gauge().DataPoints().At(0).IntValue()
+				}
+			})
+			assert.Equal(t, int64(round), failures, "round %d, target %d", round, i)
+		}
+		assert.Equal(t, round, fakeProber.Runs("192.0.2.1"))
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"math"
+	"runtime"
+	"slices"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+// essentialMetrics tell whether a target is up, they are kept when its
+// detailed metrics are shed
+var essentialMetrics = map[string]bool{
+	"ping.packet_loss":          true,
+	"ping.packet_loss.percent":  true,
+	"ping.packets.sent":         true,
+	"ping.packets.received":     true,
+	"ping.errors":               true,
+	"ping.consecutive_failures": true,
+	"ping.state_transitions":    true,
+	"ping.downtime":             true,
+}
+
+// shedLevel is how far the detailed metrics of a scrape are shed
+type shedLevel struct {
+	// shedding is set if targets of priority up to maxPriority are shed
+	shedding    bool
+	maxPriority int
+}
+
+// sheds reports whether the detailed metrics of target are shed
+func (l shedLevel) sheds(target Target) bool {
+	return l.shedding && target.Priority <= l.maxPriority
+}
+
+// heapInUse returns the bytes of heap in use, as the memory_limiter
+// processor measures memory
+func heapInUse() uint64 {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return ms.Alloc
+}
+
+// startShedding resolves the memory limit shedding is measured against,
+// disabling it if there is none
+func (s *pingScraper) startShedding() {
+	s.memoryLimit = s.cfg.Shedding.LimitMiB << 20
+	if s.memoryLimit == 0 {
+		s.memoryLimit = s.readLimits().memory
+	}
+	if s.memoryLimit == 0 {
+		s.logger.Warn("Shedding disabled, set limit_mib as the memory limit is unknown")
+	}
+}
+
+// shedLevel returns how far the detailed metrics of a scrape are shed. Above
+// the soft limit the targets of lowest priority are shed, and as memory use
+// approaches the hard limit those of higher priorities in turn, until every
+// target is shed at the hard limit.
+func (s *pingScraper) shedLevel() shedLevel {
+	if !s.cfg.Shedding.Enabled || s.memoryLimit == 0 {
+		return shedLevel{}
+	}
+	used := float64(s.readMemory()) / float64(s.memoryLimit) * 100
+	soft, hard := float64(s.cfg.Shedding.SoftLimitPercentage), float64(s.cfg.Shedding.HardLimitPercentage)
+	level := shedLevel{}
+	if used >= soft {
+		priorities := make([]int, 0, len(s.cfg.Targets))
+		for _, target := range s.cfg.Targets {
+			priorities = append(priorities, target.Priority)
+		}
+		slices.Sort(priorities)
+		priorities = slices.Compact(priorities)
+
+		tiers := len(priorities)
+		if used < hard {
+			tiers = max(int(math.Ceil((used-soft)/(hard-soft)*float64(tiers))), 1)
+		}
+		level = shedLevel{shedding: true, maxPriority: priorities[tiers-1]}
+	}
+
+	if s.shed != level {
+		if level.shedding {
+			s.logger.Warn("Memory pressure, shedding detailed metrics of low priority targets",
+				zap.Float64("memory_used_percent", used),
+				zap.Int("max_priority", level.maxPriority))
+		} else {
+			s.logger.Info("Memory pressure relieved, reporting every metric again",
+				zap.Float64("memory_used_percent", used))
+		}
+		s.shed = level
+	}
+	return level
+}
+
+// shedDetails removes every metric of md but those telling whether its target is up
+func shedDetails(md pmetric.Metrics) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sms.At(j).Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				return !essentialMetrics[m.Name()]
+			})
+		}
+	}
+}
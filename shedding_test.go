@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// metricNames returns the names of the metrics of md
+func metricNames(md pmetric.Metrics) []string {
+	var names []string
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		names = append(names, m.Name())
+	})
+	return names
+}
+
+func TestScraperShedding(t *testing.T) {
+	tests := []struct {
+		name     string
+		usedMiB  uint64
+		detailed []bool
+	}{
+		{name: "below soft limit", usedMiB: 70, detailed: []bool{true, true, true}},
+		{name: "lowest priority", usedMiB: 85, detailed: []bool{false, true, true}},
+		{name: "two priorities", usedMiB: 90, detailed: []bool{false, false, true}},
+		{name: "hard limit", usedMiB: 95, detailed: []bool{false, false, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Shedding.Enabled = true
+			cfg.Shedding.LimitMiB = 100
+			cfg.Targets = []Target{
+				{Endpoint: "192.0.2.1", Count: 4, Priority: -1},
+				{Endpoint: "192.0.2.2", Count: 4},
+				{Endpoint: "192.0.2.3", Count: 4, Priority: 10},
+			}
+
+			fakeProber := pingchecktest.NewProber()
+			for _, target := range cfg.Targets {
+				fakeProber.SetResult(target.Endpoint, prober.Statistics{PacketsSent: 4, PacketsRecv: 4, AvgRtt: time.Millisecond})
+			}
+			scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+			scraper.readMemory = func() uint64 { return tt.usedMiB << 20 }
+			require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+			defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+			for i := range cfg.Targets {
+				md, err := scraper.scrapeTarget(context.Background(), i)
+				require.NoError(t, err)
+				names := metricNames(md)
+				// Whether the target is up is always reported
+				assert.Contains(t, names, "ping.packet_loss")
+				assert.Contains(t, names, "ping.packets.received")
+				assert.Equal(t, tt.detailed[i], slices.Contains(names, "ping.duration.avg"), "targets[%d]", i)
+			}
+		})
+	}
+}
+
+func TestScraperSheddingWithoutLimit(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Shedding.Enabled = true
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4}}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 4, PacketsRecv: 4, AvgRtt: time.Millisecond})
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	scraper.readLimits = func() hostLimits { return hostLimits{} }
+	scraper.readMemory = func() uint64 { return 1 << 40 }
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	// Nothing is shed without a limit to measure memory against
+	md, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Contains(t, metricNames(md), "ping.duration.avg")
+}
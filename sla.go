@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+)
+
+// slaDefaultWindow is the sla.window of results outside of every schedule
+const slaDefaultWindow = "default"
+
+// thresholds returns the name of the window applying at t and its thresholds
+func (c *SLAConfig) thresholds(t time.Time) (string, time.Duration, *float64) {
+	// Validated with the config
+	loc, _ := time.LoadLocation(c.Timezone)
+	t = t.In(loc)
+	for _, schedule := range c.Schedules {
+		if !schedule.contains(t) {
+			continue
+		}
+		maxLatency, maxLoss := c.MaxLatency, c.MaxLoss
+		if schedule.MaxLatency > 0 {
+			maxLatency = schedule.MaxLatency
+		}
+		if schedule.MaxLoss != nil {
+			maxLoss = schedule.MaxLoss
+		}
+		return schedule.Name, maxLatency, maxLoss
+	}
+	return slaDefaultWindow, c.MaxLatency, c.MaxLoss
+}
+
+// withinSLA reports whether outcome o meets the thresholds applying at t,
+// and the window they were taken from. Failed probes never do.
+func (c *SLAConfig) withinSLA(o *probeOutcome, t time.Time) (string, bool) {
+	window, maxLatency, maxLoss := c.thresholds(t)
+	if o.err != nil || o.stats.PacketsRecv == 0 {
+		return window, false
+	}
+	if maxLatency > 0 && o.stats.AvgRtt > maxLatency {
+		return window, false
+	}
+	if maxLoss != nil && o.stats.PacketLoss/100 > *maxLoss {
+		return window, false
+	}
+	return window, true
+}
+
+// recordSLA classifies the outcome of target's probe against its SLA
+func (s *pingScraper) recordSLA(mb *metadata.MetricsBuilder, metrics metadata.MetricsConfig, target Target, o *probeOutcome) {
+	if target.SLA == nil || !metrics.PingSLAStatus.Enabled {
+		return
+	}
+	now := s.clock.Now()
+	window, ok := target.SLA.withinSLA(o, now)
+	var val int64
+	if ok {
+		val = 1
+	}
+	mb.RecordPingSLAStatusDataPoint(pcommon.NewTimestampFromTime(now), val, target.Endpoint, window)
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+func TestSLAWithinSLA(t *testing.T) {
+	loss := 0.01
+	backupLoss := 0.2
+	sla := &SLAConfig{
+		MaxLatency: 50 * time.Millisecond,
+		MaxLoss:    &loss,
+		Timezone:   "UTC",
+		Schedules: []SLASchedule{{
+			Name:       "backup",
+			TimeWindow: TimeWindow{Start: "01:00", End: "05:00"},
+			MaxLatency: 300 * time.Millisecond,
+			MaxLoss:    &backupLoss,
+		}},
+	}
+	require.NoError(t, sla.validate())
+
+	day := time.Date(2024, 1, 5, 12, 0, 0, 0, time.UTC)
+	night := time.Date(2024, 1, 5, 3, 0, 0, 0, time.UTC)
+	slow := &probeOutcome{stats: &prober.Statistics{PacketsSent: 10, PacketsRecv: 9, PacketLoss: 10, AvgRtt: 200 * time.Millisecond}}
+	fast := &probeOutcome{stats: &prober.Statistics{PacketsSent: 10, PacketsRecv: 10, AvgRtt: 20 * time.Millisecond}}
+	failed := &probeOutcome{err: errors.New("ping failed: i/o timeout")}
+
+	tests := []struct {
+		name     string
+		o        *probeOutcome
+		t        time.Time
+		window   string
+		expected bool
+	}{
+		{name: "slow by day", o: slow, t: day, window: slaDefaultWindow, expected: false},
+		{name: "slow at night", o: slow, t: night, window: "backup", expected: true},
+		{name: "fast by day", o: fast, t: day, window: slaDefaultWindow, expected: true},
+		{name: "failed at night", o: failed, t: night, window: "backup", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window, ok := sla.withinSLA(tt.o, tt.t)
+			assert.Equal(t, tt.window, window)
+			assert.Equal(t, tt.expected, ok)
+		})
+	}
+}
+
+func TestSLAScheduleInheritsThresholds(t *testing.T) {
+	loss := 0.05
+	sla := &SLAConfig{
+		MaxLatency: 50 * time.Millisecond,
+		MaxLoss:    &loss,
+		Schedules:  []SLASchedule{{Name: "weekend", TimeWindow: TimeWindow{Days: []string{"sat", "sun"}, Start: "00:00", End: "00:00"}, MaxLatency: time.Second}},
+	}
+	window, maxLatency, maxLoss := sla.thresholds(time.Date(2024, 1, 6, 12, 0, 0, 0, time.Local))
+	assert.Equal(t, "weekend", window)
+	assert.Equal(t, time.Second, maxLatency)
+	assert.Equal(t, &loss, maxLoss)
+}
+
+func TestScraperSLA(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4, SLA: &SLAConfig{
+		MaxLatency: 50 * time.Millisecond,
+		Timezone:   "America/New_York",
+		Schedules:  []SLASchedule{{Name: "overnight", TimeWindow: TimeWindow{Start: "22:00", End: "06:00"}, MaxLatency: 500 * time.Millisecond}},
+	}}}
+
+	// 03:00 in New York
+	clock := pingchecktest.NewClock(time.Date(2024, 1, 5, 8, 0, 0, 0, time.UTC))
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 4, PacketsRecv: 4, AvgRtt: 200 * time.Millisecond})
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber), WithClock(clock)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	status := func() (string, int64) {
+		md, err := scraper.scrapeTarget(context.Background(), 0)
+		require.NoError(t, err)
+		var window string
+		val := int64(-1)
+		forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+			if m.Name() == "ping.sla.status" {
+				dp := m.Gauge().DataPoints().At(0)
+				w, _ := dp.Attributes().Get("sla.window")
+				window, val = w.Str(), dp.IntValue()
+			}
+		})
+		return window, val
+	}
+
+	window, val := status()
+	assert.Equal(t, "overnight", window)
+	assert.Equal(t, int64(1), val)
+
+	// Noon in New York
+	clock.Advance(9 * time.Hour)
+	window, val = status()
+	assert.Equal(t, slaDefaultWindow, window)
+	assert.Equal(t, int64(0), val)
+}
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"cmp"
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+)
+
+// SNMP check defaults
+const (
+	defaultSNMPCommunity = "public"
+	defaultSNMPPort      = 161
+	defaultSNMPTimeout   = 2 * time.Second
+)
+
+// checkManagementPlane reports whether target's SNMP agent still answers
+// after its ping failed, or nil if snmp is not configured. Many devices
+// deprioritize ICMP under load while their management plane is fine.
+func (s *pingScraper) checkManagementPlane(ctx context.Context, target Target) *bool {
+	cfg := target.SNMP
+	if cfg == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, cmp.Or(cfg.Timeout, defaultSNMPTimeout))
+	defer cancel()
+
+	addr := net.JoinHostPort(target.Endpoint, strconv.Itoa(cmp.Or(cfg.Port, defaultSNMPPort)))
+	uptime, err := s.sysUpTime(ctx, addr, cmp.Or(cfg.Community, defaultSNMPCommunity))
+	responding := err == nil
+	if err != nil {
+		s.logger.Debug("Management plane not responding",
+			zap.String("endpoint", target.Endpoint),
+			zap.Error(err))
+	} else {
+		s.logger.Debug("Management plane responding",
+			zap.String("endpoint", target.Endpoint),
+			zap.Duration("sys_uptime", uptime))
+	}
+	return &responding
+}
+
+// recordManagementPlane records the result of the SNMP check of a failed probe
+func (s *pingScraper) recordManagementPlane(mb *metadata.MetricsBuilder, metrics metadata.MetricsConfig, target Target, o *probeOutcome) {
+	if o.managementOK == nil || !metrics.PingManagementPlaneResponding.Enabled {
+		return
+	}
+	var val int64
+	if *o.managementOK {
+		val = 1
+	}
+	mb.RecordPingManagementPlaneRespondingDataPoint(pcommon.NewTimestampFromTime(s.clock.Now()), val, target.Endpoint)
+}
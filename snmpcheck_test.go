@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// managementPlane returns the ping.management_plane.responding value of md, or -1 if missing
+func managementPlane(md pmetric.Metrics) int64 {
+	var val int64 = -1
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		if m.Name() == "ping.management_plane.responding" {
+			val = m.Gauge().DataPoints().At(0).IntValue()
+		}
+	})
+	return val
+}
+
+func TestScraperManagementPlane(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{
+		{Endpoint: "192.0.2.1", Count: 4, SNMP: &SNMPCheckConfig{Community: "secret"}},
+		{Endpoint: "192.0.2.2", Count: 4, SNMP: &SNMPCheckConfig{Port: 1161}},
+		{Endpoint: "192.0.2.3", Count: 4, SNMP: &SNMPCheckConfig{}},
+	}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetRunError("192.0.2.1", errors.New("i/o timeout"))
+	fakeProber.SetResult("192.0.2.2", prober.Statistics{PacketsSent: 4, PacketLoss: 100})
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	var mu sync.Mutex
+	var requests []string
+	scraper.sysUpTime = func(_ context.Context, addr, community string) (time.Duration, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, addr+" "+community)
+		if addr == "192.0.2.2:1161" {
+			return 0, errors.New("i/o timeout")
+		}
+		return time.Hour, nil
+	}
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	var values []int64
+	for i := range cfg.Targets {
+		md, _ := scraper.scrapeTarget(context.Background(), i)
+		values = append(values, managementPlane(md))
+	}
+	// Healthy targets are not checked
+	assert.Equal(t, []int64{1, 0, -1}, values)
+	assert.ElementsMatch(t, []string{"192.0.2.1:161 secret", "192.0.2.2:1161 public"}, requests)
+
+	ld, err := scraper.scrapeLogs(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, ld.LogRecordCount())
+	responding, ok := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Get("management_plane.responding")
+	require.True(t, ok)
+	assert.True(t, responding.Bool())
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"net"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// sourceChanged is the event of log records about a changed source address
+const sourceChanged = "source_changed"
+
+// sourceChange is a change of the local address a target is probed from
+type sourceChange struct {
+	previous net.IP
+	current  net.IP
+}
+
+// sourceWatch tracks the local address each target is probed from. A DHCP
+// renewal or a failover to another uplink changes it, leaving pingers with
+// sockets bound to an address the host no longer has.
+type sourceWatch struct {
+	// source returns the local address used to reach an IP
+	source func(net.IP) (net.IP, error)
+
+	mu      sync.Mutex
+	sources map[string]net.IP
+}
+
+func newSourceWatch() *sourceWatch {
+	return &sourceWatch{
+		source:  sourceAddress,
+		sources: make(map[string]net.IP),
+	}
+}
+
+// check looks up the source address of endpoint, reached at ip, and returns
+// the change since the last check if there was one
+func (w *sourceWatch) check(endpoint string, ip net.IP) *sourceChange {
+	current, err := w.source(ip)
+	if err != nil {
+		// No route, the address is checked again once there is one
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	previous, ok := w.sources[endpoint]
+	w.sources[endpoint] = current
+	if !ok || previous.Equal(current) {
+		return nil
+	}
+	return &sourceChange{previous: previous, current: current}
+}
+
+// checkSource recreates the pingers of target if the address it is probed
+// from changed since the last probe, and returns the change
+func (s *pingScraper) checkSource(target Target) *sourceChange {
+	s.mu.RLock()
+	pinger := s.pingers[target.Endpoint]
+	s.mu.RUnlock()
+
+	ip := net.ParseIP(target.Endpoint)
+	if r, ok := pinger.(prober.Resolved); ok && r.IPAddr() != nil {
+		ip = r.IPAddr().IP
+	}
+	if ip == nil {
+		ip = defaultRouteProbe
+	}
+	change := s.sources.check(target.Endpoint, ip)
+	if change == nil {
+		return nil
+	}
+
+	s.logger.Warn("Source address changed, recreating pinger",
+		zap.String("event", sourceChanged),
+		zap.String("endpoint", target.Endpoint),
+		zap.Stringer("previous", change.previous),
+		zap.Stringer("current", change.current))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dropPingersLocked(target.Endpoint)
+	return change
+}
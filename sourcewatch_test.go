@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+)
+
+func TestSourceWatchCheck(t *testing.T) {
+	source := net.ParseIP("192.168.1.10")
+	var routeErr error
+	w := newSourceWatch()
+	w.source = func(net.IP) (net.IP, error) {
+		return source, routeErr
+	}
+
+	// The first check only records the address
+	assert.Nil(t, w.check("192.0.2.1", net.ParseIP("192.0.2.1")))
+	assert.Nil(t, w.check("192.0.2.1", net.ParseIP("192.0.2.1")))
+
+	// No route while the lease is renewed
+	routeErr = errors.New("connect: network is unreachable")
+	assert.Nil(t, w.check("192.0.2.1", net.ParseIP("192.0.2.1")))
+
+	routeErr = nil
+	source = net.ParseIP("192.168.1.23")
+	change := w.check("192.0.2.1", net.ParseIP("192.0.2.1"))
+	require.NotNil(t, change)
+	assert.Equal(t, "192.168.1.10", change.previous.String())
+	assert.Equal(t, "192.168.1.23", change.current.String())
+	assert.Nil(t, w.check("192.0.2.1", net.ParseIP("192.0.2.1")))
+}
+
+func TestScraperSourceWatch(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.SourceWatch = true
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4, PacketSizes: []int{512}}}
+
+	fakeProber := pingchecktest.NewProber()
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	source := net.ParseIP("192.168.1.10")
+	scraper.sources.source = func(net.IP) (net.IP, error) {
+		return source, nil
+	}
+
+	ld, err := scraper.scrapeLogs(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, ld.LogRecordCount())
+	scraper.mu.RLock()
+	pinger := scraper.pingers["192.0.2.1"]
+	scraper.mu.RUnlock()
+
+	source = net.ParseIP("192.168.1.23")
+	ld, err = scraper.scrapeLogs(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, ld.LogRecordCount())
+	lr := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, sourceChanged, lr.EventName())
+	assert.Equal(t, "Source address changed", lr.Body().Str())
+	assert.Equal(t, map[string]any{
+		"net.peer.name":   "192.0.2.1",
+		"source.previous": "192.168.1.10",
+		"source.current":  "192.168.1.23",
+	}, lr.Attributes().AsRaw())
+
+	// The pingers were recreated and the target probed with the new ones
+	scraper.mu.RLock()
+	defer scraper.mu.RUnlock()
+	assert.NotSame(t, pinger, scraper.pingers["192.0.2.1"])
+	assert.Len(t, scraper.variants, 1)
+	// Regular and 512 byte probes of both scrapes
+	assert.Equal(t, 4, fakeProber.Runs("192.0.2.1"))
+}
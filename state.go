@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+)
+
+// recordState records the consecutive failures of target, whether it went up
+// or down with its probe, so alerts can wait for several failed scrapes, and
+// how long it was down
+func (s *pingScraper) recordState(mb *metadata.MetricsBuilder, metrics metadata.MetricsConfig, target Target, o *probeOutcome) {
+	now := pcommon.NewTimestampFromTime(s.clock.Now())
+	if metrics.PingConsecutiveFailures.Enabled {
+		mb.RecordPingConsecutiveFailuresDataPoint(now, int64(o.failures), target.Endpoint)
+	}
+	if metrics.PingStateTransitions.Enabled {
+		var val int64
+		if o.transitioned {
+			val = 1
+		}
+		mb.RecordPingStateTransitionsDataPoint(now, val, target.Endpoint)
+	}
+	if metrics.PingDowntime.Enabled {
+		mb.RecordPingDowntimeDataPoint(now, s.reportedDowntime(o).total.Seconds(), target.Endpoint)
+	}
+}
+
+// downtimeOf returns the total downtime of endpoint, and the share of its
+// last observation
+func (s *pingScraper) downtimeOf(endpoint string) (downtime, downtime) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	state, ok := s.states[endpoint]
+	if !ok {
+		return downtime{}, downtime{}
+	}
+	return state.downtime, state.lastDowntime
+}
+
+// reportedDowntime returns the downtime the data points of o carry, deltas
+// only carrying that since the previous probe
+func (s *pingScraper) reportedDowntime(o *probeOutcome) downtime {
+	if s.cfg.CounterTemporality == temporalityDelta {
+		return o.lastDowntime
+	}
+	return o.downtime
+}
+
+// skipDowntime restarts the downtime accounting of endpoint, for probes left
+// out such as those during holidays, whose time is not counted as downtime
+func (s *pingScraper) skipDowntime(endpoint string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if state, ok := s.states[endpoint]; ok && state.observed {
+		state.observedAt = s.clock.Now()
+	}
+}
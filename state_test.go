@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/scraper/scraperhelper"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+func TestScraperState(t *testing.T) {
+	cfg := &Config{
+		ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Targets:              []Target{{Endpoint: "192.0.2.1"}},
+	}
+	cfg.Metrics.PingConsecutiveFailures.Enabled = true
+	cfg.Metrics.PingStateTransitions.Enabled = true
+
+	fakeProber := pingchecktest.NewProber()
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	up := prober.Statistics{PacketsSent: 4, PacketsRecv: 4}
+	down := prober.Statistics{PacketsSent: 4}
+
+	type point struct {
+		failures    int64
+		transitions int64
+	}
+	var got []point
+	for _, stats := range []prober.Statistics{up, down, down, down, up, up} {
+		fakeProber.SetResult("192.0.2.1", stats)
+		metrics, err := scraper.scrapeTarget(context.Background(), 0)
+		require.NoError(t, err)
+
+		var p point
+		ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+		for i := 0; i < ms.Len(); i++ {
+			switch ms.At(i).Name() {
+			case "ping.consecutive_failures":
+				p.failures = ms.At(i).Gauge().DataPoints().At(0).IntValue()
+			case "ping.state_transitions":
+				assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+				p.transitions = ms.At(i).Sum().DataPoints().At(0).IntValue()
+			}
+		}
+		got = append(got, p)
+	}
+
+	// The first scrape is no transition, there is nothing to compare it with
+	assert.Equal(t, []point{{0, 0}, {1, 1}, {2, 0}, {3, 0}, {0, 1}, {0, 0}}, got)
+}
+
+func TestUpdateStateTransitions(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions())
+
+	failures, transitioned := scraper.updateState("192.0.2.1", true)
+	assert.Equal(t, 1, failures)
+	assert.False(t, transitioned)
+
+	failures, transitioned = scraper.updateState("192.0.2.1", false)
+	assert.Equal(t, 0, failures)
+	assert.True(t, transitioned)
+
+	// Targets are tracked separately
+	_, transitioned = scraper.updateState("192.0.2.2", false)
+	assert.False(t, transitioned)
+}
+
+func TestScraperDowntime(t *testing.T) {
+	for _, temporality := range []string{temporalityCumulative, temporalityDelta} {
+		t.Run(temporality, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.CounterTemporality = temporality
+			cfg.Metrics.PingDowntime.Enabled = true
+			cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4}}
+
+			clock := pingchecktest.NewClock(time.Unix(1_700_000_000, 0))
+			fakeProber := pingchecktest.NewProber()
+			scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type),
+				newFactoryOptions(WithProber(fakeProber), WithClock(clock)))
+			require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+			defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+			up := prober.Statistics{PacketsSent: 4, PacketsRecv: 4}
+			down := prober.Statistics{PacketsSent: 4}
+			var got []float64
+			for _, stats := range []prober.Statistics{down, down, down, up, down} {
+				fakeProber.SetResult("192.0.2.1", stats)
+				md, err := scraper.scrapeTarget(context.Background(), 0)
+				require.NoError(t, err)
+				forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+					if m.Name() == "ping.downtime" {
+						got = append(got, m.Sum().DataPoints().At(0).DoubleValue())
+					}
+				})
+				clock.Advance(time.Minute)
+			}
+
+			// The first scrape has no interval before it to count
+			if temporality == temporalityDelta {
+				assert.Equal(t, []float64{0, 60, 60, 0, 60}, got)
+			} else {
+				assert.Equal(t, []float64{0, 60, 120, 120, 180}, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+)
+
+// recordSweep records the results of target's size sweep into mb
+func (s *pingScraper) recordSweep(mb *metadata.MetricsBuilder, metrics metadata.MetricsConfig, target Target, results []variantResult) {
+	for _, r := range results {
+		// Variants of the size sweep are the only ones with a size
+		if r.variant.size == 0 {
+			continue
+		}
+		now := pcommon.NewTimestampFromTime(r.finished)
+		ip := s.ips.String(r.stats.IPAddr)
+		size := int64(r.variant.size)
+		if r.stats.PacketsRecv > 0 && metrics.PingSizeSweepDuration.Enabled {
+			mb.RecordPingSizeSweepDurationDataPoint(now, milliseconds(r.stats.AvgRtt), target.Endpoint, ip, ipVersion(r.stats.IPAddr), size)
+		}
+		if metrics.PingSizeSweepPacketLoss.Enabled {
+			mb.RecordPingSizeSweepPacketLossDataPoint(now, r.stats.PacketLoss/100.0, target.Endpoint, ip, ipVersion(r.stats.IPAddr), size)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// sizeProber answers with a round-trip time of a millisecond per 100 bytes
+// of payload, and loses every packet larger than maxSize
+type sizeProber struct {
+	maxSize int
+
+	mu    sync.Mutex
+	sizes []int
+}
+
+func (p *sizeProber) NewPinger(cfg prober.PingerConfig) (prober.Pinger, error) {
+	if cfg.Size > 8000 {
+		return nil, errors.New("message too long")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sizes = append(p.sizes, cfg.Size)
+	return &sizePinger{cfg: cfg, maxSize: p.maxSize}, nil
+}
+
+type sizePinger struct {
+	cfg     prober.PingerConfig
+	maxSize int
+}
+
+func (p *sizePinger) Run(context.Context) (*prober.Statistics, error) {
+	stats := &prober.Statistics{IPAddr: &net.IPAddr{IP: net.ParseIP(p.cfg.Endpoint)}, PacketsSent: 4}
+	if p.cfg.Size <= p.maxSize {
+		stats.PacketsRecv = 4
+		stats.AvgRtt = time.Duration(max(p.cfg.Size, prober.MinSize)) * time.Millisecond / 100
+	} else {
+		stats.PacketLoss = 100
+	}
+	return stats, nil
+}
+
+func (p *sizePinger) Stop() {}
+
+func TestScraperSizeSweep(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4, PacketSizes: []int{150, 1400, 1500, 9000}}}
+
+	p := &sizeProber{maxSize: 1400}
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(p)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	for range 2 {
+		md, err := scraper.scrapeTarget(context.Background(), 0)
+		require.NoError(t, err)
+
+		durations := make(map[int64]float64)
+		losses := make(map[int64]float64)
+		ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+		for i := 0; i < ms.Len(); i++ {
+			var values map[int64]float64
+			switch ms.At(i).Name() {
+			case "ping.size_sweep.duration":
+				values = durations
+			case "ping.size_sweep.packet_loss":
+				values = losses
+			default:
+				continue
+			}
+			dps := ms.At(i).Gauge().DataPoints()
+			for j := 0; j < dps.Len(); j++ {
+				size, _ := dps.At(j).Attributes().Get("packet.size")
+				values[size.Int()] = dps.At(j).DoubleValue()
+			}
+		}
+		// The size no pinger could be created for is left out
+		assert.Equal(t, map[int64]float64{150: 1.5, 1400: 14}, durations)
+		assert.Equal(t, map[int64]float64{150: 0, 1400: 0, 1500: 1}, losses)
+	}
+
+	// Pingers of the sweep are created once and stopped on shutdown
+	require.NoError(t, scraper.shutdown(context.Background()))
+	assert.ElementsMatch(t, []int{0, 150, 1400, 1500}, p.sizes)
+	assert.Nil(t, scraper.variants)
+}
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// setDeltaTemporality marks the sums and histograms of md as deltas since
+// start. The counts recorded for a probe only cover that probe, so they are
+// deltas already.
+func setDeltaTemporality(md pmetric.Metrics, start pcommon.Timestamp) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				switch ms.At(k).Type() {
+				case pmetric.MetricTypeSum:
+					sum := ms.At(k).Sum()
+					sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+					for l := 0; l < sum.DataPoints().Len(); l++ {
+						sum.DataPoints().At(l).SetStartTimestamp(start)
+					}
+				case pmetric.MetricTypeHistogram:
+					histogram := ms.At(k).Histogram()
+					histogram.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+					for l := 0; l < histogram.DataPoints().Len(); l++ {
+						histogram.DataPoints().At(l).SetStartTimestamp(start)
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					histogram := ms.At(k).ExponentialHistogram()
+					histogram.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+					for l := 0; l < histogram.DataPoints().Len(); l++ {
+						histogram.DataPoints().At(l).SetStartTimestamp(start)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+)
+
+func TestScraperDeltaTemporality(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4}}
+	cfg.CounterTemporality = temporalityDelta
+
+	started := time.Unix(1_700_000_000, 0)
+	clock := pingchecktest.NewClock(started)
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type),
+		newFactoryOptions(WithProber(pingchecktest.NewProber()), WithClock(clock)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	// sentPoint returns the ping.packets.sent data point of a scrape
+	sentPoint := func() (pmetric.Sum, pmetric.NumberDataPoint) {
+		clock.Advance(time.Minute)
+		md, err := scraper.scrapeTarget(context.Background(), 0)
+		require.NoError(t, err)
+		ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+		for i := 0; i < ms.Len(); i++ {
+			if ms.At(i).Name() == "ping.packets.sent" {
+				return ms.At(i).Sum(), ms.At(i).Sum().DataPoints().At(0)
+			}
+		}
+		require.Fail(t, "ping.packets.sent not found")
+		return pmetric.Sum{}, pmetric.NumberDataPoint{}
+	}
+
+	sum, dp := sentPoint()
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, sum.AggregationTemporality())
+	assert.Equal(t, pcommon.NewTimestampFromTime(started), dp.StartTimestamp())
+	assert.Equal(t, int64(4), dp.IntValue())
+
+	// The next window starts where the previous one ended
+	_, dp = sentPoint()
+	assert.Equal(t, pcommon.NewTimestampFromTime(started.Add(time.Minute)), dp.StartTimestamp())
+	assert.Equal(t, int64(4), dp.IntValue())
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// weekdays maps the day names of time windows to weekdays
+var weekdays = map[string]time.Weekday{
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+	"sun": time.Sunday,
+}
+
+// parseTimeOfDay parses HH:MM into the time since midnight
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a time of day as HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// contains reports whether t, in the time zone windows are written in, is
+// within w. A window ending before it starts runs past midnight and belongs
+// to the day it starts on, so fri 22:00 to 02:00 includes early saturday.
+func (w *TimeWindow) contains(t time.Time) bool {
+	// Validated with the config
+	start, _ := parseTimeOfDay(w.Start)
+	end, _ := parseTimeOfDay(w.End)
+
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	sinceMidnight := t.Sub(midnight)
+	day := t.Weekday()
+	switch {
+	case start < end:
+		if sinceMidnight < start || sinceMidnight >= end {
+			return false
+		}
+	case start > end:
+		if sinceMidnight < start && sinceMidnight >= end {
+			return false
+		}
+		if sinceMidnight < end {
+			day = (day + 6) % 7 // Started the day before
+		}
+	}
+	// Equal start and end spans the whole day
+	return len(w.Days) == 0 || slices.ContainsFunc(w.Days, func(d string) bool {
+		return weekdays[d] == day
+	})
+}
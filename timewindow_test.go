@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeWindowContains(t *testing.T) {
+	// 2024-01-05 is a Friday
+	at := func(day int, hour, minute int) time.Time {
+		return time.Date(2024, 1, day, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		window   TimeWindow
+		t        time.Time
+		expected bool
+	}{
+		{name: "within", window: TimeWindow{Start: "09:00", End: "17:00"}, t: at(5, 9, 0), expected: true},
+		{name: "at end", window: TimeWindow{Start: "09:00", End: "17:00"}, t: at(5, 17, 0), expected: false},
+		{name: "before start", window: TimeWindow{Start: "09:00", End: "17:00"}, t: at(5, 8, 59), expected: false},
+		{name: "weekday", window: TimeWindow{Days: []string{"mon", "fri"}, Start: "09:00", End: "17:00"}, t: at(5, 12, 0), expected: true},
+		{name: "other day", window: TimeWindow{Days: []string{"mon", "fri"}, Start: "09:00", End: "17:00"}, t: at(6, 12, 0), expected: false},
+		{name: "overnight evening", window: TimeWindow{Days: []string{"fri"}, Start: "22:00", End: "02:00"}, t: at(5, 23, 0), expected: true},
+		{name: "overnight next morning", window: TimeWindow{Days: []string{"fri"}, Start: "22:00", End: "02:00"}, t: at(6, 1, 0), expected: true},
+		{name: "overnight morning of start day", window: TimeWindow{Days: []string{"fri"}, Start: "22:00", End: "02:00"}, t: at(5, 1, 0), expected: false},
+		{name: "overnight midday", window: TimeWindow{Start: "22:00", End: "02:00"}, t: at(5, 12, 0), expected: false},
+		{name: "whole day", window: TimeWindow{Days: []string{"sat"}, Start: "00:00", End: "00:00"}, t: at(6, 15, 0), expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.window.validate())
+			assert.Equal(t, tt.expected, tt.window.contains(tt.t))
+		})
+	}
+}
+
+func TestTimeWindowValidate(t *testing.T) {
+	w := TimeWindow{Days: []string{"monday"}, Start: "9am", End: "25:00"}
+	assert.EqualError(t, w.validate(), `unknown day "monday", expected mon to sun; `+
+		`start: "9am" is not a time of day as HH:MM; end: "25:00" is not a time of day as HH:MM`)
+}
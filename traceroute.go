@@ -0,0 +1,336 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	"github.com/lukeod/pingcheckreceiver/internal/asn"
+	"github.com/lukeod/pingcheckreceiver/internal/icmpext"
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// Defaults and limits of traceroute
+const (
+	defaultTracerouteInterval     = 5 * time.Minute
+	defaultTracerouteMaxHops      = 30
+	defaultTracerouteProbesPerHop = 3
+	defaultTracerouteHopTimeout   = time.Second
+	maxTracerouteProbesPerHop     = 10
+)
+
+// Values of traceroute.asn
+const (
+	asnCymru    = "cymru"
+	asnDatabase = "database"
+)
+
+// tracerouteEvent is the event of log records describing a traced path
+const tracerouteEvent = "traceroute"
+
+var errTraceUnsupported = errors.New("the prober does not support traceroute")
+
+// traceResult is a traceroute of a target
+type traceResult struct {
+	trace *prober.Trace
+
+	// Origin AS of each hop address, if annotated, see traceroute.asn
+	asns map[netip.Addr]uint32
+}
+
+// asn returns the origin AS of the address that answered hop, if annotated
+func (r *traceResult) asn(hop prober.Hop) (uint32, bool) {
+	if hop.Addr == nil {
+		return 0, false
+	}
+	addr, ok := netip.AddrFromSlice(hop.Addr.IP)
+	if !ok {
+		return 0, false
+	}
+	n, ok := r.asns[addr.Unmap()]
+	return n, ok
+}
+
+// newASNResolvers returns the resolvers of the endpoints whose traceroute
+// asks for ASNs. Databases are read once, even if shared by endpoints.
+func newASNResolvers(targets []Target) (map[string]asn.Resolver, error) {
+	resolvers := make(map[string]asn.Resolver)
+	databases := make(map[string]*asn.Database)
+	var cymru *asn.Cymru
+	for _, target := range targets {
+		cfg := target.Traceroute
+		if cfg == nil {
+			continue
+		}
+		switch cfg.ASN {
+		case asnCymru:
+			if cymru == nil {
+				cymru = asn.NewCymru(nil)
+			}
+			resolvers[target.Endpoint] = cymru
+		case asnDatabase:
+			db, ok := databases[cfg.ASNDatabase]
+			if !ok {
+				var err error
+				if db, err = asn.LoadDatabase(cfg.ASNDatabase); err != nil {
+					return nil, err
+				}
+				databases[cfg.ASNDatabase] = db
+			}
+			resolvers[target.Endpoint] = db
+		}
+	}
+	return resolvers, nil
+}
+
+// checkTrace starts a traceroute of target in the background once its
+// interval elapsed since the previous, and returns the result of one that
+// finished since the last call, if any
+func (s *pingScraper) checkTrace(target Target) *traceResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := s.stateLocked(target.Endpoint)
+	result := state.trace
+	state.trace = nil
+
+	now := s.clock.Now()
+	interval := cmp.Or(target.Traceroute.Interval, defaultTracerouteInterval)
+	if !state.tracing && (state.tracedAt.IsZero() || now.Sub(state.tracedAt) >= interval) {
+		state.tracing, state.tracedAt = true, now
+		s.bgWG.Add(1)
+		go func() {
+			defer s.bgWG.Done()
+			defer s.recoverTrace(target.Endpoint)
+			s.traceroute(target)
+		}()
+	}
+	return result
+}
+
+// traceroute traces the path to target within the shared budget, and keeps
+// the result for the next probe to report
+func (s *pingScraper) traceroute(target Target) {
+	result, err := s.runTrace(target)
+	if s.bgCtx.Err() != nil {
+		// Shut down meanwhile
+		return
+	}
+	if err != nil {
+		s.logger.Warn("Traceroute failed",
+			zap.String("endpoint", target.Endpoint),
+			zap.Error(err))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := s.stateLocked(target.Endpoint)
+	state.tracing = false
+	if result != nil {
+		state.trace = result
+	}
+}
+
+// recoverTrace recovers a panic of the traceroute of endpoint, which runs
+// outside of its probe, so the next traceroute may start
+func (s *pingScraper) recoverTrace(endpoint string) {
+	if r := recover(); r != nil {
+		_ = s.recovered(endpoint, r)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.stateLocked(endpoint).tracing = false
+	}
+}
+
+// trace traces the path to target within the shared budget, with the
+// traceroute settings of target or the defaults if it has none
+func (s *pingScraper) trace(ctx context.Context, target Target) (*prober.Trace, error) {
+	tracer, ok := s.prober.(prober.Tracer)
+	if !ok {
+		return nil, errTraceUnsupported
+	}
+	var cfg TracerouteConfig
+	if target.Traceroute != nil {
+		cfg = *target.Traceroute
+	}
+	maxHops := cmp.Or(cfg.MaxHops, defaultTracerouteMaxHops)
+	queries := cmp.Or(cfg.ProbesPerHop, defaultTracerouteProbesPerHop)
+	release, err := s.budget.acquire(ctx, maxHops*queries)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return tracer.Trace(ctx, prober.TraceConfig{
+		Endpoint:   target.Endpoint,
+		IPAddr:     s.resolvedAddr(target.Endpoint),
+		Network:    target.probeNetwork(),
+		Privileged: s.privileged(),
+		MaxHops:    maxHops,
+		Queries:    queries,
+		Timeout:    cmp.Or(cfg.HopTimeout, defaultTracerouteHopTimeout),
+		Logger:     s.logger,
+	})
+}
+
+// diagnosticTracer returns the tracer of the diagnostics of endpoint, with the
+// traceroute settings of its first target, nil if the prober cannot trace
+func (s *pingScraper) diagnosticTracer(endpoint string) tracer {
+	if _, ok := s.prober.(prober.Tracer); !ok {
+		return nil
+	}
+	target := Target{Endpoint: endpoint}
+	for _, t := range s.cfg.Targets {
+		if t.Endpoint == endpoint {
+			target = t
+			break
+		}
+	}
+	return func(ctx context.Context) (trace *prober.Trace, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				trace, err = nil, s.recovered(endpoint, r)
+			}
+		}()
+		return s.trace(ctx, target)
+	}
+}
+
+// runTrace traces the path to target and annotates its hops
+func (s *pingScraper) runTrace(target Target) (*traceResult, error) {
+	trace, err := s.trace(s.bgCtx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &traceResult{trace: trace}
+	resolver, ok := s.asnResolvers[target.Endpoint]
+	if !ok {
+		return result, nil
+	}
+	result.asns = make(map[netip.Addr]uint32)
+	for _, hop := range trace.Hops {
+		if hop.Addr == nil {
+			continue
+		}
+		addr, ok := netip.AddrFromSlice(hop.Addr.IP)
+		if !ok {
+			continue
+		}
+		addr = addr.Unmap()
+		if _, seen := result.asns[addr]; seen {
+			continue
+		}
+		if n, err := resolver.Lookup(s.bgCtx, addr); err == nil {
+			result.asns[addr] = n
+		}
+	}
+	return result, nil
+}
+
+// recordTraceroute records the hops of the traceroute of target, if one finished
+func (s *pingScraper) recordTraceroute(mb *metadata.MetricsBuilder, metrics metadata.MetricsConfig, target Target, o *probeOutcome) {
+	if o.trace == nil {
+		return
+	}
+	now := pcommon.NewTimestampFromTime(s.clock.Now())
+	trace := o.trace.trace
+	if metrics.PingTracerouteHopCount.Enabled {
+		mb.RecordPingTracerouteHopCountDataPoint(now, int64(len(trace.Hops)), target.Endpoint, trace.Reached)
+	}
+	for _, hop := range trace.Hops {
+		ip := ""
+		if hop.Addr != nil {
+			ip = s.ips.String(hop.Addr)
+		}
+		var options []metadata.MetricAttributeOption
+		if n, ok := o.trace.asn(hop); ok {
+			options = append(options, metadata.WithTracerouteHopAsnMetricAttribute(int64(n)))
+		}
+		if labels := mplsLabels(trace, hop); labels != "" {
+			options = append(options, metadata.WithTracerouteHopMplsLabelsMetricAttribute(labels))
+		}
+		if metrics.PingTracerouteHopDuration.Enabled && len(hop.Rtts) > 0 {
+			var total time.Duration
+			for _, rtt := range hop.Rtts {
+				total += rtt
+			}
+			mb.RecordPingTracerouteHopDurationDataPoint(now, milliseconds(total/time.Duration(len(hop.Rtts))), target.Endpoint, int64(hop.TTL), ip, options...)
+		}
+		if metrics.PingTracerouteHopPacketLoss.Enabled && hop.Sent > 0 {
+			loss := 1 - float64(len(hop.Rtts))/float64(hop.Sent)
+			mb.RecordPingTracerouteHopPacketLossDataPoint(now, loss, target.Endpoint, int64(hop.TTL), ip, options...)
+		}
+	}
+}
+
+// mplsLabels returns the MPLS label stack quoted by hop of trace, empty if
+// its reply quoted none
+func mplsLabels(trace *prober.Trace, hop prober.Hop) string {
+	if hop.Reply == nil {
+		return ""
+	}
+	proto := icmpext.ProtocolICMP
+	if ipVersion(trace.IPAddr) == 6 {
+		proto = icmpext.ProtocolIPv6ICMP
+	}
+	labels, err := icmpext.Labels(proto, hop.Reply)
+	if err != nil || len(labels) == 0 {
+		return ""
+	}
+	return icmpext.FormatLabels(labels)
+}
+
+// recordTracerouteLog describes in lr the path traced to target, a line per
+// hop like traceroute prints it
+func (s *pingScraper) recordTracerouteLog(lr plog.LogRecord, target Target, result *traceResult, observed pcommon.Timestamp) {
+	lr.SetObservedTimestamp(observed)
+	lr.SetTimestamp(observed)
+	lr.SetSeverityNumber(plog.SeverityNumberInfo)
+	lr.SetSeverityText(plog.SeverityNumberInfo.String())
+	lr.SetEventName(tracerouteEvent)
+
+	trace := result.trace
+	var body strings.Builder
+	fmt.Fprintf(&body, "traceroute to %s (%s), %d hops", target.Endpoint, trace.IPAddr, len(trace.Hops))
+	for _, hop := range trace.Hops {
+		fmt.Fprintf(&body, "\n%2d  ", hop.TTL)
+		if hop.Addr == nil {
+			body.WriteString("*")
+			continue
+		}
+		body.WriteString(s.ips.String(hop.Addr))
+		if n, ok := result.asn(hop); ok {
+			fmt.Fprintf(&body, " [AS%d]", n)
+		}
+		for _, rtt := range hop.Rtts {
+			fmt.Fprintf(&body, "  %.3f ms", milliseconds(rtt))
+		}
+		for range hop.Sent - len(hop.Rtts) {
+			body.WriteString("  *")
+		}
+		if labels := mplsLabels(trace, hop); labels != "" {
+			fmt.Fprintf(&body, " <MPLS:%s>", labels)
+		}
+	}
+	lr.Body().SetStr(body.String())
+
+	attrs := lr.Attributes()
+	attrs.PutStr("net.peer.name", target.Endpoint)
+	if trace.IPAddr != nil {
+		attrs.PutStr("net.peer.ip", s.ips.String(trace.IPAddr))
+	}
+	attrs.PutBool("traceroute.reached", trace.Reached)
+	attrs.PutInt("ping.traceroute.hop_count", int64(len(trace.Hops)))
+}
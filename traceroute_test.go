@@ -0,0 +1,302 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// testTrace is a path of three hops whose second never answered
+var testTrace = prober.Trace{
+	IPAddr: &net.IPAddr{IP: net.ParseIP("192.0.2.1")},
+	Hops: []prober.Hop{
+		{TTL: 1, Addr: &net.IPAddr{IP: net.ParseIP("198.51.100.1")}, Sent: 3, Rtts: []time.Duration{time.Millisecond, 3 * time.Millisecond}},
+		{TTL: 2, Sent: 3},
+		{TTL: 3, Addr: &net.IPAddr{IP: net.ParseIP("192.0.2.1")}, Sent: 3, Rtts: []time.Duration{10 * time.Millisecond, 10 * time.Millisecond, 10 * time.Millisecond}},
+	},
+	Reached: true,
+}
+
+// hopValues returns the values of the data points of metric in md by hop,
+// and their hop IPs
+func hopValues(md pmetric.Metrics, metric string) (map[int64]float64, map[int64]string) {
+	values, ips := make(map[int64]float64), make(map[int64]string)
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		if m.Name() != metric {
+			return
+		}
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			hop, _ := dps.At(i).Attributes().Get("traceroute.hop")
+			ip, _ := dps.At(i).Attributes().Get("traceroute.hop.ip")
+			values[hop.Int()] = dps.At(i).DoubleValue()
+			ips[hop.Int()] = ip.Str()
+		}
+	})
+	return values, ips
+}
+
+// hopCount returns the ping.traceroute.hop_count data point of md, if any
+func hopCount(md pmetric.Metrics) (int64, bool, bool) {
+	var count int64
+	var reached, found bool
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		if m.Name() == "ping.traceroute.hop_count" {
+			dp := m.Gauge().DataPoints().At(0)
+			attr, _ := dp.Attributes().Get("traceroute.reached")
+			count, reached, found = dp.IntValue(), attr.Bool(), true
+		}
+	})
+	return count, reached, found
+}
+
+func TestScraperTraceroute(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Privileged = true
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4, Traceroute: &TracerouteConfig{}}}
+
+	clock := pingchecktest.NewClock(time.Unix(1_700_000_000, 0))
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetTrace("192.0.2.1", testTrace)
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber), WithClock(clock)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	// The trace runs in the background, the probe after it reports it
+	md, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	_, _, found := hopCount(md)
+	assert.False(t, found)
+	scraper.bgWG.Wait()
+
+	traceCfg, ok := fakeProber.TraceConfig("192.0.2.1")
+	require.True(t, ok)
+	assert.Equal(t, defaultTracerouteMaxHops, traceCfg.MaxHops)
+	assert.Equal(t, defaultTracerouteProbesPerHop, traceCfg.Queries)
+	assert.Equal(t, defaultTracerouteHopTimeout, traceCfg.Timeout)
+	assert.True(t, traceCfg.Privileged)
+
+	md, err = scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	count, reached, found := hopCount(md)
+	require.True(t, found)
+	assert.Equal(t, int64(3), count)
+	assert.True(t, reached)
+
+	durations, ips := hopValues(md, "ping.traceroute.hop.duration")
+	assert.Equal(t, map[int64]float64{1: 2, 3: 10}, durations)
+	assert.Equal(t, map[int64]string{1: "198.51.100.1", 3: "192.0.2.1"}, ips)
+	losses, ips := hopValues(md, "ping.traceroute.hop.packet_loss")
+	assert.InDeltaMapValues(t, map[int64]float64{1: 1.0 / 3, 2: 1, 3: 0}, losses, 1e-9)
+	assert.Empty(t, ips[2])
+
+	// Each trace is reported once, and the next waits for the interval
+	fakeProber.SetTrace("192.0.2.1", prober.Trace{IPAddr: testTrace.IPAddr, Hops: testTrace.Hops[:1]})
+	md, err = scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	scraper.bgWG.Wait()
+	_, _, found = hopCount(md)
+	assert.False(t, found)
+
+	clock.Advance(defaultTracerouteInterval)
+	_, err = scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	scraper.bgWG.Wait()
+	md, err = scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	count, reached, found = hopCount(md)
+	require.True(t, found)
+	assert.Equal(t, int64(1), count)
+	assert.False(t, reached)
+}
+
+// mplsReply returns a time-exceeded message quoting the MPLS label stack labels
+func mplsReply(t *testing.T, labels ...icmp.MPLSLabel) []byte {
+	msg := icmp.Message{
+		Type: ipv4.ICMPTypeTimeExceeded,
+		Body: &icmp.TimeExceeded{
+			Data:       make([]byte, 28),
+			Extensions: []icmp.Extension{&icmp.MPLSLabelStack{Class: 1, Type: 1, Labels: labels}},
+		},
+	}
+	b, err := msg.Marshal(nil)
+	require.NoError(t, err)
+	return b
+}
+
+func TestScraperTracerouteMPLS(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Privileged = true
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4, Traceroute: &TracerouteConfig{}}}
+
+	trace := testTrace
+	trace.Hops = slices.Clone(testTrace.Hops)
+	trace.Hops[0].Reply = mplsReply(t, icmp.MPLSLabel{Label: 24001, S: true, TTL: 1})
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetTrace("192.0.2.1", trace)
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	_, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	scraper.bgWG.Wait()
+	md, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+
+	// Only the hops that quoted a label stack carry it
+	labels := make(map[int64]string)
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		if m.Name() != "ping.traceroute.hop.packet_loss" {
+			return
+		}
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			hop, _ := dps.At(i).Attributes().Get("traceroute.hop")
+			if v, ok := dps.At(i).Attributes().Get("traceroute.hop.mpls_labels"); ok {
+				labels[hop.Int()] = v.Str()
+			}
+		}
+	})
+	assert.Equal(t, map[int64]string{1: "L=24001,TC=0,S=1,TTL=1"}, labels)
+}
+
+func TestScraperTracerouteASN(t *testing.T) {
+	database := filepath.Join(t.TempDir(), "asns.txt")
+	require.NoError(t, os.WriteFile(database, []byte("198.51.100.0/24 64500\n"), 0o600))
+	cfg := createDefaultConfig().(*Config)
+	cfg.Privileged = true
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4, Traceroute: &TracerouteConfig{
+		ASN:         asnDatabase,
+		ASNDatabase: database,
+	}}}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetTrace("192.0.2.1", testTrace)
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	_, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	scraper.bgWG.Wait()
+	md, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+
+	// Only the hops of a known origin AS carry it
+	asns := make(map[int64]int64)
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		if m.Name() != "ping.traceroute.hop.packet_loss" {
+			return
+		}
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			hop, _ := dps.At(i).Attributes().Get("traceroute.hop")
+			if v, ok := dps.At(i).Attributes().Get("traceroute.hop.asn"); ok {
+				asns[hop.Int()] = v.Int()
+			}
+		}
+	})
+	assert.Equal(t, map[int64]int64{1: 64500}, asns)
+}
+
+func TestScraperTracerouteError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Privileged = true
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4, Traceroute: &TracerouteConfig{Log: true}}}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetTraceError("192.0.2.1", prober.ErrTraceUnprivileged)
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	// Failed traces leave the probe itself alone
+	_, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	scraper.bgWG.Wait()
+	md, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	_, _, found := hopCount(md)
+	assert.False(t, found)
+	assert.Positive(t, md.MetricCount())
+}
+
+func TestScrapeLogsTraceroute(t *testing.T) {
+	database := filepath.Join(t.TempDir(), "asns.txt")
+	require.NoError(t, os.WriteFile(database, []byte("198.51.100.0/24 64500\n"), 0o600))
+	cfg := createDefaultConfig().(*Config)
+	cfg.Privileged = true
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4, Traceroute: &TracerouteConfig{
+		Log:         true,
+		ASN:         asnDatabase,
+		ASNDatabase: database,
+	}}}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetTrace("192.0.2.1", testTrace)
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	ld, err := scraper.scrapeLogs(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, ld.LogRecordCount())
+	scraper.bgWG.Wait()
+
+	ld, err = scraper.scrapeLogs(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, ld.LogRecordCount())
+	lr := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, tracerouteEvent, lr.EventName())
+	assert.Equal(t, "traceroute to 192.0.2.1 (192.0.2.1), 3 hops\n"+
+		" 1  198.51.100.1 [AS64500]  1.000 ms  3.000 ms  *\n"+
+		" 2  *\n"+
+		" 3  192.0.2.1  10.000 ms  10.000 ms  10.000 ms", lr.Body().Str())
+	assert.Equal(t, map[string]any{
+		"net.peer.name":             "192.0.2.1",
+		"net.peer.ip":               "192.0.2.1",
+		"traceroute.reached":        true,
+		"ping.traceroute.hop_count": int64(3),
+	}, lr.Attributes().AsRaw())
+}
+
+func TestNewASNResolvers(t *testing.T) {
+	database := filepath.Join(t.TempDir(), "asns.txt")
+	require.NoError(t, os.WriteFile(database, []byte("198.51.100.0/24 64500\n"), 0o600))
+
+	resolvers, err := newASNResolvers([]Target{
+		{Endpoint: "192.0.2.1", Traceroute: &TracerouteConfig{ASN: asnDatabase, ASNDatabase: database}},
+		{Endpoint: "192.0.2.2", Traceroute: &TracerouteConfig{ASN: asnDatabase, ASNDatabase: database}},
+		{Endpoint: "192.0.2.3", Traceroute: &TracerouteConfig{ASN: asnCymru}},
+		{Endpoint: "192.0.2.4", Traceroute: &TracerouteConfig{}},
+		{Endpoint: "192.0.2.5"},
+	})
+	require.NoError(t, err)
+	assert.Len(t, resolvers, 3)
+	assert.Same(t, resolvers["192.0.2.1"], resolvers["192.0.2.2"])
+
+	_, err = newASNResolvers([]Target{
+		{Endpoint: "192.0.2.1", Traceroute: &TracerouteConfig{ASN: asnDatabase, ASNDatabase: filepath.Join(t.TempDir(), "missing.txt")}},
+	})
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
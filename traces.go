@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"encoding/binary"
+	"math/rand/v2"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.uber.org/zap"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+)
+
+// Names of the spans of a probe and of its packets
+const (
+	probeSpanName  = "ping"
+	packetSpanName = "ping.packet"
+)
+
+// tracesReceiver reports a span for every probe at the collection interval.
+// The scraper helper does not drive traces, so it runs its own loop.
+type tracesReceiver struct {
+	cfg      *Config
+	logger   *zap.Logger
+	scraper  *pingScraper
+	release  func(context.Context) error
+	consumer consumer.Traces
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Start starts the scraper, and scrapes after the initial delay and then
+// every collection interval
+func (r *tracesReceiver) Start(ctx context.Context, host component.Host) error {
+	if err := r.scraper.start(ctx, host); err != nil {
+		return err
+	}
+	runCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done = make(chan struct{})
+	go r.run(runCtx)
+	return nil
+}
+
+func (r *tracesReceiver) run(ctx context.Context) {
+	defer close(r.done)
+
+	delay := time.NewTimer(r.cfg.InitialDelay)
+	defer delay.Stop()
+	select {
+	case <-ctx.Done():
+		return
+	case <-delay.C:
+	}
+
+	ticker := time.NewTicker(r.cfg.CollectionInterval)
+	defer ticker.Stop()
+	for {
+		r.scrape(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scrape reports the spans of one collection, bounded by the scrape timeout
+func (r *tracesReceiver) scrape(ctx context.Context) {
+	if r.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.cfg.Timeout)
+		defer cancel()
+	}
+	td := r.scraper.scrapeTraces(ctx)
+	if td.SpanCount() == 0 {
+		return
+	}
+	if err := r.consumer.ConsumeTraces(ctx, td); err != nil {
+		r.logger.Error("Failed to consume traces", zap.Error(err))
+	}
+}
+
+// Shutdown stops scraping and releases the scraper
+func (r *tracesReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+		<-r.done
+	}
+	return r.release(ctx)
+}
+
+// scrapeTraces reports a span for the latest probe of every target, with a
+// child span for each of its packets if packet_spans is enabled. Probes are
+// shared with the receivers of other signals of the same config.
+func (s *pingScraper) scrapeTraces(ctx context.Context) ptrace.Traces {
+	outcomes := s.latestOutcomes(ctx, pipeline.SignalTraces)
+	td := ptrace.NewTraces()
+	for i, o := range outcomes {
+		if o == nil || o.suppressed {
+			continue
+		}
+		rs := td.ResourceSpans().AppendEmpty()
+		s.resources[i].CopyTo(rs.Resource())
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.Scope().SetName(metadata.ScopeName)
+		s.recordProbeSpans(ss.Spans(), s.cfg.Targets[i], o)
+	}
+	return td
+}
+
+// recordProbeSpans describes the probe of target with outcome o in spans,
+// which fails if the probe failed or lost every packet
+func (s *pingScraper) recordProbeSpans(spans ptrace.SpanSlice, target Target, o *probeOutcome) {
+	start, end := o.started, o.finished
+	if start.IsZero() {
+		// The probe never ran, e.g. its pinger could not be created
+		start = s.clock.Now()
+		end = start
+	}
+
+	span := spans.AppendEmpty()
+	span.SetTraceID(newTraceID())
+	span.SetSpanID(newSpanID())
+	span.SetName(probeSpanName)
+	span.SetKind(ptrace.SpanKindClient)
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(end))
+
+	attrs := span.Attributes()
+	attrs.PutStr("net.peer.name", target.Endpoint)
+	if o.errorType != 0 {
+		attrs.PutStr("error.type", o.errorType.String())
+	}
+	if o.err != nil {
+		span.Status().SetCode(ptrace.StatusCodeError)
+		span.Status().SetMessage(o.err.Error())
+		return
+	}
+
+	attrs.PutStr("net.peer.ip", s.ips.String(o.stats.IPAddr))
+	attrs.PutInt("ping.packets.sent", int64(o.stats.PacketsSent))
+	attrs.PutInt("ping.packets.received", int64(o.stats.PacketsRecv))
+	attrs.PutDouble("ping.packet_loss", o.stats.PacketLoss/100.0)
+	if o.stats.PacketsRecv > 0 {
+		attrs.PutDouble("ping.duration.avg", milliseconds(o.stats.AvgRtt))
+	} else {
+		span.Status().SetCode(ptrace.StatusCodeError)
+		span.Status().SetMessage("every packet was lost")
+	}
+
+	for _, p := range o.stats.Packets {
+		child := spans.AppendEmpty()
+		child.SetTraceID(span.TraceID())
+		child.SetSpanID(newSpanID())
+		child.SetParentSpanID(span.SpanID())
+		child.SetName(packetSpanName)
+		child.SetKind(ptrace.SpanKindClient)
+		child.SetStartTimestamp(pcommon.NewTimestampFromTime(p.Sent))
+		child.Attributes().PutInt("ping.sequence", int64(p.Seq))
+		if p.Received {
+			child.SetEndTimestamp(pcommon.NewTimestampFromTime(p.Sent.Add(p.Rtt)))
+			child.Attributes().PutDouble("ping.duration", milliseconds(p.Rtt))
+			continue
+		}
+		// Lost packets were waited for until the probe ended
+		lostAt := end
+		if lostAt.Before(p.Sent) {
+			lostAt = p.Sent
+		}
+		child.SetEndTimestamp(pcommon.NewTimestampFromTime(lostAt))
+		child.Status().SetCode(ptrace.StatusCodeError)
+		child.Status().SetMessage("no reply")
+	}
+}
+
+// newTraceID returns a random trace ID
+func newTraceID() pcommon.TraceID {
+	var id pcommon.TraceID
+	binary.BigEndian.PutUint64(id[:8], rand.Uint64())
+	binary.BigEndian.PutUint64(id[8:], rand.Uint64())
+	return id
+}
+
+// newSpanID returns a random span ID
+func newSpanID() pcommon.SpanID {
+	var id pcommon.SpanID
+	binary.BigEndian.PutUint64(id[:], rand.Uint64())
+	return id
+}
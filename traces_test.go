@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+func TestScrapeTraces(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Traces.PacketSpans = true
+	cfg.Targets = []Target{
+		{Endpoint: "192.0.2.1", Count: 2},
+		{Endpoint: "192.0.2.2", Count: 2},
+	}
+
+	sent := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{
+		PacketsSent: 2,
+		PacketsRecv: 1,
+		PacketLoss:  50,
+		AvgRtt:      10 * time.Millisecond,
+		Packets: []prober.Packet{
+			{Seq: 0, Sent: sent, Received: true, Rtt: 10 * time.Millisecond},
+			{Seq: 1, Sent: sent.Add(time.Second)},
+		},
+	})
+	fakeProber.SetRunError("192.0.2.2", errors.New("i/o timeout"))
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	pingerCfg, _ := fakeProber.PingerConfig("192.0.2.1")
+	assert.True(t, pingerCfg.RecordPackets)
+
+	td := scraper.scrapeTraces(context.Background())
+	require.Equal(t, 4, td.SpanCount())
+
+	spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	require.Equal(t, 3, spans.Len())
+	probe := spans.At(0)
+	assert.Equal(t, "ping", probe.Name())
+	assert.Equal(t, ptrace.StatusCodeUnset, probe.Status().Code())
+	assert.Equal(t, map[string]any{
+		"net.peer.name":         "192.0.2.1",
+		"net.peer.ip":           "192.0.2.1",
+		"ping.packets.sent":     int64(2),
+		"ping.packets.received": int64(1),
+		"ping.packet_loss":      0.5,
+		"ping.duration.avg":     float64(10),
+	}, probe.Attributes().AsRaw())
+
+	answered := spans.At(1)
+	assert.Equal(t, "ping.packet", answered.Name())
+	assert.Equal(t, probe.TraceID(), answered.TraceID())
+	assert.Equal(t, probe.SpanID(), answered.ParentSpanID())
+	assert.Equal(t, 10*time.Millisecond, answered.EndTimestamp().AsTime().Sub(answered.StartTimestamp().AsTime()))
+	assert.Equal(t, map[string]any{"ping.sequence": int64(0), "ping.duration": float64(10)}, answered.Attributes().AsRaw())
+
+	lost := spans.At(2)
+	assert.Equal(t, ptrace.StatusCodeError, lost.Status().Code())
+	assert.Equal(t, "no reply", lost.Status().Message())
+	assert.False(t, lost.EndTimestamp().AsTime().Before(lost.StartTimestamp().AsTime()))
+
+	failed := td.ResourceSpans().At(1).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, ptrace.StatusCodeError, failed.Status().Code())
+	assert.Equal(t, "ping failed: i/o timeout", failed.Status().Message())
+	assert.Equal(t, map[string]any{
+		"net.peer.name": "192.0.2.2",
+		"error.type":    "timeout",
+	}, failed.Attributes().AsRaw())
+	assert.NotEqual(t, probe.TraceID(), failed.TraceID())
+}
+
+func TestScrapeTracesWithoutPacketSpans(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4}}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 4})
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	pingerCfg, _ := fakeProber.PingerConfig("192.0.2.1")
+	assert.False(t, pingerCfg.RecordPackets)
+
+	td := scraper.scrapeTraces(context.Background())
+	require.Equal(t, 1, td.SpanCount())
+	span := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, ptrace.StatusCodeError, span.Status().Code())
+	assert.Equal(t, "every packet was lost", span.Status().Message())
+}
+
+func TestTracesReceiver(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.CollectionInterval = 10 * time.Millisecond
+	cfg.InitialDelay = 0
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4}}
+
+	fakeProber := pingchecktest.NewProber()
+	sink := new(consumertest.TracesSink)
+	r, err := NewFactory(WithProber(fakeProber)).CreateTraces(context.Background(), receivertest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	assert.Eventually(t, func() bool {
+		return sink.SpanCount() >= 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.GreaterOrEqual(t, fakeProber.Runs("192.0.2.1"), 2)
+}
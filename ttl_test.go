@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// replyTTLs returns the values of the ping.reply.ttl data points of md
+func replyTTLs(md pmetric.Metrics) []int64 {
+	var values []int64
+	forEachMetric(md, func(_ pmetric.ScopeMetrics, m pmetric.Metric) {
+		if m.Name() == "ping.reply.ttl" {
+			values = append(values, m.Gauge().DataPoints().At(0).IntValue())
+		}
+	})
+	return values
+}
+
+func TestScraperReplyTTL(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics.PingReplyTTL.Enabled = true
+	cfg.Targets = []Target{
+		{Endpoint: "192.0.2.1", Count: 4, TTL: 16},
+		{Endpoint: "192.0.2.2", Count: 4},
+	}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 4, PacketsRecv: 4, ReplyTTL: 57})
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	pingerCfg, _ := fakeProber.PingerConfig("192.0.2.1")
+	assert.Equal(t, 16, pingerCfg.TTL)
+
+	md, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{57}, replyTTLs(md))
+
+	// Replies of unknown TTL are not reported
+	md, err = scraper.scrapeTarget(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Empty(t, replyTTLs(md))
+}
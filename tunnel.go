@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+)
+
+// recordTunnel records how the probe of a tunnel endpoint compares to that of
+// its underlay address into mb. The overhead is only known when both answered,
+// the loss delta also when the tunnel is down but the underlay is not.
+func (s *pingScraper) recordTunnel(mb *metadata.MetricsBuilder, metrics metadata.MetricsConfig, target Target, o *probeOutcome) {
+	if target.Underlay == "" || o.stats == nil {
+		return
+	}
+	for _, r := range o.variants {
+		if r.variant.underlay == "" {
+			continue
+		}
+		now := pcommon.NewTimestampFromTime(r.finished)
+		if o.stats.PacketsRecv > 0 && r.stats.PacketsRecv > 0 && metrics.PingTunnelOverhead.Enabled {
+			overhead := o.stats.AvgRtt - r.stats.AvgRtt
+			mb.RecordPingTunnelOverheadDataPoint(now, milliseconds(overhead), target.Endpoint, target.Underlay)
+		}
+		if metrics.PingTunnelPacketLossDelta.Enabled {
+			delta := (o.stats.PacketLoss - r.stats.PacketLoss) / 100.0
+			mb.RecordPingTunnelPacketLossDeltaDataPoint(now, delta, target.Endpoint, target.Underlay)
+		}
+	}
+}
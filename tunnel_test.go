@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+func TestScraperTunnel(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "10.0.0.1", Count: 4, Underlay: "203.0.113.5"}}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetResult("10.0.0.1", prober.Statistics{PacketsSent: 4, PacketsRecv: 3, PacketLoss: 25, AvgRtt: 32500 * time.Microsecond})
+	fakeProber.SetResult("203.0.113.5", prober.Statistics{PacketsSent: 4, PacketsRecv: 4, AvgRtt: 20 * time.Millisecond})
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	md, err := scraper.scrapeTarget(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, fakeProber.Runs("203.0.113.5"))
+
+	values := make(map[string]float64)
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		m := ms.At(i)
+		if m.Name() != "ping.tunnel.overhead" && m.Name() != "ping.tunnel.packet_loss_delta" {
+			continue
+		}
+		dp := m.Gauge().DataPoints().At(0)
+		underlay, _ := dp.Attributes().Get("tunnel.underlay")
+		assert.Equal(t, "203.0.113.5", underlay.Str())
+		values[m.Name()] = dp.DoubleValue()
+	}
+	assert.Equal(t, map[string]float64{
+		"ping.tunnel.overhead":          12.5,
+		"ping.tunnel.packet_loss_delta": 0.25,
+	}, values)
+}
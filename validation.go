@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/multierr"
+)
+
+// ValidationCode classifies the problem a ValidationError reports
+type ValidationCode string
+
+// Codes of ValidationError
+const (
+	// CodeRequired is an option that must be set but is not
+	CodeRequired ValidationCode = "required"
+	// CodeOutOfRange is a number or duration outside of its bounds
+	CodeOutOfRange ValidationCode = "out_of_range"
+	// CodeInvalidValue is a value that is not one of those accepted
+	CodeInvalidValue ValidationCode = "invalid_value"
+	// CodeInvalidFormat is a value that cannot be parsed, e.g. a malformed address
+	CodeInvalidFormat ValidationCode = "invalid_format"
+	// CodeConflict is an option that contradicts another one
+	CodeConflict ValidationCode = "conflict"
+	// CodeDuplicate is a value that must be unique but is not
+	CodeDuplicate ValidationCode = "duplicate"
+	// CodeForbidden is a value the receiver refuses, e.g. a denied destination
+	CodeForbidden ValidationCode = "forbidden"
+)
+
+// ValidationError is a problem with one option of a Config. Config.Validate
+// returns one for every problem it finds, combined with go.uber.org/multierr,
+// so tools editing configs can map them to the options they concern.
+type ValidationError struct {
+	// Path of the option, e.g. targets[0].count
+	Path string
+
+	// Code classifies the problem
+	Code ValidationCode
+
+	// Err describes the problem, prefixed with the blocks of Path
+	Err error
+}
+
+// Error implements error
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns Err
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors returns the problems of an error returned by Config.Validate
+func ValidationErrors(err error) []*ValidationError {
+	var errs []*ValidationError
+	for _, e := range multierr.Errors(err) {
+		var ve *ValidationError
+		if errors.As(e, &ve) {
+			errs = append(errs, ve)
+		}
+	}
+	return errs
+}
+
+// invalid returns a problem of the option at path, relative to the block
+// being validated
+func invalid(path string, code ValidationCode, format string, args ...any) error {
+	return &ValidationError{Path: path, Code: code, Err: fmt.Errorf(format, args...)}
+}
+
+// asInvalid returns err as a problem of the option at path, nil if err is nil
+func asInvalid(path string, code ValidationCode, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ValidationError{Path: path, Code: code, Err: err}
+}
+
+// within places the problems of err in block, prefixing their paths and
+// messages with it
+func within(block string, err error) error {
+	var result error
+	for _, e := range multierr.Errors(err) {
+		var ve *ValidationError
+		if !errors.As(e, &ve) {
+			ve = &ValidationError{Code: CodeInvalidValue, Err: e}
+		}
+		result = multierr.Append(result, &ValidationError{
+			Path: joinPath(block, ve.Path),
+			Code: ve.Code,
+			Err:  fmt.Errorf("%s: %w", block, ve.Err),
+		})
+	}
+	return result
+}
+
+// joinPath returns the path of the option at path within block
+func joinPath(block, path string) string {
+	switch {
+	case path == "":
+		return block
+	case strings.HasPrefix(path, "["):
+		return block + path
+	default:
+		return block + "." + path
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/scraper/scraperhelper"
+	"go.uber.org/multierr"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+func TestValidationErrors(t *testing.T) {
+	cfg := Config{
+		ControllerConfig:     scraperhelper.NewDefaultControllerConfig(),
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Profile:              "huge",
+		DeniedCIDRs:          []string{"10.0.0.0/8", "nope"},
+		Targets: []Target{
+			{Endpoint: "10.0.0.1", Count: -1, PacketSizes: []int{64, 1}},
+			{Endpoint: "", Protocol: "udp"},
+			{Endpoint: "example.com", SLA: &SLAConfig{
+				Schedules: []SLASchedule{{Name: "night", TimeWindow: TimeWindow{Days: []string{"mon", "someday"}, Start: "01:00", End: "05:00"}}},
+			}},
+			{Endpoint: "example.com", Protocol: prober.ProtocolTCP, Port: 443},
+		},
+		Overflow:    OverflowConfig{MaxDatapoints: -1},
+		Diagnostics: DiagnosticsConfig{Enabled: true, FailureThreshold: 1, Timeout: time.Second, TCPPorts: []int{0}},
+	}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+
+	type problem struct {
+		path string
+		code ValidationCode
+	}
+	var got []problem
+	for _, ve := range ValidationErrors(err) {
+		got = append(got, problem{ve.Path, ve.Code})
+	}
+	assert.Equal(t, []problem{
+		{"profile", CodeInvalidValue},
+		{"denied_cidrs[1]", CodeInvalidFormat},
+		{"targets[0].endpoint", CodeForbidden},
+		{"targets[0].count", CodeOutOfRange},
+		{"targets[0].packet_sizes[1]", CodeOutOfRange},
+		{"targets[1].endpoint", CodeRequired},
+		{"targets[1].protocol", CodeInvalidValue},
+		{"targets[2].sla.schedules[0].days[1]", CodeInvalidValue},
+		{"targets[3].endpoint", CodeConflict},
+		{"overflow.max_datapoints", CodeOutOfRange},
+		{"diagnostics.tcp_ports[0]", CodeOutOfRange},
+	}, got)
+	// Every problem is reported, with its message unchanged
+	assert.Len(t, got, len(multierr.Errors(err)))
+	assert.Contains(t, err.Error(), `targets[2]: sla: schedules[0]: unknown day "someday", expected mon to sun`)
+}
+
+func TestWithin(t *testing.T) {
+	plain := errors.New("boom")
+	err := within("targets[0]", multierr.Combine(
+		invalid("count", CodeOutOfRange, "count cannot be negative"),
+		within("packet_sizes[1]", invalid("", CodeOutOfRange, "too small")),
+		invalid("", CodeRequired, "missing"),
+		plain,
+	))
+
+	errs := ValidationErrors(err)
+	require.Len(t, errs, 4)
+	assert.Equal(t, "targets[0].count", errs[0].Path)
+	assert.Equal(t, "targets[0]: count cannot be negative", errs[0].Error())
+	assert.Equal(t, "targets[0].packet_sizes[1]", errs[1].Path)
+	assert.Equal(t, "targets[0]: packet_sizes[1]: too small", errs[1].Error())
+	assert.Equal(t, "targets[0]", errs[2].Path)
+	assert.Equal(t, CodeRequired, errs[2].Code)
+	// Errors of other kinds are kept, as invalid values of the block
+	assert.Equal(t, "targets[0]", errs[3].Path)
+	assert.Equal(t, CodeInvalidValue, errs[3].Code)
+	assert.ErrorIs(t, errs[3], plain)
+
+	assert.NoError(t, within("targets[0]", nil))
+	assert.Nil(t, ValidationErrors(nil))
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+// variantKey identifies packets probing a target in addition to its regular
+// probe: regular packets to its underlay address, to its address of another
+// family or to another of its addresses, a payload size of the size sweep, or
+// a DSCP value. Only one field is set, except dontFragment which the path
+// MTU search sets along with size, and marked which is set along with dscp.
+// The zero value stands for regular packets.
+type variantKey struct {
+	underlay     string
+	network      string
+	addr         netip.Addr
+	size         int
+	dscp         int
+	marked       bool
+	dontFragment bool
+}
+
+// regular reports whether the variant sends regular packets to the target,
+// at either of its addresses
+func (v variantKey) regular() bool {
+	return v.underlay == "" && v.size == 0 && !v.marked
+}
+
+// variantResult is the outcome of probing a target with one variant
+type variantResult struct {
+	variant  variantKey
+	finished time.Time
+	stats    *prober.Statistics
+}
+
+// targetVariants returns the variants target is probed with on every scrape
+func targetVariants(target Target) []variantKey {
+	variants := make([]variantKey, 0, 1+len(target.PacketSizes)+len(target.DSCPValues))
+	// The underlay is probed first, right after the tunnel address
+	if target.Underlay != "" {
+		variants = append(variants, variantKey{underlay: target.Underlay})
+	}
+	for _, size := range target.PacketSizes {
+		variants = append(variants, variantKey{size: size})
+	}
+	for _, dscp := range target.DSCPValues {
+		variants = append(variants, variantKey{dscp: dscp, marked: true})
+	}
+	return variants
+}
+
+// probeVariants probes target with each of variants in turn, so the runs do
+// not compete with each other for the path. Variants whose probe failed are
+// left out, the regular probe reports why the target is unreachable.
+func (s *pingScraper) probeVariants(ctx context.Context, target Target, variants []variantKey) []variantResult {
+	if len(variants) == 0 {
+		return nil
+	}
+
+	results := make([]variantResult, 0, len(variants))
+	for _, variant := range variants {
+		pinger, err := s.variantPinger(target, variant)
+		if err == nil {
+			var stats *prober.Statistics
+			if stats, err = s.run(ctx, target, pinger); err == nil {
+				results = append(results, variantResult{variant: variant, finished: s.clock.Now(), stats: stats})
+				continue
+			}
+		}
+		s.logger.Debug("Variant probe failed",
+			zap.String("endpoint", target.Endpoint),
+			zap.String("underlay", variant.underlay),
+			zap.String("network", variant.network),
+			zap.Stringer("addr", variant.addr),
+			zap.Int("size", variant.size),
+			zap.Int("dscp", variant.dscp),
+			zap.Error(err))
+	}
+	return results
+}
+
+// pingerVariant identifies the pinger of an endpoint for one variant
+type pingerVariant struct {
+	endpoint string
+	variant  variantKey
+}
+
+// variantPinger returns the pinger probing target with variant, creating it on first use
+func (s *pingScraper) variantPinger(target Target, variant variantKey) (prober.Pinger, error) {
+	key := pingerVariant{endpoint: target.Endpoint, variant: variant}
+	s.mu.RLock()
+	pinger, ok := s.variants[key]
+	s.mu.RUnlock()
+	if ok {
+		return pinger, nil
+	}
+
+	pinger, err := s.newVariantPinger(target, variant)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.variants == nil {
+		pinger.Stop()
+		return nil, errors.New("scraper is shut down")
+	}
+	if existing, ok := s.variants[key]; ok {
+		pinger.Stop()
+		return existing, nil
+	}
+	s.variants[key] = pinger
+	return pinger, nil
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"bytes"
+	"cmp"
+	"net"
+
+	"go.uber.org/zap"
+)
+
+// Wake-on-fail defaults
+const (
+	defaultWakeFailures  = 3
+	defaultWakeBroadcast = "255.255.255.255:9"
+)
+
+// magicPacket returns the Wake-on-LAN magic packet of mac, six 0xff bytes
+// followed by sixteen repetitions of the address
+func magicPacket(mac net.HardwareAddr) []byte {
+	return append(bytes.Repeat([]byte{0xff}, 6), bytes.Repeat(mac, 16)...)
+}
+
+// sendUDP sends packet to addr in a single datagram. Go enables broadcasting
+// on datagram sockets, so addr may be a broadcast address.
+func sendUDP(addr string, packet []byte) error {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = conn.Write(packet)
+	return err
+}
+
+// wakeIfAsleep sends target's Wake-on-LAN packet if its last wake_on_fail
+// failures scrapes failed, so a sleeping device is up by a following probe
+func (s *pingScraper) wakeIfAsleep(target Target) {
+	cfg := target.WakeOnFail
+	if cfg == nil {
+		return
+	}
+	s.mu.Lock()
+	failures := s.stateLocked(target.Endpoint).consecutiveFailures
+	s.mu.Unlock()
+	if failures < cmp.Or(cfg.Failures, defaultWakeFailures) {
+		return
+	}
+
+	// Validated with the config
+	mac, _ := net.ParseMAC(cfg.MAC)
+	broadcast := cmp.Or(cfg.Broadcast, defaultWakeBroadcast)
+	if err := s.sendWake(broadcast, magicPacket(mac)); err != nil {
+		s.logger.Warn("Failed to send Wake-on-LAN packet",
+			zap.String("endpoint", target.Endpoint),
+			zap.String("mac", mac.String()),
+			zap.Error(err))
+		return
+	}
+	s.logger.Info("Sent Wake-on-LAN packet",
+		zap.String("endpoint", target.Endpoint),
+		zap.String("mac", mac.String()),
+		zap.String("broadcast", broadcast),
+		zap.Int("failures", failures))
+}
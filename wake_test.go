@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pingcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/lukeod/pingcheckreceiver/internal/metadata"
+	"github.com/lukeod/pingcheckreceiver/pingchecktest"
+	"github.com/lukeod/pingcheckreceiver/prober"
+)
+
+func TestMagicPacket(t *testing.T) {
+	mac, err := net.ParseMAC("00:11:22:33:44:55")
+	require.NoError(t, err)
+
+	packet := magicPacket(mac)
+	require.Len(t, packet, 102)
+	assert.Equal(t, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, packet[:6])
+	for i := 6; i < len(packet); i += 6 {
+		assert.Equal(t, []byte(mac), packet[i:i+6])
+	}
+}
+
+func TestSendUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, sendUDP(conn.LocalAddr().String(), []byte("wake")))
+	buf := make([]byte, 16)
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "wake", string(buf[:n]))
+}
+
+func TestScraperWakeOnFail(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []Target{{Endpoint: "192.0.2.1", Count: 4, WakeOnFail: &WakeOnFailConfig{
+		MAC:      "00:11:22:33:44:55",
+		Failures: 2,
+	}}}
+
+	fakeProber := pingchecktest.NewProber()
+	fakeProber.SetRunError("192.0.2.1", errors.New("i/o timeout"))
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), newFactoryOptions(WithProber(fakeProber)))
+	var sent []string
+	scraper.sendWake = func(addr string, packet []byte) error {
+		assert.Len(t, packet, 102)
+		sent = append(sent, addr)
+		return nil
+	}
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	scrape := func() {
+		_, err := scraper.scrapeTarget(context.Background(), 0)
+		require.Error(t, err)
+	}
+
+	// Sent before every probe once two scrapes in a row failed
+	scrape()
+	scrape()
+	assert.Empty(t, sent)
+	scrape()
+	scrape()
+	assert.Equal(t, []string{defaultWakeBroadcast, defaultWakeBroadcast}, sent)
+
+	// Not once the target answers again
+	fakeProber.SetResult("192.0.2.1", prober.Statistics{PacketsSent: 4, PacketsRecv: 4})
+	for range 2 {
+		_, err := scraper.scrapeTarget(context.Background(), 0)
+		require.NoError(t, err)
+	}
+	assert.Len(t, sent, 3)
+}